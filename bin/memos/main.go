@@ -35,17 +35,7 @@ var (
 		Use:   "memos",
 		Short: `An open source, lightweight note-taking service. Easily capture and share your great thoughts.`,
 		Run: func(_ *cobra.Command, _ []string) {
-			instanceProfile := &profile.Profile{
-				Mode:        viper.GetString("mode"),
-				Addr:        viper.GetString("addr"),
-				Port:        viper.GetInt("port"),
-				UNIXSock:    viper.GetString("unix-sock"),
-				Data:        viper.GetString("data"),
-				Driver:      viper.GetString("driver"),
-				DSN:         viper.GetString("dsn"),
-				InstanceURL: viper.GetString("instance-url"),
-				Version:     version.GetCurrentVersion(viper.GetString("mode")),
-			}
+			instanceProfile := getInstanceProfile()
 			if err := instanceProfile.Validate(); err != nil {
 				panic(err)
 			}
@@ -145,6 +135,20 @@ func init() {
 	}
 }
 
+func getInstanceProfile() *profile.Profile {
+	return &profile.Profile{
+		Mode:        viper.GetString("mode"),
+		Addr:        viper.GetString("addr"),
+		Port:        viper.GetInt("port"),
+		UNIXSock:    viper.GetString("unix-sock"),
+		Data:        viper.GetString("data"),
+		Driver:      viper.GetString("driver"),
+		DSN:         viper.GetString("dsn"),
+		InstanceURL: viper.GetString("instance-url"),
+		Version:     version.GetCurrentVersion(viper.GetString("mode")),
+	}
+}
+
 func printGreetings(profile *profile.Profile) {
 	if profile.IsDev() {
 		println("Development mode is enabled")
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db"
+)
+
+var (
+	storageCmd = &cobra.Command{
+		Use:   "storage",
+		Short: "Manage the storage of the attachments",
+	}
+	storageMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Move the stored contents of the attachments from a storage to another",
+		Long: `Move the stored contents of the attachments from a storage to another in batches.
+Each content is verified against its checksum before it is deleted from the source storage,
+so the migration is safe to run while the server is running, and is resumed by running it again.
+The target storage is configured by the workspace storage setting, e.g. the S3 config and the filepath template.`,
+		Example:      "memos storage migrate --from database --to s3",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			from, err := parseStorageType(cmd.Flag("from").Value.String())
+			if err != nil {
+				return err
+			}
+			to, err := parseStorageType(cmd.Flag("to").Value.String())
+			if err != nil {
+				return err
+			}
+			batchSize, err := cmd.Flags().GetInt("batch-size")
+			if err != nil {
+				return err
+			}
+
+			instanceProfile := getInstanceProfile()
+			if err := instanceProfile.Validate(); err != nil {
+				return err
+			}
+			ctx := context.Background()
+			dbDriver, err := db.NewDBDriver(instanceProfile)
+			if err != nil {
+				return errors.Wrap(err, "failed to create db driver")
+			}
+			storeInstance := store.New(dbDriver, instanceProfile)
+			defer storeInstance.Close()
+			if err := storeInstance.Migrate(ctx); err != nil {
+				return errors.Wrap(err, "failed to migrate")
+			}
+
+			migration, err := storeInstance.MigrateAttachmentStorage(ctx, &store.MigrateAttachmentStorage{
+				From:      from,
+				To:        to,
+				BatchSize: batchSize,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Migrated %d attachments, %d failed, %d remaining in the source storage\n", migration.MigratedCount, migration.FailedCount, migration.RemainingCount)
+			if migration.FailedCount > 0 {
+				return errors.Errorf("failed to migrate %d attachments, run the command again to retry", migration.FailedCount)
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	storageMigrateCmd.Flags().String("from", "", `storage to move the contents from, can be "database" or "local" or "s3"`)
+	storageMigrateCmd.Flags().String("to", "", `storage to move the contents to, can be "database" or "local" or "s3"`)
+	storageMigrateCmd.Flags().Int("batch-size", 100, "number of the stored contents listed at once")
+	if err := storageMigrateCmd.MarkFlagRequired("from"); err != nil {
+		panic(err)
+	}
+	if err := storageMigrateCmd.MarkFlagRequired("to"); err != nil {
+		panic(err)
+	}
+
+	storageCmd.AddCommand(storageMigrateCmd)
+	rootCmd.AddCommand(storageCmd)
+}
+
+func parseStorageType(storageType string) (storepb.WorkspaceStorageSetting_StorageType, error) {
+	value, ok := storepb.WorkspaceStorageSetting_StorageType_value[strings.ToUpper(storageType)]
+	if !ok || value == int32(storepb.WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED) {
+		return storepb.WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED, errors.Errorf("invalid storage type %q", storageType)
+	}
+	return storepb.WorkspaceStorageSetting_StorageType(value), nil
+}
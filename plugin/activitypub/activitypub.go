@@ -0,0 +1,170 @@
+// Package activitypub implements the parts of ActivityPub, WebFinger and HTTP signatures
+// needed to federate memos with the fediverse.
+package activitypub
+
+const (
+	// ContentType is the media type of ActivityStreams documents.
+	ContentType = "application/activity+json"
+	// JRDContentType is the media type of WebFinger responses.
+	JRDContentType = "application/jrd+json"
+	// PublicAddress is the special collection that addresses an object to everyone.
+	PublicAddress = "https://www.w3.org/ns/activitystreams#Public"
+)
+
+// The types of the activities that are sent and handled.
+const (
+	ActivityTypeCreate = "Create"
+	ActivityTypeUpdate = "Update"
+	ActivityTypeDelete = "Delete"
+	ActivityTypeFollow = "Follow"
+	ActivityTypeAccept = "Accept"
+	ActivityTypeUndo   = "Undo"
+)
+
+// Context is the JSON-LD context of the documents served to other servers.
+var Context = []any{
+	"https://www.w3.org/ns/activitystreams",
+	"https://w3id.org/security/v1",
+}
+
+// Actor is an ActivityPub actor, e.g. a Person.
+type Actor struct {
+	Context           any        `json:"@context,omitempty"`
+	ID                string     `json:"id"`
+	Type              string     `json:"type"`
+	PreferredUsername string     `json:"preferredUsername,omitempty"`
+	Name              string     `json:"name,omitempty"`
+	Summary           string     `json:"summary,omitempty"`
+	URL               string     `json:"url,omitempty"`
+	Inbox             string     `json:"inbox"`
+	Outbox            string     `json:"outbox,omitempty"`
+	Followers         string     `json:"followers,omitempty"`
+	Endpoints         *Endpoints `json:"endpoints,omitempty"`
+	PublicKey         *PublicKey `json:"publicKey,omitempty"`
+	Published         string     `json:"published,omitempty"`
+}
+
+// Endpoints holds the additional endpoints of an actor.
+type Endpoints struct {
+	SharedInbox string `json:"sharedInbox,omitempty"`
+}
+
+// PublicKey is the key that the requests of an actor are signed with.
+type PublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// Activity is an activity sent to or received from an inbox.
+// The object is either the ID of an object or the object itself.
+type Activity struct {
+	Context   any      `json:"@context,omitempty"`
+	ID        string   `json:"id,omitempty"`
+	Type      string   `json:"type"`
+	Actor     string   `json:"actor"`
+	Object    any      `json:"object,omitempty"`
+	To        []string `json:"to,omitempty"`
+	Cc        []string `json:"cc,omitempty"`
+	Published string   `json:"published,omitempty"`
+}
+
+// Note is a short post, which memos are published as.
+type Note struct {
+	Context      any         `json:"@context,omitempty"`
+	ID           string      `json:"id"`
+	Type         string      `json:"type"`
+	AttributedTo string      `json:"attributedTo"`
+	Content      string      `json:"content"`
+	Source       *Source     `json:"source,omitempty"`
+	URL          string      `json:"url,omitempty"`
+	Published    string      `json:"published,omitempty"`
+	Updated      string      `json:"updated,omitempty"`
+	To           []string    `json:"to,omitempty"`
+	Cc           []string    `json:"cc,omitempty"`
+	Tag          []*Tag      `json:"tag,omitempty"`
+	Attachment   []*Document `json:"attachment,omitempty"`
+}
+
+// Source is the source of an object's content, e.g. its markdown.
+type Source struct {
+	Content   string `json:"content"`
+	MediaType string `json:"mediaType"`
+}
+
+// Tag is a tag of an object, e.g. a Hashtag.
+type Tag struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Href string `json:"href,omitempty"`
+}
+
+// Document is a file attached to an object.
+type Document struct {
+	Type      string `json:"type"`
+	MediaType string `json:"mediaType,omitempty"`
+	URL       string `json:"url"`
+	Name      string `json:"name,omitempty"`
+}
+
+// Tombstone replaces a deleted object.
+type Tombstone struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// OrderedCollection is a collection such as an outbox or a followers list.
+type OrderedCollection struct {
+	Context      any    `json:"@context,omitempty"`
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	TotalItems   int    `json:"totalItems"`
+	First        string `json:"first,omitempty"`
+	OrderedItems []any  `json:"orderedItems,omitempty"`
+}
+
+// OrderedCollectionPage is a page of an OrderedCollection.
+type OrderedCollectionPage struct {
+	Context      any    `json:"@context,omitempty"`
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	PartOf       string `json:"partOf"`
+	Next         string `json:"next,omitempty"`
+	OrderedItems []any  `json:"orderedItems"`
+}
+
+// WebFinger is a WebFinger JSON resource descriptor.
+type WebFinger struct {
+	Subject string           `json:"subject"`
+	Aliases []string         `json:"aliases,omitempty"`
+	Links   []*WebFingerLink `json:"links"`
+}
+
+// WebFingerLink is a link of a WebFinger resource.
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href"`
+}
+
+// GetObjectID returns the ID of an object that is either an ID or an object with an "id" field.
+func GetObjectID(object any) string {
+	switch v := object.(type) {
+	case string:
+		return v
+	case map[string]any:
+		id, _ := v["id"].(string)
+		return id
+	default:
+		return ""
+	}
+}
+
+// GetObjectType returns the type of an object, or an empty string if the object is only an ID.
+func GetObjectType(object any) string {
+	if v, ok := object.(map[string]any); ok {
+		objectType, _ := v["type"].(string)
+		return objectType
+	}
+	return ""
+}
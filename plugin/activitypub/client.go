@@ -0,0 +1,84 @@
+package activitypub
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/httpgetter"
+)
+
+const (
+	// maxResponseSize is the maximum size of a document fetched from another server.
+	maxResponseSize = 1 << 20
+)
+
+// HTTPClient is the client the other servers are requested with. The addresses of the actors and inboxes are given by
+// the other servers, so it refuses to connect to internal IP addresses. Tests replace it to reach their stub servers.
+var HTTPClient = httpgetter.NewClient(30 * time.Second)
+
+// FetchActor fetches the actor document at the URL.
+// The request is signed when a key is given, since some servers only serve signed fetches.
+func FetchActor(ctx context.Context, actorURL string, keyID string, privateKey *rsa.PrivateKey) (*Actor, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, actorURL, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to construct request to %s", actorURL)
+	}
+	req.Header.Set("Accept", ContentType)
+	if privateKey != nil {
+		if err := SignRequest(req, keyID, privateKey, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch actor %s", actorURL)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read actor %s", actorURL)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.Errorf("failed to fetch actor %s, status code: %d", actorURL, resp.StatusCode)
+	}
+
+	actor := &Actor{}
+	if err := json.Unmarshal(body, actor); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal actor %s", actorURL)
+	}
+	if actor.ID == "" || actor.Inbox == "" {
+		return nil, errors.Errorf("invalid actor %s", actorURL)
+	}
+	return actor, nil
+}
+
+// Deliver posts the JSON encoded activity to the inbox, signed with the key of the sending actor.
+func Deliver(ctx context.Context, inboxURL string, keyID string, privateKey *rsa.PrivateKey, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, inboxURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to construct request to %s", inboxURL)
+	}
+	req.Header.Set("Content-Type", ContentType)
+	if err := SignRequest(req, keyID, privateKey, body); err != nil {
+		return err
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deliver activity to %s", inboxURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("failed to deliver activity to %s, status code: %d, response body: %s", inboxURL, resp.StatusCode, b)
+	}
+	return nil
+}
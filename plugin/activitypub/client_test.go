@@ -0,0 +1,99 @@
+package activitypub
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/httpgetter"
+)
+
+func TestFetchActorAndDeliver(t *testing.T) {
+	privateKeyPem, publicKeyPem, err := GenerateKeyPair()
+	require.NoError(t, err)
+	privateKey, err := ParsePrivateKey(privateKeyPem)
+	require.NoError(t, err)
+
+	var server *httptest.Server
+	received := make(chan *Activity, 1)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/alice":
+			w.Header().Set("Content-Type", ContentType)
+			_ = json.NewEncoder(w).Encode(&Actor{
+				ID:    server.URL + "/users/alice",
+				Type:  "Person",
+				Inbox: server.URL + "/users/alice/inbox",
+				PublicKey: &PublicKey{
+					ID:           server.URL + "/users/alice#main-key",
+					Owner:        server.URL + "/users/alice",
+					PublicKeyPem: publicKeyPem,
+				},
+			})
+		case "/users/alice/inbox":
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			signature, err := ParseSignature(r)
+			if err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			publicKey, err := ParsePublicKey(publicKeyPem)
+			if err != nil || signature.Verify(r, publicKey, body) != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			activity := &Activity{}
+			if err := json.Unmarshal(body, activity); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			received <- activity
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The stub server is on the loopback address, which is refused by default.
+	ctx := context.Background()
+	_, err = FetchActor(ctx, server.URL+"/users/alice", "", nil)
+	require.ErrorIs(t, err, httpgetter.ErrInternalIP)
+	defaultClient := HTTPClient
+	HTTPClient = server.Client()
+	defer func() { HTTPClient = defaultClient }()
+
+	actor, err := FetchActor(ctx, server.URL+"/users/alice", server.URL+"/users/alice#main-key", privateKey)
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/users/alice/inbox", actor.Inbox)
+	require.Equal(t, publicKeyPem, actor.PublicKey.PublicKeyPem)
+
+	body, err := json.Marshal(&Activity{
+		Context: Context,
+		ID:      server.URL + "/activities/1",
+		Type:    ActivityTypeCreate,
+		Actor:   actor.ID,
+		Object:  &Note{ID: server.URL + "/notes/1", Type: "Note", Content: "<p>Hello</p>"},
+	})
+	require.NoError(t, err)
+	err = Deliver(ctx, actor.Inbox, actor.PublicKey.ID, privateKey, body)
+	require.NoError(t, err)
+	activity := <-received
+	require.Equal(t, ActivityTypeCreate, activity.Type)
+	require.Equal(t, server.URL+"/notes/1", GetObjectID(activity.Object))
+	require.Equal(t, "Note", GetObjectType(activity.Object))
+
+	_, err = FetchActor(ctx, server.URL+"/users/unknown", "", nil)
+	require.Error(t, err)
+	err = Deliver(ctx, server.URL+"/unknown/inbox", actor.PublicKey.ID, privateKey, body)
+	require.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package activitypub
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// keySize is the size of the generated RSA keys.
+	keySize = 2048
+	// maxClockSkew is how far the date of a signed request may be from now.
+	maxClockSkew = 12 * time.Hour
+)
+
+// Signature is a parsed HTTP signature, following draft-cavage-http-signatures as used by Mastodon.
+type Signature struct {
+	KeyID     string
+	Algorithm string
+	Headers   []string
+	Signature []byte
+}
+
+// GenerateKeyPair generates an RSA key pair and returns the private and public keys in PEM format.
+func GenerateKeyPair() (string, string, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to generate key")
+	}
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to marshal private key")
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to marshal public key")
+	}
+	privateKeyPem := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes})
+	publicKeyPem := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes})
+	return string(privateKeyPem), string(publicKeyPem), nil
+}
+
+// ParsePrivateKey parses a PEM encoded RSA private key.
+func ParsePrivateKey(privateKeyPem string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPem))
+	if block == nil {
+		return nil, errors.New("invalid private key")
+	}
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private key")
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return privateKey, nil
+}
+
+// ParsePublicKey parses a PEM encoded RSA public key.
+func ParsePublicKey(publicKeyPem string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPem))
+	if block == nil {
+		return nil, errors.New("invalid public key")
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse public key")
+	}
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an RSA key")
+	}
+	return publicKey, nil
+}
+
+// SignRequest signs the request with the private key of an actor.
+// The body is the request body, or nil for requests without a body.
+func SignRequest(r *http.Request, keyID string, privateKey *rsa.PrivateKey, body []byte) error {
+	if r.Header.Get("Date") == "" {
+		r.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	}
+	headers := []string{"(request-target)", "host", "date"}
+	if body != nil {
+		r.Header.Set("Digest", getDigest(body))
+		headers = append(headers, "digest")
+	}
+
+	hashed := sha256.Sum256([]byte(buildSigningString(r, headers)))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return errors.Wrap(err, "failed to sign request")
+	}
+	r.Header.Set("Signature", fmt.Sprintf(`keyId="%s",algorithm="rsa-sha256",headers="%s",signature="%s"`,
+		keyID, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+	return nil
+}
+
+// ParseSignature parses the Signature header of the request.
+func ParseSignature(r *http.Request) (*Signature, error) {
+	header := r.Header.Get("Signature")
+	if header == "" {
+		return nil, errors.New("signature header not found")
+	}
+	signature := &Signature{
+		// The default when no headers are listed.
+		Headers: []string{"date"},
+	}
+	for _, param := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "keyId":
+			signature.KeyID = value
+		case "algorithm":
+			signature.Algorithm = value
+		case "headers":
+			signature.Headers = strings.Fields(strings.ToLower(value))
+		case "signature":
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to decode signature")
+			}
+			signature.Signature = decoded
+		default:
+		}
+	}
+	if signature.KeyID == "" || len(signature.Signature) == 0 {
+		return nil, errors.New("invalid signature header")
+	}
+	return signature, nil
+}
+
+// Verify verifies the signature of the request with the public key of the signing actor.
+// Requests with a body must sign its digest, and the date must be recent to prevent replays.
+func (s *Signature) Verify(r *http.Request, publicKey *rsa.PublicKey, body []byte) error {
+	if s.Algorithm != "" && s.Algorithm != "rsa-sha256" && s.Algorithm != "hs2019" {
+		return errors.Errorf("unsupported signature algorithm %q", s.Algorithm)
+	}
+	for _, required := range []string{"(request-target)", "host", "date"} {
+		if !slices.Contains(s.Headers, required) {
+			return errors.Errorf("header %q is not signed", required)
+		}
+	}
+	date, err := http.ParseTime(r.Header.Get("Date"))
+	if err != nil {
+		return errors.Wrap(err, "invalid date header")
+	}
+	if skew := time.Since(date); skew > maxClockSkew || skew < -maxClockSkew {
+		return errors.New("date header is out of range")
+	}
+	if body != nil {
+		if !slices.Contains(s.Headers, "digest") {
+			return errors.New(`header "digest" is not signed`)
+		}
+		if r.Header.Get("Digest") != getDigest(body) {
+			return errors.New("digest does not match the body")
+		}
+	}
+
+	hashed := sha256.Sum256([]byte(buildSigningString(r, s.Headers)))
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], s.Signature); err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	return nil
+}
+
+func buildSigningString(r *http.Request, headers []string) string {
+	lines := make([]string, 0, len(headers))
+	for _, header := range headers {
+		var value string
+		switch header {
+		case "(request-target)":
+			value = strings.ToLower(r.Method) + " " + r.URL.RequestURI()
+		case "host":
+			value = r.Host
+			if value == "" {
+				value = r.URL.Host
+			}
+		default:
+			value = strings.Join(r.Header.Values(header), ", ")
+		}
+		lines = append(lines, header+": "+value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func getDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,50 @@
+package activitypub
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerifyRequest(t *testing.T) {
+	privateKeyPem, publicKeyPem, err := GenerateKeyPair()
+	require.NoError(t, err)
+	privateKey, err := ParsePrivateKey(privateKeyPem)
+	require.NoError(t, err)
+	publicKey, err := ParsePublicKey(publicKeyPem)
+	require.NoError(t, err)
+
+	body := []byte(`{"type":"Follow"}`)
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/ap/users/alice/inbox", bytes.NewReader(body))
+	require.NoError(t, SignRequest(req, "https://remote.example/users/bob#main-key", privateKey, body))
+
+	signature, err := ParseSignature(req)
+	require.NoError(t, err)
+	require.Equal(t, "https://remote.example/users/bob#main-key", signature.KeyID)
+	require.Equal(t, []string{"(request-target)", "host", "date", "digest"}, signature.Headers)
+	require.NoError(t, signature.Verify(req, publicKey, body))
+
+	// A tampered body does not match the signed digest.
+	require.Error(t, signature.Verify(req, publicKey, []byte(`{"type":"Undo"}`)))
+
+	// A different key does not verify the signature.
+	_, otherPublicKeyPem, err := GenerateKeyPair()
+	require.NoError(t, err)
+	otherPublicKey, err := ParsePublicKey(otherPublicKeyPem)
+	require.NoError(t, err)
+	require.Error(t, signature.Verify(req, otherPublicKey, body))
+
+	// A stale date is rejected.
+	req.Header.Set("Date", time.Now().Add(-24*time.Hour).UTC().Format(http.TimeFormat))
+	require.Error(t, signature.Verify(req, publicKey, body))
+}
+
+func TestParseSignatureWithoutHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/inbox", nil)
+	_, err := ParseSignature(req)
+	require.Error(t, err)
+}
@@ -0,0 +1,443 @@
+// Package exif reads and strips the EXIF metadata of JPEG and PNG images.
+// Reference: https://www.cipa.jp/std/documents/e/DC-008-2012_E.pdf
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	tagMake               = 0x010F
+	tagModel              = 0x0110
+	tagOrientation        = 0x0112
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+	tagPixelXDimension    = 0xA002
+	tagPixelYDimension    = 0xA003
+	tagLensModel          = 0xA434
+
+	tagGPSLatitudeRef  = 0x0001
+	tagGPSLatitude     = 0x0002
+	tagGPSLongitudeRef = 0x0003
+	tagGPSLongitude    = 0x0004
+	tagGPSAltitudeRef  = 0x0005
+	tagGPSAltitude     = 0x0006
+
+	typeByte      = 1
+	typeASCII     = 2
+	typeShort     = 3
+	typeLong      = 4
+	typeRational  = 5
+	typeUndefined = 7
+	typeSLong     = 9
+	typeSRational = 10
+
+	// maxIFDEntries bounds the entries read from an IFD of a malformed image.
+	maxIFDEntries  = 1024
+	dateTimeLayout = "2006:01:02 15:04:05"
+)
+
+var (
+	jpegExifHeader = []byte("Exif\x00\x00")
+	pngSignature   = []byte("\x89PNG\r\n\x1a\n")
+	typeSizes      = map[uint16]uint32{typeByte: 1, typeASCII: 1, typeShort: 2, typeLong: 4, typeRational: 8, typeUndefined: 1, typeSLong: 4, typeSRational: 8}
+)
+
+// Metadata is the metadata read from the EXIF data of an image.
+type Metadata struct {
+	// Orientation is the EXIF orientation from 1 to 8, it is 1 when missing.
+	Orientation int
+	// TakenTime is the time the photo was taken, it is zero when missing.
+	TakenTime time.Time
+	Make      string
+	Model     string
+	LensModel string
+	// Width and Height are the pixel dimensions recorded by the camera, they are zero when missing.
+	Width  int
+	Height int
+	// Location is where the photo was taken, it is nil when missing.
+	Location *Location
+}
+
+// Location is the GPS position of a photo.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+	// Altitude is in meters above the sea level.
+	Altitude float64
+}
+
+// Extract returns the EXIF metadata of the JPEG or PNG image, or nil if it has none.
+func Extract(content []byte) (*Metadata, error) {
+	tiff, err := findTIFF(content)
+	if err != nil || tiff == nil {
+		return nil, err
+	}
+	reader, err := newTIFFReader(tiff)
+	if err != nil {
+		return nil, err
+	}
+	ifd0, err := reader.readIFD(reader.firstIFDOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{
+		Orientation: 1,
+		Make:        reader.getString(ifd0, tagMake),
+		Model:       reader.getString(ifd0, tagModel),
+	}
+	if orientation, ok := reader.getInt(ifd0, tagOrientation); ok && orientation >= 1 && orientation <= 8 {
+		metadata.Orientation = orientation
+	}
+	if offset, ok := reader.getInt(ifd0, tagExifIFD); ok {
+		if exifIFD, err := reader.readIFD(uint32(offset)); err == nil {
+			metadata.LensModel = reader.getString(exifIFD, tagLensModel)
+			metadata.Width, _ = reader.getInt(exifIFD, tagPixelXDimension)
+			metadata.Height, _ = reader.getInt(exifIFD, tagPixelYDimension)
+			metadata.TakenTime = parseDateTime(reader.getString(exifIFD, tagDateTimeOriginal), reader.getString(exifIFD, tagOffsetTimeOriginal))
+		}
+	}
+	if offset, ok := reader.getInt(ifd0, tagGPSIFD); ok {
+		if gpsIFD, err := reader.readIFD(uint32(offset)); err == nil {
+			metadata.Location = reader.getLocation(gpsIFD)
+		}
+	}
+	return metadata, nil
+}
+
+// Strip removes the EXIF data of the JPEG or PNG image, the orientation is kept so that the image is still displayed
+// the right way up. The other images are returned unchanged.
+func Strip(content []byte) ([]byte, error) {
+	metadata, err := Extract(content)
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		return content, nil
+	}
+	var orientationTIFF []byte
+	if metadata.Orientation != 1 {
+		orientationTIFF = buildOrientationTIFF(metadata.Orientation)
+	}
+	if bytes.HasPrefix(content, pngSignature) {
+		return stripPNG(content, orientationTIFF)
+	}
+	return stripJPEG(content, orientationTIFF)
+}
+
+// findTIFF returns the TIFF structure holding the EXIF data of the image.
+func findTIFF(content []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xFF, 0xD8}):
+		var tiff []byte
+		err := walkJPEG(content, func(marker byte, segment []byte) {
+			if tiff == nil && marker == 0xE1 && bytes.HasPrefix(segment, jpegExifHeader) {
+				tiff = segment[len(jpegExifHeader):]
+			}
+		})
+		return tiff, err
+	case bytes.HasPrefix(content, pngSignature):
+		var tiff []byte
+		err := walkPNG(content, func(chunkType string, data []byte) {
+			if tiff == nil && chunkType == "eXIf" {
+				tiff = data
+			}
+		})
+		return tiff, err
+	default:
+		return nil, nil
+	}
+}
+
+// walkJPEG calls the function with the marker and the data of the segments before the image data.
+func walkJPEG(content []byte, fn func(marker byte, segment []byte)) error {
+	offset := 2
+	for offset+4 <= len(content) {
+		if content[offset] != 0xFF {
+			return errors.New("invalid jpeg segment")
+		}
+		marker := content[offset+1]
+		// The start of scan is followed by the compressed image data.
+		if marker == 0xDA {
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(content[offset+2:]))
+		if length < 2 || offset+2+length > len(content) {
+			return errors.New("invalid jpeg segment length")
+		}
+		fn(marker, content[offset+4:offset+2+length])
+		offset += 2 + length
+	}
+	return nil
+}
+
+func stripJPEG(content []byte, orientationTIFF []byte) ([]byte, error) {
+	buffer := bytes.NewBuffer(make([]byte, 0, len(content)))
+	buffer.Write(content[:2])
+	if orientationTIFF != nil {
+		segment := append(append([]byte{}, jpegExifHeader...), orientationTIFF...)
+		buffer.Write([]byte{0xFF, 0xE1})
+		_ = binary.Write(buffer, binary.BigEndian, uint16(len(segment)+2))
+		buffer.Write(segment)
+	}
+	offset := 2
+	err := walkJPEG(content, func(marker byte, segment []byte) {
+		end := offset + 4 + len(segment)
+		if !(marker == 0xE1 && bytes.HasPrefix(segment, jpegExifHeader)) {
+			buffer.Write(content[offset:end])
+		}
+		offset = end
+	})
+	if err != nil {
+		return nil, err
+	}
+	buffer.Write(content[offset:])
+	return buffer.Bytes(), nil
+}
+
+// walkPNG calls the function with the type and the data of the chunks.
+func walkPNG(content []byte, fn func(chunkType string, data []byte)) error {
+	offset := len(pngSignature)
+	for offset+12 <= len(content) {
+		length := int(binary.BigEndian.Uint32(content[offset:]))
+		if length < 0 || offset+12+length > len(content) {
+			return errors.New("invalid png chunk length")
+		}
+		chunkType := string(content[offset+4 : offset+8])
+		fn(chunkType, content[offset+8:offset+8+length])
+		offset += 12 + length
+		if chunkType == "IEND" {
+			return nil
+		}
+	}
+	return nil
+}
+
+func stripPNG(content []byte, orientationTIFF []byte) ([]byte, error) {
+	buffer := bytes.NewBuffer(make([]byte, 0, len(content)))
+	buffer.Write(pngSignature)
+	offset := len(pngSignature)
+	err := walkPNG(content, func(chunkType string, data []byte) {
+		end := offset + 12 + len(data)
+		if chunkType == "IDAT" && orientationTIFF != nil {
+			// The eXIf chunk must come before the image data.
+			writePNGChunk(buffer, "eXIf", orientationTIFF)
+			orientationTIFF = nil
+		}
+		if chunkType != "eXIf" {
+			buffer.Write(content[offset:end])
+		}
+		offset = end
+	})
+	if err != nil {
+		return nil, err
+	}
+	buffer.Write(content[offset:])
+	return buffer.Bytes(), nil
+}
+
+func writePNGChunk(buffer *bytes.Buffer, chunkType string, data []byte) {
+	_ = binary.Write(buffer, binary.BigEndian, uint32(len(data)))
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(chunkType))
+	crc.Write(data)
+	buffer.WriteString(chunkType)
+	buffer.Write(data)
+	_ = binary.Write(buffer, binary.BigEndian, crc.Sum32())
+}
+
+// buildOrientationTIFF returns a TIFF structure with only the orientation tag.
+func buildOrientationTIFF(orientation int) []byte {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString("MM")
+	_ = binary.Write(buffer, binary.BigEndian, []uint16{42})
+	_ = binary.Write(buffer, binary.BigEndian, []uint32{8})
+	_ = binary.Write(buffer, binary.BigEndian, []uint16{1, tagOrientation, typeShort})
+	_ = binary.Write(buffer, binary.BigEndian, []uint32{1})
+	_ = binary.Write(buffer, binary.BigEndian, []uint16{uint16(orientation), 0})
+	_ = binary.Write(buffer, binary.BigEndian, []uint32{0})
+	return buffer.Bytes()
+}
+
+type ifdEntry struct {
+	dataType uint16
+	count    uint32
+	// value is the data of the entry, or the offset of the data if it doesn't fit.
+	value []byte
+}
+
+type tiffReader struct {
+	data           []byte
+	order          binary.ByteOrder
+	firstIFDOffset uint32
+}
+
+func newTIFFReader(data []byte) (*tiffReader, error) {
+	if len(data) < 8 {
+		return nil, errors.New("invalid tiff header")
+	}
+	reader := &tiffReader{data: data}
+	switch string(data[:2]) {
+	case "II":
+		reader.order = binary.LittleEndian
+	case "MM":
+		reader.order = binary.BigEndian
+	default:
+		return nil, errors.New("invalid tiff byte order")
+	}
+	if reader.order.Uint16(data[2:]) != 42 {
+		return nil, errors.New("invalid tiff magic number")
+	}
+	reader.firstIFDOffset = reader.order.Uint32(data[4:])
+	return reader, nil
+}
+
+func (r *tiffReader) readIFD(offset uint32) (map[uint16]ifdEntry, error) {
+	if uint64(offset)+2 > uint64(len(r.data)) {
+		return nil, errors.New("invalid ifd offset")
+	}
+	count := int(r.order.Uint16(r.data[offset:]))
+	if count > maxIFDEntries || int(offset)+2+count*12 > len(r.data) {
+		return nil, errors.New("invalid ifd entry count")
+	}
+	entries := map[uint16]ifdEntry{}
+	for i := 0; i < count; i++ {
+		entry := r.data[int(offset)+2+i*12:]
+		entries[r.order.Uint16(entry)] = ifdEntry{
+			dataType: r.order.Uint16(entry[2:]),
+			count:    r.order.Uint32(entry[4:]),
+			value:    entry[8:12],
+		}
+	}
+	return entries, nil
+}
+
+// getData returns the data of the entry.
+func (r *tiffReader) getData(entry ifdEntry) ([]byte, bool) {
+	typeSize, ok := typeSizes[entry.dataType]
+	if !ok {
+		return nil, false
+	}
+	size := uint64(typeSize) * uint64(entry.count)
+	if size <= 4 {
+		return entry.value[:size], true
+	}
+	offset := uint64(r.order.Uint32(entry.value))
+	if offset+size > uint64(len(r.data)) {
+		return nil, false
+	}
+	return r.data[offset : offset+size], true
+}
+
+func (r *tiffReader) getString(ifd map[uint16]ifdEntry, tag uint16) string {
+	entry, ok := ifd[tag]
+	if !ok || entry.dataType != typeASCII {
+		return ""
+	}
+	data, ok := r.getData(entry)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(data), "\x00"))
+}
+
+func (r *tiffReader) getInt(ifd map[uint16]ifdEntry, tag uint16) (int, bool) {
+	entry, ok := ifd[tag]
+	if !ok || entry.count == 0 {
+		return 0, false
+	}
+	data, ok := r.getData(entry)
+	if !ok {
+		return 0, false
+	}
+	switch entry.dataType {
+	case typeByte, typeUndefined:
+		return int(data[0]), true
+	case typeShort:
+		return int(r.order.Uint16(data)), true
+	case typeLong:
+		return int(r.order.Uint32(data)), true
+	case typeSLong:
+		return int(int32(r.order.Uint32(data))), true
+	default:
+		return 0, false
+	}
+}
+
+func (r *tiffReader) getRationals(ifd map[uint16]ifdEntry, tag uint16) []float64 {
+	entry, ok := ifd[tag]
+	if !ok || (entry.dataType != typeRational && entry.dataType != typeSRational) {
+		return nil
+	}
+	data, ok := r.getData(entry)
+	if !ok {
+		return nil
+	}
+	values := []float64{}
+	for i := 0; i+8 <= len(data); i += 8 {
+		numerator, denominator := float64(r.order.Uint32(data[i:])), float64(r.order.Uint32(data[i+4:]))
+		if entry.dataType == typeSRational {
+			numerator, denominator = float64(int32(r.order.Uint32(data[i:]))), float64(int32(r.order.Uint32(data[i+4:])))
+		}
+		if denominator == 0 {
+			return nil
+		}
+		values = append(values, numerator/denominator)
+	}
+	return values
+}
+
+func (r *tiffReader) getLocation(gpsIFD map[uint16]ifdEntry) *Location {
+	latitude, longitude := r.getRationals(gpsIFD, tagGPSLatitude), r.getRationals(gpsIFD, tagGPSLongitude)
+	if len(latitude) != 3 || len(longitude) != 3 {
+		return nil
+	}
+	location := &Location{
+		Latitude:  latitude[0] + latitude[1]/60 + latitude[2]/3600,
+		Longitude: longitude[0] + longitude[1]/60 + longitude[2]/3600,
+	}
+	if r.getString(gpsIFD, tagGPSLatitudeRef) == "S" {
+		location.Latitude = -location.Latitude
+	}
+	if r.getString(gpsIFD, tagGPSLongitudeRef) == "W" {
+		location.Longitude = -location.Longitude
+	}
+	if math.Abs(location.Latitude) > 90 || math.Abs(location.Longitude) > 180 {
+		return nil
+	}
+	if altitude := r.getRationals(gpsIFD, tagGPSAltitude); len(altitude) == 1 {
+		location.Altitude = altitude[0]
+		// The reference 1 is below the sea level.
+		if ref, ok := r.getInt(gpsIFD, tagGPSAltitudeRef); ok && ref == 1 {
+			location.Altitude = -location.Altitude
+		}
+	}
+	return location
+}
+
+// parseDateTime parses the date time of the photo, it is in UTC when the offset is missing.
+func parseDateTime(dateTime string, offset string) time.Time {
+	if dateTime == "" {
+		return time.Time{}
+	}
+	if offset != "" {
+		if t, err := time.Parse(dateTimeLayout+"-07:00", dateTime+offset); err == nil {
+			return t
+		}
+	}
+	t, err := time.Parse(dateTimeLayout, dateTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
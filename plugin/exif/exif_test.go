@@ -0,0 +1,165 @@
+package exif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testEntry struct {
+	tag      uint16
+	dataType uint16
+	count    uint32
+	data     []byte
+}
+
+// buildIFD returns the IFD at the offset of the TIFF structure, followed by the data of its entries.
+func buildIFD(offset int, entries []testEntry) []byte {
+	ifd, data := &bytes.Buffer{}, &bytes.Buffer{}
+	dataOffset := offset + 2 + len(entries)*12 + 4
+	_ = binary.Write(ifd, binary.BigEndian, uint16(len(entries)))
+	for _, entry := range entries {
+		_ = binary.Write(ifd, binary.BigEndian, []uint16{entry.tag, entry.dataType})
+		_ = binary.Write(ifd, binary.BigEndian, entry.count)
+		if len(entry.data) <= 4 {
+			ifd.Write(append(entry.data, make([]byte, 4-len(entry.data))...))
+			continue
+		}
+		_ = binary.Write(ifd, binary.BigEndian, uint32(dataOffset+data.Len()))
+		data.Write(entry.data)
+	}
+	_ = binary.Write(ifd, binary.BigEndian, uint32(0))
+	return append(ifd.Bytes(), data.Bytes()...)
+}
+
+func ascii(value string) testEntry {
+	return testEntry{dataType: typeASCII, count: uint32(len(value) + 1), data: append([]byte(value), 0)}
+}
+
+func rationals(values ...uint32) testEntry {
+	data := &bytes.Buffer{}
+	_ = binary.Write(data, binary.BigEndian, values)
+	return testEntry{dataType: typeRational, count: uint32(len(values) / 2), data: data.Bytes()}
+}
+
+func long(value uint32) testEntry {
+	return testEntry{dataType: typeLong, count: 1, data: binary.BigEndian.AppendUint32(nil, value)}
+}
+
+func withTag(tag uint16, entry testEntry) testEntry {
+	entry.tag = tag
+	return entry
+}
+
+// buildTIFF returns the EXIF data of a photo taken by a camera with a GPS position.
+func buildTIFF() []byte {
+	ifd0Entries := func(exifOffset, gpsOffset uint32) []testEntry {
+		return []testEntry{
+			withTag(tagMake, ascii("Canon")),
+			withTag(tagModel, ascii("EOS R5")),
+			{tag: tagOrientation, dataType: typeShort, count: 1, data: []byte{0, 6}},
+			withTag(tagExifIFD, long(exifOffset)),
+			withTag(tagGPSIFD, long(gpsOffset)),
+		}
+	}
+	exifOffset := 8 + len(buildIFD(8, ifd0Entries(0, 0)))
+	exifIFD := buildIFD(exifOffset, []testEntry{
+		withTag(tagDateTimeOriginal, ascii("2024:05:01 12:30:00")),
+		withTag(tagOffsetTimeOriginal, ascii("+02:00")),
+		withTag(tagPixelXDimension, long(4000)),
+		withTag(tagPixelYDimension, long(3000)),
+		withTag(tagLensModel, ascii("RF24-105mm F4 L IS USM")),
+	})
+	gpsOffset := exifOffset + len(exifIFD)
+	gpsIFD := buildIFD(gpsOffset, []testEntry{
+		withTag(tagGPSLatitudeRef, ascii("N")),
+		withTag(tagGPSLatitude, rationals(48, 1, 51, 1, 36, 1)),
+		withTag(tagGPSLongitudeRef, ascii("W")),
+		withTag(tagGPSLongitude, rationals(2, 1, 21, 1, 0, 1)),
+		{tag: tagGPSAltitudeRef, dataType: typeByte, count: 1, data: []byte{0}},
+		withTag(tagGPSAltitude, rationals(355, 10)),
+	})
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = append(tiff, buildIFD(8, ifd0Entries(uint32(exifOffset), uint32(gpsOffset)))...)
+	tiff = append(tiff, exifIFD...)
+	return append(tiff, gpsIFD...)
+}
+
+func buildJPEG(t *testing.T, tiff []byte) []byte {
+	buffer := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(buffer, image.NewNRGBA(image.Rect(0, 0, 40, 30)), nil))
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := binary.BigEndian.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(segment)+2))
+	return append(append(append([]byte{0xFF, 0xD8}, app1...), segment...), buffer.Bytes()[2:]...)
+}
+
+func buildPNG(t *testing.T, tiff []byte) []byte {
+	buffer := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buffer, image.NewNRGBA(image.Rect(0, 0, 40, 30))))
+	content := buffer.Bytes()
+	// The IHDR chunk is followed by the other chunks.
+	ihdrEnd := len(pngSignature) + 12 + 13
+	chunk := &bytes.Buffer{}
+	writePNGChunk(chunk, "eXIf", tiff)
+	return append(append(append([]byte{}, content[:ihdrEnd]...), chunk.Bytes()...), content[ihdrEnd:]...)
+}
+
+func TestExtract(t *testing.T) {
+	for name, content := range map[string][]byte{"jpeg": buildJPEG(t, buildTIFF()), "png": buildPNG(t, buildTIFF())} {
+		t.Run(name, func(t *testing.T) {
+			metadata, err := Extract(content)
+			require.NoError(t, err)
+			require.NotNil(t, metadata)
+			require.Equal(t, 6, metadata.Orientation)
+			require.Equal(t, "Canon", metadata.Make)
+			require.Equal(t, "EOS R5", metadata.Model)
+			require.Equal(t, "RF24-105mm F4 L IS USM", metadata.LensModel)
+			require.Equal(t, 4000, metadata.Width)
+			require.Equal(t, 3000, metadata.Height)
+			require.True(t, time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC).Equal(metadata.TakenTime))
+			require.NotNil(t, metadata.Location)
+			require.InDelta(t, 48.86, metadata.Location.Latitude, 0.001)
+			require.InDelta(t, -2.35, metadata.Location.Longitude, 0.001)
+			require.InDelta(t, 35.5, metadata.Location.Altitude, 0.001)
+		})
+	}
+
+	metadata, err := Extract([]byte("plain text"))
+	require.NoError(t, err)
+	require.Nil(t, metadata)
+
+	// The truncated data is rejected instead of read out of bounds.
+	tiff := buildTIFF()
+	_, err = Extract(buildJPEG(t, tiff[:12]))
+	require.Error(t, err)
+}
+
+func TestStrip(t *testing.T) {
+	for name, content := range map[string][]byte{"jpeg": buildJPEG(t, buildTIFF()), "png": buildPNG(t, buildTIFF())} {
+		t.Run(name, func(t *testing.T) {
+			stripped, err := Strip(content)
+			require.NoError(t, err)
+			require.Less(t, len(stripped), len(content))
+			_, _, err = image.Decode(bytes.NewReader(stripped))
+			require.NoError(t, err)
+
+			// Only the orientation is kept.
+			metadata, err := Extract(stripped)
+			require.NoError(t, err)
+			require.Equal(t, &Metadata{Orientation: 6}, metadata)
+		})
+	}
+
+	// The images without EXIF data are unchanged.
+	buffer := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buffer, image.NewNRGBA(image.Rect(0, 0, 4, 4))))
+	stripped, err := Strip(buffer.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, buffer.Bytes(), stripped)
+}
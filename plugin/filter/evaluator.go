@@ -0,0 +1,192 @@
+package filter
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	exprv1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// Memo is the memo a filter is evaluated against in-process.
+type Memo struct {
+	Content     string
+	CreatorID   int64
+	CreatedTs   int64
+	UpdatedTs   int64
+	Pinned      bool
+	Tags        []string
+	Visibility  string
+	HasTaskList bool
+}
+
+// Evaluate evaluates the parsed filter against the memo.
+// It supports the same expressions with the same meaning as the SQL conversions of the store drivers.
+func Evaluate(expr *exprv1.Expr, memo *Memo) (bool, error) {
+	switch v := expr.ExprKind.(type) {
+	case *exprv1.Expr_CallExpr:
+		args := v.CallExpr.Args
+		switch v.CallExpr.Function {
+		case "_||_", "_&&_":
+			if len(args) != 2 {
+				return false, errors.Errorf("invalid number of arguments for %s", v.CallExpr.Function)
+			}
+			left, err := Evaluate(args[0], memo)
+			if err != nil {
+				return false, err
+			}
+			right, err := Evaluate(args[1], memo)
+			if err != nil {
+				return false, err
+			}
+			if v.CallExpr.Function == "_||_" {
+				return left || right, nil
+			}
+			return left && right, nil
+		case "!_":
+			if len(args) != 1 {
+				return false, errors.Errorf("invalid number of arguments for %s", v.CallExpr.Function)
+			}
+			result, err := Evaluate(args[0], memo)
+			if err != nil {
+				return false, err
+			}
+			return !result, nil
+		case "_==_", "_!=_", "_<_", "_>_", "_<=_", "_>=_":
+			if len(args) != 2 {
+				return false, errors.Errorf("invalid number of arguments for %s", v.CallExpr.Function)
+			}
+			identifier, err := GetIdentExprName(args[0])
+			if err != nil {
+				return false, err
+			}
+			value, err := GetExprValue(args[1])
+			if err != nil {
+				return false, err
+			}
+			return compare(v.CallExpr.Function, identifier, value, memo)
+		case "@in":
+			if len(args) != 2 {
+				return false, errors.Errorf("invalid number of arguments for %s", v.CallExpr.Function)
+			}
+			identifier, err := GetIdentExprName(args[0])
+			if err != nil {
+				return false, err
+			}
+			values := []string{}
+			for _, element := range args[1].GetListExpr().GetElements() {
+				value, err := GetConstValue(element)
+				if err != nil {
+					return false, err
+				}
+				valueStr, ok := value.(string)
+				if !ok {
+					return false, errors.New("invalid string value")
+				}
+				values = append(values, valueStr)
+			}
+			switch identifier {
+			case "tag":
+				for _, tag := range memo.Tags {
+					if slices.Contains(values, tag) {
+						return true, nil
+					}
+				}
+				return false, nil
+			case "visibility":
+				return slices.Contains(values, memo.Visibility), nil
+			default:
+				return false, errors.Errorf("invalid identifier for %s", v.CallExpr.Function)
+			}
+		case "contains":
+			if len(args) != 1 {
+				return false, errors.Errorf("invalid number of arguments for %s", v.CallExpr.Function)
+			}
+			identifier, err := GetIdentExprName(v.CallExpr.Target)
+			if err != nil {
+				return false, err
+			}
+			if identifier != "content" {
+				return false, errors.Errorf("invalid identifier for %s", v.CallExpr.Function)
+			}
+			arg, err := GetConstValue(args[0])
+			if err != nil {
+				return false, err
+			}
+			argStr, ok := arg.(string)
+			if !ok {
+				return false, errors.New("invalid string value")
+			}
+			return strings.Contains(memo.Content, argStr), nil
+		default:
+			return false, errors.Errorf("unsupported function %s", v.CallExpr.Function)
+		}
+	case *exprv1.Expr_IdentExpr:
+		switch v.IdentExpr.GetName() {
+		case "pinned":
+			return memo.Pinned, nil
+		case "has_task_list":
+			return memo.HasTaskList, nil
+		default:
+			return false, errors.Errorf("invalid identifier %s", v.IdentExpr.GetName())
+		}
+	default:
+		return false, errors.New("unsupported expression")
+	}
+}
+
+func compare(function string, identifier string, value any, memo *Memo) (bool, error) {
+	switch identifier {
+	case "created_ts", "updated_ts", "creator_id":
+		valueInt, ok := value.(int64)
+		if !ok {
+			return false, errors.Errorf("invalid integer value for %s", identifier)
+		}
+		factor := memo.CreatorID
+		if identifier == "created_ts" {
+			factor = memo.CreatedTs
+		} else if identifier == "updated_ts" {
+			factor = memo.UpdatedTs
+		} else if function != "_==_" && function != "_!=_" {
+			return false, errors.Errorf("invalid operator for %s", function)
+		}
+		switch function {
+		case "_==_":
+			return factor == valueInt, nil
+		case "_!=_":
+			return factor != valueInt, nil
+		case "_<_":
+			return factor < valueInt, nil
+		case "_>_":
+			return factor > valueInt, nil
+		case "_<=_":
+			return factor <= valueInt, nil
+		default:
+			return factor >= valueInt, nil
+		}
+	case "visibility", "content":
+		if function != "_==_" && function != "_!=_" {
+			return false, errors.Errorf("invalid operator for %s", function)
+		}
+		valueStr, ok := value.(string)
+		if !ok {
+			return false, errors.New("invalid string value")
+		}
+		factor := memo.Content
+		if identifier == "visibility" {
+			factor = memo.Visibility
+		}
+		return (factor == valueStr) == (function == "_==_"), nil
+	case "has_task_list":
+		if function != "_==_" && function != "_!=_" {
+			return false, errors.Errorf("invalid operator for %s", function)
+		}
+		valueBool, ok := value.(bool)
+		if !ok {
+			return false, errors.New("invalid boolean value for has_task_list")
+		}
+		return (memo.HasTaskList == valueBool) == (function == "_==_"), nil
+	default:
+		return false, errors.Errorf("invalid identifier for %s", function)
+	}
+}
@@ -0,0 +1,48 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	memo := &Memo{
+		Content:     "Deployed v1.2 to production",
+		CreatorID:   1,
+		CreatedTs:   1700000000,
+		UpdatedTs:   1700000100,
+		Tags:        []string{"deploy", "ops"},
+		Visibility:  "PUBLIC",
+		HasTaskList: true,
+	}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: `tag in ["deploy"] && visibility == "PUBLIC"`, want: true},
+		{filter: `tag in ["deploy"] && visibility == "PRIVATE"`, want: false},
+		{filter: `tag in ["dep"]`, want: false},
+		{filter: `!(tag in ["release"])`, want: true},
+		{filter: `visibility in ["PROTECTED", "PUBLIC"]`, want: true},
+		{filter: `content.contains("production")`, want: true},
+		{filter: `creator_id == 2 || pinned`, want: false},
+		{filter: `has_task_list`, want: true},
+		{filter: `has_task_list == false`, want: false},
+		{filter: `created_ts > 1600000000 && updated_ts <= 1700000100`, want: true},
+		{filter: `created_ts > now() - 60 * 60`, want: false},
+	}
+	for _, test := range tests {
+		parsedExpr, err := Parse(test.filter, MemoFilterCELAttributes...)
+		require.NoError(t, err)
+		got, err := Evaluate(parsedExpr.GetExpr(), memo)
+		require.NoError(t, err, test.filter)
+		require.Equal(t, test.want, got, test.filter)
+	}
+
+	// Expressions without an SQL conversion are not supported either.
+	parsedExpr, err := Parse(`content > "a"`, MemoFilterCELAttributes...)
+	require.NoError(t, err)
+	_, err = Evaluate(parsedExpr.GetExpr(), memo)
+	require.Error(t, err)
+}
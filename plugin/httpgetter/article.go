@@ -0,0 +1,401 @@
+package httpgetter
+
+import (
+	"io"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// maxArticleBodySize is the maximum size of a page that will be read for article extraction.
+	maxArticleBodySize = 5 << 20
+	// maxExcerptLength is the maximum length in runes of a generated excerpt.
+	maxExcerptLength = 300
+	// minParagraphLength is the minimum length of a text block to be considered as article content.
+	minParagraphLength = 25
+)
+
+var (
+	unlikelyCandidatesPattern = regexp.MustCompile(`(?i)banner|breadcrumbs|combx|comment|community|cover-wrap|disqus|extra|footer|gdpr|header|legends|menu|related|remark|replies|rss|shoutbox|sidebar|skyscraper|social|sponsor|supplemental|ad-break|agegate|pagination|pager|popup|yom-remote`)
+	maybeCandidatePattern     = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveWeightPattern     = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|pagination|post|text|blog|story`)
+	negativeWeightPattern     = regexp.MustCompile(`(?i)-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|foot|footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+	whitespacePattern         = regexp.MustCompile(`\s+`)
+)
+
+// Article is the main content extracted from a web page.
+type Article struct {
+	// URL is the final URL of the page after redirects.
+	URL string `json:"url"`
+	// Title is the title of the article.
+	Title string `json:"title"`
+	// Excerpt is a short summary of the article.
+	Excerpt string `json:"excerpt"`
+	// Content is the plain text of the article, paragraphs separated by blank lines.
+	Content string `json:"content"`
+	// Image is the absolute URL of the main image of the article.
+	Image string `json:"image"`
+	// SiteName is the name of the site publishing the article.
+	SiteName string `json:"siteName"`
+}
+
+// GetArticle fetches the page at urlStr and extracts its main article content
+// with a readability-style scoring algorithm.
+func GetArticle(urlStr string) (*Article, error) {
+	if err := validateURL(urlStr); err != nil {
+		return nil, err
+	}
+
+	response, err := HTTPClient.Get(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	mediatype, err := getMediatype(response)
+	if err != nil {
+		return nil, err
+	}
+	if mediatype != "text/html" {
+		return nil, errors.New("not a HTML page")
+	}
+
+	article, err := extractArticle(io.LimitReader(response.Body, maxArticleBodySize), response.Request.URL)
+	if err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+func extractArticle(r io.Reader, pageURL *url.URL) (*Article, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse HTML")
+	}
+
+	article := &Article{
+		URL: pageURL.String(),
+	}
+	var description string
+	forEachNode(doc, func(n *html.Node) bool {
+		switch n.DataAtom {
+		case atom.Title:
+			if article.Title == "" {
+				article.Title = normalizeText(textContent(n))
+			}
+		case atom.Meta:
+			key, content := getAttr(n, "property"), getAttr(n, "content")
+			if key == "" {
+				key = getAttr(n, "name")
+			}
+			switch strings.ToLower(key) {
+			case "og:title", "twitter:title":
+				article.Title = normalizeText(content)
+			case "description", "og:description", "twitter:description":
+				if description == "" || strings.HasPrefix(key, "og:") {
+					description = normalizeText(content)
+				}
+			case "og:image", "twitter:image":
+				if article.Image == "" {
+					article.Image = resolveURL(pageURL, content)
+				}
+			case "og:site_name":
+				article.SiteName = normalizeText(content)
+			}
+		case atom.Body:
+			return false
+		}
+		return true
+	})
+
+	body := findFirst(doc, atom.Body)
+	if body == nil {
+		body = doc
+	}
+	removeUnlikelyNodes(body)
+
+	topCandidate := findTopCandidate(body)
+	if topCandidate == nil {
+		topCandidate = body
+	}
+	paragraphs := collectParagraphs(topCandidate)
+	article.Content = strings.Join(paragraphs, "\n\n")
+
+	if article.Title == "" {
+		if h1 := findFirst(body, atom.H1); h1 != nil {
+			article.Title = normalizeText(textContent(h1))
+		}
+	}
+	if article.Image == "" {
+		if img := findFirst(topCandidate, atom.Img); img != nil {
+			article.Image = resolveURL(pageURL, getAttr(img, "src"))
+		}
+	}
+	article.Excerpt = description
+	if article.Excerpt == "" && len(paragraphs) > 0 {
+		article.Excerpt = paragraphs[0]
+	}
+	article.Excerpt = truncateText(article.Excerpt, maxExcerptLength)
+	if article.SiteName == "" {
+		article.SiteName = pageURL.Hostname()
+	}
+	return article, nil
+}
+
+// removeUnlikelyNodes strips nodes that never contain article content, such as scripts,
+// navigation and elements whose class or id looks like a sidebar, comment or advert.
+func removeUnlikelyNodes(root *html.Node) {
+	var toRemove []*html.Node
+	forEachNode(root, func(n *html.Node) bool {
+		if n.Type == html.CommentNode {
+			toRemove = append(toRemove, n)
+			return false
+		}
+		if n.Type != html.ElementNode || n == root {
+			return true
+		}
+		switch n.DataAtom {
+		case atom.Script, atom.Style, atom.Noscript, atom.Iframe, atom.Form, atom.Nav, atom.Aside, atom.Footer, atom.Button, atom.Select, atom.Svg:
+			toRemove = append(toRemove, n)
+			return false
+		case atom.Body, atom.Article, atom.Main, atom.A:
+			return true
+		}
+		matchString := getAttr(n, "class") + " " + getAttr(n, "id")
+		if unlikelyCandidatesPattern.MatchString(matchString) && !maybeCandidatePattern.MatchString(matchString) {
+			toRemove = append(toRemove, n)
+			return false
+		}
+		return true
+	})
+	for _, n := range toRemove {
+		if n.Parent != nil {
+			n.Parent.RemoveChild(n)
+		}
+	}
+}
+
+// findTopCandidate scores the ancestors of every paragraph-like element and returns the
+// element most likely to be the article container.
+func findTopCandidate(root *html.Node) *html.Node {
+	scores := map[*html.Node]float64{}
+	initialize := func(n *html.Node) {
+		if _, ok := scores[n]; ok {
+			return
+		}
+		scores[n] = initialScore(n)
+	}
+
+	forEachNode(root, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch n.DataAtom {
+		case atom.P, atom.Pre, atom.Td, atom.Blockquote:
+		default:
+			return true
+		}
+		text := normalizeText(textContent(n))
+		if utf8.RuneCountInString(text) < minParagraphLength {
+			return false
+		}
+
+		score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "，"))
+		score += math.Min(float64(utf8.RuneCountInString(text))/100, 3)
+
+		level := 0
+		for ancestor := n.Parent; ancestor != nil && ancestor.Type == html.ElementNode && level < 3; ancestor = ancestor.Parent {
+			initialize(ancestor)
+			switch level {
+			case 0:
+				scores[ancestor] += score
+			case 1:
+				scores[ancestor] += score / 2
+			default:
+				scores[ancestor] += score / float64(level*3)
+			}
+			level++
+		}
+		return false
+	})
+
+	var topCandidate *html.Node
+	var topScore float64
+	for n, score := range scores {
+		score *= 1 - linkDensity(n)
+		if topCandidate == nil || score > topScore {
+			topCandidate, topScore = n, score
+		}
+	}
+	return topCandidate
+}
+
+func initialScore(n *html.Node) float64 {
+	var score float64
+	switch n.DataAtom {
+	case atom.Article:
+		score = 10
+	case atom.Div, atom.Main, atom.Section:
+		score = 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score = 3
+	case atom.Address, atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form:
+		score = -3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score = -5
+	}
+	for _, value := range []string{getAttr(n, "class"), getAttr(n, "id")} {
+		if value == "" {
+			continue
+		}
+		if negativeWeightPattern.MatchString(value) {
+			score -= 25
+		}
+		if positiveWeightPattern.MatchString(value) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// linkDensity returns the proportion of the text of n that is inside links.
+func linkDensity(n *html.Node) float64 {
+	textLength := utf8.RuneCountInString(normalizeText(textContent(n)))
+	if textLength == 0 {
+		return 0
+	}
+	linkLength := 0
+	forEachNode(n, func(child *html.Node) bool {
+		if child.DataAtom == atom.A {
+			linkLength += utf8.RuneCountInString(normalizeText(textContent(child)))
+			return false
+		}
+		return true
+	})
+	return float64(linkLength) / float64(textLength)
+}
+
+// collectParagraphs returns the text blocks of the given node in document order.
+func collectParagraphs(root *html.Node) []string {
+	paragraphs := []string{}
+	forEachNode(root, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+		switch n.DataAtom {
+		case atom.P, atom.Pre, atom.Blockquote, atom.Li, atom.H2, atom.H3, atom.H4:
+			text := normalizeText(textContent(n))
+			if text != "" && (n.DataAtom != atom.P || utf8.RuneCountInString(text) >= minParagraphLength || linkDensity(n) < 0.5) {
+				paragraphs = append(paragraphs, text)
+			}
+			return false
+		}
+		return true
+	})
+	if len(paragraphs) == 0 {
+		if text := normalizeText(textContent(root)); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+	return paragraphs
+}
+
+// forEachNode walks the tree in depth-first order. Children of a node are skipped when fn returns false.
+func forEachNode(n *html.Node, fn func(*html.Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		forEachNode(child, fn)
+	}
+}
+
+func findFirst(root *html.Node, a atom.Atom) *html.Node {
+	var found *html.Node
+	forEachNode(root, func(n *html.Node) bool {
+		if found != nil {
+			return false
+		}
+		if n.Type == html.ElementNode && n.DataAtom == a {
+			found = n
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+func textContent(n *html.Node) string {
+	var builder strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			builder.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			if n.DataAtom == atom.Script || n.DataAtom == atom.Style {
+				return
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		// Separate block level elements so that their words are not joined together.
+		if n.Type == html.ElementNode && isBlockElement(n.DataAtom) {
+			builder.WriteString(" ")
+		}
+	}
+	walk(n)
+	return builder.String()
+}
+
+func isBlockElement(a atom.Atom) bool {
+	switch a {
+	case atom.Address, atom.Article, atom.Blockquote, atom.Br, atom.Dd, atom.Div, atom.Dl, atom.Dt, atom.Figcaption,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Hr, atom.Li, atom.Main, atom.Ol, atom.P, atom.Pre,
+		atom.Section, atom.Table, atom.Td, atom.Th, atom.Tr, atom.Ul:
+		return true
+	}
+	return false
+}
+
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func normalizeText(s string) string {
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(s, " "))
+}
+
+func truncateText(s string, length int) string {
+	if utf8.RuneCountInString(s) <= length {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:length])) + "..."
+}
+
+func resolveURL(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(u).String()
+}
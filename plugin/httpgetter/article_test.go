@@ -0,0 +1,71 @@
+package httpgetter
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractArticle(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html>
+<head>
+  <title>Fallback title</title>
+  <meta property="og:title" content="Understanding Readability">
+  <meta property="og:site_name" content="Example Blog">
+  <meta property="og:image" content="/images/cover.png">
+</head>
+<body>
+  <nav><a href="/">Home</a> <a href="/about">About</a></nav>
+  <div class="sidebar">
+    <p>Subscribe to our newsletter, it is great, really, we promise, no spam.</p>
+  </div>
+  <div class="post-content">
+    <h1>Understanding Readability</h1>
+    <p>Readability algorithms score the blocks of a page, and the block with the highest score is taken as the article.</p>
+    <p>Paragraphs with many commas, long sentences, and few links increase the score of their parents.</p>
+    <p>Navigation, sidebars, comments and adverts are removed before scoring, so they never win.</p>
+  </div>
+  <div id="comments"><p>First comment here, with a lot of text that is not part of the article.</p></div>
+  <footer>Copyright</footer>
+</body>
+</html>`
+	pageURL, err := url.Parse("https://example.com/posts/readability")
+	require.NoError(t, err)
+
+	article, err := extractArticle(strings.NewReader(page), pageURL)
+	require.NoError(t, err)
+	require.Equal(t, "Understanding Readability", article.Title)
+	require.Equal(t, "Example Blog", article.SiteName)
+	require.Equal(t, "https://example.com/images/cover.png", article.Image)
+	require.True(t, strings.HasPrefix(article.Content, "Readability algorithms score"))
+	require.Contains(t, article.Content, "so they never win.")
+	require.NotContains(t, article.Content, "newsletter")
+	require.NotContains(t, article.Content, "First comment")
+	require.Equal(t, "Readability algorithms score the blocks of a page, and the block with the highest score is taken as the article.", article.Excerpt)
+}
+
+func TestExtractArticleExcerptFromMeta(t *testing.T) {
+	page := `<html><head><meta name="description" content="A short summary."></head>
+<body><article><p>` + strings.Repeat("word ", 100) + `</p><img src="photo.jpg"></article></body></html>`
+	pageURL, err := url.Parse("https://example.com/a/b")
+	require.NoError(t, err)
+
+	article, err := extractArticle(strings.NewReader(page), pageURL)
+	require.NoError(t, err)
+	require.Equal(t, "A short summary.", article.Excerpt)
+	require.Equal(t, "https://example.com/a/photo.jpg", article.Image)
+	require.Equal(t, "example.com", article.SiteName)
+}
+
+func TestGetArticleForInternal(t *testing.T) {
+	if _, err := GetArticle("http://127.0.0.1/article"); !errors.Is(err, ErrInternalIP) {
+		t.Errorf("Expected error for internal IP, got %v", err)
+	}
+	if _, err := GetImage("http://192.168.0.1/image.png", 1<<20); !errors.Is(err, ErrInternalIP) {
+		t.Errorf("Expected error for internal IP, got %v", err)
+	}
+}
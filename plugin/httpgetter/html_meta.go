@@ -3,8 +3,6 @@ package httpgetter
 import (
 	"fmt"
 	"io"
-	"net"
-	"net/http"
 	"net/url"
 
 	"github.com/pkg/errors"
@@ -14,18 +12,6 @@ import (
 
 var ErrInternalIP = errors.New("internal IP addresses are not allowed")
 
-var httpClient = &http.Client{
-	CheckRedirect: func(req *http.Request, via []*http.Request) error {
-		if err := validateURL(req.URL.String()); err != nil {
-			return errors.Wrap(err, "redirect to internal IP")
-		}
-		if len(via) >= 10 {
-			return errors.New("too many redirects")
-		}
-		return nil
-	},
-}
-
 type HTMLMeta struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
@@ -37,7 +23,7 @@ func GetHTMLMeta(urlStr string) (*HTMLMeta, error) {
 		return nil, err
 	}
 
-	response, err := httpClient.Get(urlStr)
+	response, err := HTTPClient.Get(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +102,7 @@ func extractMetaProperty(token html.Token, prop string) (content string, ok bool
 	return content, ok
 }
 
+// validateURL checks that the URL can be fetched, the internal IP addresses are refused by the HTTP client when dialing.
 func validateURL(urlStr string) error {
 	u, err := url.Parse(urlStr)
 	if err != nil {
@@ -126,31 +113,9 @@ func validateURL(urlStr string) error {
 		return errors.New("only http/https protocols are allowed")
 	}
 
-	host := u.Hostname()
-	if host == "" {
+	if u.Hostname() == "" {
 		return errors.New("empty hostname")
 	}
-
-	// check if the hostname is an IP
-	if ip := net.ParseIP(host); ip != nil {
-		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() {
-			return errors.Wrap(ErrInternalIP, ip.String())
-		}
-		return nil
-	}
-
-	// check if it's a hostname, resolve it and check all returned IPs
-	ips, err := net.LookupIP(host)
-	if err != nil {
-		return errors.Errorf("failed to resolve hostname: %v", err)
-	}
-
-	for _, ip := range ips {
-		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() {
-			return errors.Wrapf(ErrInternalIP, "host=%s, ip=%s", host, ip.String())
-		}
-	}
-
 	return nil
 }
 
@@ -3,22 +3,24 @@ package httpgetter
 import (
 	"errors"
 	"io"
-	"net/http"
-	"net/url"
 	"strings"
 )
 
+// ErrImageTooLarge is returned when the image is larger than the maximum size.
+var ErrImageTooLarge = errors.New("image is too large")
+
 type Image struct {
 	Blob      []byte
 	Mediatype string
 }
 
-func GetImage(urlStr string) (*Image, error) {
-	if _, err := url.Parse(urlStr); err != nil {
+// GetImage fetches the image at urlStr, which must not be larger than maxSize bytes.
+func GetImage(urlStr string, maxSize int64) (*Image, error) {
+	if err := validateURL(urlStr); err != nil {
 		return nil, err
 	}
 
-	response, err := http.Get(urlStr)
+	response, err := HTTPClient.Get(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -32,10 +34,16 @@ func GetImage(urlStr string) (*Image, error) {
 		return nil, errors.New("wrong image mediatype")
 	}
 
-	bodyBytes, err := io.ReadAll(response.Body)
+	if response.ContentLength > maxSize {
+		return nil, ErrImageTooLarge
+	}
+	bodyBytes, err := io.ReadAll(io.LimitReader(response.Body, maxSize+1))
 	if err != nil {
 		return nil, err
 	}
+	if int64(len(bodyBytes)) > maxSize {
+		return nil, ErrImageTooLarge
+	}
 
 	image := &Image{
 		Blob:      bodyBytes,
@@ -0,0 +1,36 @@
+package httpgetter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		if r.URL.Path == "/streamed.png" {
+			// Flushing before writing the body leaves out its length.
+			w.(http.Flusher).Flush()
+		}
+		_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer server.Close()
+	// The test server is on a loopback address, which the default client refuses.
+	defaultClient := HTTPClient
+	HTTPClient = server.Client()
+	defer func() { HTTPClient = defaultClient }()
+
+	image, err := GetImage(server.URL+"/image.png", 100)
+	require.NoError(t, err)
+	require.Equal(t, "image/png", image.Mediatype)
+	require.Len(t, image.Blob, 100)
+
+	_, err = GetImage(server.URL+"/image.png", 99)
+	require.ErrorIs(t, err, ErrImageTooLarge)
+	_, err = GetImage(server.URL+"/streamed.png", 99)
+	require.ErrorIs(t, err, ErrImageTooLarge)
+}
@@ -0,0 +1,291 @@
+package httpgetter
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// maxWebmentionContentLength is the maximum length in runes of the content of a mention.
+	maxWebmentionContentLength = 500
+)
+
+// The types of a mention, derived from the microformats2 class of the link to the target.
+const (
+	WebmentionTypeMention  = "mention"
+	WebmentionTypeReply    = "reply"
+	WebmentionTypeLike     = "like"
+	WebmentionTypeRepost   = "repost"
+	WebmentionTypeBookmark = "bookmark"
+)
+
+// ErrWebmentionTargetNotFound is returned when the source of a Webmention does not link to its target.
+var ErrWebmentionTargetNotFound = errors.New("source does not link to target")
+
+// ErrWebmentionSourceGone is returned when the source of a Webmention has been deleted.
+var ErrWebmentionSourceGone = errors.New("source is gone")
+
+var linkHeaderPattern = regexp.MustCompile(`<([^>]*)>\s*;[^,]*?rel="?([^",]*)"?`)
+
+// WebmentionSource is the page that mentions a target, as found when verifying a Webmention.
+type WebmentionSource struct {
+	// URL is the final URL of the source after redirects.
+	URL string `json:"url"`
+	// Type is the kind of mention, e.g. a reply or a like.
+	Type string `json:"type"`
+	// Content is the plain text of the mentioning entry.
+	Content string `json:"content"`
+	// AuthorName is the name of the author of the mentioning entry.
+	AuthorName string `json:"authorName"`
+	// AuthorURL is the URL of the author of the mentioning entry.
+	AuthorURL string `json:"authorUrl"`
+	// AuthorPhoto is the URL of the photo of the author.
+	AuthorPhoto string `json:"authorPhoto"`
+	// Published is the publication time of the mentioning entry as written on the page.
+	Published string `json:"published"`
+}
+
+// GetWebmentionSource fetches the source of a Webmention and verifies that it links to the target.
+func GetWebmentionSource(sourceURL string, targetURL string) (*WebmentionSource, error) {
+	if err := validateURL(sourceURL); err != nil {
+		return nil, err
+	}
+
+	response, err := HTTPClient.Get(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusGone {
+		return nil, ErrWebmentionSourceGone
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, errors.Errorf("failed to fetch source, status code: %d", response.StatusCode)
+	}
+
+	mediatype, err := getMediatype(response)
+	if err != nil {
+		return nil, err
+	}
+	if mediatype != "text/html" {
+		return nil, errors.New("not a HTML page")
+	}
+
+	return extractWebmentionSource(io.LimitReader(response.Body, maxArticleBodySize), response.Request.URL, targetURL)
+}
+
+// GetWebmentionEndpoint discovers the Webmention endpoint of the target.
+// An empty string is returned when the target does not accept Webmentions.
+func GetWebmentionEndpoint(targetURL string) (string, error) {
+	if err := validateURL(targetURL); err != nil {
+		return "", err
+	}
+
+	response, err := HTTPClient.Get(targetURL)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	for _, link := range response.Header.Values("Link") {
+		if endpoint := extractWebmentionEndpointFromLinkHeader(link, response.Request.URL); endpoint != "" {
+			return endpoint, nil
+		}
+	}
+	mediatype, err := getMediatype(response)
+	if err != nil || mediatype != "text/html" {
+		return "", nil
+	}
+	return extractWebmentionEndpoint(io.LimitReader(response.Body, maxArticleBodySize), response.Request.URL)
+}
+
+// SendWebmention notifies the endpoint that the source links to the target.
+func SendWebmention(endpoint string, sourceURL string, targetURL string) error {
+	if err := validateURL(endpoint); err != nil {
+		return err
+	}
+
+	response, err := HTTPClient.PostForm(endpoint, url.Values{
+		"source": {sourceURL},
+		"target": {targetURL},
+	})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return errors.Errorf("failed to send webmention to %s, status code: %d", endpoint, response.StatusCode)
+	}
+	return nil
+}
+
+func extractWebmentionSource(r io.Reader, pageURL *url.URL, targetURL string) (*WebmentionSource, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse HTML")
+	}
+
+	// Find the first element linking to the target.
+	target := normalizeWebmentionURL(targetURL)
+	var link *html.Node
+	forEachNode(doc, func(n *html.Node) bool {
+		if link != nil {
+			return false
+		}
+		if n.Type != html.ElementNode {
+			return true
+		}
+		for _, key := range []string{"href", "src"} {
+			if ref := getAttr(n, key); ref != "" && normalizeWebmentionURL(resolveURL(pageURL, ref)) == target {
+				link = n
+				return false
+			}
+		}
+		return true
+	})
+	if link == nil {
+		return nil, ErrWebmentionTargetNotFound
+	}
+
+	source := &WebmentionSource{
+		URL:  pageURL.String(),
+		Type: WebmentionTypeMention,
+	}
+	switch {
+	case hasClass(link, "u-in-reply-to"):
+		source.Type = WebmentionTypeReply
+	case hasClass(link, "u-like-of"):
+		source.Type = WebmentionTypeLike
+	case hasClass(link, "u-repost-of"):
+		source.Type = WebmentionTypeRepost
+	case hasClass(link, "u-bookmark-of"):
+		source.Type = WebmentionTypeBookmark
+	}
+
+	// The mentioning entry is the h-entry around the link, falling back to the whole page.
+	entry := doc
+	for n := link.Parent; n != nil; n = n.Parent {
+		if hasClass(n, "h-entry") {
+			entry = n
+			break
+		}
+	}
+	if content := findFirstWithClass(entry, "e-content", "p-content", "p-summary", "p-name"); content != nil {
+		source.Content = truncateText(normalizeText(textContent(content)), maxWebmentionContentLength)
+	} else if title := findFirst(doc, atom.Title); title != nil {
+		source.Content = truncateText(normalizeText(textContent(title)), maxWebmentionContentLength)
+	}
+	if published := findFirstWithClass(entry, "dt-published"); published != nil {
+		source.Published = getAttr(published, "datetime")
+		if source.Published == "" {
+			source.Published = normalizeText(textContent(published))
+		}
+	}
+	if author := findFirstWithClass(entry, "p-author", "u-author"); author != nil {
+		if name := findFirstWithClass(author, "p-name"); name != nil {
+			source.AuthorName = normalizeText(textContent(name))
+		} else {
+			source.AuthorName = normalizeText(textContent(author))
+		}
+		if u := findFirstWithClass(author, "u-url"); u != nil {
+			source.AuthorURL = resolveURL(pageURL, getAttr(u, "href"))
+		} else if href := getAttr(author, "href"); href != "" {
+			source.AuthorURL = resolveURL(pageURL, href)
+		}
+		if photo := findFirstWithClass(author, "u-photo"); photo != nil {
+			source.AuthorPhoto = resolveURL(pageURL, getAttr(photo, "src"))
+		}
+	}
+	if source.AuthorName == "" {
+		forEachNode(doc, func(n *html.Node) bool {
+			if n.Type == html.ElementNode && n.DataAtom == atom.Meta && getAttr(n, "name") == "author" {
+				source.AuthorName = normalizeText(getAttr(n, "content"))
+				return false
+			}
+			return source.AuthorName == ""
+		})
+	}
+	if source.AuthorName == "" {
+		source.AuthorName = pageURL.Hostname()
+	}
+	return source, nil
+}
+
+func extractWebmentionEndpointFromLinkHeader(header string, pageURL *url.URL) string {
+	for _, match := range linkHeaderPattern.FindAllStringSubmatch(header, -1) {
+		if slices.Contains(strings.Fields(match[2]), "webmention") {
+			return resolveURL(pageURL, match[1])
+		}
+	}
+	return ""
+}
+
+func extractWebmentionEndpoint(r io.Reader, pageURL *url.URL) (string, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse HTML")
+	}
+	endpoint, found := "", false
+	forEachNode(doc, func(n *html.Node) bool {
+		if found {
+			return false
+		}
+		if n.Type == html.ElementNode && (n.DataAtom == atom.Link || n.DataAtom == atom.A) {
+			if slices.Contains(strings.Fields(getAttr(n, "rel")), "webmention") {
+				// An empty href is valid and refers to the page itself.
+				endpoint, found = resolveURL(pageURL, getAttr(n, "href")), true
+				if endpoint == "" {
+					endpoint = pageURL.String()
+				}
+				return false
+			}
+		}
+		return true
+	})
+	return endpoint, nil
+}
+
+func findFirstWithClass(root *html.Node, classes ...string) *html.Node {
+	for _, class := range classes {
+		var found *html.Node
+		forEachNode(root, func(n *html.Node) bool {
+			if found != nil {
+				return false
+			}
+			if hasClass(n, class) {
+				found = n
+				return false
+			}
+			return true
+		})
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func hasClass(n *html.Node, class string) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	return slices.Contains(strings.Fields(getAttr(n, "class")), class)
+}
+
+// normalizeWebmentionURL makes URLs that only differ in their fragment or trailing slash comparable.
+func normalizeWebmentionURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.Fragment = ""
+	return strings.TrimSuffix(u.String(), "/")
+}
@@ -0,0 +1,80 @@
+package httpgetter
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractWebmentionSource(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html>
+<head><title>A reply</title></head>
+<body>
+  <article class="h-entry">
+    <a class="p-author h-card" href="/about"><img class="u-photo" src="/me.jpg"><span class="p-name">Jane Doe</span></a>
+    <p>In reply to <a class="u-in-reply-to" href="https://memos.example.com/memos/abc#top">a memo</a></p>
+    <div class="e-content"><p>Great point,</p><p>I agree.</p></div>
+    <time class="dt-published" datetime="2025-01-02T03:04:05Z">January 2</time>
+  </article>
+</body>
+</html>`
+	pageURL, err := url.Parse("https://blog.example.org/replies/1")
+	require.NoError(t, err)
+
+	source, err := extractWebmentionSource(strings.NewReader(page), pageURL, "https://memos.example.com/memos/abc/")
+	require.NoError(t, err)
+	require.Equal(t, WebmentionTypeReply, source.Type)
+	require.Equal(t, "Great point, I agree.", source.Content)
+	require.Equal(t, "Jane Doe", source.AuthorName)
+	require.Equal(t, "https://blog.example.org/about", source.AuthorURL)
+	require.Equal(t, "https://blog.example.org/me.jpg", source.AuthorPhoto)
+	require.Equal(t, "2025-01-02T03:04:05Z", source.Published)
+
+	// A plain link without microformats is a mention attributed to the site.
+	page = `<html><head><title>Links of the week</title></head><body><p>See <a href="/memos/abc">this</a>.</p></body></html>`
+	pageURL, err = url.Parse("https://memos.example.com/links")
+	require.NoError(t, err)
+	source, err = extractWebmentionSource(strings.NewReader(page), pageURL, "https://memos.example.com/memos/abc")
+	require.NoError(t, err)
+	require.Equal(t, WebmentionTypeMention, source.Type)
+	require.Equal(t, "Links of the week", source.Content)
+	require.Equal(t, "memos.example.com", source.AuthorName)
+
+	_, err = extractWebmentionSource(strings.NewReader(page), pageURL, "https://memos.example.com/memos/other")
+	require.ErrorIs(t, err, ErrWebmentionTargetNotFound)
+}
+
+func TestExtractWebmentionEndpoint(t *testing.T) {
+	pageURL, err := url.Parse("https://example.com/posts/1")
+	require.NoError(t, err)
+
+	require.Equal(t, "https://example.com/webmention", extractWebmentionEndpointFromLinkHeader(`</webmention>; rel="webmention"`, pageURL))
+	require.Equal(t, "https://hooks.example.net/wm", extractWebmentionEndpointFromLinkHeader(`<https://example.com/feed>; rel="alternate", <https://hooks.example.net/wm>; rel="webmention other"`, pageURL))
+	require.Equal(t, "", extractWebmentionEndpointFromLinkHeader(`</feed>; rel="alternate"`, pageURL))
+
+	endpoint, err := extractWebmentionEndpoint(strings.NewReader(`<html><head><link rel="stylesheet" href="/a.css"><link rel="webmention" href="/endpoint?x=1"></head></html>`), pageURL)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/endpoint?x=1", endpoint)
+	endpoint, err = extractWebmentionEndpoint(strings.NewReader(`<html><body><a rel="webmention" href="">here</a></body></html>`), pageURL)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/posts/1", endpoint)
+	endpoint, err = extractWebmentionEndpoint(strings.NewReader(`<html><body>nothing</body></html>`), pageURL)
+	require.NoError(t, err)
+	require.Equal(t, "", endpoint)
+}
+
+func TestWebmentionForInternal(t *testing.T) {
+	if _, err := GetWebmentionSource("http://127.0.0.1/post", "https://example.com/memos/abc"); !errors.Is(err, ErrInternalIP) {
+		t.Errorf("Expected error for internal IP, got %v", err)
+	}
+	if _, err := GetWebmentionEndpoint("http://192.168.0.1/post"); !errors.Is(err, ErrInternalIP) {
+		t.Errorf("Expected error for internal IP, got %v", err)
+	}
+	if err := SendWebmention("http://10.0.0.1/webmention", "https://example.com/memos/abc", "https://example.org/post"); !errors.Is(err, ErrInternalIP) {
+		t.Errorf("Expected error for internal IP, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+// Package local stores the contents as files in the local file system.
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/storage"
+)
+
+// Backend stores the contents as files, the keys are the slash separated paths of the files
+// which are either absolute or relative to the root folder.
+type Backend struct {
+	root string
+}
+
+var _ storage.Backend = (*Backend)(nil)
+
+func NewBackend(root string) *Backend {
+	return &Backend{root: root}
+}
+
+func (b *Backend) getPath(key string) string {
+	p := filepath.FromSlash(key)
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(b.root, p)
+	}
+	return p
+}
+
+// Put writes the content to a temporary file first, so that the file is never left half written.
+func (b *Backend) Put(_ context.Context, key string, _ string, content io.Reader) (string, error) {
+	p := b.getPath(key)
+	if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+		return "", errors.Wrap(err, "failed to create directory")
+	}
+	tempPath := p + ".tmp"
+	file, err := os.Create(tempPath)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create file")
+	}
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		os.Remove(tempPath)
+		return "", errors.Wrap(err, "failed to write file")
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tempPath)
+		return "", errors.Wrap(err, "failed to write file")
+	}
+	if err := os.Rename(tempPath, p); err != nil {
+		os.Remove(tempPath)
+		return "", errors.Wrap(err, "failed to rename file")
+	}
+	return key, nil
+}
+
+func (b *Backend) Get(_ context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	file, err := os.Open(b.getPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, errors.Wrap(err, "failed to seek file")
+	}
+	if length < 0 {
+		return file, nil
+	}
+	return &limitedFile{Reader: io.LimitReader(file, length), file: file}, nil
+}
+
+func (b *Backend) Delete(_ context.Context, key string) error {
+	if err := os.Remove(b.getPath(key)); err != nil {
+		if os.IsNotExist(err) {
+			return storage.ErrNotFound
+		}
+		return errors.Wrap(err, "failed to delete file")
+	}
+	return nil
+}
+
+// Presign isn't supported, the files are served by the server.
+func (*Backend) Presign(_ context.Context, _ string, _ time.Duration) (string, error) {
+	return "", storage.ErrNotSupported
+}
+
+func (b *Backend) Stat(_ context.Context, key string) (*storage.ObjectInfo, error) {
+	info, err := os.Stat(b.getPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, errors.Wrap(err, "failed to stat file")
+	}
+	return &storage.ObjectInfo{
+		Size:         info.Size(),
+		ModifiedTime: info.ModTime(),
+	}, nil
+}
+
+type limitedFile struct {
+	io.Reader
+	file *os.File
+}
+
+func (f *limitedFile) Close() error {
+	return f.file.Close()
+}
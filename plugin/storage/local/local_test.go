@@ -0,0 +1,65 @@
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/storage"
+)
+
+func TestBackend(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	backend := NewBackend(root)
+
+	key, err := backend.Put(ctx, "assets/2025/hello.txt", "text/plain", strings.NewReader("hello world"))
+	require.NoError(t, err)
+	require.Equal(t, "assets/2025/hello.txt", key)
+	content, err := os.ReadFile(filepath.Join(root, "assets", "2025", "hello.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(content))
+
+	info, err := backend.Stat(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, int64(11), info.Size)
+
+	read := func(offset, length int64) string {
+		body, err := backend.Get(ctx, key, offset, length)
+		require.NoError(t, err)
+		defer body.Close()
+		content, err := io.ReadAll(body)
+		require.NoError(t, err)
+		return string(content)
+	}
+	require.Equal(t, "hello world", read(0, -1))
+	require.Equal(t, "world", read(6, -1))
+	require.Equal(t, "lo w", read(3, 4))
+
+	// The content is replaced.
+	_, err = backend.Put(ctx, key, "text/plain", strings.NewReader("bye"))
+	require.NoError(t, err)
+	require.Equal(t, "bye", read(0, -1))
+
+	// The absolute keys aren't relative to the root folder.
+	absoluteKey := filepath.ToSlash(filepath.Join(t.TempDir(), "absolute.txt"))
+	_, err = backend.Put(ctx, absoluteKey, "text/plain", strings.NewReader("absolute"))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.FromSlash(absoluteKey))
+	require.NoError(t, err)
+
+	_, err = backend.Presign(ctx, key, storage.PresignExpiration)
+	require.ErrorIs(t, err, storage.ErrNotSupported)
+
+	require.NoError(t, backend.Delete(ctx, key))
+	_, err = backend.Get(ctx, key, 0, -1)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = backend.Stat(ctx, key)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	require.ErrorIs(t, backend.Delete(ctx, key), storage.ErrNotFound)
+}
@@ -2,7 +2,9 @@ package s3
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -10,16 +12,21 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/pkg/errors"
 
+	"github.com/usememos/memos/plugin/storage"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
+// Client stores the contents as objects in a bucket of S3, the keys are the keys of the objects.
 type Client struct {
 	Client *s3.Client
 	Bucket *string
 }
 
+var _ storage.Backend = (*Client)(nil)
+
 func NewClient(ctx context.Context, s3Config *storepb.StorageS3Config) (*Client, error) {
 	cfg, err := config.LoadDefaultConfig(ctx,
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(s3Config.AccessKeyId, s3Config.AccessKeySecret, "")),
@@ -41,13 +48,13 @@ func NewClient(ctx context.Context, s3Config *storepb.StorageS3Config) (*Client,
 	}, nil
 }
 
-// UploadObject uploads an object to S3.
-func (c *Client) UploadObject(ctx context.Context, key string, fileType string, content io.Reader) (string, error) {
+// Put uploads the content as an object in S3.
+func (c *Client) Put(ctx context.Context, key string, contentType string, content io.Reader) (string, error) {
 	uploader := manager.NewUploader(c.Client)
 	putInput := s3.PutObjectInput{
 		Bucket:      c.Bucket,
 		Key:         aws.String(key),
-		ContentType: aws.String(fileType),
+		ContentType: aws.String(contentType),
 		Body:        content,
 	}
 	result, err := uploader.Upload(ctx, &putInput)
@@ -62,31 +69,80 @@ func (c *Client) UploadObject(ctx context.Context, key string, fileType string,
 	return *resultKey, nil
 }
 
-// PresignGetObject presigns an object in S3.
-func (c *Client) PresignGetObject(ctx context.Context, key string) (string, error) {
+// Get requests the range of an object in S3.
+func (c *Client) Get(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	getInput := &s3.GetObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(key),
+	}
+	if length >= 0 {
+		if length == 0 {
+			return io.NopCloser(strings.NewReader("")), nil
+		}
+		getInput.Range = aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	} else if offset > 0 {
+		getInput.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+	}
+	output, err := c.Client.GetObject(ctx, getInput)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+	return output.Body, nil
+}
+
+// Delete deletes an object in S3.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	_, err := c.Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: c.Bucket,
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to delete object")
+	}
+	return nil
+}
+
+// Presign presigns an object in S3 for downloading.
+func (c *Client) Presign(ctx context.Context, key string, expires time.Duration) (string, error) {
 	presignClient := s3.NewPresignClient(c.Client)
 	presignResult, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(*c.Bucket),
 		Key:    aws.String(key),
 	}, func(opts *s3.PresignOptions) {
-		// Set the expiration time of the presigned URL to 5 days.
-		// Reference: https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
-		opts.Expires = time.Duration(5 * 24 * time.Hour)
+		opts.Expires = expires
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to presign put object")
+		return "", errors.Wrap(err, "failed to presign get object")
 	}
 	return presignResult.URL, nil
 }
 
-// DeleteObject deletes an object in S3.
-func (c *Client) DeleteObject(ctx context.Context, key string) error {
-	_, err := c.Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+// Stat requests the head of an object in S3.
+func (c *Client) Stat(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	output, err := c.Client.HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: c.Bucket,
 		Key:    aws.String(key),
 	})
 	if err != nil {
-		return errors.Wrap(err, "failed to delete object")
+		if isNotFound(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, errors.Wrap(err, "failed to head object")
 	}
-	return nil
+	objectInfo := &storage.ObjectInfo{
+		Size: aws.ToInt64(output.ContentLength),
+	}
+	if output.LastModified != nil {
+		objectInfo.ModifiedTime = *output.LastModified
+	}
+	return objectInfo, nil
+}
+
+func isNotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	return errors.As(err, &noSuchKey) || errors.As(err, &notFound)
 }
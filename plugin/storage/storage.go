@@ -0,0 +1,107 @@
+// Package storage defines the backends the contents of the attachments are stored in.
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// PresignExpiration is how long the presigned URLs of the contents are valid.
+// Reference: https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
+const PresignExpiration = 5 * 24 * time.Hour
+
+var (
+	// ErrNotFound is returned when no content is stored with the key.
+	ErrNotFound = errors.New("content not found")
+	// ErrNotSupported is returned by the backends which don't support an operation, such as presigning local files.
+	ErrNotSupported = errors.New("operation not supported")
+)
+
+// Backend stores the contents by their keys.
+type Backend interface {
+	// Put stores the content with the key, replacing the content stored with it, and returns the key of the stored content.
+	Put(ctx context.Context, key string, contentType string, content io.Reader) (string, error)
+	// Get opens the content from the offset, a negative length reads the content to its end.
+	Get(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error)
+	// Delete deletes the content.
+	Delete(ctx context.Context, key string) error
+	// Presign returns a URL the content can be downloaded from directly for the duration.
+	Presign(ctx context.Context, key string, expires time.Duration) (string, error)
+	// Stat returns the information of the content.
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+}
+
+// ObjectInfo is the information of a stored content.
+type ObjectInfo struct {
+	Size         int64
+	ModifiedTime time.Time
+}
+
+// NewReader returns a reader of the content of the size stored in the backend.
+// The content is requested from the offset of the first read after each seek, so only the read ranges are downloaded.
+func NewReader(ctx context.Context, backend Backend, key string, size int64) io.ReadSeekCloser {
+	return &reader{
+		ctx:     ctx,
+		backend: backend,
+		key:     key,
+		size:    size,
+	}
+}
+
+type reader struct {
+	ctx     context.Context
+	backend Backend
+	key     string
+	size    int64
+	offset  int64
+	body    io.ReadCloser
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.backend.Get(r.ctx, r.key, r.offset, -1)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to get content")
+		}
+		r.body = body
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	if offset != r.offset && r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+func (r *reader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
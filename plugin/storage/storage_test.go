@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBackend serves a single content and counts the requests.
+type memoryBackend struct {
+	content  string
+	requests int
+}
+
+func (*memoryBackend) Put(_ context.Context, key string, _ string, _ io.Reader) (string, error) {
+	return key, nil
+}
+
+func (b *memoryBackend) Get(_ context.Context, _ string, offset int64, length int64) (io.ReadCloser, error) {
+	b.requests++
+	content := b.content[offset:]
+	if length >= 0 {
+		content = content[:length]
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func (*memoryBackend) Delete(_ context.Context, _ string) error {
+	return nil
+}
+
+func (*memoryBackend) Presign(_ context.Context, _ string, _ time.Duration) (string, error) {
+	return "", ErrNotSupported
+}
+
+func (b *memoryBackend) Stat(_ context.Context, _ string) (*ObjectInfo, error) {
+	return &ObjectInfo{Size: int64(len(b.content))}, nil
+}
+
+func TestReader(t *testing.T) {
+	backend := &memoryBackend{content: "hello world"}
+	reader := NewReader(context.Background(), backend, "key", int64(len(backend.content)))
+	defer reader.Close()
+
+	// Nothing is requested until the first read.
+	offset, err := reader.Seek(-5, io.SeekEnd)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), offset)
+	require.Equal(t, 0, backend.requests)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "world", string(content))
+	require.Equal(t, 1, backend.requests)
+
+	_, err = reader.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	buffer := make([]byte, 5)
+	_, err = io.ReadFull(reader, buffer)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(buffer))
+	require.Equal(t, 2, backend.requests)
+
+	// Seeking to the current offset keeps reading the same response.
+	_, err = reader.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	_, err = io.ReadFull(reader, buffer)
+	require.NoError(t, err)
+	require.Equal(t, " worl", string(buffer))
+	require.Equal(t, 2, backend.requests)
+
+	_, err = reader.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+}
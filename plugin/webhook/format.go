@@ -0,0 +1,220 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// The limits of the text fields of the messages of each service.
+const (
+	maxSlackTextLength    = 3000
+	maxDiscordTextLength  = 4096
+	maxDiscordTitleLength = 256
+	maxTeamsTextLength    = 20000
+)
+
+// Message is the data the formatted requests are rendered from.
+// It is the data of custom templates, e.g. `{"text": {{json .Title}}}`.
+type Message struct {
+	Payload *v1pb.WebhookRequestPayload
+	// Title summarizes the event, e.g. "Memo created".
+	Title string
+	// Text is the content of the event, e.g. the content of the memo or the comment.
+	Text string
+	// URL is the link to the memo, it is empty if the instance URL is unknown.
+	URL string
+}
+
+// NewMessage returns the message of the payload, with links relative to the instance URL.
+func NewMessage(payload *v1pb.WebhookRequestPayload, instanceURL string) *Message {
+	message := &Message{
+		Payload: payload,
+		Title:   getMessageTitle(payload.ActivityType),
+		Text:    getMessageText(payload),
+	}
+	if instanceURL != "" && payload.GetMemo().GetName() != "" {
+		message.URL = strings.TrimSuffix(instanceURL, "/") + "/" + payload.Memo.Name
+	}
+	return message
+}
+
+// ParseTemplate parses a custom template, with the `json` function to encode values as JSON.
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(text)
+}
+
+// Render returns the request body of the message in the format, the raw format is the protojson encoded payload.
+func Render(format storepb.WebhookPayload_Format, templateText string, message *Message) ([]byte, error) {
+	switch format {
+	case storepb.WebhookPayload_SLACK:
+		return json.Marshal(renderSlack(message))
+	case storepb.WebhookPayload_DISCORD:
+		return json.Marshal(renderDiscord(message))
+	case storepb.WebhookPayload_TEAMS:
+		return json.Marshal(renderTeams(message))
+	case storepb.WebhookPayload_TEMPLATE:
+		tmpl, err := ParseTemplate(templateText)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse webhook template")
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, message); err != nil {
+			return nil, errors.Wrap(err, "failed to execute webhook template")
+		}
+		return buf.Bytes(), nil
+	default:
+		return protojson.Marshal(message.Payload)
+	}
+}
+
+func renderSlack(message *Message) map[string]any {
+	// Slack mrkdwn only requires these characters to be escaped.
+	escaper := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	title := "*" + escaper.Replace(message.Title) + "*"
+	if message.URL != "" {
+		title = "*<" + message.URL + "|" + escaper.Replace(message.Title) + ">*"
+	}
+	blocks := []map[string]any{{
+		"type": "section",
+		"text": map[string]any{"type": "mrkdwn", "text": title},
+	}}
+	if content := message.Text; content != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": truncate(escaper.Replace(content), maxSlackTextLength)},
+		})
+	}
+	if creator := message.Payload.GetCreator(); creator != "" {
+		blocks = append(blocks, map[string]any{
+			"type":     "context",
+			"elements": []map[string]any{{"type": "mrkdwn", "text": escaper.Replace(creator)}},
+		})
+	}
+	return map[string]any{
+		// The text is the fallback of notifications.
+		"text":   truncate(message.Title+": "+message.Text, maxSlackTextLength),
+		"blocks": blocks,
+	}
+}
+
+func renderDiscord(message *Message) map[string]any {
+	embed := map[string]any{
+		"title":       truncate(message.Title, maxDiscordTitleLength),
+		"description": truncate(message.Text, maxDiscordTextLength),
+	}
+	if message.URL != "" {
+		embed["url"] = message.URL
+	}
+	if createTime := message.Payload.GetCreateTime(); createTime != nil {
+		embed["timestamp"] = createTime.AsTime().Format(time.RFC3339)
+	}
+	if creator := message.Payload.GetCreator(); creator != "" {
+		embed["footer"] = map[string]any{"text": creator}
+	}
+	return map[string]any{
+		"embeds": []map[string]any{embed},
+	}
+}
+
+func renderTeams(message *Message) map[string]any {
+	body := []map[string]any{{
+		"type":   "TextBlock",
+		"text":   message.Title,
+		"weight": "Bolder",
+		"size":   "Medium",
+		"wrap":   true,
+	}}
+	if content := message.Text; content != "" {
+		body = append(body, map[string]any{
+			"type": "TextBlock",
+			"text": truncate(content, maxTeamsTextLength),
+			"wrap": true,
+		})
+	}
+	card := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if message.URL != "" {
+		card["actions"] = []map[string]any{{
+			"type":  "Action.OpenUrl",
+			"title": "Open memo",
+			"url":   message.URL,
+		}}
+	}
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content":     card,
+		}},
+	}
+}
+
+func getMessageTitle(activityType string) string {
+	switch activityType {
+	case "memos.memo.created":
+		return "Memo created"
+	case "memos.memo.updated":
+		return "Memo updated"
+	case "memos.memo.deleted":
+		return "Memo deleted"
+	case "memos.comment.created":
+		return "Comment created"
+	case "memos.reaction.added":
+		return "Reaction added"
+	case "memos.reaction.removed":
+		return "Reaction removed"
+	case "memos.attachment.uploaded":
+		return "Attachment uploaded"
+	case "memos.attachment.deleted":
+		return "Attachment deleted"
+	case "memos.memo.relation.changed":
+		return "Memo relations changed"
+	case "memos.user.created":
+		return "User created"
+	case "memos.user.signed_in":
+		return "User signed in"
+	default:
+		return activityType
+	}
+}
+
+func getMessageText(payload *v1pb.WebhookRequestPayload) string {
+	switch event := payload.Event.(type) {
+	case *v1pb.WebhookRequestPayload_Comment:
+		return event.Comment.GetContent()
+	case *v1pb.WebhookRequestPayload_Reaction:
+		return event.Reaction.GetReactionType()
+	case *v1pb.WebhookRequestPayload_Attachment:
+		return event.Attachment.GetFilename()
+	case *v1pb.WebhookRequestPayload_User:
+		return event.User.GetUsername()
+	default:
+		return payload.GetMemo().GetContent()
+	}
+}
+
+// truncate truncates s to at most length runes.
+func truncate(s string, length int) string {
+	if runes := []rune(s); len(runes) > length {
+		return string(runes[:length-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,63 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestRender(t *testing.T) {
+	message := NewMessage(&v1pb.WebhookRequestPayload{
+		ActivityType: "memos.memo.created",
+		Memo: &v1pb.Memo{
+			Name:    "memos/1",
+			Content: "hello <world>",
+		},
+	}, "http://localhost:8080/")
+	require.Equal(t, "Memo created", message.Title)
+	require.Equal(t, "http://localhost:8080/memos/1", message.URL)
+
+	tests := []struct {
+		format   storepb.WebhookPayload_Format
+		contains string
+	}{
+		{format: storepb.WebhookPayload_RAW, contains: `"activityType":"memos.memo.created"`},
+		{format: storepb.WebhookPayload_SLACK, contains: `"blocks"`},
+		{format: storepb.WebhookPayload_DISCORD, contains: `"embeds"`},
+		{format: storepb.WebhookPayload_TEAMS, contains: `"AdaptiveCard"`},
+	}
+	for _, test := range tests {
+		body, err := Render(test.format, "", message)
+		require.NoError(t, err)
+		require.True(t, json.Valid(body), test.format.String())
+		require.Contains(t, string(body), test.contains, test.format.String())
+	}
+
+	body, err := Render(storepb.WebhookPayload_TEMPLATE, `{"text": {{json .Payload.Memo.Content}}, "url": "{{.URL}}"}`, message)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"text": "hello <world>", "url": "http://localhost:8080/memos/1"}`, string(body))
+
+	_, err = Render(storepb.WebhookPayload_TEMPLATE, `{{.Missing`, message)
+	require.Error(t, err)
+}
+
+func TestPostFormatValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"code":1}`)
+	}))
+	defer server.Close()
+
+	// Only the raw format expects a memos style response.
+	_, err := Post(context.Background(), &Request{URL: server.URL, Body: []byte(`{}`), Format: storepb.WebhookPayload_RAW})
+	require.Error(t, err)
+	_, err = Post(context.Background(), &Request{URL: server.URL, Body: []byte(`{}`), Format: storepb.WebhookPayload_SLACK})
+	require.NoError(t, err)
+}
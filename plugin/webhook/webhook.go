@@ -2,16 +2,21 @@ package webhook
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
-	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
-	"google.golang.org/protobuf/encoding/protojson"
 
-	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
 var (
@@ -19,62 +24,128 @@ var (
 	timeout = 30 * time.Second
 )
 
-// Post posts the message to webhook endpoint.
-func Post(requestPayload *v1pb.WebhookRequestPayload) error {
-	body, err := protojson.Marshal(requestPayload)
-	if err != nil {
-		return errors.Wrapf(err, "failed to marshal webhook request to %s", requestPayload.Url)
+const (
+	// SignatureHeader is the header of the signature of a request, in the form of `t=<timestamp>,v1=<signature>`.
+	SignatureHeader = "X-Memos-Signature"
+	// DeliveryHeader is the header of the delivery ID of a request, receivers use it to reject replays.
+	DeliveryHeader = "X-Memos-Delivery"
+
+	signatureVersion = "v1"
+)
+
+// maxResponseBodySize is the maximum size of the response body read from a webhook endpoint.
+const maxResponseBodySize = 64 << 10
+
+// Response is the response of a webhook endpoint.
+type Response struct {
+	StatusCode int
+	Body       []byte
+	Latency    time.Duration
+}
+
+// Request is a request to a webhook endpoint.
+type Request struct {
+	URL  string
+	Body []byte
+	// Secret is the signing secret of the webhook, the request is not signed if it is empty.
+	Secret string
+	// DeliveryID identifies the delivery of the request, it is the same across retries.
+	DeliveryID string
+	// Format is the format of the body, it decides how the response is validated.
+	Format storepb.WebhookPayload_Format
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp and the body, joined by a dot.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature header of a request signed with the secret.
+// Requests signed more than tolerance ago are rejected to prevent replays.
+func Verify(secret string, header string, body []byte, tolerance time.Duration) error {
+	var timestamp int64
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid signature timestamp")
+			}
+			timestamp = ts
+		case signatureVersion:
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return errors.New("invalid signature header")
+	}
+	if age := time.Since(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+		return errors.New("signature timestamp is out of tolerance")
 	}
+	expected := Sign(secret, timestamp, body)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return errors.New("signature mismatch")
+}
 
-	req, err := http.NewRequest("POST", requestPayload.Url, bytes.NewBuffer(body))
+// Post posts the JSON body of the request to the webhook endpoint.
+// The response is returned whenever the endpoint responded, even if the request was rejected.
+func Post(ctx context.Context, request *Request) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewBuffer(request.Body))
 	if err != nil {
-		return errors.Wrapf(err, "failed to construct webhook request to %s", requestPayload.Url)
+		return nil, errors.Wrapf(err, "failed to construct webhook request to %s", request.URL)
 	}
-
 	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{
-		Timeout: timeout,
+	if request.DeliveryID != "" {
+		req.Header.Set(DeliveryHeader, request.DeliveryID)
 	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return errors.Wrapf(err, "failed to post webhook to %s", requestPayload.Url)
+	if request.Secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(SignatureHeader, fmt.Sprintf("t=%d,%s=%s", timestamp, signatureVersion, Sign(request.Secret, timestamp, request.Body)))
 	}
 
-	b, err := io.ReadAll(resp.Body)
+	startTime := time.Now()
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return errors.Wrapf(err, "failed to read webhook response from %s", requestPayload.Url)
+		return nil, errors.Wrapf(err, "failed to post webhook to %s", request.URL)
 	}
 	defer resp.Body.Close()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Body:       b,
+		Latency:    time.Since(startTime),
+	}
+	if err != nil {
+		return response, errors.Wrapf(err, "failed to read webhook response from %s", request.URL)
+	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return errors.Errorf("failed to post webhook %s, status code: %d, response body: %s", requestPayload.Url, resp.StatusCode, b)
+		return response, errors.Errorf("failed to post webhook %s, status code: %d, response body: %s", request.URL, resp.StatusCode, b)
 	}
 
-	response := &struct {
+	// Only memos endpoints are expected to report errors in the body, chat services reply with their own bodies.
+	if request.Format != storepb.WebhookPayload_FORMAT_UNSPECIFIED && request.Format != storepb.WebhookPayload_RAW {
+		return response, nil
+	}
+	// Endpoints may report errors with a JSON body of `{"code": ..., "message": ...}`, any other body is accepted.
+	result := &struct {
 		Code    int    `json:"code"`
 		Message string `json:"message"`
 	}{}
-	if err := json.Unmarshal(b, response); err != nil {
-		return errors.Wrapf(err, "failed to unmarshal webhook response from %s", requestPayload.Url)
-	}
-
-	if response.Code != 0 {
-		return errors.Errorf("receive error code sent by webhook server, code %d, msg: %s", response.Code, response.Message)
+	if err := json.Unmarshal(b, result); err == nil && result.Code != 0 {
+		return response, errors.Errorf("receive error code sent by webhook server, code %d, msg: %s", result.Code, result.Message)
 	}
 
-	return nil
-}
-
-// PostAsync posts the message to webhook endpoint asynchronously.
-// It spawns a new goroutine to handle the request and does not wait for the response.
-func PostAsync(requestPayload *v1pb.WebhookRequestPayload) {
-	go func() {
-		if err := Post(requestPayload); err != nil {
-			// Since we're in a goroutine, we can only log the error
-			slog.Warn("Failed to dispatch webhook asynchronously",
-				slog.String("url", requestPayload.Url),
-				slog.String("activityType", requestPayload.ActivityType),
-				slog.Any("err", err))
-		}
-	}()
+	return response, nil
 }
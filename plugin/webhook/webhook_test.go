@@ -1 +1,64 @@
 package webhook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostSignature(t *testing.T) {
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		fmt.Fprint(w, `{"code":0}`)
+	}))
+	defer server.Close()
+
+	response, err := Post(context.Background(), &Request{
+		URL:        server.URL,
+		Body:       []byte(`{"activityType":"memos.memo.created"}`),
+		Secret:     "secret",
+		DeliveryID: "42",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "42", header.Get(DeliveryHeader))
+	signature := header.Get(SignatureHeader)
+	require.NoError(t, Verify("secret", signature, body, time.Minute))
+	require.Error(t, Verify("other", signature, body, time.Minute))
+	require.Error(t, Verify("secret", signature, []byte(`{}`), time.Minute))
+
+	// Requests without a secret are not signed.
+	_, err = Post(context.Background(), &Request{URL: server.URL, Body: []byte(`{}`)})
+	require.NoError(t, err)
+	require.Empty(t, header.Get(SignatureHeader))
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{}`)
+	timestamp := time.Now().Add(-10 * time.Minute).Unix()
+	header := fmt.Sprintf("t=%d,v1=%s", timestamp, Sign("secret", timestamp, body))
+	require.NoError(t, Verify("secret", header, body, time.Hour))
+	// Old signatures are rejected as replays.
+	require.Error(t, Verify("secret", header, body, 5*time.Minute))
+	require.Error(t, Verify("secret", "v1=abc", body, time.Hour))
+}
+
+func TestPostRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"code":1,"message":"nope"}`)
+	}))
+	defer server.Close()
+
+	response, err := Post(context.Background(), &Request{URL: server.URL, Body: []byte(`{}`)})
+	require.Error(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+}
@@ -45,7 +45,9 @@ type Attachment struct {
 	Size int64 `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
 	// Optional. The related memo. Refer to `Memo.name`.
 	// Format: memos/{memo}
-	Memo          *string `protobuf:"bytes,9,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	Memo *string `protobuf:"bytes,9,opt,name=memo,proto3,oneof" json:"memo,omitempty"`
+	// Output only. The metadata read from the EXIF data of the image.
+	ImageMetadata *ImageMetadata `protobuf:"bytes,10,opt,name=image_metadata,json=imageMetadata,proto3" json:"image_metadata,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -136,20 +138,125 @@ func (x *Attachment) GetMemo() string {
 	return ""
 }
 
+func (x *Attachment) GetImageMetadata() *ImageMetadata {
+	if x != nil {
+		return x.ImageMetadata
+	}
+	return nil
+}
+
+type ImageMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The pixel dimensions of the image as displayed.
+	Width  int32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// The time the photo was taken.
+	TakenTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=taken_time,json=takenTime,proto3" json:"taken_time,omitempty"`
+	CameraMake  string                 `protobuf:"bytes,4,opt,name=camera_make,json=cameraMake,proto3" json:"camera_make,omitempty"`
+	CameraModel string                 `protobuf:"bytes,5,opt,name=camera_model,json=cameraModel,proto3" json:"camera_model,omitempty"`
+	LensModel   string                 `protobuf:"bytes,6,opt,name=lens_model,json=lensModel,proto3" json:"lens_model,omitempty"`
+	// Where the photo was taken, only returned to the creator of the attachment.
+	Location      *ImageMetadata_Location `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageMetadata) Reset() {
+	*x = ImageMetadata{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageMetadata) ProtoMessage() {}
+
+func (x *ImageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageMetadata.ProtoReflect.Descriptor instead.
+func (*ImageMetadata) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ImageMetadata) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ImageMetadata) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ImageMetadata) GetTakenTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TakenTime
+	}
+	return nil
+}
+
+func (x *ImageMetadata) GetCameraMake() string {
+	if x != nil {
+		return x.CameraMake
+	}
+	return ""
+}
+
+func (x *ImageMetadata) GetCameraModel() string {
+	if x != nil {
+		return x.CameraModel
+	}
+	return ""
+}
+
+func (x *ImageMetadata) GetLensModel() string {
+	if x != nil {
+		return x.LensModel
+	}
+	return ""
+}
+
+func (x *ImageMetadata) GetLocation() *ImageMetadata_Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
 type CreateAttachmentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. The attachment to create.
 	Attachment *Attachment `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
 	// Optional. The attachment ID to use for this attachment.
 	// If empty, a unique ID will be generated.
-	AttachmentId  string `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	AttachmentId string `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	// Optional. Whether to set the location of the related memo to where the photo was taken,
+	// if the memo has no location yet.
+	SetMemoLocation bool `protobuf:"varint,3,opt,name=set_memo_location,json=setMemoLocation,proto3" json:"set_memo_location,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CreateAttachmentRequest) Reset() {
 	*x = CreateAttachmentRequest{}
-	mi := &file_api_v1_attachment_service_proto_msgTypes[1]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -161,7 +268,7 @@ func (x *CreateAttachmentRequest) String() string {
 func (*CreateAttachmentRequest) ProtoMessage() {}
 
 func (x *CreateAttachmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_attachment_service_proto_msgTypes[1]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -174,7 +281,7 @@ func (x *CreateAttachmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAttachmentRequest.ProtoReflect.Descriptor instead.
 func (*CreateAttachmentRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{1}
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateAttachmentRequest) GetAttachment() *Attachment {
@@ -191,6 +298,13 @@ func (x *CreateAttachmentRequest) GetAttachmentId() string {
 	return ""
 }
 
+func (x *CreateAttachmentRequest) GetSetMemoLocation() bool {
+	if x != nil {
+		return x.SetMemoLocation
+	}
+	return false
+}
+
 type ListAttachmentsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Optional. The maximum number of attachments to return.
@@ -215,7 +329,7 @@ type ListAttachmentsRequest struct {
 
 func (x *ListAttachmentsRequest) Reset() {
 	*x = ListAttachmentsRequest{}
-	mi := &file_api_v1_attachment_service_proto_msgTypes[2]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -227,7 +341,7 @@ func (x *ListAttachmentsRequest) String() string {
 func (*ListAttachmentsRequest) ProtoMessage() {}
 
 func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_attachment_service_proto_msgTypes[2]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -240,7 +354,7 @@ func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
 func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{2}
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ListAttachmentsRequest) GetPageSize() int32 {
@@ -286,7 +400,7 @@ type ListAttachmentsResponse struct {
 
 func (x *ListAttachmentsResponse) Reset() {
 	*x = ListAttachmentsResponse{}
-	mi := &file_api_v1_attachment_service_proto_msgTypes[3]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -298,7 +412,7 @@ func (x *ListAttachmentsResponse) String() string {
 func (*ListAttachmentsResponse) ProtoMessage() {}
 
 func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_attachment_service_proto_msgTypes[3]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,7 +425,7 @@ func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
 func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{3}
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
@@ -346,7 +460,7 @@ type GetAttachmentRequest struct {
 
 func (x *GetAttachmentRequest) Reset() {
 	*x = GetAttachmentRequest{}
-	mi := &file_api_v1_attachment_service_proto_msgTypes[4]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -358,7 +472,7 @@ func (x *GetAttachmentRequest) String() string {
 func (*GetAttachmentRequest) ProtoMessage() {}
 
 func (x *GetAttachmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_attachment_service_proto_msgTypes[4]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +485,7 @@ func (x *GetAttachmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAttachmentRequest.ProtoReflect.Descriptor instead.
 func (*GetAttachmentRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{4}
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetAttachmentRequest) GetName() string {
@@ -396,7 +510,7 @@ type GetAttachmentBinaryRequest struct {
 
 func (x *GetAttachmentBinaryRequest) Reset() {
 	*x = GetAttachmentBinaryRequest{}
-	mi := &file_api_v1_attachment_service_proto_msgTypes[5]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -408,7 +522,7 @@ func (x *GetAttachmentBinaryRequest) String() string {
 func (*GetAttachmentBinaryRequest) ProtoMessage() {}
 
 func (x *GetAttachmentBinaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_attachment_service_proto_msgTypes[5]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,7 +535,7 @@ func (x *GetAttachmentBinaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAttachmentBinaryRequest.ProtoReflect.Descriptor instead.
 func (*GetAttachmentBinaryRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{5}
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetAttachmentBinaryRequest) GetName() string {
@@ -457,7 +571,7 @@ type UpdateAttachmentRequest struct {
 
 func (x *UpdateAttachmentRequest) Reset() {
 	*x = UpdateAttachmentRequest{}
-	mi := &file_api_v1_attachment_service_proto_msgTypes[6]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -469,7 +583,7 @@ func (x *UpdateAttachmentRequest) String() string {
 func (*UpdateAttachmentRequest) ProtoMessage() {}
 
 func (x *UpdateAttachmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_attachment_service_proto_msgTypes[6]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +596,7 @@ func (x *UpdateAttachmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAttachmentRequest.ProtoReflect.Descriptor instead.
 func (*UpdateAttachmentRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{6}
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateAttachmentRequest) GetAttachment() *Attachment {
@@ -510,7 +624,7 @@ type DeleteAttachmentRequest struct {
 
 func (x *DeleteAttachmentRequest) Reset() {
 	*x = DeleteAttachmentRequest{}
-	mi := &file_api_v1_attachment_service_proto_msgTypes[7]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -522,7 +636,7 @@ func (x *DeleteAttachmentRequest) String() string {
 func (*DeleteAttachmentRequest) ProtoMessage() {}
 
 func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_attachment_service_proto_msgTypes[7]
+	mi := &file_api_v1_attachment_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -535,7 +649,7 @@ func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
 func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{7}
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteAttachmentRequest) GetName() string {
@@ -545,11 +659,433 @@ func (x *DeleteAttachmentRequest) GetName() string {
 	return ""
 }
 
+type GetAttachmentDeduplicationReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentDeduplicationReportRequest) Reset() {
+	*x = GetAttachmentDeduplicationReportRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentDeduplicationReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentDeduplicationReportRequest) ProtoMessage() {}
+
+func (x *GetAttachmentDeduplicationReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentDeduplicationReportRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentDeduplicationReportRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{9}
+}
+
+type AttachmentDeduplicationReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of the unique contents stored.
+	UniqueContentCount int32 `protobuf:"varint,1,opt,name=unique_content_count,json=uniqueContentCount,proto3" json:"unique_content_count,omitempty"`
+	// The number of the attachments with a stored content.
+	AttachmentCount int32 `protobuf:"varint,2,opt,name=attachment_count,json=attachmentCount,proto3" json:"attachment_count,omitempty"`
+	// The total size in bytes of the attachments with a stored content.
+	AttachmentBytes int64 `protobuf:"varint,3,opt,name=attachment_bytes,json=attachmentBytes,proto3" json:"attachment_bytes,omitempty"`
+	// The total size in bytes of the stored contents.
+	StoredBytes int64 `protobuf:"varint,4,opt,name=stored_bytes,json=storedBytes,proto3" json:"stored_bytes,omitempty"`
+	// The size in bytes saved by storing the same contents once.
+	SavedBytes    int64 `protobuf:"varint,5,opt,name=saved_bytes,json=savedBytes,proto3" json:"saved_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentDeduplicationReport) Reset() {
+	*x = AttachmentDeduplicationReport{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentDeduplicationReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentDeduplicationReport) ProtoMessage() {}
+
+func (x *AttachmentDeduplicationReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentDeduplicationReport.ProtoReflect.Descriptor instead.
+func (*AttachmentDeduplicationReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AttachmentDeduplicationReport) GetUniqueContentCount() int32 {
+	if x != nil {
+		return x.UniqueContentCount
+	}
+	return 0
+}
+
+func (x *AttachmentDeduplicationReport) GetAttachmentCount() int32 {
+	if x != nil {
+		return x.AttachmentCount
+	}
+	return 0
+}
+
+func (x *AttachmentDeduplicationReport) GetAttachmentBytes() int64 {
+	if x != nil {
+		return x.AttachmentBytes
+	}
+	return 0
+}
+
+func (x *AttachmentDeduplicationReport) GetStoredBytes() int64 {
+	if x != nil {
+		return x.StoredBytes
+	}
+	return 0
+}
+
+func (x *AttachmentDeduplicationReport) GetSavedBytes() int64 {
+	if x != nil {
+		return x.SavedBytes
+	}
+	return 0
+}
+
+type MigrateAttachmentStorageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The storage the contents are moved from.
+	Source WorkspaceStorageSetting_StorageType `protobuf:"varint,1,opt,name=source,proto3,enum=memos.api.v1.WorkspaceStorageSetting_StorageType" json:"source,omitempty"`
+	// The storage the contents are moved to.
+	Target WorkspaceStorageSetting_StorageType `protobuf:"varint,2,opt,name=target,proto3,enum=memos.api.v1.WorkspaceStorageSetting_StorageType" json:"target,omitempty"`
+	// The maximum number of the stored contents moved by the call.
+	// The default is 100.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MigrateAttachmentStorageRequest) Reset() {
+	*x = MigrateAttachmentStorageRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateAttachmentStorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateAttachmentStorageRequest) ProtoMessage() {}
+
+func (x *MigrateAttachmentStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateAttachmentStorageRequest.ProtoReflect.Descriptor instead.
+func (*MigrateAttachmentStorageRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MigrateAttachmentStorageRequest) GetSource() WorkspaceStorageSetting_StorageType {
+	if x != nil {
+		return x.Source
+	}
+	return WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED
+}
+
+func (x *MigrateAttachmentStorageRequest) GetTarget() WorkspaceStorageSetting_StorageType {
+	if x != nil {
+		return x.Target
+	}
+	return WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED
+}
+
+func (x *MigrateAttachmentStorageRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type MigrateAttachmentStorageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of the attachments moved to the target storage.
+	MigratedCount int32 `protobuf:"varint,1,opt,name=migrated_count,json=migratedCount,proto3" json:"migrated_count,omitempty"`
+	// The number of the attachments failed to be moved, they are kept in the source storage.
+	FailedCount int32 `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	// The number of the attachments still in the source storage.
+	RemainingCount int32 `protobuf:"varint,3,opt,name=remaining_count,json=remainingCount,proto3" json:"remaining_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MigrateAttachmentStorageResponse) Reset() {
+	*x = MigrateAttachmentStorageResponse{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateAttachmentStorageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateAttachmentStorageResponse) ProtoMessage() {}
+
+func (x *MigrateAttachmentStorageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateAttachmentStorageResponse.ProtoReflect.Descriptor instead.
+func (*MigrateAttachmentStorageResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MigrateAttachmentStorageResponse) GetMigratedCount() int32 {
+	if x != nil {
+		return x.MigratedCount
+	}
+	return 0
+}
+
+func (x *MigrateAttachmentStorageResponse) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *MigrateAttachmentStorageResponse) GetRemainingCount() int32 {
+	if x != nil {
+		return x.RemainingCount
+	}
+	return 0
+}
+
+type CleanupOrphanedAttachmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether to only report the orphans without deleting them.
+	DryRun        bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupOrphanedAttachmentsRequest) Reset() {
+	*x = CleanupOrphanedAttachmentsRequest{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupOrphanedAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupOrphanedAttachmentsRequest) ProtoMessage() {}
+
+func (x *CleanupOrphanedAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupOrphanedAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*CleanupOrphanedAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CleanupOrphanedAttachmentsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type CleanupOrphanedAttachmentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The orphaned attachments.
+	Attachments []*Attachment `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// The paths of the orphaned files, the paths of the files in the data folder are relative to it.
+	Files []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	// The total size in bytes of the orphaned attachments, contents and files.
+	TotalBytes int64 `protobuf:"varint,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	// The SHA-256 hashes of the stored contents without an attachment.
+	ContentHashes []string `protobuf:"bytes,4,rep,name=content_hashes,json=contentHashes,proto3" json:"content_hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupOrphanedAttachmentsResponse) Reset() {
+	*x = CleanupOrphanedAttachmentsResponse{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupOrphanedAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupOrphanedAttachmentsResponse) ProtoMessage() {}
+
+func (x *CleanupOrphanedAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupOrphanedAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*CleanupOrphanedAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CleanupOrphanedAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *CleanupOrphanedAttachmentsResponse) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *CleanupOrphanedAttachmentsResponse) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *CleanupOrphanedAttachmentsResponse) GetContentHashes() []string {
+	if x != nil {
+		return x.ContentHashes
+	}
+	return nil
+}
+
+type ImageMetadata_Location struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Latitude  float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// The altitude in meters above the sea level.
+	Altitude      float64 `protobuf:"fixed64,3,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageMetadata_Location) Reset() {
+	*x = ImageMetadata_Location{}
+	mi := &file_api_v1_attachment_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageMetadata_Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageMetadata_Location) ProtoMessage() {}
+
+func (x *ImageMetadata_Location) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_attachment_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageMetadata_Location.ProtoReflect.Descriptor instead.
+func (*ImageMetadata_Location) Descriptor() ([]byte, []int) {
+	return file_api_v1_attachment_service_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *ImageMetadata_Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *ImageMetadata_Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *ImageMetadata_Location) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
 var File_api_v1_attachment_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1fapi/v1/attachment_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/httpbody.proto\x1a\x19google/api/resource.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x03\n" +
+	"\x1fapi/v1/attachment_service.proto\x12\fmemos.api.v1\x1a\x1eapi/v1/workspace_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/httpbody.proto\x1a\x19google/api/resource.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xca\x03\n" +
 	"\n" +
 	"Attachment\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12@\n" +
@@ -560,15 +1096,33 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\rexternal_link\x18\x06 \x01(\tB\x03\xe0A\x01R\fexternalLink\x12\x17\n" +
 	"\x04type\x18\a \x01(\tB\x03\xe0A\x02R\x04type\x12\x17\n" +
 	"\x04size\x18\b \x01(\x03B\x03\xe0A\x03R\x04size\x12\x1c\n" +
-	"\x04memo\x18\t \x01(\tB\x03\xe0A\x01H\x00R\x04memo\x88\x01\x01:O\xeaAL\n" +
+	"\x04memo\x18\t \x01(\tB\x03\xe0A\x01H\x00R\x04memo\x88\x01\x01\x12G\n" +
+	"\x0eimage_metadata\x18\n" +
+	" \x01(\v2\x1b.memos.api.v1.ImageMetadataB\x03\xe0A\x03R\rimageMetadata:O\xeaAL\n" +
 	"\x17memos.api.v1/Attachment\x12\x18attachments/{attachment}*\vattachments2\n" +
 	"attachmentB\a\n" +
-	"\x05_memoJ\x04\b\x02\x10\x03\"\x82\x01\n" +
+	"\x05_memoJ\x04\b\x02\x10\x03\"\xff\x02\n" +
+	"\rImageMetadata\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x129\n" +
+	"\n" +
+	"taken_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttakenTime\x12\x1f\n" +
+	"\vcamera_make\x18\x04 \x01(\tR\n" +
+	"cameraMake\x12!\n" +
+	"\fcamera_model\x18\x05 \x01(\tR\vcameraModel\x12\x1d\n" +
+	"\n" +
+	"lens_model\x18\x06 \x01(\tR\tlensModel\x12@\n" +
+	"\blocation\x18\a \x01(\v2$.memos.api.v1.ImageMetadata.LocationR\blocation\x1a`\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\baltitude\x18\x03 \x01(\x01R\baltitude\"\xb3\x01\n" +
 	"\x17CreateAttachmentRequest\x12=\n" +
 	"\n" +
 	"attachment\x18\x01 \x01(\v2\x18.memos.api.v1.AttachmentB\x03\xe0A\x02R\n" +
 	"attachment\x12(\n" +
-	"\rattachment_id\x18\x02 \x01(\tB\x03\xe0A\x01R\fattachmentId\"\x9b\x01\n" +
+	"\rattachment_id\x18\x02 \x01(\tB\x03\xe0A\x01R\fattachmentId\x12/\n" +
+	"\x11set_memo_location\x18\x03 \x01(\bB\x03\xe0A\x01R\x0fsetMemoLocation\"\x9b\x01\n" +
 	"\x16ListAttachmentsRequest\x12 \n" +
 	"\tpage_size\x18\x01 \x01(\x05B\x03\xe0A\x01R\bpageSize\x12\"\n" +
 	"\n" +
@@ -596,7 +1150,32 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"updateMask\"N\n" +
 	"\x17DeleteAttachmentRequest\x123\n" +
 	"\x04name\x18\x01 \x01(\tB\x1f\xe0A\x02\xfaA\x19\n" +
-	"\x17memos.api.v1/AttachmentR\x04name2\xdb\x06\n" +
+	"\x17memos.api.v1/AttachmentR\x04name\")\n" +
+	"'GetAttachmentDeduplicationReportRequest\"\xeb\x01\n" +
+	"\x1dAttachmentDeduplicationReport\x120\n" +
+	"\x14unique_content_count\x18\x01 \x01(\x05R\x12uniqueContentCount\x12)\n" +
+	"\x10attachment_count\x18\x02 \x01(\x05R\x0fattachmentCount\x12)\n" +
+	"\x10attachment_bytes\x18\x03 \x01(\x03R\x0fattachmentBytes\x12!\n" +
+	"\fstored_bytes\x18\x04 \x01(\x03R\vstoredBytes\x12\x1f\n" +
+	"\vsaved_bytes\x18\x05 \x01(\x03R\n" +
+	"savedBytes\"\xdc\x01\n" +
+	"\x1fMigrateAttachmentStorageRequest\x12N\n" +
+	"\x06source\x18\x01 \x01(\x0e21.memos.api.v1.WorkspaceStorageSetting.StorageTypeB\x03\xe0A\x02R\x06source\x12N\n" +
+	"\x06target\x18\x02 \x01(\x0e21.memos.api.v1.WorkspaceStorageSetting.StorageTypeB\x03\xe0A\x02R\x06target\x12\x19\n" +
+	"\x05limit\x18\x03 \x01(\x05B\x03\xe0A\x01R\x05limit\"\x95\x01\n" +
+	" MigrateAttachmentStorageResponse\x12%\n" +
+	"\x0emigrated_count\x18\x01 \x01(\x05R\rmigratedCount\x12!\n" +
+	"\ffailed_count\x18\x02 \x01(\x05R\vfailedCount\x12'\n" +
+	"\x0fremaining_count\x18\x03 \x01(\x05R\x0eremainingCount\"<\n" +
+	"!CleanupOrphanedAttachmentsRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"\xbe\x01\n" +
+	"\"CleanupOrphanedAttachmentsResponse\x12:\n" +
+	"\vattachments\x18\x01 \x03(\v2\x18.memos.api.v1.AttachmentR\vattachments\x12\x14\n" +
+	"\x05files\x18\x02 \x03(\tR\x05files\x12\x1f\n" +
+	"\vtotal_bytes\x18\x03 \x01(\x03R\n" +
+	"totalBytes\x12%\n" +
+	"\x0econtent_hashes\x18\x04 \x03(\tR\rcontentHashes2\xf1\n" +
+	"\n" +
 	"\x11AttachmentService\x12\x89\x01\n" +
 	"\x10CreateAttachment\x12%.memos.api.v1.CreateAttachmentRequest\x1a\x18.memos.api.v1.Attachment\"4\xdaA\n" +
 	"attachment\x82\xd3\xe4\x93\x02!:\n" +
@@ -606,7 +1185,10 @@ const file_api_v1_attachment_service_proto_rawDesc = "" +
 	"\x13GetAttachmentBinary\x12(.memos.api.v1.GetAttachmentBinaryRequest\x1a\x14.google.api.HttpBody\"=\xdaA\rname,filename\x82\xd3\xe4\x93\x02'\x12%/file/{name=attachments/*}/{filename}\x12\xa9\x01\n" +
 	"\x10UpdateAttachment\x12%.memos.api.v1.UpdateAttachmentRequest\x1a\x18.memos.api.v1.Attachment\"T\xdaA\x16attachment,update_mask\x82\xd3\xe4\x93\x025:\n" +
 	"attachment2'/api/v1/{attachment.name=attachments/*}\x12~\n" +
-	"\x10DeleteAttachment\x12%.memos.api.v1.DeleteAttachmentRequest\x1a\x16.google.protobuf.Empty\"+\xdaA\x04name\x82\xd3\xe4\x93\x02\x1e*\x1c/api/v1/{name=attachments/*}B\xae\x01\n" +
+	"\x10DeleteAttachment\x12%.memos.api.v1.DeleteAttachmentRequest\x1a\x16.google.protobuf.Empty\"+\xdaA\x04name\x82\xd3\xe4\x93\x02\x1e*\x1c/api/v1/{name=attachments/*}\x12\xb7\x01\n" +
+	" GetAttachmentDeduplicationReport\x125.memos.api.v1.GetAttachmentDeduplicationReportRequest\x1a+.memos.api.v1.AttachmentDeduplicationReport\"/\x82\xd3\xe4\x93\x02)\x12'/api/v1/attachments:deduplicationReport\x12\xa8\x01\n" +
+	"\x18MigrateAttachmentStorage\x12-.memos.api.v1.MigrateAttachmentStorageRequest\x1a..memos.api.v1.MigrateAttachmentStorageResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/attachments:migrateStorage\x12\xae\x01\n" +
+	"\x1aCleanupOrphanedAttachments\x12/.memos.api.v1.CleanupOrphanedAttachmentsRequest\x1a0.memos.api.v1.CleanupOrphanedAttachmentsResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/attachments:cleanupOrphansB\xae\x01\n" +
 	"\x10com.memos.api.v1B\x16AttachmentServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
 var (
@@ -621,44 +1203,65 @@ func file_api_v1_attachment_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_attachment_service_proto_rawDescData
 }
 
-var file_api_v1_attachment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_v1_attachment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_api_v1_attachment_service_proto_goTypes = []any{
-	(*Attachment)(nil),                 // 0: memos.api.v1.Attachment
-	(*CreateAttachmentRequest)(nil),    // 1: memos.api.v1.CreateAttachmentRequest
-	(*ListAttachmentsRequest)(nil),     // 2: memos.api.v1.ListAttachmentsRequest
-	(*ListAttachmentsResponse)(nil),    // 3: memos.api.v1.ListAttachmentsResponse
-	(*GetAttachmentRequest)(nil),       // 4: memos.api.v1.GetAttachmentRequest
-	(*GetAttachmentBinaryRequest)(nil), // 5: memos.api.v1.GetAttachmentBinaryRequest
-	(*UpdateAttachmentRequest)(nil),    // 6: memos.api.v1.UpdateAttachmentRequest
-	(*DeleteAttachmentRequest)(nil),    // 7: memos.api.v1.DeleteAttachmentRequest
-	(*timestamppb.Timestamp)(nil),      // 8: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),      // 9: google.protobuf.FieldMask
-	(*httpbody.HttpBody)(nil),          // 10: google.api.HttpBody
-	(*emptypb.Empty)(nil),              // 11: google.protobuf.Empty
+	(*Attachment)(nil),                              // 0: memos.api.v1.Attachment
+	(*ImageMetadata)(nil),                           // 1: memos.api.v1.ImageMetadata
+	(*CreateAttachmentRequest)(nil),                 // 2: memos.api.v1.CreateAttachmentRequest
+	(*ListAttachmentsRequest)(nil),                  // 3: memos.api.v1.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),                 // 4: memos.api.v1.ListAttachmentsResponse
+	(*GetAttachmentRequest)(nil),                    // 5: memos.api.v1.GetAttachmentRequest
+	(*GetAttachmentBinaryRequest)(nil),              // 6: memos.api.v1.GetAttachmentBinaryRequest
+	(*UpdateAttachmentRequest)(nil),                 // 7: memos.api.v1.UpdateAttachmentRequest
+	(*DeleteAttachmentRequest)(nil),                 // 8: memos.api.v1.DeleteAttachmentRequest
+	(*GetAttachmentDeduplicationReportRequest)(nil), // 9: memos.api.v1.GetAttachmentDeduplicationReportRequest
+	(*AttachmentDeduplicationReport)(nil),           // 10: memos.api.v1.AttachmentDeduplicationReport
+	(*MigrateAttachmentStorageRequest)(nil),         // 11: memos.api.v1.MigrateAttachmentStorageRequest
+	(*MigrateAttachmentStorageResponse)(nil),        // 12: memos.api.v1.MigrateAttachmentStorageResponse
+	(*CleanupOrphanedAttachmentsRequest)(nil),       // 13: memos.api.v1.CleanupOrphanedAttachmentsRequest
+	(*CleanupOrphanedAttachmentsResponse)(nil),      // 14: memos.api.v1.CleanupOrphanedAttachmentsResponse
+	(*ImageMetadata_Location)(nil),                  // 15: memos.api.v1.ImageMetadata.Location
+	(*timestamppb.Timestamp)(nil),                   // 16: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),                   // 17: google.protobuf.FieldMask
+	(WorkspaceStorageSetting_StorageType)(0),        // 18: memos.api.v1.WorkspaceStorageSetting.StorageType
+	(*httpbody.HttpBody)(nil),                       // 19: google.api.HttpBody
+	(*emptypb.Empty)(nil),                           // 20: google.protobuf.Empty
 }
 var file_api_v1_attachment_service_proto_depIdxs = []int32{
-	8,  // 0: memos.api.v1.Attachment.create_time:type_name -> google.protobuf.Timestamp
-	0,  // 1: memos.api.v1.CreateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
-	0,  // 2: memos.api.v1.ListAttachmentsResponse.attachments:type_name -> memos.api.v1.Attachment
-	0,  // 3: memos.api.v1.UpdateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
-	9,  // 4: memos.api.v1.UpdateAttachmentRequest.update_mask:type_name -> google.protobuf.FieldMask
-	1,  // 5: memos.api.v1.AttachmentService.CreateAttachment:input_type -> memos.api.v1.CreateAttachmentRequest
-	2,  // 6: memos.api.v1.AttachmentService.ListAttachments:input_type -> memos.api.v1.ListAttachmentsRequest
-	4,  // 7: memos.api.v1.AttachmentService.GetAttachment:input_type -> memos.api.v1.GetAttachmentRequest
-	5,  // 8: memos.api.v1.AttachmentService.GetAttachmentBinary:input_type -> memos.api.v1.GetAttachmentBinaryRequest
-	6,  // 9: memos.api.v1.AttachmentService.UpdateAttachment:input_type -> memos.api.v1.UpdateAttachmentRequest
-	7,  // 10: memos.api.v1.AttachmentService.DeleteAttachment:input_type -> memos.api.v1.DeleteAttachmentRequest
-	0,  // 11: memos.api.v1.AttachmentService.CreateAttachment:output_type -> memos.api.v1.Attachment
-	3,  // 12: memos.api.v1.AttachmentService.ListAttachments:output_type -> memos.api.v1.ListAttachmentsResponse
-	0,  // 13: memos.api.v1.AttachmentService.GetAttachment:output_type -> memos.api.v1.Attachment
-	10, // 14: memos.api.v1.AttachmentService.GetAttachmentBinary:output_type -> google.api.HttpBody
-	0,  // 15: memos.api.v1.AttachmentService.UpdateAttachment:output_type -> memos.api.v1.Attachment
-	11, // 16: memos.api.v1.AttachmentService.DeleteAttachment:output_type -> google.protobuf.Empty
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	16, // 0: memos.api.v1.Attachment.create_time:type_name -> google.protobuf.Timestamp
+	1,  // 1: memos.api.v1.Attachment.image_metadata:type_name -> memos.api.v1.ImageMetadata
+	16, // 2: memos.api.v1.ImageMetadata.taken_time:type_name -> google.protobuf.Timestamp
+	15, // 3: memos.api.v1.ImageMetadata.location:type_name -> memos.api.v1.ImageMetadata.Location
+	0,  // 4: memos.api.v1.CreateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
+	0,  // 5: memos.api.v1.ListAttachmentsResponse.attachments:type_name -> memos.api.v1.Attachment
+	0,  // 6: memos.api.v1.UpdateAttachmentRequest.attachment:type_name -> memos.api.v1.Attachment
+	17, // 7: memos.api.v1.UpdateAttachmentRequest.update_mask:type_name -> google.protobuf.FieldMask
+	18, // 8: memos.api.v1.MigrateAttachmentStorageRequest.source:type_name -> memos.api.v1.WorkspaceStorageSetting.StorageType
+	18, // 9: memos.api.v1.MigrateAttachmentStorageRequest.target:type_name -> memos.api.v1.WorkspaceStorageSetting.StorageType
+	0,  // 10: memos.api.v1.CleanupOrphanedAttachmentsResponse.attachments:type_name -> memos.api.v1.Attachment
+	2,  // 11: memos.api.v1.AttachmentService.CreateAttachment:input_type -> memos.api.v1.CreateAttachmentRequest
+	3,  // 12: memos.api.v1.AttachmentService.ListAttachments:input_type -> memos.api.v1.ListAttachmentsRequest
+	5,  // 13: memos.api.v1.AttachmentService.GetAttachment:input_type -> memos.api.v1.GetAttachmentRequest
+	6,  // 14: memos.api.v1.AttachmentService.GetAttachmentBinary:input_type -> memos.api.v1.GetAttachmentBinaryRequest
+	7,  // 15: memos.api.v1.AttachmentService.UpdateAttachment:input_type -> memos.api.v1.UpdateAttachmentRequest
+	8,  // 16: memos.api.v1.AttachmentService.DeleteAttachment:input_type -> memos.api.v1.DeleteAttachmentRequest
+	9,  // 17: memos.api.v1.AttachmentService.GetAttachmentDeduplicationReport:input_type -> memos.api.v1.GetAttachmentDeduplicationReportRequest
+	11, // 18: memos.api.v1.AttachmentService.MigrateAttachmentStorage:input_type -> memos.api.v1.MigrateAttachmentStorageRequest
+	13, // 19: memos.api.v1.AttachmentService.CleanupOrphanedAttachments:input_type -> memos.api.v1.CleanupOrphanedAttachmentsRequest
+	0,  // 20: memos.api.v1.AttachmentService.CreateAttachment:output_type -> memos.api.v1.Attachment
+	4,  // 21: memos.api.v1.AttachmentService.ListAttachments:output_type -> memos.api.v1.ListAttachmentsResponse
+	0,  // 22: memos.api.v1.AttachmentService.GetAttachment:output_type -> memos.api.v1.Attachment
+	19, // 23: memos.api.v1.AttachmentService.GetAttachmentBinary:output_type -> google.api.HttpBody
+	0,  // 24: memos.api.v1.AttachmentService.UpdateAttachment:output_type -> memos.api.v1.Attachment
+	20, // 25: memos.api.v1.AttachmentService.DeleteAttachment:output_type -> google.protobuf.Empty
+	10, // 26: memos.api.v1.AttachmentService.GetAttachmentDeduplicationReport:output_type -> memos.api.v1.AttachmentDeduplicationReport
+	12, // 27: memos.api.v1.AttachmentService.MigrateAttachmentStorage:output_type -> memos.api.v1.MigrateAttachmentStorageResponse
+	14, // 28: memos.api.v1.AttachmentService.CleanupOrphanedAttachments:output_type -> memos.api.v1.CleanupOrphanedAttachmentsResponse
+	20, // [20:29] is the sub-list for method output_type
+	11, // [11:20] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_attachment_service_proto_init() }
@@ -666,6 +1269,7 @@ func file_api_v1_attachment_service_proto_init() {
 	if File_api_v1_attachment_service_proto != nil {
 		return
 	}
+	file_api_v1_workspace_service_proto_init()
 	file_api_v1_attachment_service_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -673,7 +1277,7 @@ func file_api_v1_attachment_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_attachment_service_proto_rawDesc), len(file_api_v1_attachment_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
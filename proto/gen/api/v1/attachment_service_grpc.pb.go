@@ -21,12 +21,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AttachmentService_CreateAttachment_FullMethodName    = "/memos.api.v1.AttachmentService/CreateAttachment"
-	AttachmentService_ListAttachments_FullMethodName     = "/memos.api.v1.AttachmentService/ListAttachments"
-	AttachmentService_GetAttachment_FullMethodName       = "/memos.api.v1.AttachmentService/GetAttachment"
-	AttachmentService_GetAttachmentBinary_FullMethodName = "/memos.api.v1.AttachmentService/GetAttachmentBinary"
-	AttachmentService_UpdateAttachment_FullMethodName    = "/memos.api.v1.AttachmentService/UpdateAttachment"
-	AttachmentService_DeleteAttachment_FullMethodName    = "/memos.api.v1.AttachmentService/DeleteAttachment"
+	AttachmentService_CreateAttachment_FullMethodName                 = "/memos.api.v1.AttachmentService/CreateAttachment"
+	AttachmentService_ListAttachments_FullMethodName                  = "/memos.api.v1.AttachmentService/ListAttachments"
+	AttachmentService_GetAttachment_FullMethodName                    = "/memos.api.v1.AttachmentService/GetAttachment"
+	AttachmentService_GetAttachmentBinary_FullMethodName              = "/memos.api.v1.AttachmentService/GetAttachmentBinary"
+	AttachmentService_UpdateAttachment_FullMethodName                 = "/memos.api.v1.AttachmentService/UpdateAttachment"
+	AttachmentService_DeleteAttachment_FullMethodName                 = "/memos.api.v1.AttachmentService/DeleteAttachment"
+	AttachmentService_GetAttachmentDeduplicationReport_FullMethodName = "/memos.api.v1.AttachmentService/GetAttachmentDeduplicationReport"
+	AttachmentService_MigrateAttachmentStorage_FullMethodName         = "/memos.api.v1.AttachmentService/MigrateAttachmentStorage"
+	AttachmentService_CleanupOrphanedAttachments_FullMethodName       = "/memos.api.v1.AttachmentService/CleanupOrphanedAttachments"
 )
 
 // AttachmentServiceClient is the client API for AttachmentService service.
@@ -45,6 +48,17 @@ type AttachmentServiceClient interface {
 	UpdateAttachment(ctx context.Context, in *UpdateAttachmentRequest, opts ...grpc.CallOption) (*Attachment, error)
 	// DeleteAttachment deletes a attachment by name.
 	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetAttachmentDeduplicationReport returns how much storage space is saved by storing the same contents once.
+	// Only the admins can get the report.
+	GetAttachmentDeduplicationReport(ctx context.Context, in *GetAttachmentDeduplicationReportRequest, opts ...grpc.CallOption) (*AttachmentDeduplicationReport, error)
+	// MigrateAttachmentStorage moves the stored contents of the attachments from a storage to another.
+	// The contents are moved in batches, and the migration is resumed by calling it again until no attachment remains.
+	// Only the admins can migrate the storage.
+	MigrateAttachmentStorage(ctx context.Context, in *MigrateAttachmentStorageRequest, opts ...grpc.CallOption) (*MigrateAttachmentStorageResponse, error)
+	// CleanupOrphanedAttachments deletes the attachments unlinked from any memo for longer than the grace period,
+	// the stored contents without an attachment, and the files of the local storage and the thumbnail cache without an attachment.
+	// The orphans are only reported in the dry run. Only the admins can clean up the attachments.
+	CleanupOrphanedAttachments(ctx context.Context, in *CleanupOrphanedAttachmentsRequest, opts ...grpc.CallOption) (*CleanupOrphanedAttachmentsResponse, error)
 }
 
 type attachmentServiceClient struct {
@@ -115,6 +129,36 @@ func (c *attachmentServiceClient) DeleteAttachment(ctx context.Context, in *Dele
 	return out, nil
 }
 
+func (c *attachmentServiceClient) GetAttachmentDeduplicationReport(ctx context.Context, in *GetAttachmentDeduplicationReportRequest, opts ...grpc.CallOption) (*AttachmentDeduplicationReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachmentDeduplicationReport)
+	err := c.cc.Invoke(ctx, AttachmentService_GetAttachmentDeduplicationReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) MigrateAttachmentStorage(ctx context.Context, in *MigrateAttachmentStorageRequest, opts ...grpc.CallOption) (*MigrateAttachmentStorageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MigrateAttachmentStorageResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_MigrateAttachmentStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attachmentServiceClient) CleanupOrphanedAttachments(ctx context.Context, in *CleanupOrphanedAttachmentsRequest, opts ...grpc.CallOption) (*CleanupOrphanedAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupOrphanedAttachmentsResponse)
+	err := c.cc.Invoke(ctx, AttachmentService_CleanupOrphanedAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AttachmentServiceServer is the server API for AttachmentService service.
 // All implementations must embed UnimplementedAttachmentServiceServer
 // for forward compatibility.
@@ -131,6 +175,17 @@ type AttachmentServiceServer interface {
 	UpdateAttachment(context.Context, *UpdateAttachmentRequest) (*Attachment, error)
 	// DeleteAttachment deletes a attachment by name.
 	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*emptypb.Empty, error)
+	// GetAttachmentDeduplicationReport returns how much storage space is saved by storing the same contents once.
+	// Only the admins can get the report.
+	GetAttachmentDeduplicationReport(context.Context, *GetAttachmentDeduplicationReportRequest) (*AttachmentDeduplicationReport, error)
+	// MigrateAttachmentStorage moves the stored contents of the attachments from a storage to another.
+	// The contents are moved in batches, and the migration is resumed by calling it again until no attachment remains.
+	// Only the admins can migrate the storage.
+	MigrateAttachmentStorage(context.Context, *MigrateAttachmentStorageRequest) (*MigrateAttachmentStorageResponse, error)
+	// CleanupOrphanedAttachments deletes the attachments unlinked from any memo for longer than the grace period,
+	// the stored contents without an attachment, and the files of the local storage and the thumbnail cache without an attachment.
+	// The orphans are only reported in the dry run. Only the admins can clean up the attachments.
+	CleanupOrphanedAttachments(context.Context, *CleanupOrphanedAttachmentsRequest) (*CleanupOrphanedAttachmentsResponse, error)
 	mustEmbedUnimplementedAttachmentServiceServer()
 }
 
@@ -159,6 +214,15 @@ func (UnimplementedAttachmentServiceServer) UpdateAttachment(context.Context, *U
 func (UnimplementedAttachmentServiceServer) DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
 }
+func (UnimplementedAttachmentServiceServer) GetAttachmentDeduplicationReport(context.Context, *GetAttachmentDeduplicationReportRequest) (*AttachmentDeduplicationReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachmentDeduplicationReport not implemented")
+}
+func (UnimplementedAttachmentServiceServer) MigrateAttachmentStorage(context.Context, *MigrateAttachmentStorageRequest) (*MigrateAttachmentStorageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateAttachmentStorage not implemented")
+}
+func (UnimplementedAttachmentServiceServer) CleanupOrphanedAttachments(context.Context, *CleanupOrphanedAttachmentsRequest) (*CleanupOrphanedAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanupOrphanedAttachments not implemented")
+}
 func (UnimplementedAttachmentServiceServer) mustEmbedUnimplementedAttachmentServiceServer() {}
 func (UnimplementedAttachmentServiceServer) testEmbeddedByValue()                           {}
 
@@ -288,6 +352,60 @@ func _AttachmentService_DeleteAttachment_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AttachmentService_GetAttachmentDeduplicationReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentDeduplicationReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).GetAttachmentDeduplicationReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_GetAttachmentDeduplicationReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).GetAttachmentDeduplicationReport(ctx, req.(*GetAttachmentDeduplicationReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_MigrateAttachmentStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateAttachmentStorageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).MigrateAttachmentStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_MigrateAttachmentStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).MigrateAttachmentStorage(ctx, req.(*MigrateAttachmentStorageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttachmentService_CleanupOrphanedAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupOrphanedAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttachmentServiceServer).CleanupOrphanedAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttachmentService_CleanupOrphanedAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttachmentServiceServer).CleanupOrphanedAttachments(ctx, req.(*CleanupOrphanedAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AttachmentService_ServiceDesc is the grpc.ServiceDesc for AttachmentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -319,6 +437,18 @@ var AttachmentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAttachment",
 			Handler:    _AttachmentService_DeleteAttachment_Handler,
 		},
+		{
+			MethodName: "GetAttachmentDeduplicationReport",
+			Handler:    _AttachmentService_GetAttachmentDeduplicationReport_Handler,
+		},
+		{
+			MethodName: "MigrateAttachmentStorage",
+			Handler:    _AttachmentService_MigrateAttachmentStorage_Handler,
+		},
+		{
+			MethodName: "CleanupOrphanedAttachments",
+			Handler:    _AttachmentService_CleanupOrphanedAttachments_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/attachment_service.proto",
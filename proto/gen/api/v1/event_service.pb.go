@@ -0,0 +1,324 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: api/v1/event_service.proto
+
+package apiv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WatchEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. The resume token of the last received event, the missed events after it are sent first.
+	// Tokens expire when the server restarts or when too many events happened since,
+	// in which case the stream fails with OUT_OF_RANGE and clients should reload their data.
+	ResumeToken string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// Optional. The event types to receive, e.g. "memos.memo.created".
+	// Supported types: memos.memo.created, memos.memo.updated, memos.memo.deleted,
+	// memos.comment.created, memos.reaction.added, memos.reaction.removed, memos.inbox.created.
+	// If empty, all events are received.
+	EventTypes    []string `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_api_v1_event_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_event_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_event_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchEventsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type WatchEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The type of the event, e.g. "memos.memo.created".
+	// Heartbeats of the type "memos.heartbeat" are sent to keep idle streams alive.
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// The token to resume the stream after this event, it is empty for heartbeats.
+	ResumeToken string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// The time of the event.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// The resource name of the user that caused the event, if any.
+	// Format: users/{user}
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// The memo the event is about, if any.
+	Memo *Memo `protobuf:"bytes,5,opt,name=memo,proto3" json:"memo,omitempty"`
+	// The event specific data.
+	//
+	// Types that are valid to be assigned to Event:
+	//
+	//	*WatchEventsResponse_Comment
+	//	*WatchEventsResponse_Reaction
+	//	*WatchEventsResponse_Inbox
+	Event         isWatchEventsResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsResponse) Reset() {
+	*x = WatchEventsResponse{}
+	mi := &file_api_v1_event_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsResponse) ProtoMessage() {}
+
+func (x *WatchEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_event_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsResponse.ProtoReflect.Descriptor instead.
+func (*WatchEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_event_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchEventsResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WatchEventsResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *WatchEventsResponse) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *WatchEventsResponse) GetMemo() *Memo {
+	if x != nil {
+		return x.Memo
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetEvent() isWatchEventsResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetComment() *Memo {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_Comment); ok {
+			return x.Comment
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetReaction() *Reaction {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_Reaction); ok {
+			return x.Reaction
+		}
+	}
+	return nil
+}
+
+func (x *WatchEventsResponse) GetInbox() *Inbox {
+	if x != nil {
+		if x, ok := x.Event.(*WatchEventsResponse_Inbox); ok {
+			return x.Inbox
+		}
+	}
+	return nil
+}
+
+type isWatchEventsResponse_Event interface {
+	isWatchEventsResponse_Event()
+}
+
+type WatchEventsResponse_Comment struct {
+	// The comment of a comment created event.
+	Comment *Memo `protobuf:"bytes,6,opt,name=comment,proto3,oneof"`
+}
+
+type WatchEventsResponse_Reaction struct {
+	// The reaction of a reaction added or removed event.
+	Reaction *Reaction `protobuf:"bytes,7,opt,name=reaction,proto3,oneof"`
+}
+
+type WatchEventsResponse_Inbox struct {
+	// The inbox of an inbox created event, it is only sent to its receiver.
+	Inbox *Inbox `protobuf:"bytes,8,opt,name=inbox,proto3,oneof"`
+}
+
+func (*WatchEventsResponse_Comment) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_Reaction) isWatchEventsResponse_Event() {}
+
+func (*WatchEventsResponse_Inbox) isWatchEventsResponse_Event() {}
+
+var File_api_v1_event_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_event_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapi/v1/event_service.proto\x12\fmemos.api.v1\x1a\x1aapi/v1/inbox_service.proto\x1a\x19api/v1/memo_service.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"b\n" +
+	"\x12WatchEventsRequest\x12&\n" +
+	"\fresume_token\x18\x01 \x01(\tB\x03\xe0A\x01R\vresumeToken\x12$\n" +
+	"\vevent_types\x18\x02 \x03(\tB\x03\xe0A\x01R\n" +
+	"eventTypes\"\xe3\x02\n" +
+	"\x13WatchEventsResponse\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12&\n" +
+	"\x04memo\x18\x05 \x01(\v2\x12.memos.api.v1.MemoR\x04memo\x12.\n" +
+	"\acomment\x18\x06 \x01(\v2\x12.memos.api.v1.MemoH\x00R\acomment\x124\n" +
+	"\breaction\x18\a \x01(\v2\x16.memos.api.v1.ReactionH\x00R\breaction\x12+\n" +
+	"\x05inbox\x18\b \x01(\v2\x13.memos.api.v1.InboxH\x00R\x05inboxB\a\n" +
+	"\x05event2f\n" +
+	"\fEventService\x12V\n" +
+	"\vWatchEvents\x12 .memos.api.v1.WatchEventsRequest\x1a!.memos.api.v1.WatchEventsResponse\"\x000\x01B\xa9\x01\n" +
+	"\x10com.memos.api.v1B\x11EventServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
+
+var (
+	file_api_v1_event_service_proto_rawDescOnce sync.Once
+	file_api_v1_event_service_proto_rawDescData []byte
+)
+
+func file_api_v1_event_service_proto_rawDescGZIP() []byte {
+	file_api_v1_event_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_event_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_event_service_proto_rawDesc), len(file_api_v1_event_service_proto_rawDesc)))
+	})
+	return file_api_v1_event_service_proto_rawDescData
+}
+
+var file_api_v1_event_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_v1_event_service_proto_goTypes = []any{
+	(*WatchEventsRequest)(nil),    // 0: memos.api.v1.WatchEventsRequest
+	(*WatchEventsResponse)(nil),   // 1: memos.api.v1.WatchEventsResponse
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*Memo)(nil),                  // 3: memos.api.v1.Memo
+	(*Reaction)(nil),              // 4: memos.api.v1.Reaction
+	(*Inbox)(nil),                 // 5: memos.api.v1.Inbox
+}
+var file_api_v1_event_service_proto_depIdxs = []int32{
+	2, // 0: memos.api.v1.WatchEventsResponse.create_time:type_name -> google.protobuf.Timestamp
+	3, // 1: memos.api.v1.WatchEventsResponse.memo:type_name -> memos.api.v1.Memo
+	3, // 2: memos.api.v1.WatchEventsResponse.comment:type_name -> memos.api.v1.Memo
+	4, // 3: memos.api.v1.WatchEventsResponse.reaction:type_name -> memos.api.v1.Reaction
+	5, // 4: memos.api.v1.WatchEventsResponse.inbox:type_name -> memos.api.v1.Inbox
+	0, // 5: memos.api.v1.EventService.WatchEvents:input_type -> memos.api.v1.WatchEventsRequest
+	1, // 6: memos.api.v1.EventService.WatchEvents:output_type -> memos.api.v1.WatchEventsResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_event_service_proto_init() }
+func file_api_v1_event_service_proto_init() {
+	if File_api_v1_event_service_proto != nil {
+		return
+	}
+	file_api_v1_inbox_service_proto_init()
+	file_api_v1_memo_service_proto_init()
+	file_api_v1_event_service_proto_msgTypes[1].OneofWrappers = []any{
+		(*WatchEventsResponse_Comment)(nil),
+		(*WatchEventsResponse_Reaction)(nil),
+		(*WatchEventsResponse_Inbox)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_event_service_proto_rawDesc), len(file_api_v1_event_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_event_service_proto_goTypes,
+		DependencyIndexes: file_api_v1_event_service_proto_depIdxs,
+		MessageInfos:      file_api_v1_event_service_proto_msgTypes,
+	}.Build()
+	File_api_v1_event_service_proto = out.File
+	file_api_v1_event_service_proto_goTypes = nil
+	file_api_v1_event_service_proto_depIdxs = nil
+}
@@ -35,6 +35,9 @@ const (
 	UserService_ListUserAccessTokens_FullMethodName  = "/memos.api.v1.UserService/ListUserAccessTokens"
 	UserService_CreateUserAccessToken_FullMethodName = "/memos.api.v1.UserService/CreateUserAccessToken"
 	UserService_DeleteUserAccessToken_FullMethodName = "/memos.api.v1.UserService/DeleteUserAccessToken"
+	UserService_ListUserFeedTokens_FullMethodName    = "/memos.api.v1.UserService/ListUserFeedTokens"
+	UserService_CreateUserFeedToken_FullMethodName   = "/memos.api.v1.UserService/CreateUserFeedToken"
+	UserService_DeleteUserFeedToken_FullMethodName   = "/memos.api.v1.UserService/DeleteUserFeedToken"
 	UserService_ListUserSessions_FullMethodName      = "/memos.api.v1.UserService/ListUserSessions"
 	UserService_RevokeUserSession_FullMethodName     = "/memos.api.v1.UserService/RevokeUserSession"
 )
@@ -71,6 +74,12 @@ type UserServiceClient interface {
 	CreateUserAccessToken(ctx context.Context, in *CreateUserAccessTokenRequest, opts ...grpc.CallOption) (*UserAccessToken, error)
 	// DeleteUserAccessToken deletes an access token.
 	DeleteUserAccessToken(ctx context.Context, in *DeleteUserAccessTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListUserFeedTokens returns a list of feed tokens for a user.
+	ListUserFeedTokens(ctx context.Context, in *ListUserFeedTokensRequest, opts ...grpc.CallOption) (*ListUserFeedTokensResponse, error)
+	// CreateUserFeedToken creates a new feed token for a user.
+	CreateUserFeedToken(ctx context.Context, in *CreateUserFeedTokenRequest, opts ...grpc.CallOption) (*UserFeedToken, error)
+	// DeleteUserFeedToken revokes a feed token.
+	DeleteUserFeedToken(ctx context.Context, in *DeleteUserFeedTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// ListUserSessions returns a list of active sessions for a user.
 	ListUserSessions(ctx context.Context, in *ListUserSessionsRequest, opts ...grpc.CallOption) (*ListUserSessionsResponse, error)
 	// RevokeUserSession revokes a specific session for a user.
@@ -225,6 +234,36 @@ func (c *userServiceClient) DeleteUserAccessToken(ctx context.Context, in *Delet
 	return out, nil
 }
 
+func (c *userServiceClient) ListUserFeedTokens(ctx context.Context, in *ListUserFeedTokensRequest, opts ...grpc.CallOption) (*ListUserFeedTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserFeedTokensResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUserFeedTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateUserFeedToken(ctx context.Context, in *CreateUserFeedTokenRequest, opts ...grpc.CallOption) (*UserFeedToken, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserFeedToken)
+	err := c.cc.Invoke(ctx, UserService_CreateUserFeedToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteUserFeedToken(ctx context.Context, in *DeleteUserFeedTokenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_DeleteUserFeedToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ListUserSessions(ctx context.Context, in *ListUserSessionsRequest, opts ...grpc.CallOption) (*ListUserSessionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListUserSessionsResponse)
@@ -277,6 +316,12 @@ type UserServiceServer interface {
 	CreateUserAccessToken(context.Context, *CreateUserAccessTokenRequest) (*UserAccessToken, error)
 	// DeleteUserAccessToken deletes an access token.
 	DeleteUserAccessToken(context.Context, *DeleteUserAccessTokenRequest) (*emptypb.Empty, error)
+	// ListUserFeedTokens returns a list of feed tokens for a user.
+	ListUserFeedTokens(context.Context, *ListUserFeedTokensRequest) (*ListUserFeedTokensResponse, error)
+	// CreateUserFeedToken creates a new feed token for a user.
+	CreateUserFeedToken(context.Context, *CreateUserFeedTokenRequest) (*UserFeedToken, error)
+	// DeleteUserFeedToken revokes a feed token.
+	DeleteUserFeedToken(context.Context, *DeleteUserFeedTokenRequest) (*emptypb.Empty, error)
 	// ListUserSessions returns a list of active sessions for a user.
 	ListUserSessions(context.Context, *ListUserSessionsRequest) (*ListUserSessionsResponse, error)
 	// RevokeUserSession revokes a specific session for a user.
@@ -333,6 +378,15 @@ func (UnimplementedUserServiceServer) CreateUserAccessToken(context.Context, *Cr
 func (UnimplementedUserServiceServer) DeleteUserAccessToken(context.Context, *DeleteUserAccessTokenRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserAccessToken not implemented")
 }
+func (UnimplementedUserServiceServer) ListUserFeedTokens(context.Context, *ListUserFeedTokensRequest) (*ListUserFeedTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserFeedTokens not implemented")
+}
+func (UnimplementedUserServiceServer) CreateUserFeedToken(context.Context, *CreateUserFeedTokenRequest) (*UserFeedToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUserFeedToken not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteUserFeedToken(context.Context, *DeleteUserFeedTokenRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserFeedToken not implemented")
+}
 func (UnimplementedUserServiceServer) ListUserSessions(context.Context, *ListUserSessionsRequest) (*ListUserSessionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUserSessions not implemented")
 }
@@ -612,6 +666,60 @@ func _UserService_DeleteUserAccessToken_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ListUserFeedTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserFeedTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUserFeedTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUserFeedTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUserFeedTokens(ctx, req.(*ListUserFeedTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateUserFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUserFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateUserFeedToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUserFeedToken(ctx, req.(*CreateUserFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteUserFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteUserFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteUserFeedToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteUserFeedToken(ctx, req.(*DeleteUserFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ListUserSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListUserSessionsRequest)
 	if err := dec(in); err != nil {
@@ -711,6 +819,18 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUserAccessToken",
 			Handler:    _UserService_DeleteUserAccessToken_Handler,
 		},
+		{
+			MethodName: "ListUserFeedTokens",
+			Handler:    _UserService_ListUserFeedTokens_Handler,
+		},
+		{
+			MethodName: "CreateUserFeedToken",
+			Handler:    _UserService_CreateUserFeedToken_Handler,
+		},
+		{
+			MethodName: "DeleteUserFeedToken",
+			Handler:    _UserService_DeleteUserFeedToken_Handler,
+		},
 		{
 			MethodName: "ListUserSessions",
 			Handler:    _UserService_ListUserSessions_Handler,
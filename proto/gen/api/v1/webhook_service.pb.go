@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -25,6 +26,176 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Webhook_Format int32
+
+const (
+	Webhook_FORMAT_UNSPECIFIED Webhook_Format = 0
+	// The protojson encoded WebhookRequestPayload.
+	Webhook_RAW Webhook_Format = 1
+	// A Slack incoming webhook message with blocks.
+	Webhook_SLACK Webhook_Format = 2
+	// A Discord webhook message with an embed.
+	Webhook_DISCORD Webhook_Format = 3
+	// A Microsoft Teams message with an adaptive card.
+	Webhook_TEAMS Webhook_Format = 4
+	// The output of the custom template.
+	Webhook_TEMPLATE Webhook_Format = 5
+)
+
+// Enum value maps for Webhook_Format.
+var (
+	Webhook_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "RAW",
+		2: "SLACK",
+		3: "DISCORD",
+		4: "TEAMS",
+		5: "TEMPLATE",
+	}
+	Webhook_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"RAW":                1,
+		"SLACK":              2,
+		"DISCORD":            3,
+		"TEAMS":              4,
+		"TEMPLATE":           5,
+	}
+)
+
+func (x Webhook_Format) Enum() *Webhook_Format {
+	p := new(Webhook_Format)
+	*p = x
+	return p
+}
+
+func (x Webhook_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Webhook_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_webhook_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Webhook_Format) Type() protoreflect.EnumType {
+	return &file_api_v1_webhook_service_proto_enumTypes[0]
+}
+
+func (x Webhook_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Webhook_Format.Descriptor instead.
+func (Webhook_Format) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// The scope of the events the webhook receives.
+type Webhook_Scope int32
+
+const (
+	Webhook_SCOPE_UNSPECIFIED Webhook_Scope = 0
+	// The webhook receives the events of its creator.
+	Webhook_USER Webhook_Scope = 1
+	// The webhook receives the events of all users, it is managed by all hosts and admins.
+	Webhook_WORKSPACE Webhook_Scope = 2
+)
+
+// Enum value maps for Webhook_Scope.
+var (
+	Webhook_Scope_name = map[int32]string{
+		0: "SCOPE_UNSPECIFIED",
+		1: "USER",
+		2: "WORKSPACE",
+	}
+	Webhook_Scope_value = map[string]int32{
+		"SCOPE_UNSPECIFIED": 0,
+		"USER":              1,
+		"WORKSPACE":         2,
+	}
+)
+
+func (x Webhook_Scope) Enum() *Webhook_Scope {
+	p := new(Webhook_Scope)
+	*p = x
+	return p
+}
+
+func (x Webhook_Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Webhook_Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_webhook_service_proto_enumTypes[1].Descriptor()
+}
+
+func (Webhook_Scope) Type() protoreflect.EnumType {
+	return &file_api_v1_webhook_service_proto_enumTypes[1]
+}
+
+func (x Webhook_Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Webhook_Scope.Descriptor instead.
+func (Webhook_Scope) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{0, 1}
+}
+
+type WebhookDelivery_Status int32
+
+const (
+	WebhookDelivery_STATUS_UNSPECIFIED WebhookDelivery_Status = 0
+	// The delivery is waiting for its next attempt.
+	WebhookDelivery_PENDING WebhookDelivery_Status = 1
+	// The delivery was accepted by the webhook.
+	WebhookDelivery_SUCCEEDED WebhookDelivery_Status = 2
+	// The delivery failed all of its attempts and will not be retried.
+	WebhookDelivery_DEAD WebhookDelivery_Status = 3
+)
+
+// Enum value maps for WebhookDelivery_Status.
+var (
+	WebhookDelivery_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "PENDING",
+		2: "SUCCEEDED",
+		3: "DEAD",
+	}
+	WebhookDelivery_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"PENDING":            1,
+		"SUCCEEDED":          2,
+		"DEAD":               3,
+	}
+)
+
+func (x WebhookDelivery_Status) Enum() *WebhookDelivery_Status {
+	p := new(WebhookDelivery_Status)
+	*p = x
+	return p
+}
+
+func (x WebhookDelivery_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDelivery_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_webhook_service_proto_enumTypes[2].Descriptor()
+}
+
+func (WebhookDelivery_Status) Type() protoreflect.EnumType {
+	return &file_api_v1_webhook_service_proto_enumTypes[2]
+}
+
+func (x WebhookDelivery_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDelivery_Status.Descriptor instead.
+func (WebhookDelivery_Status) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{8, 0}
+}
+
 type Webhook struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The resource name of the webhook.
@@ -46,7 +217,36 @@ type Webhook struct {
 	// Output only. The last update timestamp.
 	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
 	// Output only. The etag for this resource.
-	Etag          string `protobuf:"bytes,9,opt,name=etag,proto3" json:"etag,omitempty"`
+	Etag string `protobuf:"bytes,9,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Output only. The secret used to sign the requests to the webhook.
+	// Requests carry the `X-Memos-Signature` header in the form of `t=<timestamp>,v1=<signature>`,
+	// where the signature is the hex encoded HMAC-SHA256 of `<timestamp>.<body>`.
+	// Only set in the responses of `CreateWebhook` and `RotateWebhookSecret`.
+	SigningSecret string `protobuf:"bytes,10,opt,name=signing_secret,json=signingSecret,proto3" json:"signing_secret,omitempty"`
+	// Optional. The event types the webhook is subscribed to.
+	// Supported types: memos.memo.created, memos.memo.updated, memos.memo.deleted,
+	// memos.comment.created, memos.reaction.added, memos.reaction.removed,
+	// memos.attachment.uploaded, memos.attachment.deleted, memos.memo.relation.changed,
+	// memos.user.created, memos.user.signed_in.
+	// If empty, the webhook receives all events.
+	EventTypes []string `protobuf:"bytes,11,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Optional. The CEL filter over the memo of the event, with the attributes of memo filters.
+	// Example: `tag in ["deploy"] && visibility == "PUBLIC"`
+	// If empty, all events match, otherwise the events without a memo, e.g. user events, never match.
+	Filter string `protobuf:"bytes,12,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Optional. The format of the requests.
+	// If unspecified, the RAW format is used.
+	Format Webhook_Format `protobuf:"varint,13,opt,name=format,proto3,enum=memos.api.v1.Webhook_Format" json:"format,omitempty"`
+	// Optional. The Go text/template of the requests in the TEMPLATE format.
+	// The template receives `.Payload` with the WebhookRequestPayload, `.Title` and `.Text` of the event, `.URL` of the memo,
+	// and the `json` function encodes values as JSON, e.g. `{"text": {{json .Payload.Memo.Content}}}`.
+	Template string `protobuf:"bytes,14,opt,name=template,proto3" json:"template,omitempty"`
+	// Optional. The scope of the webhook, it can't be changed after creation.
+	// If unspecified, the USER scope is used. Only hosts and admins can create WORKSPACE webhooks.
+	Scope Webhook_Scope `protobuf:"varint,15,opt,name=scope,proto3,enum=memos.api.v1.Webhook_Scope" json:"scope,omitempty"`
+	// Optional. The visibilities of the memos of other users that WORKSPACE webhooks receive events of.
+	// If empty, only the events of public memos are received. The events without a memo, e.g. user events, are always received.
+	Visibilities  []Visibility `protobuf:"varint,16,rep,packed,name=visibilities,proto3,enum=memos.api.v1.Visibility" json:"visibilities,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -144,6 +344,55 @@ func (x *Webhook) GetEtag() string {
 	return ""
 }
 
+func (x *Webhook) GetSigningSecret() string {
+	if x != nil {
+		return x.SigningSecret
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *Webhook) GetFormat() Webhook_Format {
+	if x != nil {
+		return x.Format
+	}
+	return Webhook_FORMAT_UNSPECIFIED
+}
+
+func (x *Webhook) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *Webhook) GetScope() Webhook_Scope {
+	if x != nil {
+		return x.Scope
+	}
+	return Webhook_SCOPE_UNSPECIFIED
+}
+
+func (x *Webhook) GetVisibilities() []Visibility {
+	if x != nil {
+		return x.Visibilities
+	}
+	return nil
+}
+
 type ListWebhooksRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Optional. The maximum number of webhooks to return.
@@ -546,37 +795,29 @@ func (x *DeleteWebhookRequest) GetForce() bool {
 	return false
 }
 
-type WebhookRequestPayload struct {
+type RotateWebhookSecretRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The target URL for the webhook request.
-	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	// The type of activity that triggered this webhook.
-	ActivityType string `protobuf:"bytes,2,opt,name=activity_type,json=activityType,proto3" json:"activity_type,omitempty"`
-	// The resource name of the creator.
-	// Format: users/{user}
-	Creator string `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
-	// The creation timestamp of the activity.
-	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
-	// The memo that triggered this webhook (if applicable).
-	Memo          *Memo `protobuf:"bytes,5,opt,name=memo,proto3" json:"memo,omitempty"`
+	// Required. The resource name of the webhook.
+	// Format: webhooks/{webhook}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WebhookRequestPayload) Reset() {
-	*x = WebhookRequestPayload{}
+func (x *RotateWebhookSecretRequest) Reset() {
+	*x = RotateWebhookSecretRequest{}
 	mi := &file_api_v1_webhook_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WebhookRequestPayload) String() string {
+func (x *RotateWebhookSecretRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WebhookRequestPayload) ProtoMessage() {}
+func (*RotateWebhookSecretRequest) ProtoMessage() {}
 
-func (x *WebhookRequestPayload) ProtoReflect() protoreflect.Message {
+func (x *RotateWebhookSecretRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_webhook_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -588,167 +829,1254 @@ func (x *WebhookRequestPayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WebhookRequestPayload.ProtoReflect.Descriptor instead.
-func (*WebhookRequestPayload) Descriptor() ([]byte, []int) {
+// Deprecated: Use RotateWebhookSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateWebhookSecretRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *WebhookRequestPayload) GetUrl() string {
+func (x *RotateWebhookSecretRequest) GetName() string {
 	if x != nil {
-		return x.Url
+		return x.Name
 	}
 	return ""
 }
 
-func (x *WebhookRequestPayload) GetActivityType() string {
+type WebhookDelivery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resource name of the delivery.
+	// Format: webhooks/{webhook}/deliveries/{delivery}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Output only. The status of the delivery.
+	Status WebhookDelivery_Status `protobuf:"varint,2,opt,name=status,proto3,enum=memos.api.v1.WebhookDelivery_Status" json:"status,omitempty"`
+	// Output only. The type of activity that triggered the delivery.
+	ActivityType string `protobuf:"bytes,3,opt,name=activity_type,json=activityType,proto3" json:"activity_type,omitempty"`
+	// Output only. The URL the request was posted to.
+	Url string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	// Output only. The number of attempts made so far.
+	Attempts int32 `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// Output only. The time of the next attempt of a pending delivery.
+	NextAttemptTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=next_attempt_time,json=nextAttemptTime,proto3" json:"next_attempt_time,omitempty"`
+	// Output only. The JSON body posted to the webhook.
+	RequestBody string `protobuf:"bytes,7,opt,name=request_body,json=requestBody,proto3" json:"request_body,omitempty"`
+	// Output only. The HTTP status code of the last response, or 0 if no response was received.
+	ResponseStatus int32 `protobuf:"varint,8,opt,name=response_status,json=responseStatus,proto3" json:"response_status,omitempty"`
+	// Output only. The body of the last response, truncated.
+	ResponseBody string `protobuf:"bytes,9,opt,name=response_body,json=responseBody,proto3" json:"response_body,omitempty"`
+	// Output only. The duration of the last attempt.
+	Latency *durationpb.Duration `protobuf:"bytes,10,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Output only. The error of the last failed attempt.
+	Error string `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	// Output only. The creation timestamp.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Output only. The last update timestamp.
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WebhookDelivery) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() WebhookDelivery_Status {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookDelivery_STATUS_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetActivityType() string {
 	if x != nil {
 		return x.ActivityType
 	}
 	return ""
 }
 
-func (x *WebhookRequestPayload) GetCreator() string {
+func (x *WebhookDelivery) GetUrl() string {
 	if x != nil {
-		return x.Creator
+		return x.Url
 	}
 	return ""
 }
 
-func (x *WebhookRequestPayload) GetCreateTime() *timestamppb.Timestamp {
+func (x *WebhookDelivery) GetAttempts() int32 {
 	if x != nil {
-		return x.CreateTime
+		return x.Attempts
 	}
-	return nil
+	return 0
 }
 
-func (x *WebhookRequestPayload) GetMemo() *Memo {
+func (x *WebhookDelivery) GetNextAttemptTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Memo
+		return x.NextAttemptTime
 	}
 	return nil
 }
 
-var File_api_v1_webhook_service_proto protoreflect.FileDescriptor
-
-const file_api_v1_webhook_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1capi/v1/webhook_service.proto\x12\fmemos.api.v1\x1a\x13api/v1/common.proto\x1a\x19api/v1/memo_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xac\x03\n" +
-	"\aWebhook\x12\x17\n" +
-	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12\x15\n" +
-	"\x03uid\x18\x02 \x01(\tB\x03\xe0A\x03R\x03uid\x12&\n" +
-	"\fdisplay_name\x18\x03 \x01(\tB\x03\xe0A\x02R\vdisplayName\x12\x15\n" +
-	"\x03url\x18\x04 \x01(\tB\x03\xe0A\x02R\x03url\x12\x1d\n" +
-	"\acreator\x18\x05 \x01(\tB\x03\xe0A\x03R\acreator\x12.\n" +
-	"\x05state\x18\x06 \x01(\x0e2\x13.memos.api.v1.StateB\x03\xe0A\x02R\x05state\x12@\n" +
-	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
-	"createTime\x12@\n" +
-	"\vupdate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
-	"updateTime\x12\x17\n" +
-	"\x04etag\x18\t \x01(\tB\x03\xe0A\x03R\x04etag:F\xeaAC\n" +
-	"\x14memos.api.v1/Webhook\x12\x12webhooks/{webhook}\x1a\x04name*\bwebhooks2\awebhook\"\xc0\x01\n" +
-	"\x13ListWebhooksRequest\x12 \n" +
-	"\tpage_size\x18\x01 \x01(\x05B\x03\xe0A\x01R\bpageSize\x12\"\n" +
-	"\n" +
-	"page_token\x18\x02 \x01(\tB\x03\xe0A\x01R\tpageToken\x12\x1b\n" +
-	"\x06filter\x18\x03 \x01(\tB\x03\xe0A\x01R\x06filter\x12\x1e\n" +
-	"\border_by\x18\x04 \x01(\tB\x03\xe0A\x01R\aorderBy\x12&\n" +
-	"\fshow_deleted\x18\x05 \x01(\bB\x03\xe0A\x01R\vshowDeleted\"\x90\x01\n" +
-	"\x14ListWebhooksResponse\x121\n" +
-	"\bwebhooks\x18\x01 \x03(\v2\x15.memos.api.v1.WebhookR\bwebhooks\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
-	"\n" +
-	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\x83\x01\n" +
-	"\x11GetWebhookRequest\x120\n" +
-	"\x04name\x18\x01 \x01(\tB\x1c\xe0A\x02\xfaA\x16\n" +
-	"\x14memos.api.v1/WebhookR\x04name\x12<\n" +
-	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x01R\breadMask\"\xc1\x01\n" +
-	"\x14CreateWebhookRequest\x127\n" +
-	"\awebhook\x18\x01 \x01(\v2\x15.memos.api.v1.WebhookB\x06\xe0A\x02\xe0A\x04R\awebhook\x12\"\n" +
-	"\n" +
-	"webhook_id\x18\x02 \x01(\tB\x03\xe0A\x01R\twebhookId\x12(\n" +
-	"\rvalidate_only\x18\x03 \x01(\bB\x03\xe0A\x01R\fvalidateOnly\x12\"\n" +
-	"\n" +
-	"request_id\x18\x04 \x01(\tB\x03\xe0A\x01R\trequestId\"\xb8\x01\n" +
-	"\x14UpdateWebhookRequest\x124\n" +
-	"\awebhook\x18\x01 \x01(\v2\x15.memos.api.v1.WebhookB\x03\xe0A\x02R\awebhook\x12@\n" +
-	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x02R\n" +
-	"updateMask\x12(\n" +
-	"\rallow_missing\x18\x03 \x01(\bB\x03\xe0A\x01R\fallowMissing\"c\n" +
-	"\x14DeleteWebhookRequest\x120\n" +
-	"\x04name\x18\x01 \x01(\tB\x1c\xe0A\x02\xfaA\x16\n" +
-	"\x14memos.api.v1/WebhookR\x04name\x12\x19\n" +
-	"\x05force\x18\x02 \x01(\bB\x03\xe0A\x01R\x05force\"\xfc\x01\n" +
-	"\x15WebhookRequestPayload\x12\x15\n" +
-	"\x03url\x18\x01 \x01(\tB\x03\xe0A\x02R\x03url\x12(\n" +
-	"\ractivity_type\x18\x02 \x01(\tB\x03\xe0A\x02R\factivityType\x123\n" +
-	"\acreator\x18\x03 \x01(\tB\x19\xe0A\x03\xfaA\x13\n" +
-	"\x11memos.api.v1/UserR\acreator\x12@\n" +
-	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
-	"createTime\x12+\n" +
-	"\x04memo\x18\x05 \x01(\v2\x12.memos.api.v1.MemoB\x03\xe0A\x01R\x04memo2\xf8\x04\n" +
-	"\x0eWebhookService\x12o\n" +
-	"\fListWebhooks\x12!.memos.api.v1.ListWebhooksRequest\x1a\".memos.api.v1.ListWebhooksResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/webhooks\x12n\n" +
-	"\n" +
-	"GetWebhook\x12\x1f.memos.api.v1.GetWebhookRequest\x1a\x15.memos.api.v1.Webhook\"(\xdaA\x04name\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/{name=webhooks/*}\x12w\n" +
-	"\rCreateWebhook\x12\".memos.api.v1.CreateWebhookRequest\x1a\x15.memos.api.v1.Webhook\"+\xdaA\awebhook\x82\xd3\xe4\x93\x02\x1b:\awebhook\"\x10/api/v1/webhooks\x12\x94\x01\n" +
-	"\rUpdateWebhook\x12\".memos.api.v1.UpdateWebhookRequest\x1a\x15.memos.api.v1.Webhook\"H\xdaA\x13webhook,update_mask\x82\xd3\xe4\x93\x02,:\awebhook2!/api/v1/{webhook.name=webhooks/*}\x12u\n" +
-	"\rDeleteWebhook\x12\".memos.api.v1.DeleteWebhookRequest\x1a\x16.google.protobuf.Empty\"(\xdaA\x04name\x82\xd3\xe4\x93\x02\x1b*\x19/api/v1/{name=webhooks/*}B\xab\x01\n" +
-	"\x10com.memos.api.v1B\x13WebhookServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
-
-var (
-	file_api_v1_webhook_service_proto_rawDescOnce sync.Once
-	file_api_v1_webhook_service_proto_rawDescData []byte
-)
+func (x *WebhookDelivery) GetRequestBody() string {
+	if x != nil {
+		return x.RequestBody
+	}
+	return ""
+}
 
-func file_api_v1_webhook_service_proto_rawDescGZIP() []byte {
-	file_api_v1_webhook_service_proto_rawDescOnce.Do(func() {
-		file_api_v1_webhook_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_webhook_service_proto_rawDesc), len(file_api_v1_webhook_service_proto_rawDesc)))
-	})
-	return file_api_v1_webhook_service_proto_rawDescData
+func (x *WebhookDelivery) GetResponseStatus() int32 {
+	if x != nil {
+		return x.ResponseStatus
+	}
+	return 0
 }
 
-var file_api_v1_webhook_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_api_v1_webhook_service_proto_goTypes = []any{
-	(*Webhook)(nil),               // 0: memos.api.v1.Webhook
-	(*ListWebhooksRequest)(nil),   // 1: memos.api.v1.ListWebhooksRequest
-	(*ListWebhooksResponse)(nil),  // 2: memos.api.v1.ListWebhooksResponse
-	(*GetWebhookRequest)(nil),     // 3: memos.api.v1.GetWebhookRequest
-	(*CreateWebhookRequest)(nil),  // 4: memos.api.v1.CreateWebhookRequest
-	(*UpdateWebhookRequest)(nil),  // 5: memos.api.v1.UpdateWebhookRequest
-	(*DeleteWebhookRequest)(nil),  // 6: memos.api.v1.DeleteWebhookRequest
-	(*WebhookRequestPayload)(nil), // 7: memos.api.v1.WebhookRequestPayload
-	(State)(0),                    // 8: memos.api.v1.State
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil), // 10: google.protobuf.FieldMask
-	(*Memo)(nil),                  // 11: memos.api.v1.Memo
-	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
+func (x *WebhookDelivery) GetResponseBody() string {
+	if x != nil {
+		return x.ResponseBody
+	}
+	return ""
 }
-var file_api_v1_webhook_service_proto_depIdxs = []int32{
-	8,  // 0: memos.api.v1.Webhook.state:type_name -> memos.api.v1.State
-	9,  // 1: memos.api.v1.Webhook.create_time:type_name -> google.protobuf.Timestamp
-	9,  // 2: memos.api.v1.Webhook.update_time:type_name -> google.protobuf.Timestamp
-	0,  // 3: memos.api.v1.ListWebhooksResponse.webhooks:type_name -> memos.api.v1.Webhook
-	10, // 4: memos.api.v1.GetWebhookRequest.read_mask:type_name -> google.protobuf.FieldMask
-	0,  // 5: memos.api.v1.CreateWebhookRequest.webhook:type_name -> memos.api.v1.Webhook
-	0,  // 6: memos.api.v1.UpdateWebhookRequest.webhook:type_name -> memos.api.v1.Webhook
-	10, // 7: memos.api.v1.UpdateWebhookRequest.update_mask:type_name -> google.protobuf.FieldMask
-	9,  // 8: memos.api.v1.WebhookRequestPayload.create_time:type_name -> google.protobuf.Timestamp
-	11, // 9: memos.api.v1.WebhookRequestPayload.memo:type_name -> memos.api.v1.Memo
-	1,  // 10: memos.api.v1.WebhookService.ListWebhooks:input_type -> memos.api.v1.ListWebhooksRequest
-	3,  // 11: memos.api.v1.WebhookService.GetWebhook:input_type -> memos.api.v1.GetWebhookRequest
-	4,  // 12: memos.api.v1.WebhookService.CreateWebhook:input_type -> memos.api.v1.CreateWebhookRequest
-	5,  // 13: memos.api.v1.WebhookService.UpdateWebhook:input_type -> memos.api.v1.UpdateWebhookRequest
-	6,  // 14: memos.api.v1.WebhookService.DeleteWebhook:input_type -> memos.api.v1.DeleteWebhookRequest
-	2,  // 15: memos.api.v1.WebhookService.ListWebhooks:output_type -> memos.api.v1.ListWebhooksResponse
-	0,  // 16: memos.api.v1.WebhookService.GetWebhook:output_type -> memos.api.v1.Webhook
-	0,  // 17: memos.api.v1.WebhookService.CreateWebhook:output_type -> memos.api.v1.Webhook
-	0,  // 18: memos.api.v1.WebhookService.UpdateWebhook:output_type -> memos.api.v1.Webhook
-	12, // 19: memos.api.v1.WebhookService.DeleteWebhook:output_type -> google.protobuf.Empty
-	15, // [15:20] is the sub-list for method output_type
-	10, // [10:15] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+
+func (x *WebhookDelivery) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the webhook.
+	// Format: webhooks/{webhook}
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Optional. The maximum number of deliveries to return.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. A page token, received from a previous `ListWebhookDeliveries` call.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The list of deliveries.
+	Deliveries []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	// A token that can be sent as `page_token` to retrieve the next page.
+	// If this field is omitted, there are no subsequent pages.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RedeliverWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the delivery to redeliver.
+	// Format: webhooks/{webhook}/deliveries/{delivery}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookRequest) Reset() {
+	*x = RedeliverWebhookRequest{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverWebhookRequest) ProtoMessage() {}
+
+func (x *RedeliverWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RedeliverWebhookRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type IncomingWebhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resource name of the incoming webhook.
+	// Format: incomingWebhooks/{incoming_webhook}
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Required. The display name of the incoming webhook.
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Output only. The resource name of the creator.
+	// Format: users/{user}
+	Creator string `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	// Optional. The tags appended to the memos created by the hook.
+	Tags []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Optional. The visibility of the memos created by the hook.
+	// If unspecified, memos are private.
+	Visibility Visibility `protobuf:"varint,5,opt,name=visibility,proto3,enum=memos.api.v1.Visibility" json:"visibility,omitempty"`
+	// Optional. The Go text/template used to render the memo content.
+	// The template receives `.Body` with the raw request body and `.Data`
+	// with the decoded JSON or form body.
+	// If empty, the request body is used as the content.
+	Template string `protobuf:"bytes,6,opt,name=template,proto3" json:"template,omitempty"`
+	// Output only. The secret path to post to, e.g. "/hooks/in/{token}".
+	// Anyone who knows the path can create memos as the creator.
+	Path string `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	// Output only. The creation timestamp.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Output only. The last update timestamp.
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncomingWebhook) Reset() {
+	*x = IncomingWebhook{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncomingWebhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomingWebhook) ProtoMessage() {}
+
+func (x *IncomingWebhook) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomingWebhook.ProtoReflect.Descriptor instead.
+func (*IncomingWebhook) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IncomingWebhook) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IncomingWebhook) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *IncomingWebhook) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *IncomingWebhook) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *IncomingWebhook) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+func (x *IncomingWebhook) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *IncomingWebhook) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *IncomingWebhook) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *IncomingWebhook) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type ListIncomingWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncomingWebhooksRequest) Reset() {
+	*x = ListIncomingWebhooksRequest{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomingWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomingWebhooksRequest) ProtoMessage() {}
+
+func (x *ListIncomingWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomingWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListIncomingWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{13}
+}
+
+type ListIncomingWebhooksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The list of incoming webhooks.
+	IncomingWebhooks []*IncomingWebhook `protobuf:"bytes,1,rep,name=incoming_webhooks,json=incomingWebhooks,proto3" json:"incoming_webhooks,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListIncomingWebhooksResponse) Reset() {
+	*x = ListIncomingWebhooksResponse{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncomingWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncomingWebhooksResponse) ProtoMessage() {}
+
+func (x *ListIncomingWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncomingWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListIncomingWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListIncomingWebhooksResponse) GetIncomingWebhooks() []*IncomingWebhook {
+	if x != nil {
+		return x.IncomingWebhooks
+	}
+	return nil
+}
+
+type GetIncomingWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the incoming webhook.
+	// Format: incomingWebhooks/{incoming_webhook}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIncomingWebhookRequest) Reset() {
+	*x = GetIncomingWebhookRequest{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIncomingWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIncomingWebhookRequest) ProtoMessage() {}
+
+func (x *GetIncomingWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIncomingWebhookRequest.ProtoReflect.Descriptor instead.
+func (*GetIncomingWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetIncomingWebhookRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateIncomingWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The incoming webhook to create.
+	IncomingWebhook *IncomingWebhook `protobuf:"bytes,1,opt,name=incoming_webhook,json=incomingWebhook,proto3" json:"incoming_webhook,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateIncomingWebhookRequest) Reset() {
+	*x = CreateIncomingWebhookRequest{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIncomingWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIncomingWebhookRequest) ProtoMessage() {}
+
+func (x *CreateIncomingWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIncomingWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateIncomingWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateIncomingWebhookRequest) GetIncomingWebhook() *IncomingWebhook {
+	if x != nil {
+		return x.IncomingWebhook
+	}
+	return nil
+}
+
+type UpdateIncomingWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The incoming webhook to update.
+	IncomingWebhook *IncomingWebhook `protobuf:"bytes,1,opt,name=incoming_webhook,json=incomingWebhook,proto3" json:"incoming_webhook,omitempty"`
+	// Required. The list of fields to update.
+	// Updating "path" generates a new secret path and invalidates the old one.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateIncomingWebhookRequest) Reset() {
+	*x = UpdateIncomingWebhookRequest{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIncomingWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIncomingWebhookRequest) ProtoMessage() {}
+
+func (x *UpdateIncomingWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIncomingWebhookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateIncomingWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateIncomingWebhookRequest) GetIncomingWebhook() *IncomingWebhook {
+	if x != nil {
+		return x.IncomingWebhook
+	}
+	return nil
+}
+
+func (x *UpdateIncomingWebhookRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteIncomingWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the incoming webhook to delete.
+	// Format: incomingWebhooks/{incoming_webhook}
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIncomingWebhookRequest) Reset() {
+	*x = DeleteIncomingWebhookRequest{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIncomingWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIncomingWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteIncomingWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIncomingWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteIncomingWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteIncomingWebhookRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WebhookRequestPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The target URL for the webhook request.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The type of activity that triggered this webhook.
+	ActivityType string `protobuf:"bytes,2,opt,name=activity_type,json=activityType,proto3" json:"activity_type,omitempty"`
+	// The resource name of the creator.
+	// Format: users/{user}
+	Creator string `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	// The creation timestamp of the activity.
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// The memo that triggered this webhook (if applicable).
+	// For comment, reaction, attachment and relation events, it is the memo the event is about.
+	Memo *Memo `protobuf:"bytes,5,opt,name=memo,proto3" json:"memo,omitempty"`
+	// The event specific data, it is unset for memo created, updated and deleted events.
+	//
+	// Types that are valid to be assigned to Event:
+	//
+	//	*WebhookRequestPayload_Comment
+	//	*WebhookRequestPayload_Reaction
+	//	*WebhookRequestPayload_Attachment
+	//	*WebhookRequestPayload_Relations
+	//	*WebhookRequestPayload_User
+	Event         isWebhookRequestPayload_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookRequestPayload) Reset() {
+	*x = WebhookRequestPayload{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookRequestPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookRequestPayload) ProtoMessage() {}
+
+func (x *WebhookRequestPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookRequestPayload.ProtoReflect.Descriptor instead.
+func (*WebhookRequestPayload) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WebhookRequestPayload) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookRequestPayload) GetActivityType() string {
+	if x != nil {
+		return x.ActivityType
+	}
+	return ""
+}
+
+func (x *WebhookRequestPayload) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *WebhookRequestPayload) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *WebhookRequestPayload) GetMemo() *Memo {
+	if x != nil {
+		return x.Memo
+	}
+	return nil
+}
+
+func (x *WebhookRequestPayload) GetEvent() isWebhookRequestPayload_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WebhookRequestPayload) GetComment() *Memo {
+	if x != nil {
+		if x, ok := x.Event.(*WebhookRequestPayload_Comment); ok {
+			return x.Comment
+		}
+	}
+	return nil
+}
+
+func (x *WebhookRequestPayload) GetReaction() *Reaction {
+	if x != nil {
+		if x, ok := x.Event.(*WebhookRequestPayload_Reaction); ok {
+			return x.Reaction
+		}
+	}
+	return nil
+}
+
+func (x *WebhookRequestPayload) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Event.(*WebhookRequestPayload_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *WebhookRequestPayload) GetRelations() *WebhookRequestPayload_MemoRelations {
+	if x != nil {
+		if x, ok := x.Event.(*WebhookRequestPayload_Relations); ok {
+			return x.Relations
+		}
+	}
+	return nil
+}
+
+func (x *WebhookRequestPayload) GetUser() *User {
+	if x != nil {
+		if x, ok := x.Event.(*WebhookRequestPayload_User); ok {
+			return x.User
+		}
+	}
+	return nil
+}
+
+type isWebhookRequestPayload_Event interface {
+	isWebhookRequestPayload_Event()
+}
+
+type WebhookRequestPayload_Comment struct {
+	// The comment of a comment created event.
+	Comment *Memo `protobuf:"bytes,6,opt,name=comment,proto3,oneof"`
+}
+
+type WebhookRequestPayload_Reaction struct {
+	// The reaction of a reaction added or removed event.
+	Reaction *Reaction `protobuf:"bytes,7,opt,name=reaction,proto3,oneof"`
+}
+
+type WebhookRequestPayload_Attachment struct {
+	// The attachment of an attachment uploaded or deleted event.
+	Attachment *Attachment `protobuf:"bytes,8,opt,name=attachment,proto3,oneof"`
+}
+
+type WebhookRequestPayload_Relations struct {
+	// The relations of a memo relation changed event.
+	Relations *WebhookRequestPayload_MemoRelations `protobuf:"bytes,9,opt,name=relations,proto3,oneof"`
+}
+
+type WebhookRequestPayload_User struct {
+	// The user of a user created or signed in event.
+	User *User `protobuf:"bytes,10,opt,name=user,proto3,oneof"`
+}
+
+func (*WebhookRequestPayload_Comment) isWebhookRequestPayload_Event() {}
+
+func (*WebhookRequestPayload_Reaction) isWebhookRequestPayload_Event() {}
+
+func (*WebhookRequestPayload_Attachment) isWebhookRequestPayload_Event() {}
+
+func (*WebhookRequestPayload_Relations) isWebhookRequestPayload_Event() {}
+
+func (*WebhookRequestPayload_User) isWebhookRequestPayload_Event() {}
+
+type WebhookRequestPayload_MemoRelations struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The relations of the memo after the change.
+	Relations     []*MemoRelation `protobuf:"bytes,1,rep,name=relations,proto3" json:"relations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookRequestPayload_MemoRelations) Reset() {
+	*x = WebhookRequestPayload_MemoRelations{}
+	mi := &file_api_v1_webhook_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookRequestPayload_MemoRelations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookRequestPayload_MemoRelations) ProtoMessage() {}
+
+func (x *WebhookRequestPayload_MemoRelations) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_webhook_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookRequestPayload_MemoRelations.ProtoReflect.Descriptor instead.
+func (*WebhookRequestPayload_MemoRelations) Descriptor() ([]byte, []int) {
+	return file_api_v1_webhook_service_proto_rawDescGZIP(), []int{19, 0}
+}
+
+func (x *WebhookRequestPayload_MemoRelations) GetRelations() []*MemoRelation {
+	if x != nil {
+		return x.Relations
+	}
+	return nil
+}
+
+var File_api_v1_webhook_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_webhook_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1capi/v1/webhook_service.proto\x12\fmemos.api.v1\x1a\x1fapi/v1/attachment_service.proto\x1a\x13api/v1/common.proto\x1a\x19api/v1/memo_service.proto\x1a\x19api/v1/user_service.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x19google/api/resource.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\a\n" +
+	"\aWebhook\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12\x15\n" +
+	"\x03uid\x18\x02 \x01(\tB\x03\xe0A\x03R\x03uid\x12&\n" +
+	"\fdisplay_name\x18\x03 \x01(\tB\x03\xe0A\x02R\vdisplayName\x12\x15\n" +
+	"\x03url\x18\x04 \x01(\tB\x03\xe0A\x02R\x03url\x12\x1d\n" +
+	"\acreator\x18\x05 \x01(\tB\x03\xe0A\x03R\acreator\x12.\n" +
+	"\x05state\x18\x06 \x01(\x0e2\x13.memos.api.v1.StateB\x03\xe0A\x02R\x05state\x12@\n" +
+	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12@\n" +
+	"\vupdate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"updateTime\x12\x17\n" +
+	"\x04etag\x18\t \x01(\tB\x03\xe0A\x03R\x04etag\x12*\n" +
+	"\x0esigning_secret\x18\n" +
+	" \x01(\tB\x03\xe0A\x03R\rsigningSecret\x12$\n" +
+	"\vevent_types\x18\v \x03(\tB\x03\xe0A\x01R\n" +
+	"eventTypes\x12\x1b\n" +
+	"\x06filter\x18\f \x01(\tB\x03\xe0A\x01R\x06filter\x129\n" +
+	"\x06format\x18\r \x01(\x0e2\x1c.memos.api.v1.Webhook.FormatB\x03\xe0A\x01R\x06format\x12\x1f\n" +
+	"\btemplate\x18\x0e \x01(\tB\x03\xe0A\x01R\btemplate\x126\n" +
+	"\x05scope\x18\x0f \x01(\x0e2\x1b.memos.api.v1.Webhook.ScopeB\x03\xe0A\x05R\x05scope\x12A\n" +
+	"\fvisibilities\x18\x10 \x03(\x0e2\x18.memos.api.v1.VisibilityB\x03\xe0A\x01R\fvisibilities\"Z\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03RAW\x10\x01\x12\t\n" +
+	"\x05SLACK\x10\x02\x12\v\n" +
+	"\aDISCORD\x10\x03\x12\t\n" +
+	"\x05TEAMS\x10\x04\x12\f\n" +
+	"\bTEMPLATE\x10\x05\"7\n" +
+	"\x05Scope\x12\x15\n" +
+	"\x11SCOPE_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04USER\x10\x01\x12\r\n" +
+	"\tWORKSPACE\x10\x02:F\xeaAC\n" +
+	"\x14memos.api.v1/Webhook\x12\x12webhooks/{webhook}\x1a\x04name*\bwebhooks2\awebhook\"\xc0\x01\n" +
+	"\x13ListWebhooksRequest\x12 \n" +
+	"\tpage_size\x18\x01 \x01(\x05B\x03\xe0A\x01R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tB\x03\xe0A\x01R\tpageToken\x12\x1b\n" +
+	"\x06filter\x18\x03 \x01(\tB\x03\xe0A\x01R\x06filter\x12\x1e\n" +
+	"\border_by\x18\x04 \x01(\tB\x03\xe0A\x01R\aorderBy\x12&\n" +
+	"\fshow_deleted\x18\x05 \x01(\bB\x03\xe0A\x01R\vshowDeleted\"\x90\x01\n" +
+	"\x14ListWebhooksResponse\x121\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x15.memos.api.v1.WebhookR\bwebhooks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"\x83\x01\n" +
+	"\x11GetWebhookRequest\x120\n" +
+	"\x04name\x18\x01 \x01(\tB\x1c\xe0A\x02\xfaA\x16\n" +
+	"\x14memos.api.v1/WebhookR\x04name\x12<\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x01R\breadMask\"\xc1\x01\n" +
+	"\x14CreateWebhookRequest\x127\n" +
+	"\awebhook\x18\x01 \x01(\v2\x15.memos.api.v1.WebhookB\x06\xe0A\x02\xe0A\x04R\awebhook\x12\"\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tB\x03\xe0A\x01R\twebhookId\x12(\n" +
+	"\rvalidate_only\x18\x03 \x01(\bB\x03\xe0A\x01R\fvalidateOnly\x12\"\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tB\x03\xe0A\x01R\trequestId\"\xb8\x01\n" +
+	"\x14UpdateWebhookRequest\x124\n" +
+	"\awebhook\x18\x01 \x01(\v2\x15.memos.api.v1.WebhookB\x03\xe0A\x02R\awebhook\x12@\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x02R\n" +
+	"updateMask\x12(\n" +
+	"\rallow_missing\x18\x03 \x01(\bB\x03\xe0A\x01R\fallowMissing\"c\n" +
+	"\x14DeleteWebhookRequest\x120\n" +
+	"\x04name\x18\x01 \x01(\tB\x1c\xe0A\x02\xfaA\x16\n" +
+	"\x14memos.api.v1/WebhookR\x04name\x12\x19\n" +
+	"\x05force\x18\x02 \x01(\bB\x03\xe0A\x01R\x05force\"N\n" +
+	"\x1aRotateWebhookSecretRequest\x120\n" +
+	"\x04name\x18\x01 \x01(\tB\x1c\xe0A\x02\xfaA\x16\n" +
+	"\x14memos.api.v1/WebhookR\x04name\"\xb4\x06\n" +
+	"\x0fWebhookDelivery\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12A\n" +
+	"\x06status\x18\x02 \x01(\x0e2$.memos.api.v1.WebhookDelivery.StatusB\x03\xe0A\x03R\x06status\x12(\n" +
+	"\ractivity_type\x18\x03 \x01(\tB\x03\xe0A\x03R\factivityType\x12\x15\n" +
+	"\x03url\x18\x04 \x01(\tB\x03\xe0A\x03R\x03url\x12\x1f\n" +
+	"\battempts\x18\x05 \x01(\x05B\x03\xe0A\x03R\battempts\x12K\n" +
+	"\x11next_attempt_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\x0fnextAttemptTime\x12&\n" +
+	"\frequest_body\x18\a \x01(\tB\x03\xe0A\x03R\vrequestBody\x12,\n" +
+	"\x0fresponse_status\x18\b \x01(\x05B\x03\xe0A\x03R\x0eresponseStatus\x12(\n" +
+	"\rresponse_body\x18\t \x01(\tB\x03\xe0A\x03R\fresponseBody\x128\n" +
+	"\alatency\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationB\x03\xe0A\x03R\alatency\x12\x19\n" +
+	"\x05error\x18\v \x01(\tB\x03\xe0A\x03R\x05error\x12@\n" +
+	"\vcreate_time\x18\f \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12@\n" +
+	"\vupdate_time\x18\r \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"updateTime\"F\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aPENDING\x10\x01\x12\r\n" +
+	"\tSUCCEEDED\x10\x02\x12\b\n" +
+	"\x04DEAD\x10\x03:u\xeaAr\n" +
+	"\x1cmemos.api.v1/WebhookDelivery\x12(webhooks/{webhook}/deliveries/{delivery}\x1a\x04name*\x11webhookDeliveries2\x0fwebhookDelivery\"\x9a\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x124\n" +
+	"\x06parent\x18\x01 \x01(\tB\x1c\xe0A\x02\xfaA\x16\n" +
+	"\x14memos.api.v1/WebhookR\x06parent\x12 \n" +
+	"\tpage_size\x18\x02 \x01(\x05B\x03\xe0A\x01R\bpageSize\x12\"\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tB\x03\xe0A\x01R\tpageToken\"\x86\x01\n" +
+	"\x1dListWebhookDeliveriesResponse\x12=\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1d.memos.api.v1.WebhookDeliveryR\n" +
+	"deliveries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"S\n" +
+	"\x17RedeliverWebhookRequest\x128\n" +
+	"\x04name\x18\x01 \x01(\tB$\xe0A\x02\xfaA\x1e\n" +
+	"\x1cmemos.api.v1/WebhookDeliveryR\x04name\"\xf8\x03\n" +
+	"\x0fIncomingWebhook\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\bR\x04name\x12&\n" +
+	"\fdisplay_name\x18\x02 \x01(\tB\x03\xe0A\x02R\vdisplayName\x12\x1d\n" +
+	"\acreator\x18\x03 \x01(\tB\x03\xe0A\x03R\acreator\x12\x17\n" +
+	"\x04tags\x18\x04 \x03(\tB\x03\xe0A\x01R\x04tags\x12=\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\x0e2\x18.memos.api.v1.VisibilityB\x03\xe0A\x01R\n" +
+	"visibility\x12\x1f\n" +
+	"\btemplate\x18\x06 \x01(\tB\x03\xe0A\x01R\btemplate\x12\x17\n" +
+	"\x04path\x18\a \x01(\tB\x03\xe0A\x03R\x04path\x12@\n" +
+	"\vcreate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12@\n" +
+	"\vupdate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"updateTime:o\xeaAl\n" +
+	"\x1cmemos.api.v1/IncomingWebhook\x12#incomingWebhooks/{incoming_webhook}\x1a\x04name*\x10incomingWebhooks2\x0fincomingWebhook\"\x1d\n" +
+	"\x1bListIncomingWebhooksRequest\"j\n" +
+	"\x1cListIncomingWebhooksResponse\x12J\n" +
+	"\x11incoming_webhooks\x18\x01 \x03(\v2\x1d.memos.api.v1.IncomingWebhookR\x10incomingWebhooks\"U\n" +
+	"\x19GetIncomingWebhookRequest\x128\n" +
+	"\x04name\x18\x01 \x01(\tB$\xe0A\x02\xfaA\x1e\n" +
+	"\x1cmemos.api.v1/IncomingWebhookR\x04name\"m\n" +
+	"\x1cCreateIncomingWebhookRequest\x12M\n" +
+	"\x10incoming_webhook\x18\x01 \x01(\v2\x1d.memos.api.v1.IncomingWebhookB\x03\xe0A\x02R\x0fincomingWebhook\"\xaf\x01\n" +
+	"\x1cUpdateIncomingWebhookRequest\x12M\n" +
+	"\x10incoming_webhook\x18\x01 \x01(\v2\x1d.memos.api.v1.IncomingWebhookB\x03\xe0A\x02R\x0fincomingWebhook\x12@\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x02R\n" +
+	"updateMask\"X\n" +
+	"\x1cDeleteIncomingWebhookRequest\x128\n" +
+	"\x04name\x18\x01 \x01(\tB$\xe0A\x02\xfaA\x1e\n" +
+	"\x1cmemos.api.v1/IncomingWebhookR\x04name\"\xef\x04\n" +
+	"\x15WebhookRequestPayload\x12\x15\n" +
+	"\x03url\x18\x01 \x01(\tB\x03\xe0A\x02R\x03url\x12(\n" +
+	"\ractivity_type\x18\x02 \x01(\tB\x03\xe0A\x02R\factivityType\x123\n" +
+	"\acreator\x18\x03 \x01(\tB\x19\xe0A\x03\xfaA\x13\n" +
+	"\x11memos.api.v1/UserR\acreator\x12@\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"createTime\x12+\n" +
+	"\x04memo\x18\x05 \x01(\v2\x12.memos.api.v1.MemoB\x03\xe0A\x01R\x04memo\x12.\n" +
+	"\acomment\x18\x06 \x01(\v2\x12.memos.api.v1.MemoH\x00R\acomment\x124\n" +
+	"\breaction\x18\a \x01(\v2\x16.memos.api.v1.ReactionH\x00R\breaction\x12:\n" +
+	"\n" +
+	"attachment\x18\b \x01(\v2\x18.memos.api.v1.AttachmentH\x00R\n" +
+	"attachment\x12Q\n" +
+	"\trelations\x18\t \x01(\v21.memos.api.v1.WebhookRequestPayload.MemoRelationsH\x00R\trelations\x12(\n" +
+	"\x04user\x18\n" +
+	" \x01(\v2\x12.memos.api.v1.UserH\x00R\x04user\x1aI\n" +
+	"\rMemoRelations\x128\n" +
+	"\trelations\x18\x01 \x03(\v2\x1a.memos.api.v1.MemoRelationR\trelationsB\a\n" +
+	"\x05event2\x87\x0f\n" +
+	"\x0eWebhookService\x12o\n" +
+	"\fListWebhooks\x12!.memos.api.v1.ListWebhooksRequest\x1a\".memos.api.v1.ListWebhooksResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/webhooks\x12n\n" +
+	"\n" +
+	"GetWebhook\x12\x1f.memos.api.v1.GetWebhookRequest\x1a\x15.memos.api.v1.Webhook\"(\xdaA\x04name\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/{name=webhooks/*}\x12w\n" +
+	"\rCreateWebhook\x12\".memos.api.v1.CreateWebhookRequest\x1a\x15.memos.api.v1.Webhook\"+\xdaA\awebhook\x82\xd3\xe4\x93\x02\x1b:\awebhook\"\x10/api/v1/webhooks\x12\x94\x01\n" +
+	"\rUpdateWebhook\x12\".memos.api.v1.UpdateWebhookRequest\x1a\x15.memos.api.v1.Webhook\"H\xdaA\x13webhook,update_mask\x82\xd3\xe4\x93\x02,:\awebhook2!/api/v1/{webhook.name=webhooks/*}\x12u\n" +
+	"\rDeleteWebhook\x12\".memos.api.v1.DeleteWebhookRequest\x1a\x16.google.protobuf.Empty\"(\xdaA\x04name\x82\xd3\xe4\x93\x02\x1b*\x19/api/v1/{name=webhooks/*}\x12\x90\x01\n" +
+	"\x13RotateWebhookSecret\x12(.memos.api.v1.RotateWebhookSecretRequest\x1a\x15.memos.api.v1.Webhook\"8\xdaA\x04name\x82\xd3\xe4\x93\x02+:\x01*\"&/api/v1/{name=webhooks/*}:rotateSecret\x12\xa9\x01\n" +
+	"\x15ListWebhookDeliveries\x12*.memos.api.v1.ListWebhookDeliveriesRequest\x1a+.memos.api.v1.ListWebhookDeliveriesResponse\"7\xdaA\x06parent\x82\xd3\xe4\x93\x02(\x12&/api/v1/{parent=webhooks/*}/deliveries\x12\x9c\x01\n" +
+	"\x10RedeliverWebhook\x12%.memos.api.v1.RedeliverWebhookRequest\x1a\x1d.memos.api.v1.WebhookDelivery\"B\xdaA\x04name\x82\xd3\xe4\x93\x025:\x01*\"0/api/v1/{name=webhooks/*/deliveries/*}:redeliver\x12\x8f\x01\n" +
+	"\x14ListIncomingWebhooks\x12).memos.api.v1.ListIncomingWebhooksRequest\x1a*.memos.api.v1.ListIncomingWebhooksResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/incomingWebhooks\x12\x8e\x01\n" +
+	"\x12GetIncomingWebhook\x12'.memos.api.v1.GetIncomingWebhookRequest\x1a\x1d.memos.api.v1.IncomingWebhook\"0\xdaA\x04name\x82\xd3\xe4\x93\x02#\x12!/api/v1/{name=incomingWebhooks/*}\x12\xa9\x01\n" +
+	"\x15CreateIncomingWebhook\x12*.memos.api.v1.CreateIncomingWebhookRequest\x1a\x1d.memos.api.v1.IncomingWebhook\"E\xdaA\x10incoming_webhook\x82\xd3\xe4\x93\x02,:\x10incoming_webhook\"\x18/api/v1/incomingWebhooks\x12\xcf\x01\n" +
+	"\x15UpdateIncomingWebhook\x12*.memos.api.v1.UpdateIncomingWebhookRequest\x1a\x1d.memos.api.v1.IncomingWebhook\"k\xdaA\x1cincoming_webhook,update_mask\x82\xd3\xe4\x93\x02F:\x10incoming_webhook22/api/v1/{incoming_webhook.name=incomingWebhooks/*}\x12\x8d\x01\n" +
+	"\x15DeleteIncomingWebhook\x12*.memos.api.v1.DeleteIncomingWebhookRequest\x1a\x16.google.protobuf.Empty\"0\xdaA\x04name\x82\xd3\xe4\x93\x02#*!/api/v1/{name=incomingWebhooks/*}B\xab\x01\n" +
+	"\x10com.memos.api.v1B\x13WebhookServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
+
+var (
+	file_api_v1_webhook_service_proto_rawDescOnce sync.Once
+	file_api_v1_webhook_service_proto_rawDescData []byte
+)
+
+func file_api_v1_webhook_service_proto_rawDescGZIP() []byte {
+	file_api_v1_webhook_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_webhook_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_webhook_service_proto_rawDesc), len(file_api_v1_webhook_service_proto_rawDesc)))
+	})
+	return file_api_v1_webhook_service_proto_rawDescData
+}
+
+var file_api_v1_webhook_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_v1_webhook_service_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_api_v1_webhook_service_proto_goTypes = []any{
+	(Webhook_Format)(0),                         // 0: memos.api.v1.Webhook.Format
+	(Webhook_Scope)(0),                          // 1: memos.api.v1.Webhook.Scope
+	(WebhookDelivery_Status)(0),                 // 2: memos.api.v1.WebhookDelivery.Status
+	(*Webhook)(nil),                             // 3: memos.api.v1.Webhook
+	(*ListWebhooksRequest)(nil),                 // 4: memos.api.v1.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),                // 5: memos.api.v1.ListWebhooksResponse
+	(*GetWebhookRequest)(nil),                   // 6: memos.api.v1.GetWebhookRequest
+	(*CreateWebhookRequest)(nil),                // 7: memos.api.v1.CreateWebhookRequest
+	(*UpdateWebhookRequest)(nil),                // 8: memos.api.v1.UpdateWebhookRequest
+	(*DeleteWebhookRequest)(nil),                // 9: memos.api.v1.DeleteWebhookRequest
+	(*RotateWebhookSecretRequest)(nil),          // 10: memos.api.v1.RotateWebhookSecretRequest
+	(*WebhookDelivery)(nil),                     // 11: memos.api.v1.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),        // 12: memos.api.v1.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),       // 13: memos.api.v1.ListWebhookDeliveriesResponse
+	(*RedeliverWebhookRequest)(nil),             // 14: memos.api.v1.RedeliverWebhookRequest
+	(*IncomingWebhook)(nil),                     // 15: memos.api.v1.IncomingWebhook
+	(*ListIncomingWebhooksRequest)(nil),         // 16: memos.api.v1.ListIncomingWebhooksRequest
+	(*ListIncomingWebhooksResponse)(nil),        // 17: memos.api.v1.ListIncomingWebhooksResponse
+	(*GetIncomingWebhookRequest)(nil),           // 18: memos.api.v1.GetIncomingWebhookRequest
+	(*CreateIncomingWebhookRequest)(nil),        // 19: memos.api.v1.CreateIncomingWebhookRequest
+	(*UpdateIncomingWebhookRequest)(nil),        // 20: memos.api.v1.UpdateIncomingWebhookRequest
+	(*DeleteIncomingWebhookRequest)(nil),        // 21: memos.api.v1.DeleteIncomingWebhookRequest
+	(*WebhookRequestPayload)(nil),               // 22: memos.api.v1.WebhookRequestPayload
+	(*WebhookRequestPayload_MemoRelations)(nil), // 23: memos.api.v1.WebhookRequestPayload.MemoRelations
+	(State)(0),                    // 24: memos.api.v1.State
+	(*timestamppb.Timestamp)(nil), // 25: google.protobuf.Timestamp
+	(Visibility)(0),               // 26: memos.api.v1.Visibility
+	(*fieldmaskpb.FieldMask)(nil), // 27: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),   // 28: google.protobuf.Duration
+	(*Memo)(nil),                  // 29: memos.api.v1.Memo
+	(*Reaction)(nil),              // 30: memos.api.v1.Reaction
+	(*Attachment)(nil),            // 31: memos.api.v1.Attachment
+	(*User)(nil),                  // 32: memos.api.v1.User
+	(*MemoRelation)(nil),          // 33: memos.api.v1.MemoRelation
+	(*emptypb.Empty)(nil),         // 34: google.protobuf.Empty
+}
+var file_api_v1_webhook_service_proto_depIdxs = []int32{
+	24, // 0: memos.api.v1.Webhook.state:type_name -> memos.api.v1.State
+	25, // 1: memos.api.v1.Webhook.create_time:type_name -> google.protobuf.Timestamp
+	25, // 2: memos.api.v1.Webhook.update_time:type_name -> google.protobuf.Timestamp
+	0,  // 3: memos.api.v1.Webhook.format:type_name -> memos.api.v1.Webhook.Format
+	1,  // 4: memos.api.v1.Webhook.scope:type_name -> memos.api.v1.Webhook.Scope
+	26, // 5: memos.api.v1.Webhook.visibilities:type_name -> memos.api.v1.Visibility
+	3,  // 6: memos.api.v1.ListWebhooksResponse.webhooks:type_name -> memos.api.v1.Webhook
+	27, // 7: memos.api.v1.GetWebhookRequest.read_mask:type_name -> google.protobuf.FieldMask
+	3,  // 8: memos.api.v1.CreateWebhookRequest.webhook:type_name -> memos.api.v1.Webhook
+	3,  // 9: memos.api.v1.UpdateWebhookRequest.webhook:type_name -> memos.api.v1.Webhook
+	27, // 10: memos.api.v1.UpdateWebhookRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 11: memos.api.v1.WebhookDelivery.status:type_name -> memos.api.v1.WebhookDelivery.Status
+	25, // 12: memos.api.v1.WebhookDelivery.next_attempt_time:type_name -> google.protobuf.Timestamp
+	28, // 13: memos.api.v1.WebhookDelivery.latency:type_name -> google.protobuf.Duration
+	25, // 14: memos.api.v1.WebhookDelivery.create_time:type_name -> google.protobuf.Timestamp
+	25, // 15: memos.api.v1.WebhookDelivery.update_time:type_name -> google.protobuf.Timestamp
+	11, // 16: memos.api.v1.ListWebhookDeliveriesResponse.deliveries:type_name -> memos.api.v1.WebhookDelivery
+	26, // 17: memos.api.v1.IncomingWebhook.visibility:type_name -> memos.api.v1.Visibility
+	25, // 18: memos.api.v1.IncomingWebhook.create_time:type_name -> google.protobuf.Timestamp
+	25, // 19: memos.api.v1.IncomingWebhook.update_time:type_name -> google.protobuf.Timestamp
+	15, // 20: memos.api.v1.ListIncomingWebhooksResponse.incoming_webhooks:type_name -> memos.api.v1.IncomingWebhook
+	15, // 21: memos.api.v1.CreateIncomingWebhookRequest.incoming_webhook:type_name -> memos.api.v1.IncomingWebhook
+	15, // 22: memos.api.v1.UpdateIncomingWebhookRequest.incoming_webhook:type_name -> memos.api.v1.IncomingWebhook
+	27, // 23: memos.api.v1.UpdateIncomingWebhookRequest.update_mask:type_name -> google.protobuf.FieldMask
+	25, // 24: memos.api.v1.WebhookRequestPayload.create_time:type_name -> google.protobuf.Timestamp
+	29, // 25: memos.api.v1.WebhookRequestPayload.memo:type_name -> memos.api.v1.Memo
+	29, // 26: memos.api.v1.WebhookRequestPayload.comment:type_name -> memos.api.v1.Memo
+	30, // 27: memos.api.v1.WebhookRequestPayload.reaction:type_name -> memos.api.v1.Reaction
+	31, // 28: memos.api.v1.WebhookRequestPayload.attachment:type_name -> memos.api.v1.Attachment
+	23, // 29: memos.api.v1.WebhookRequestPayload.relations:type_name -> memos.api.v1.WebhookRequestPayload.MemoRelations
+	32, // 30: memos.api.v1.WebhookRequestPayload.user:type_name -> memos.api.v1.User
+	33, // 31: memos.api.v1.WebhookRequestPayload.MemoRelations.relations:type_name -> memos.api.v1.MemoRelation
+	4,  // 32: memos.api.v1.WebhookService.ListWebhooks:input_type -> memos.api.v1.ListWebhooksRequest
+	6,  // 33: memos.api.v1.WebhookService.GetWebhook:input_type -> memos.api.v1.GetWebhookRequest
+	7,  // 34: memos.api.v1.WebhookService.CreateWebhook:input_type -> memos.api.v1.CreateWebhookRequest
+	8,  // 35: memos.api.v1.WebhookService.UpdateWebhook:input_type -> memos.api.v1.UpdateWebhookRequest
+	9,  // 36: memos.api.v1.WebhookService.DeleteWebhook:input_type -> memos.api.v1.DeleteWebhookRequest
+	10, // 37: memos.api.v1.WebhookService.RotateWebhookSecret:input_type -> memos.api.v1.RotateWebhookSecretRequest
+	12, // 38: memos.api.v1.WebhookService.ListWebhookDeliveries:input_type -> memos.api.v1.ListWebhookDeliveriesRequest
+	14, // 39: memos.api.v1.WebhookService.RedeliverWebhook:input_type -> memos.api.v1.RedeliverWebhookRequest
+	16, // 40: memos.api.v1.WebhookService.ListIncomingWebhooks:input_type -> memos.api.v1.ListIncomingWebhooksRequest
+	18, // 41: memos.api.v1.WebhookService.GetIncomingWebhook:input_type -> memos.api.v1.GetIncomingWebhookRequest
+	19, // 42: memos.api.v1.WebhookService.CreateIncomingWebhook:input_type -> memos.api.v1.CreateIncomingWebhookRequest
+	20, // 43: memos.api.v1.WebhookService.UpdateIncomingWebhook:input_type -> memos.api.v1.UpdateIncomingWebhookRequest
+	21, // 44: memos.api.v1.WebhookService.DeleteIncomingWebhook:input_type -> memos.api.v1.DeleteIncomingWebhookRequest
+	5,  // 45: memos.api.v1.WebhookService.ListWebhooks:output_type -> memos.api.v1.ListWebhooksResponse
+	3,  // 46: memos.api.v1.WebhookService.GetWebhook:output_type -> memos.api.v1.Webhook
+	3,  // 47: memos.api.v1.WebhookService.CreateWebhook:output_type -> memos.api.v1.Webhook
+	3,  // 48: memos.api.v1.WebhookService.UpdateWebhook:output_type -> memos.api.v1.Webhook
+	34, // 49: memos.api.v1.WebhookService.DeleteWebhook:output_type -> google.protobuf.Empty
+	3,  // 50: memos.api.v1.WebhookService.RotateWebhookSecret:output_type -> memos.api.v1.Webhook
+	13, // 51: memos.api.v1.WebhookService.ListWebhookDeliveries:output_type -> memos.api.v1.ListWebhookDeliveriesResponse
+	11, // 52: memos.api.v1.WebhookService.RedeliverWebhook:output_type -> memos.api.v1.WebhookDelivery
+	17, // 53: memos.api.v1.WebhookService.ListIncomingWebhooks:output_type -> memos.api.v1.ListIncomingWebhooksResponse
+	15, // 54: memos.api.v1.WebhookService.GetIncomingWebhook:output_type -> memos.api.v1.IncomingWebhook
+	15, // 55: memos.api.v1.WebhookService.CreateIncomingWebhook:output_type -> memos.api.v1.IncomingWebhook
+	15, // 56: memos.api.v1.WebhookService.UpdateIncomingWebhook:output_type -> memos.api.v1.IncomingWebhook
+	34, // 57: memos.api.v1.WebhookService.DeleteIncomingWebhook:output_type -> google.protobuf.Empty
+	45, // [45:58] is the sub-list for method output_type
+	32, // [32:45] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_webhook_service_proto_init() }
@@ -756,20 +2084,30 @@ func file_api_v1_webhook_service_proto_init() {
 	if File_api_v1_webhook_service_proto != nil {
 		return
 	}
+	file_api_v1_attachment_service_proto_init()
 	file_api_v1_common_proto_init()
 	file_api_v1_memo_service_proto_init()
+	file_api_v1_user_service_proto_init()
+	file_api_v1_webhook_service_proto_msgTypes[19].OneofWrappers = []any{
+		(*WebhookRequestPayload_Comment)(nil),
+		(*WebhookRequestPayload_Reaction)(nil),
+		(*WebhookRequestPayload_Attachment)(nil),
+		(*WebhookRequestPayload_Relations)(nil),
+		(*WebhookRequestPayload_User)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_webhook_service_proto_rawDesc), len(file_api_v1_webhook_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v1_webhook_service_proto_goTypes,
 		DependencyIndexes: file_api_v1_webhook_service_proto_depIdxs,
+		EnumInfos:         file_api_v1_webhook_service_proto_enumTypes,
 		MessageInfos:      file_api_v1_webhook_service_proto_msgTypes,
 	}.Build()
 	File_api_v1_webhook_service_proto = out.File
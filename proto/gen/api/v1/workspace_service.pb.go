@@ -78,6 +78,58 @@ func (WorkspaceStorageSetting_StorageType) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{5, 0}
 }
 
+type WorkspaceStorageSetting_ExifStripPolicy int32
+
+const (
+	// EXIF_STRIP_POLICY_UNSPECIFIED keeps the EXIF data of the images.
+	WorkspaceStorageSetting_EXIF_STRIP_POLICY_UNSPECIFIED WorkspaceStorageSetting_ExifStripPolicy = 0
+	// ALWAYS strips the EXIF data of all the uploaded images.
+	WorkspaceStorageSetting_ALWAYS WorkspaceStorageSetting_ExifStripPolicy = 1
+	// PUBLIC_MEMOS strips the EXIF data of the images attached to public memos.
+	WorkspaceStorageSetting_PUBLIC_MEMOS WorkspaceStorageSetting_ExifStripPolicy = 2
+)
+
+// Enum value maps for WorkspaceStorageSetting_ExifStripPolicy.
+var (
+	WorkspaceStorageSetting_ExifStripPolicy_name = map[int32]string{
+		0: "EXIF_STRIP_POLICY_UNSPECIFIED",
+		1: "ALWAYS",
+		2: "PUBLIC_MEMOS",
+	}
+	WorkspaceStorageSetting_ExifStripPolicy_value = map[string]int32{
+		"EXIF_STRIP_POLICY_UNSPECIFIED": 0,
+		"ALWAYS":                        1,
+		"PUBLIC_MEMOS":                  2,
+	}
+)
+
+func (x WorkspaceStorageSetting_ExifStripPolicy) Enum() *WorkspaceStorageSetting_ExifStripPolicy {
+	p := new(WorkspaceStorageSetting_ExifStripPolicy)
+	*p = x
+	return p
+}
+
+func (x WorkspaceStorageSetting_ExifStripPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceStorageSetting_ExifStripPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_workspace_service_proto_enumTypes[1].Descriptor()
+}
+
+func (WorkspaceStorageSetting_ExifStripPolicy) Type() protoreflect.EnumType {
+	return &file_api_v1_workspace_service_proto_enumTypes[1]
+}
+
+func (x WorkspaceStorageSetting_ExifStripPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceStorageSetting_ExifStripPolicy.Descriptor instead.
+func (WorkspaceStorageSetting_ExifStripPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_workspace_service_proto_rawDescGZIP(), []int{5, 1}
+}
+
 // Workspace profile message containing basic workspace information.
 type WorkspaceProfile struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -318,8 +370,11 @@ type WorkspaceGeneralSetting struct {
 	DisallowChangeUsername bool `protobuf:"varint,7,opt,name=disallow_change_username,json=disallowChangeUsername,proto3" json:"disallow_change_username,omitempty"`
 	// disallow_change_nickname disallows changing nickname.
 	DisallowChangeNickname bool `protobuf:"varint,8,opt,name=disallow_change_nickname,json=disallowChangeNickname,proto3" json:"disallow_change_nickname,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// disallow_search_engine_indexing opts the workspace out of search engine indexing.
+	// robots.txt then disallows all crawlers and no sitemap is served.
+	DisallowSearchEngineIndexing bool `protobuf:"varint,9,opt,name=disallow_search_engine_indexing,json=disallowSearchEngineIndexing,proto3" json:"disallow_search_engine_indexing,omitempty"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
 }
 
 func (x *WorkspaceGeneralSetting) Reset() {
@@ -408,6 +463,13 @@ func (x *WorkspaceGeneralSetting) GetDisallowChangeNickname() bool {
 	return false
 }
 
+func (x *WorkspaceGeneralSetting) GetDisallowSearchEngineIndexing() bool {
+	if x != nil {
+		return x.DisallowSearchEngineIndexing
+	}
+	return false
+}
+
 type WorkspaceCustomProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -494,9 +556,11 @@ type WorkspaceStorageSetting struct {
 	// The max upload size in megabytes.
 	UploadSizeLimitMb int64 `protobuf:"varint,3,opt,name=upload_size_limit_mb,json=uploadSizeLimitMb,proto3" json:"upload_size_limit_mb,omitempty"`
 	// The S3 config.
-	S3Config      *WorkspaceStorageSetting_S3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	S3Config *WorkspaceStorageSetting_S3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
+	// The policy of stripping the EXIF data of the stored images, the orientation is always kept.
+	ExifStripPolicy WorkspaceStorageSetting_ExifStripPolicy `protobuf:"varint,5,opt,name=exif_strip_policy,json=exifStripPolicy,proto3,enum=memos.api.v1.WorkspaceStorageSetting_ExifStripPolicy" json:"exif_strip_policy,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *WorkspaceStorageSetting) Reset() {
@@ -557,6 +621,13 @@ func (x *WorkspaceStorageSetting) GetS3Config() *WorkspaceStorageSetting_S3Confi
 	return nil
 }
 
+func (x *WorkspaceStorageSetting) GetExifStripPolicy() WorkspaceStorageSetting_ExifStripPolicy {
+	if x != nil {
+		return x.ExifStripPolicy
+	}
+	return WorkspaceStorageSetting_EXIF_STRIP_POLICY_UNSPECIFIED
+}
+
 type WorkspaceMemoRelatedSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// disallow_public_visibility disallows set memo as public visibility.
@@ -887,7 +958,7 @@ const file_api_v1_workspace_service_proto_rawDesc = "" +
 	"\x0fstorage_setting\x18\x03 \x01(\v2%.memos.api.v1.WorkspaceStorageSettingH\x00R\x0estorageSetting\x12]\n" +
 	"\x14memo_related_setting\x18\x04 \x01(\v2).memos.api.v1.WorkspaceMemoRelatedSettingH\x00R\x12memoRelatedSetting:f\xeaAc\n" +
 	"\x1eapi.memos.dev/WorkspaceSetting\x12\x1cworkspace/settings/{setting}*\x11workspaceSettings2\x10workspaceSettingB\a\n" +
-	"\x05value\"\xd9\x03\n" +
+	"\x05value\"\xa0\x04\n" +
 	"\x17WorkspaceGeneralSetting\x12<\n" +
 	"\x1adisallow_user_registration\x18\x01 \x01(\bR\x18disallowUserRegistration\x124\n" +
 	"\x16disallow_password_auth\x18\x02 \x01(\bR\x14disallowPasswordAuth\x12+\n" +
@@ -896,7 +967,8 @@ const file_api_v1_workspace_service_proto_rawDesc = "" +
 	"\x0ecustom_profile\x18\x05 \x01(\v2$.memos.api.v1.WorkspaceCustomProfileR\rcustomProfile\x121\n" +
 	"\x15week_start_day_offset\x18\x06 \x01(\x05R\x12weekStartDayOffset\x128\n" +
 	"\x18disallow_change_username\x18\a \x01(\bR\x16disallowChangeUsername\x128\n" +
-	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\"\xa3\x01\n" +
+	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\x12E\n" +
+	"\x1fdisallow_search_engine_indexing\x18\t \x01(\bR\x1cdisallowSearchEngineIndexing\"\xa3\x01\n" +
 	"\x16WorkspaceCustomProfile\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
@@ -904,12 +976,13 @@ const file_api_v1_workspace_service_proto_rawDesc = "" +
 	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x1e\n" +
 	"\n" +
 	"appearance\x18\x05 \x01(\tR\n" +
-	"appearance\"\xb7\x04\n" +
+	"appearance\"\xee\x05\n" +
 	"\x17WorkspaceStorageSetting\x12T\n" +
 	"\fstorage_type\x18\x01 \x01(\x0e21.memos.api.v1.WorkspaceStorageSetting.StorageTypeR\vstorageType\x12+\n" +
 	"\x11filepath_template\x18\x02 \x01(\tR\x10filepathTemplate\x12/\n" +
 	"\x14upload_size_limit_mb\x18\x03 \x01(\x03R\x11uploadSizeLimitMb\x12K\n" +
-	"\ts3_config\x18\x04 \x01(\v2..memos.api.v1.WorkspaceStorageSetting.S3ConfigR\bs3Config\x1a\xcc\x01\n" +
+	"\ts3_config\x18\x04 \x01(\v2..memos.api.v1.WorkspaceStorageSetting.S3ConfigR\bs3Config\x12a\n" +
+	"\x11exif_strip_policy\x18\x05 \x01(\x0e25.memos.api.v1.WorkspaceStorageSetting.ExifStripPolicyR\x0fexifStripPolicy\x1a\xcc\x01\n" +
 	"\bS3Config\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
 	"\x11access_key_secret\x18\x02 \x01(\tR\x0faccessKeySecret\x12\x1a\n" +
@@ -921,7 +994,12 @@ const file_api_v1_workspace_service_proto_rawDesc = "" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\"\x94\x04\n" +
+	"\x02S3\x10\x03\"R\n" +
+	"\x0fExifStripPolicy\x12!\n" +
+	"\x1dEXIF_STRIP_POLICY_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06ALWAYS\x10\x01\x12\x10\n" +
+	"\fPUBLIC_MEMOS\x10\x02\"\x94\x04\n" +
 	"\x1bWorkspaceMemoRelatedSetting\x12<\n" +
 	"\x1adisallow_public_visibility\x18\x01 \x01(\bR\x18disallowPublicVisibility\x127\n" +
 	"\x18display_with_update_time\x18\x02 \x01(\bR\x15displayWithUpdateTime\x120\n" +
@@ -959,42 +1037,44 @@ func file_api_v1_workspace_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_workspace_service_proto_rawDescData
 }
 
-var file_api_v1_workspace_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_v1_workspace_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
 var file_api_v1_workspace_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_api_v1_workspace_service_proto_goTypes = []any{
-	(WorkspaceStorageSetting_StorageType)(0), // 0: memos.api.v1.WorkspaceStorageSetting.StorageType
-	(*WorkspaceProfile)(nil),                 // 1: memos.api.v1.WorkspaceProfile
-	(*GetWorkspaceProfileRequest)(nil),       // 2: memos.api.v1.GetWorkspaceProfileRequest
-	(*WorkspaceSetting)(nil),                 // 3: memos.api.v1.WorkspaceSetting
-	(*WorkspaceGeneralSetting)(nil),          // 4: memos.api.v1.WorkspaceGeneralSetting
-	(*WorkspaceCustomProfile)(nil),           // 5: memos.api.v1.WorkspaceCustomProfile
-	(*WorkspaceStorageSetting)(nil),          // 6: memos.api.v1.WorkspaceStorageSetting
-	(*WorkspaceMemoRelatedSetting)(nil),      // 7: memos.api.v1.WorkspaceMemoRelatedSetting
-	(*GetWorkspaceSettingRequest)(nil),       // 8: memos.api.v1.GetWorkspaceSettingRequest
-	(*UpdateWorkspaceSettingRequest)(nil),    // 9: memos.api.v1.UpdateWorkspaceSettingRequest
-	(*WorkspaceStorageSetting_S3Config)(nil), // 10: memos.api.v1.WorkspaceStorageSetting.S3Config
-	(*fieldmaskpb.FieldMask)(nil),            // 11: google.protobuf.FieldMask
+	(WorkspaceStorageSetting_StorageType)(0),     // 0: memos.api.v1.WorkspaceStorageSetting.StorageType
+	(WorkspaceStorageSetting_ExifStripPolicy)(0), // 1: memos.api.v1.WorkspaceStorageSetting.ExifStripPolicy
+	(*WorkspaceProfile)(nil),                     // 2: memos.api.v1.WorkspaceProfile
+	(*GetWorkspaceProfileRequest)(nil),           // 3: memos.api.v1.GetWorkspaceProfileRequest
+	(*WorkspaceSetting)(nil),                     // 4: memos.api.v1.WorkspaceSetting
+	(*WorkspaceGeneralSetting)(nil),              // 5: memos.api.v1.WorkspaceGeneralSetting
+	(*WorkspaceCustomProfile)(nil),               // 6: memos.api.v1.WorkspaceCustomProfile
+	(*WorkspaceStorageSetting)(nil),              // 7: memos.api.v1.WorkspaceStorageSetting
+	(*WorkspaceMemoRelatedSetting)(nil),          // 8: memos.api.v1.WorkspaceMemoRelatedSetting
+	(*GetWorkspaceSettingRequest)(nil),           // 9: memos.api.v1.GetWorkspaceSettingRequest
+	(*UpdateWorkspaceSettingRequest)(nil),        // 10: memos.api.v1.UpdateWorkspaceSettingRequest
+	(*WorkspaceStorageSetting_S3Config)(nil),     // 11: memos.api.v1.WorkspaceStorageSetting.S3Config
+	(*fieldmaskpb.FieldMask)(nil),                // 12: google.protobuf.FieldMask
 }
 var file_api_v1_workspace_service_proto_depIdxs = []int32{
-	4,  // 0: memos.api.v1.WorkspaceSetting.general_setting:type_name -> memos.api.v1.WorkspaceGeneralSetting
-	6,  // 1: memos.api.v1.WorkspaceSetting.storage_setting:type_name -> memos.api.v1.WorkspaceStorageSetting
-	7,  // 2: memos.api.v1.WorkspaceSetting.memo_related_setting:type_name -> memos.api.v1.WorkspaceMemoRelatedSetting
-	5,  // 3: memos.api.v1.WorkspaceGeneralSetting.custom_profile:type_name -> memos.api.v1.WorkspaceCustomProfile
+	5,  // 0: memos.api.v1.WorkspaceSetting.general_setting:type_name -> memos.api.v1.WorkspaceGeneralSetting
+	7,  // 1: memos.api.v1.WorkspaceSetting.storage_setting:type_name -> memos.api.v1.WorkspaceStorageSetting
+	8,  // 2: memos.api.v1.WorkspaceSetting.memo_related_setting:type_name -> memos.api.v1.WorkspaceMemoRelatedSetting
+	6,  // 3: memos.api.v1.WorkspaceGeneralSetting.custom_profile:type_name -> memos.api.v1.WorkspaceCustomProfile
 	0,  // 4: memos.api.v1.WorkspaceStorageSetting.storage_type:type_name -> memos.api.v1.WorkspaceStorageSetting.StorageType
-	10, // 5: memos.api.v1.WorkspaceStorageSetting.s3_config:type_name -> memos.api.v1.WorkspaceStorageSetting.S3Config
-	3,  // 6: memos.api.v1.UpdateWorkspaceSettingRequest.setting:type_name -> memos.api.v1.WorkspaceSetting
-	11, // 7: memos.api.v1.UpdateWorkspaceSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
-	2,  // 8: memos.api.v1.WorkspaceService.GetWorkspaceProfile:input_type -> memos.api.v1.GetWorkspaceProfileRequest
-	8,  // 9: memos.api.v1.WorkspaceService.GetWorkspaceSetting:input_type -> memos.api.v1.GetWorkspaceSettingRequest
-	9,  // 10: memos.api.v1.WorkspaceService.UpdateWorkspaceSetting:input_type -> memos.api.v1.UpdateWorkspaceSettingRequest
-	1,  // 11: memos.api.v1.WorkspaceService.GetWorkspaceProfile:output_type -> memos.api.v1.WorkspaceProfile
-	3,  // 12: memos.api.v1.WorkspaceService.GetWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
-	3,  // 13: memos.api.v1.WorkspaceService.UpdateWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
-	11, // [11:14] is the sub-list for method output_type
-	8,  // [8:11] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	11, // 5: memos.api.v1.WorkspaceStorageSetting.s3_config:type_name -> memos.api.v1.WorkspaceStorageSetting.S3Config
+	1,  // 6: memos.api.v1.WorkspaceStorageSetting.exif_strip_policy:type_name -> memos.api.v1.WorkspaceStorageSetting.ExifStripPolicy
+	4,  // 7: memos.api.v1.UpdateWorkspaceSettingRequest.setting:type_name -> memos.api.v1.WorkspaceSetting
+	12, // 8: memos.api.v1.UpdateWorkspaceSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
+	3,  // 9: memos.api.v1.WorkspaceService.GetWorkspaceProfile:input_type -> memos.api.v1.GetWorkspaceProfileRequest
+	9,  // 10: memos.api.v1.WorkspaceService.GetWorkspaceSetting:input_type -> memos.api.v1.GetWorkspaceSettingRequest
+	10, // 11: memos.api.v1.WorkspaceService.UpdateWorkspaceSetting:input_type -> memos.api.v1.UpdateWorkspaceSettingRequest
+	2,  // 12: memos.api.v1.WorkspaceService.GetWorkspaceProfile:output_type -> memos.api.v1.WorkspaceProfile
+	4,  // 13: memos.api.v1.WorkspaceService.GetWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
+	4,  // 14: memos.api.v1.WorkspaceService.UpdateWorkspaceSetting:output_type -> memos.api.v1.WorkspaceSetting
+	12, // [12:15] is the sub-list for method output_type
+	9,  // [9:12] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_workspace_service_proto_init() }
@@ -1012,7 +1092,7 @@ func file_api_v1_workspace_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_workspace_service_proto_rawDesc), len(file_api_v1_workspace_service_proto_rawDesc)),
-			NumEnums:      1,
+			NumEnums:      2,
 			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
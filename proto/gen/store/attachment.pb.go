@@ -82,7 +82,9 @@ type AttachmentPayload struct {
 	// Types that are valid to be assigned to Payload:
 	//
 	//	*AttachmentPayload_S3Object_
-	Payload       isAttachmentPayload_Payload `protobuf_oneof:"payload"`
+	Payload isAttachmentPayload_Payload `protobuf_oneof:"payload"`
+	// image_metadata is the metadata read from the EXIF data of the image.
+	ImageMetadata *AttachmentPayload_ImageMetadata `protobuf:"bytes,2,opt,name=image_metadata,json=imageMetadata,proto3" json:"image_metadata,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -133,6 +135,13 @@ func (x *AttachmentPayload) GetS3Object() *AttachmentPayload_S3Object {
 	return nil
 }
 
+func (x *AttachmentPayload) GetImageMetadata() *AttachmentPayload_ImageMetadata {
+	if x != nil {
+		return x.ImageMetadata
+	}
+	return nil
+}
+
 type isAttachmentPayload_Payload interface {
 	isAttachmentPayload_Payload()
 }
@@ -206,17 +215,199 @@ func (x *AttachmentPayload_S3Object) GetLastPresignedTime() *timestamppb.Timesta
 	return nil
 }
 
+type AttachmentPayload_ImageMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// width and height are the pixel dimensions of the image as displayed.
+	Width  int32 `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height int32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// taken_time is the time the photo was taken.
+	TakenTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=taken_time,json=takenTime,proto3" json:"taken_time,omitempty"`
+	CameraMake  string                 `protobuf:"bytes,4,opt,name=camera_make,json=cameraMake,proto3" json:"camera_make,omitempty"`
+	CameraModel string                 `protobuf:"bytes,5,opt,name=camera_model,json=cameraModel,proto3" json:"camera_model,omitempty"`
+	LensModel   string                 `protobuf:"bytes,6,opt,name=lens_model,json=lensModel,proto3" json:"lens_model,omitempty"`
+	// location is where the photo was taken.
+	Location *AttachmentPayload_Location `protobuf:"bytes,7,opt,name=location,proto3" json:"location,omitempty"`
+	// has_exif is whether the stored content still contains the EXIF data.
+	HasExif       bool `protobuf:"varint,8,opt,name=has_exif,json=hasExif,proto3" json:"has_exif,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentPayload_ImageMetadata) Reset() {
+	*x = AttachmentPayload_ImageMetadata{}
+	mi := &file_store_attachment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentPayload_ImageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentPayload_ImageMetadata) ProtoMessage() {}
+
+func (x *AttachmentPayload_ImageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentPayload_ImageMetadata.ProtoReflect.Descriptor instead.
+func (*AttachmentPayload_ImageMetadata) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetTakenTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TakenTime
+	}
+	return nil
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetCameraMake() string {
+	if x != nil {
+		return x.CameraMake
+	}
+	return ""
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetCameraModel() string {
+	if x != nil {
+		return x.CameraModel
+	}
+	return ""
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetLensModel() string {
+	if x != nil {
+		return x.LensModel
+	}
+	return ""
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetLocation() *AttachmentPayload_Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *AttachmentPayload_ImageMetadata) GetHasExif() bool {
+	if x != nil {
+		return x.HasExif
+	}
+	return false
+}
+
+type AttachmentPayload_Location struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Latitude  float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// altitude is in meters above the sea level.
+	Altitude      float64 `protobuf:"fixed64,3,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentPayload_Location) Reset() {
+	*x = AttachmentPayload_Location{}
+	mi := &file_store_attachment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentPayload_Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentPayload_Location) ProtoMessage() {}
+
+func (x *AttachmentPayload_Location) ProtoReflect() protoreflect.Message {
+	mi := &file_store_attachment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentPayload_Location.ProtoReflect.Descriptor instead.
+func (*AttachmentPayload_Location) Descriptor() ([]byte, []int) {
+	return file_store_attachment_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *AttachmentPayload_Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *AttachmentPayload_Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *AttachmentPayload_Location) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
 var File_store_attachment_proto protoreflect.FileDescriptor
 
 const file_store_attachment_proto_rawDesc = "" +
 	"\n" +
-	"\x16store/attachment.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1dstore/workspace_setting.proto\"\x8c\x02\n" +
+	"\x16store/attachment.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1dstore/workspace_setting.proto\"\x81\x06\n" +
 	"\x11AttachmentPayload\x12F\n" +
-	"\ts3_object\x18\x01 \x01(\v2'.memos.store.AttachmentPayload.S3ObjectH\x00R\bs3Object\x1a\xa3\x01\n" +
+	"\ts3_object\x18\x01 \x01(\v2'.memos.store.AttachmentPayload.S3ObjectH\x00R\bs3Object\x12S\n" +
+	"\x0eimage_metadata\x18\x02 \x01(\v2,.memos.store.AttachmentPayload.ImageMetadataR\rimageMetadata\x1a\xa3\x01\n" +
 	"\bS3Object\x129\n" +
 	"\ts3_config\x18\x01 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12J\n" +
-	"\x13last_presigned_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x11lastPresignedTimeB\t\n" +
+	"\x13last_presigned_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x11lastPresignedTime\x1a\xbb\x02\n" +
+	"\rImageMetadata\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x129\n" +
+	"\n" +
+	"taken_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttakenTime\x12\x1f\n" +
+	"\vcamera_make\x18\x04 \x01(\tR\n" +
+	"cameraMake\x12!\n" +
+	"\fcamera_model\x18\x05 \x01(\tR\vcameraModel\x12\x1d\n" +
+	"\n" +
+	"lens_model\x18\x06 \x01(\tR\tlensModel\x12C\n" +
+	"\blocation\x18\a \x01(\v2'.memos.store.AttachmentPayload.LocationR\blocation\x12\x19\n" +
+	"\bhas_exif\x18\b \x01(\bR\ahasExif\x1a`\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\baltitude\x18\x03 \x01(\x01R\baltitudeB\t\n" +
 	"\apayload*a\n" +
 	"\x15AttachmentStorageType\x12'\n" +
 	"#ATTACHMENT_STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
@@ -238,23 +429,28 @@ func file_store_attachment_proto_rawDescGZIP() []byte {
 }
 
 var file_store_attachment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_store_attachment_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_store_attachment_proto_goTypes = []any{
-	(AttachmentStorageType)(0),         // 0: memos.store.AttachmentStorageType
-	(*AttachmentPayload)(nil),          // 1: memos.store.AttachmentPayload
-	(*AttachmentPayload_S3Object)(nil), // 2: memos.store.AttachmentPayload.S3Object
-	(*StorageS3Config)(nil),            // 3: memos.store.StorageS3Config
-	(*timestamppb.Timestamp)(nil),      // 4: google.protobuf.Timestamp
+	(AttachmentStorageType)(0),              // 0: memos.store.AttachmentStorageType
+	(*AttachmentPayload)(nil),               // 1: memos.store.AttachmentPayload
+	(*AttachmentPayload_S3Object)(nil),      // 2: memos.store.AttachmentPayload.S3Object
+	(*AttachmentPayload_ImageMetadata)(nil), // 3: memos.store.AttachmentPayload.ImageMetadata
+	(*AttachmentPayload_Location)(nil),      // 4: memos.store.AttachmentPayload.Location
+	(*StorageS3Config)(nil),                 // 5: memos.store.StorageS3Config
+	(*timestamppb.Timestamp)(nil),           // 6: google.protobuf.Timestamp
 }
 var file_store_attachment_proto_depIdxs = []int32{
 	2, // 0: memos.store.AttachmentPayload.s3_object:type_name -> memos.store.AttachmentPayload.S3Object
-	3, // 1: memos.store.AttachmentPayload.S3Object.s3_config:type_name -> memos.store.StorageS3Config
-	4, // 2: memos.store.AttachmentPayload.S3Object.last_presigned_time:type_name -> google.protobuf.Timestamp
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 1: memos.store.AttachmentPayload.image_metadata:type_name -> memos.store.AttachmentPayload.ImageMetadata
+	5, // 2: memos.store.AttachmentPayload.S3Object.s3_config:type_name -> memos.store.StorageS3Config
+	6, // 3: memos.store.AttachmentPayload.S3Object.last_presigned_time:type_name -> google.protobuf.Timestamp
+	6, // 4: memos.store.AttachmentPayload.ImageMetadata.taken_time:type_name -> google.protobuf.Timestamp
+	4, // 5: memos.store.AttachmentPayload.ImageMetadata.location:type_name -> memos.store.AttachmentPayload.Location
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_store_attachment_proto_init() }
@@ -272,7 +468,7 @@ func file_store_attachment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_attachment_proto_rawDesc), len(file_store_attachment_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -38,6 +38,10 @@ const (
 	UserSettingKey_SHORTCUTS UserSettingKey = 5
 	// User authentication sessions.
 	UserSettingKey_SESSIONS UserSettingKey = 6
+	// The ActivityPub key pair of the user.
+	UserSettingKey_ACTIVITYPUB UserSettingKey = 7
+	// The tokens authenticating the private feeds of the user.
+	UserSettingKey_FEED_TOKENS UserSettingKey = 8
 )
 
 // Enum value maps for UserSettingKey.
@@ -50,6 +54,8 @@ var (
 		4: "MEMO_VISIBILITY",
 		5: "SHORTCUTS",
 		6: "SESSIONS",
+		7: "ACTIVITYPUB",
+		8: "FEED_TOKENS",
 	}
 	UserSettingKey_value = map[string]int32{
 		"USER_SETTING_KEY_UNSPECIFIED": 0,
@@ -59,6 +65,8 @@ var (
 		"MEMO_VISIBILITY":              4,
 		"SHORTCUTS":                    5,
 		"SESSIONS":                     6,
+		"ACTIVITYPUB":                  7,
+		"FEED_TOKENS":                  8,
 	}
 )
 
@@ -101,6 +109,8 @@ type UserSetting struct {
 	//	*UserSetting_MemoVisibility
 	//	*UserSetting_Shortcuts
 	//	*UserSetting_Sessions
+	//	*UserSetting_Activitypub
+	//	*UserSetting_FeedTokens
 	Value         isUserSetting_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -211,6 +221,24 @@ func (x *UserSetting) GetSessions() *SessionsUserSetting {
 	return nil
 }
 
+func (x *UserSetting) GetActivitypub() *ActivityPubUserSetting {
+	if x != nil {
+		if x, ok := x.Value.(*UserSetting_Activitypub); ok {
+			return x.Activitypub
+		}
+	}
+	return nil
+}
+
+func (x *UserSetting) GetFeedTokens() *FeedTokensUserSetting {
+	if x != nil {
+		if x, ok := x.Value.(*UserSetting_FeedTokens); ok {
+			return x.FeedTokens
+		}
+	}
+	return nil
+}
+
 type isUserSetting_Value interface {
 	isUserSetting_Value()
 }
@@ -239,6 +267,14 @@ type UserSetting_Sessions struct {
 	Sessions *SessionsUserSetting `protobuf:"bytes,8,opt,name=sessions,proto3,oneof"`
 }
 
+type UserSetting_Activitypub struct {
+	Activitypub *ActivityPubUserSetting `protobuf:"bytes,9,opt,name=activitypub,proto3,oneof"`
+}
+
+type UserSetting_FeedTokens struct {
+	FeedTokens *FeedTokensUserSetting `protobuf:"bytes,10,opt,name=feed_tokens,json=feedTokens,proto3,oneof"`
+}
+
 func (*UserSetting_AccessTokens) isUserSetting_Value() {}
 
 func (*UserSetting_Locale) isUserSetting_Value() {}
@@ -251,6 +287,10 @@ func (*UserSetting_Shortcuts) isUserSetting_Value() {}
 
 func (*UserSetting_Sessions) isUserSetting_Value() {}
 
+func (*UserSetting_Activitypub) isUserSetting_Value() {}
+
+func (*UserSetting_FeedTokens) isUserSetting_Value() {}
+
 type AccessTokensUserSetting struct {
 	state         protoimpl.MessageState                 `protogen:"open.v1"`
 	AccessTokens  []*AccessTokensUserSetting_AccessToken `protobuf:"bytes,1,rep,name=access_tokens,json=accessTokens,proto3" json:"access_tokens,omitempty"`
@@ -383,6 +423,104 @@ func (x *SessionsUserSetting) GetSessions() []*SessionsUserSetting_Session {
 	return nil
 }
 
+type ActivityPubUserSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The PEM encoded private key used to sign outgoing activities.
+	PrivateKey string `protobuf:"bytes,1,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	// The PEM encoded public key published on the actor.
+	PublicKey     string `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivityPubUserSetting) Reset() {
+	*x = ActivityPubUserSetting{}
+	mi := &file_store_user_setting_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityPubUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityPubUserSetting) ProtoMessage() {}
+
+func (x *ActivityPubUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityPubUserSetting.ProtoReflect.Descriptor instead.
+func (*ActivityPubUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ActivityPubUserSetting) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *ActivityPubUserSetting) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+type FeedTokensUserSetting struct {
+	state         protoimpl.MessageState             `protogen:"open.v1"`
+	FeedTokens    []*FeedTokensUserSetting_FeedToken `protobuf:"bytes,1,rep,name=feed_tokens,json=feedTokens,proto3" json:"feed_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedTokensUserSetting) Reset() {
+	*x = FeedTokensUserSetting{}
+	mi := &file_store_user_setting_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedTokensUserSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedTokensUserSetting) ProtoMessage() {}
+
+func (x *FeedTokensUserSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedTokensUserSetting.ProtoReflect.Descriptor instead.
+func (*FeedTokensUserSetting) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FeedTokensUserSetting) GetFeedTokens() []*FeedTokensUserSetting_FeedToken {
+	if x != nil {
+		return x.FeedTokens
+	}
+	return nil
+}
+
 type AccessTokensUserSetting_AccessToken struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The access token is a JWT token.
@@ -396,7 +534,7 @@ type AccessTokensUserSetting_AccessToken struct {
 
 func (x *AccessTokensUserSetting_AccessToken) Reset() {
 	*x = AccessTokensUserSetting_AccessToken{}
-	mi := &file_store_user_setting_proto_msgTypes[4]
+	mi := &file_store_user_setting_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -408,7 +546,7 @@ func (x *AccessTokensUserSetting_AccessToken) String() string {
 func (*AccessTokensUserSetting_AccessToken) ProtoMessage() {}
 
 func (x *AccessTokensUserSetting_AccessToken) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[4]
+	mi := &file_store_user_setting_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +587,7 @@ type ShortcutsUserSetting_Shortcut struct {
 
 func (x *ShortcutsUserSetting_Shortcut) Reset() {
 	*x = ShortcutsUserSetting_Shortcut{}
-	mi := &file_store_user_setting_proto_msgTypes[5]
+	mi := &file_store_user_setting_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -461,7 +599,7 @@ func (x *ShortcutsUserSetting_Shortcut) String() string {
 func (*ShortcutsUserSetting_Shortcut) ProtoMessage() {}
 
 func (x *ShortcutsUserSetting_Shortcut) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[5]
+	mi := &file_store_user_setting_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +654,7 @@ type SessionsUserSetting_Session struct {
 
 func (x *SessionsUserSetting_Session) Reset() {
 	*x = SessionsUserSetting_Session{}
-	mi := &file_store_user_setting_proto_msgTypes[6]
+	mi := &file_store_user_setting_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -528,7 +666,7 @@ func (x *SessionsUserSetting_Session) String() string {
 func (*SessionsUserSetting_Session) ProtoMessage() {}
 
 func (x *SessionsUserSetting_Session) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[6]
+	mi := &file_store_user_setting_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -599,7 +737,7 @@ type SessionsUserSetting_ClientInfo struct {
 
 func (x *SessionsUserSetting_ClientInfo) Reset() {
 	*x = SessionsUserSetting_ClientInfo{}
-	mi := &file_store_user_setting_proto_msgTypes[7]
+	mi := &file_store_user_setting_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -611,7 +749,7 @@ func (x *SessionsUserSetting_ClientInfo) String() string {
 func (*SessionsUserSetting_ClientInfo) ProtoMessage() {}
 
 func (x *SessionsUserSetting_ClientInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_store_user_setting_proto_msgTypes[7]
+	mi := &file_store_user_setting_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,11 +807,83 @@ func (x *SessionsUserSetting_ClientInfo) GetCountry() string {
 	return ""
 }
 
+type FeedTokensUserSetting_FeedToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The unique identifier of the token.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The hex encoded SHA-256 hash of the token.
+	// The token itself is only returned when it is created.
+	TokenHash string `protobuf:"bytes,2,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash,omitempty"`
+	// A description for the token.
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedTokensUserSetting_FeedToken) Reset() {
+	*x = FeedTokensUserSetting_FeedToken{}
+	mi := &file_store_user_setting_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedTokensUserSetting_FeedToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedTokensUserSetting_FeedToken) ProtoMessage() {}
+
+func (x *FeedTokensUserSetting_FeedToken) ProtoReflect() protoreflect.Message {
+	mi := &file_store_user_setting_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedTokensUserSetting_FeedToken.ProtoReflect.Descriptor instead.
+func (*FeedTokensUserSetting_FeedToken) Descriptor() ([]byte, []int) {
+	return file_store_user_setting_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *FeedTokensUserSetting_FeedToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FeedTokensUserSetting_FeedToken) GetTokenHash() string {
+	if x != nil {
+		return x.TokenHash
+	}
+	return ""
+}
+
+func (x *FeedTokensUserSetting_FeedToken) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FeedTokensUserSetting_FeedToken) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
 var File_store_user_setting_proto protoreflect.FileDescriptor
 
 const file_store_user_setting_proto_rawDesc = "" +
 	"\n" +
-	"\x18store/user_setting.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\"\x95\x03\n" +
+	"\x18store/user_setting.proto\x12\vmemos.store\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa5\x04\n" +
 	"\vUserSetting\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12-\n" +
 	"\x03key\x18\x02 \x01(\x0e2\x1b.memos.store.UserSettingKeyR\x03key\x12K\n" +
@@ -684,7 +894,11 @@ const file_store_user_setting_proto_rawDesc = "" +
 	"appearance\x12)\n" +
 	"\x0fmemo_visibility\x18\x06 \x01(\tH\x00R\x0ememoVisibility\x12A\n" +
 	"\tshortcuts\x18\a \x01(\v2!.memos.store.ShortcutsUserSettingH\x00R\tshortcuts\x12>\n" +
-	"\bsessions\x18\b \x01(\v2 .memos.store.SessionsUserSettingH\x00R\bsessionsB\a\n" +
+	"\bsessions\x18\b \x01(\v2 .memos.store.SessionsUserSettingH\x00R\bsessions\x12G\n" +
+	"\vactivitypub\x18\t \x01(\v2#.memos.store.ActivityPubUserSettingH\x00R\vactivitypub\x12E\n" +
+	"\vfeed_tokens\x18\n" +
+	" \x01(\v2\".memos.store.FeedTokensUserSettingH\x00R\n" +
+	"feedTokensB\a\n" +
 	"\x05value\"\xc4\x01\n" +
 	"\x17AccessTokensUserSetting\x12U\n" +
 	"\raccess_tokens\x18\x01 \x03(\v20.memos.store.AccessTokensUserSetting.AccessTokenR\faccessTokens\x1aR\n" +
@@ -719,7 +933,22 @@ const file_store_user_setting_proto_rawDesc = "" +
 	"deviceType\x12\x0e\n" +
 	"\x02os\x18\x04 \x01(\tR\x02os\x12\x18\n" +
 	"\abrowser\x18\x05 \x01(\tR\abrowser\x12\x18\n" +
-	"\acountry\x18\x06 \x01(\tR\acountry*\x93\x01\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\"X\n" +
+	"\x16ActivityPubUserSetting\x12\x1f\n" +
+	"\vprivate_key\x18\x01 \x01(\tR\n" +
+	"privateKey\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\tR\tpublicKey\"\x82\x02\n" +
+	"\x15FeedTokensUserSetting\x12M\n" +
+	"\vfeed_tokens\x18\x01 \x03(\v2,.memos.store.FeedTokensUserSetting.FeedTokenR\n" +
+	"feedTokens\x1a\x99\x01\n" +
+	"\tFeedToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"token_hash\x18\x02 \x01(\tR\ttokenHash\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12;\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime*\xb5\x01\n" +
 	"\x0eUserSettingKey\x12 \n" +
 	"\x1cUSER_SETTING_KEY_UNSPECIFIED\x10\x00\x12\x11\n" +
 	"\rACCESS_TOKENS\x10\x01\x12\n" +
@@ -729,7 +958,9 @@ const file_store_user_setting_proto_rawDesc = "" +
 	"APPEARANCE\x10\x03\x12\x13\n" +
 	"\x0fMEMO_VISIBILITY\x10\x04\x12\r\n" +
 	"\tSHORTCUTS\x10\x05\x12\f\n" +
-	"\bSESSIONS\x10\x06B\x9b\x01\n" +
+	"\bSESSIONS\x10\x06\x12\x0f\n" +
+	"\vACTIVITYPUB\x10\a\x12\x0f\n" +
+	"\vFEED_TOKENS\x10\bB\x9b\x01\n" +
 	"\x0fcom.memos.storeB\x10UserSettingProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
 
 var (
@@ -745,36 +976,43 @@ func file_store_user_setting_proto_rawDescGZIP() []byte {
 }
 
 var file_store_user_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_user_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_store_user_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_store_user_setting_proto_goTypes = []any{
 	(UserSettingKey)(0),                         // 0: memos.store.UserSettingKey
 	(*UserSetting)(nil),                         // 1: memos.store.UserSetting
 	(*AccessTokensUserSetting)(nil),             // 2: memos.store.AccessTokensUserSetting
 	(*ShortcutsUserSetting)(nil),                // 3: memos.store.ShortcutsUserSetting
 	(*SessionsUserSetting)(nil),                 // 4: memos.store.SessionsUserSetting
-	(*AccessTokensUserSetting_AccessToken)(nil), // 5: memos.store.AccessTokensUserSetting.AccessToken
-	(*ShortcutsUserSetting_Shortcut)(nil),       // 6: memos.store.ShortcutsUserSetting.Shortcut
-	(*SessionsUserSetting_Session)(nil),         // 7: memos.store.SessionsUserSetting.Session
-	(*SessionsUserSetting_ClientInfo)(nil),      // 8: memos.store.SessionsUserSetting.ClientInfo
-	(*timestamppb.Timestamp)(nil),               // 9: google.protobuf.Timestamp
+	(*ActivityPubUserSetting)(nil),              // 5: memos.store.ActivityPubUserSetting
+	(*FeedTokensUserSetting)(nil),               // 6: memos.store.FeedTokensUserSetting
+	(*AccessTokensUserSetting_AccessToken)(nil), // 7: memos.store.AccessTokensUserSetting.AccessToken
+	(*ShortcutsUserSetting_Shortcut)(nil),       // 8: memos.store.ShortcutsUserSetting.Shortcut
+	(*SessionsUserSetting_Session)(nil),         // 9: memos.store.SessionsUserSetting.Session
+	(*SessionsUserSetting_ClientInfo)(nil),      // 10: memos.store.SessionsUserSetting.ClientInfo
+	(*FeedTokensUserSetting_FeedToken)(nil),     // 11: memos.store.FeedTokensUserSetting.FeedToken
+	(*timestamppb.Timestamp)(nil),               // 12: google.protobuf.Timestamp
 }
 var file_store_user_setting_proto_depIdxs = []int32{
 	0,  // 0: memos.store.UserSetting.key:type_name -> memos.store.UserSettingKey
 	2,  // 1: memos.store.UserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting
 	3,  // 2: memos.store.UserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting
 	4,  // 3: memos.store.UserSetting.sessions:type_name -> memos.store.SessionsUserSetting
-	5,  // 4: memos.store.AccessTokensUserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting.AccessToken
-	6,  // 5: memos.store.ShortcutsUserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting.Shortcut
-	7,  // 6: memos.store.SessionsUserSetting.sessions:type_name -> memos.store.SessionsUserSetting.Session
-	9,  // 7: memos.store.SessionsUserSetting.Session.create_time:type_name -> google.protobuf.Timestamp
-	9,  // 8: memos.store.SessionsUserSetting.Session.expire_time:type_name -> google.protobuf.Timestamp
-	9,  // 9: memos.store.SessionsUserSetting.Session.last_accessed_time:type_name -> google.protobuf.Timestamp
-	8,  // 10: memos.store.SessionsUserSetting.Session.client_info:type_name -> memos.store.SessionsUserSetting.ClientInfo
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	5,  // 4: memos.store.UserSetting.activitypub:type_name -> memos.store.ActivityPubUserSetting
+	6,  // 5: memos.store.UserSetting.feed_tokens:type_name -> memos.store.FeedTokensUserSetting
+	7,  // 6: memos.store.AccessTokensUserSetting.access_tokens:type_name -> memos.store.AccessTokensUserSetting.AccessToken
+	8,  // 7: memos.store.ShortcutsUserSetting.shortcuts:type_name -> memos.store.ShortcutsUserSetting.Shortcut
+	9,  // 8: memos.store.SessionsUserSetting.sessions:type_name -> memos.store.SessionsUserSetting.Session
+	11, // 9: memos.store.FeedTokensUserSetting.feed_tokens:type_name -> memos.store.FeedTokensUserSetting.FeedToken
+	12, // 10: memos.store.SessionsUserSetting.Session.create_time:type_name -> google.protobuf.Timestamp
+	12, // 11: memos.store.SessionsUserSetting.Session.expire_time:type_name -> google.protobuf.Timestamp
+	12, // 12: memos.store.SessionsUserSetting.Session.last_accessed_time:type_name -> google.protobuf.Timestamp
+	10, // 13: memos.store.SessionsUserSetting.Session.client_info:type_name -> memos.store.SessionsUserSetting.ClientInfo
+	12, // 14: memos.store.FeedTokensUserSetting.FeedToken.create_time:type_name -> google.protobuf.Timestamp
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_store_user_setting_proto_init() }
@@ -789,6 +1027,8 @@ func file_store_user_setting_proto_init() {
 		(*UserSetting_MemoVisibility)(nil),
 		(*UserSetting_Shortcuts)(nil),
 		(*UserSetting_Sessions)(nil),
+		(*UserSetting_Activitypub)(nil),
+		(*UserSetting_FeedTokens)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -796,7 +1036,7 @@ func file_store_user_setting_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_user_setting_proto_rawDesc), len(file_store_user_setting_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   8,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,448 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: store/webhook.proto
+
+package store
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WebhookPayload_Format int32
+
+const (
+	WebhookPayload_FORMAT_UNSPECIFIED WebhookPayload_Format = 0
+	WebhookPayload_RAW                WebhookPayload_Format = 1
+	WebhookPayload_SLACK              WebhookPayload_Format = 2
+	WebhookPayload_DISCORD            WebhookPayload_Format = 3
+	WebhookPayload_TEAMS              WebhookPayload_Format = 4
+	WebhookPayload_TEMPLATE           WebhookPayload_Format = 5
+)
+
+// Enum value maps for WebhookPayload_Format.
+var (
+	WebhookPayload_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "RAW",
+		2: "SLACK",
+		3: "DISCORD",
+		4: "TEAMS",
+		5: "TEMPLATE",
+	}
+	WebhookPayload_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"RAW":                1,
+		"SLACK":              2,
+		"DISCORD":            3,
+		"TEAMS":              4,
+		"TEMPLATE":           5,
+	}
+)
+
+func (x WebhookPayload_Format) Enum() *WebhookPayload_Format {
+	p := new(WebhookPayload_Format)
+	*p = x
+	return p
+}
+
+func (x WebhookPayload_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookPayload_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_webhook_proto_enumTypes[0].Descriptor()
+}
+
+func (WebhookPayload_Format) Type() protoreflect.EnumType {
+	return &file_store_webhook_proto_enumTypes[0]
+}
+
+func (x WebhookPayload_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookPayload_Format.Descriptor instead.
+func (WebhookPayload_Format) EnumDescriptor() ([]byte, []int) {
+	return file_store_webhook_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type IncomingWebhookPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The tags appended to the memos created by the hook.
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// The visibility of the memos created by the hook, e.g. "PRIVATE".
+	Visibility string `protobuf:"bytes,2,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	// The Go text/template used to render the memo content.
+	// If empty, the request body is used as the content.
+	Template      string `protobuf:"bytes,3,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncomingWebhookPayload) Reset() {
+	*x = IncomingWebhookPayload{}
+	mi := &file_store_webhook_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncomingWebhookPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncomingWebhookPayload) ProtoMessage() {}
+
+func (x *IncomingWebhookPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_webhook_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncomingWebhookPayload.ProtoReflect.Descriptor instead.
+func (*IncomingWebhookPayload) Descriptor() ([]byte, []int) {
+	return file_store_webhook_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IncomingWebhookPayload) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *IncomingWebhookPayload) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *IncomingWebhookPayload) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+type WebhookDeliveryPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The URL the request was posted to.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The type of activity that triggered the delivery, e.g. "memos.memo.created", or the type of the ActivityPub activity.
+	ActivityType string `protobuf:"bytes,2,opt,name=activity_type,json=activityType,proto3" json:"activity_type,omitempty"`
+	// The JSON body posted to the webhook.
+	RequestBody string `protobuf:"bytes,3,opt,name=request_body,json=requestBody,proto3" json:"request_body,omitempty"`
+	// The HTTP status code of the last response, or 0 if no response was received.
+	ResponseStatus int32 `protobuf:"varint,4,opt,name=response_status,json=responseStatus,proto3" json:"response_status,omitempty"`
+	// The body of the last response, truncated.
+	ResponseBody string `protobuf:"bytes,5,opt,name=response_body,json=responseBody,proto3" json:"response_body,omitempty"`
+	// The duration of the last attempt in milliseconds.
+	LatencyMs int64 `protobuf:"varint,6,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// The error of the last failed attempt.
+	Error string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// The user whose ActivityPub key signs the request, set on the deliveries of activities to ActivityPub inboxes,
+	// which have no webhook.
+	ActivityPubUserId int32 `protobuf:"varint,8,opt,name=activity_pub_user_id,json=activityPubUserId,proto3" json:"activity_pub_user_id,omitempty"`
+	// The ID of the ActivityPub key signing the request.
+	ActivityPubKeyId string `protobuf:"bytes,9,opt,name=activity_pub_key_id,json=activityPubKeyId,proto3" json:"activity_pub_key_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveryPayload) Reset() {
+	*x = WebhookDeliveryPayload{}
+	mi := &file_store_webhook_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDeliveryPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveryPayload) ProtoMessage() {}
+
+func (x *WebhookDeliveryPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_webhook_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDeliveryPayload.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryPayload) Descriptor() ([]byte, []int) {
+	return file_store_webhook_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WebhookDeliveryPayload) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryPayload) GetActivityType() string {
+	if x != nil {
+		return x.ActivityType
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryPayload) GetRequestBody() string {
+	if x != nil {
+		return x.RequestBody
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryPayload) GetResponseStatus() int32 {
+	if x != nil {
+		return x.ResponseStatus
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryPayload) GetResponseBody() string {
+	if x != nil {
+		return x.ResponseBody
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryPayload) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryPayload) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryPayload) GetActivityPubUserId() int32 {
+	if x != nil {
+		return x.ActivityPubUserId
+	}
+	return 0
+}
+
+func (x *WebhookDeliveryPayload) GetActivityPubKeyId() string {
+	if x != nil {
+		return x.ActivityPubKeyId
+	}
+	return ""
+}
+
+type WebhookPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The secret used to sign the requests to the webhook.
+	SigningSecret string `protobuf:"bytes,1,opt,name=signing_secret,json=signingSecret,proto3" json:"signing_secret,omitempty"`
+	// The event types the webhook is subscribed to, e.g. "memos.memo.created".
+	// If empty, the webhook receives all events.
+	EventTypes []string `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// The CEL filter over the memo of the event, e.g. `tag in ["deploy"]`.
+	// If empty, all memos match.
+	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// The format of the requests, unspecified is raw.
+	Format WebhookPayload_Format `protobuf:"varint,4,opt,name=format,proto3,enum=memos.store.WebhookPayload_Format" json:"format,omitempty"`
+	// The Go text/template of the requests in the TEMPLATE format.
+	Template string `protobuf:"bytes,5,opt,name=template,proto3" json:"template,omitempty"`
+	// The memo visibilities, e.g. "PUBLIC", that workspace webhooks receive the events of other users of.
+	// If empty, only public memos are received.
+	Visibilities  []string `protobuf:"bytes,6,rep,name=visibilities,proto3" json:"visibilities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookPayload) Reset() {
+	*x = WebhookPayload{}
+	mi := &file_store_webhook_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookPayload) ProtoMessage() {}
+
+func (x *WebhookPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_webhook_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookPayload.ProtoReflect.Descriptor instead.
+func (*WebhookPayload) Descriptor() ([]byte, []int) {
+	return file_store_webhook_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WebhookPayload) GetSigningSecret() string {
+	if x != nil {
+		return x.SigningSecret
+	}
+	return ""
+}
+
+func (x *WebhookPayload) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *WebhookPayload) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *WebhookPayload) GetFormat() WebhookPayload_Format {
+	if x != nil {
+		return x.Format
+	}
+	return WebhookPayload_FORMAT_UNSPECIFIED
+}
+
+func (x *WebhookPayload) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *WebhookPayload) GetVisibilities() []string {
+	if x != nil {
+		return x.Visibilities
+	}
+	return nil
+}
+
+var File_store_webhook_proto protoreflect.FileDescriptor
+
+const file_store_webhook_proto_rawDesc = "" +
+	"\n" +
+	"\x13store/webhook.proto\x12\vmemos.store\"h\n" +
+	"\x16IncomingWebhookPayload\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x02 \x01(\tR\n" +
+	"visibility\x12\x1a\n" +
+	"\btemplate\x18\x03 \x01(\tR\btemplate\"\xd5\x02\n" +
+	"\x16WebhookDeliveryPayload\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12#\n" +
+	"\ractivity_type\x18\x02 \x01(\tR\factivityType\x12!\n" +
+	"\frequest_body\x18\x03 \x01(\tR\vrequestBody\x12'\n" +
+	"\x0fresponse_status\x18\x04 \x01(\x05R\x0eresponseStatus\x12#\n" +
+	"\rresponse_body\x18\x05 \x01(\tR\fresponseBody\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x06 \x01(\x03R\tlatencyMs\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12/\n" +
+	"\x14activity_pub_user_id\x18\b \x01(\x05R\x11activityPubUserId\x12-\n" +
+	"\x13activity_pub_key_id\x18\t \x01(\tR\x10activityPubKeyId\"\xc8\x02\n" +
+	"\x0eWebhookPayload\x12%\n" +
+	"\x0esigning_secret\x18\x01 \x01(\tR\rsigningSecret\x12\x1f\n" +
+	"\vevent_types\x18\x02 \x03(\tR\n" +
+	"eventTypes\x12\x16\n" +
+	"\x06filter\x18\x03 \x01(\tR\x06filter\x12:\n" +
+	"\x06format\x18\x04 \x01(\x0e2\".memos.store.WebhookPayload.FormatR\x06format\x12\x1a\n" +
+	"\btemplate\x18\x05 \x01(\tR\btemplate\x12\"\n" +
+	"\fvisibilities\x18\x06 \x03(\tR\fvisibilities\"Z\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\a\n" +
+	"\x03RAW\x10\x01\x12\t\n" +
+	"\x05SLACK\x10\x02\x12\v\n" +
+	"\aDISCORD\x10\x03\x12\t\n" +
+	"\x05TEAMS\x10\x04\x12\f\n" +
+	"\bTEMPLATE\x10\x05B\x97\x01\n" +
+	"\x0fcom.memos.storeB\fWebhookProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
+
+var (
+	file_store_webhook_proto_rawDescOnce sync.Once
+	file_store_webhook_proto_rawDescData []byte
+)
+
+func file_store_webhook_proto_rawDescGZIP() []byte {
+	file_store_webhook_proto_rawDescOnce.Do(func() {
+		file_store_webhook_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_store_webhook_proto_rawDesc), len(file_store_webhook_proto_rawDesc)))
+	})
+	return file_store_webhook_proto_rawDescData
+}
+
+var file_store_webhook_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_store_webhook_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_store_webhook_proto_goTypes = []any{
+	(WebhookPayload_Format)(0),     // 0: memos.store.WebhookPayload.Format
+	(*IncomingWebhookPayload)(nil), // 1: memos.store.IncomingWebhookPayload
+	(*WebhookDeliveryPayload)(nil), // 2: memos.store.WebhookDeliveryPayload
+	(*WebhookPayload)(nil),         // 3: memos.store.WebhookPayload
+}
+var file_store_webhook_proto_depIdxs = []int32{
+	0, // 0: memos.store.WebhookPayload.format:type_name -> memos.store.WebhookPayload.Format
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_store_webhook_proto_init() }
+func file_store_webhook_proto_init() {
+	if File_store_webhook_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_webhook_proto_rawDesc), len(file_store_webhook_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_store_webhook_proto_goTypes,
+		DependencyIndexes: file_store_webhook_proto_depIdxs,
+		EnumInfos:         file_store_webhook_proto_enumTypes,
+		MessageInfos:      file_store_webhook_proto_msgTypes,
+	}.Build()
+	File_store_webhook_proto = out.File
+	file_store_webhook_proto_goTypes = nil
+	file_store_webhook_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: store/webmention.proto
+
+package store
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WebmentionPayload struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The plain text content of the source.
+	Content     string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	AuthorName  string `protobuf:"bytes,2,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`
+	AuthorUrl   string `protobuf:"bytes,3,opt,name=author_url,json=authorUrl,proto3" json:"author_url,omitempty"`
+	AuthorPhoto string `protobuf:"bytes,4,opt,name=author_photo,json=authorPhoto,proto3" json:"author_photo,omitempty"`
+	// The publish time of the source in unix seconds, or 0 if it is unknown.
+	PublishedTs   int64 `protobuf:"varint,5,opt,name=published_ts,json=publishedTs,proto3" json:"published_ts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebmentionPayload) Reset() {
+	*x = WebmentionPayload{}
+	mi := &file_store_webmention_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebmentionPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebmentionPayload) ProtoMessage() {}
+
+func (x *WebmentionPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_webmention_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebmentionPayload.ProtoReflect.Descriptor instead.
+func (*WebmentionPayload) Descriptor() ([]byte, []int) {
+	return file_store_webmention_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WebmentionPayload) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *WebmentionPayload) GetAuthorName() string {
+	if x != nil {
+		return x.AuthorName
+	}
+	return ""
+}
+
+func (x *WebmentionPayload) GetAuthorUrl() string {
+	if x != nil {
+		return x.AuthorUrl
+	}
+	return ""
+}
+
+func (x *WebmentionPayload) GetAuthorPhoto() string {
+	if x != nil {
+		return x.AuthorPhoto
+	}
+	return ""
+}
+
+func (x *WebmentionPayload) GetPublishedTs() int64 {
+	if x != nil {
+		return x.PublishedTs
+	}
+	return 0
+}
+
+var File_store_webmention_proto protoreflect.FileDescriptor
+
+const file_store_webmention_proto_rawDesc = "" +
+	"\n" +
+	"\x16store/webmention.proto\x12\vmemos.store\"\xb3\x01\n" +
+	"\x11WebmentionPayload\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12\x1f\n" +
+	"\vauthor_name\x18\x02 \x01(\tR\n" +
+	"authorName\x12\x1d\n" +
+	"\n" +
+	"author_url\x18\x03 \x01(\tR\tauthorUrl\x12!\n" +
+	"\fauthor_photo\x18\x04 \x01(\tR\vauthorPhoto\x12!\n" +
+	"\fpublished_ts\x18\x05 \x01(\x03R\vpublishedTsB\x9a\x01\n" +
+	"\x0fcom.memos.storeB\x0fWebmentionProtoP\x01Z)github.com/usememos/memos/proto/gen/store\xa2\x02\x03MSX\xaa\x02\vMemos.Store\xca\x02\vMemos\\Store\xe2\x02\x17Memos\\Store\\GPBMetadata\xea\x02\fMemos::Storeb\x06proto3"
+
+var (
+	file_store_webmention_proto_rawDescOnce sync.Once
+	file_store_webmention_proto_rawDescData []byte
+)
+
+func file_store_webmention_proto_rawDescGZIP() []byte {
+	file_store_webmention_proto_rawDescOnce.Do(func() {
+		file_store_webmention_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_store_webmention_proto_rawDesc), len(file_store_webmention_proto_rawDesc)))
+	})
+	return file_store_webmention_proto_rawDescData
+}
+
+var file_store_webmention_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_store_webmention_proto_goTypes = []any{
+	(*WebmentionPayload)(nil), // 0: memos.store.WebmentionPayload
+}
+var file_store_webmention_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_store_webmention_proto_init() }
+func file_store_webmention_proto_init() {
+	if File_store_webmention_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_webmention_proto_rawDesc), len(file_store_webmention_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_store_webmention_proto_goTypes,
+		DependencyIndexes: file_store_webmention_proto_depIdxs,
+		MessageInfos:      file_store_webmention_proto_msgTypes,
+	}.Build()
+	File_store_webmention_proto = out.File
+	file_store_webmention_proto_goTypes = nil
+	file_store_webmention_proto_depIdxs = nil
+}
@@ -135,6 +135,58 @@ func (WorkspaceStorageSetting_StorageType) EnumDescriptor() ([]byte, []int) {
 	return file_store_workspace_setting_proto_rawDescGZIP(), []int{4, 0}
 }
 
+type WorkspaceStorageSetting_ExifStripPolicy int32
+
+const (
+	// EXIF_STRIP_POLICY_UNSPECIFIED keeps the EXIF data of the images.
+	WorkspaceStorageSetting_EXIF_STRIP_POLICY_UNSPECIFIED WorkspaceStorageSetting_ExifStripPolicy = 0
+	// ALWAYS strips the EXIF data of all the uploaded images.
+	WorkspaceStorageSetting_ALWAYS WorkspaceStorageSetting_ExifStripPolicy = 1
+	// PUBLIC_MEMOS strips the EXIF data of the images attached to public memos.
+	WorkspaceStorageSetting_PUBLIC_MEMOS WorkspaceStorageSetting_ExifStripPolicy = 2
+)
+
+// Enum value maps for WorkspaceStorageSetting_ExifStripPolicy.
+var (
+	WorkspaceStorageSetting_ExifStripPolicy_name = map[int32]string{
+		0: "EXIF_STRIP_POLICY_UNSPECIFIED",
+		1: "ALWAYS",
+		2: "PUBLIC_MEMOS",
+	}
+	WorkspaceStorageSetting_ExifStripPolicy_value = map[string]int32{
+		"EXIF_STRIP_POLICY_UNSPECIFIED": 0,
+		"ALWAYS":                        1,
+		"PUBLIC_MEMOS":                  2,
+	}
+)
+
+func (x WorkspaceStorageSetting_ExifStripPolicy) Enum() *WorkspaceStorageSetting_ExifStripPolicy {
+	p := new(WorkspaceStorageSetting_ExifStripPolicy)
+	*p = x
+	return p
+}
+
+func (x WorkspaceStorageSetting_ExifStripPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkspaceStorageSetting_ExifStripPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_workspace_setting_proto_enumTypes[2].Descriptor()
+}
+
+func (WorkspaceStorageSetting_ExifStripPolicy) Type() protoreflect.EnumType {
+	return &file_store_workspace_setting_proto_enumTypes[2]
+}
+
+func (x WorkspaceStorageSetting_ExifStripPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkspaceStorageSetting_ExifStripPolicy.Descriptor instead.
+func (WorkspaceStorageSetting_ExifStripPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_store_workspace_setting_proto_rawDescGZIP(), []int{4, 1}
+}
+
 type WorkspaceSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Key   WorkspaceSettingKey    `protobuf:"varint,1,opt,name=key,proto3,enum=memos.store.WorkspaceSettingKey" json:"key,omitempty"`
@@ -331,8 +383,11 @@ type WorkspaceGeneralSetting struct {
 	DisallowChangeUsername bool `protobuf:"varint,7,opt,name=disallow_change_username,json=disallowChangeUsername,proto3" json:"disallow_change_username,omitempty"`
 	// disallow_change_nickname disallows changing nickname.
 	DisallowChangeNickname bool `protobuf:"varint,8,opt,name=disallow_change_nickname,json=disallowChangeNickname,proto3" json:"disallow_change_nickname,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// disallow_search_engine_indexing opts the workspace out of search engine indexing.
+	// robots.txt then disallows all crawlers and no sitemap is served.
+	DisallowSearchEngineIndexing bool `protobuf:"varint,9,opt,name=disallow_search_engine_indexing,json=disallowSearchEngineIndexing,proto3" json:"disallow_search_engine_indexing,omitempty"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
 }
 
 func (x *WorkspaceGeneralSetting) Reset() {
@@ -421,6 +476,13 @@ func (x *WorkspaceGeneralSetting) GetDisallowChangeNickname() bool {
 	return false
 }
 
+func (x *WorkspaceGeneralSetting) GetDisallowSearchEngineIndexing() bool {
+	if x != nil {
+		return x.DisallowSearchEngineIndexing
+	}
+	return false
+}
+
 type WorkspaceCustomProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -507,9 +569,11 @@ type WorkspaceStorageSetting struct {
 	// The max upload size in megabytes.
 	UploadSizeLimitMb int64 `protobuf:"varint,3,opt,name=upload_size_limit_mb,json=uploadSizeLimitMb,proto3" json:"upload_size_limit_mb,omitempty"`
 	// The S3 config.
-	S3Config      *StorageS3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	S3Config *StorageS3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
+	// The policy of stripping the EXIF data of the stored images, the orientation is always kept.
+	ExifStripPolicy WorkspaceStorageSetting_ExifStripPolicy `protobuf:"varint,5,opt,name=exif_strip_policy,json=exifStripPolicy,proto3,enum=memos.store.WorkspaceStorageSetting_ExifStripPolicy" json:"exif_strip_policy,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *WorkspaceStorageSetting) Reset() {
@@ -570,6 +634,13 @@ func (x *WorkspaceStorageSetting) GetS3Config() *StorageS3Config {
 	return nil
 }
 
+func (x *WorkspaceStorageSetting) GetExifStripPolicy() WorkspaceStorageSetting_ExifStripPolicy {
+	if x != nil {
+		return x.ExifStripPolicy
+	}
+	return WorkspaceStorageSetting_EXIF_STRIP_POLICY_UNSPECIFIED
+}
+
 // Reference: https://developers.cloudflare.com/r2/examples/aws/aws-sdk-go/
 type StorageS3Config struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -796,7 +867,7 @@ const file_store_workspace_setting_proto_rawDesc = "" +
 	"\x15WorkspaceBasicSetting\x12\x1d\n" +
 	"\n" +
 	"secret_key\x18\x01 \x01(\tR\tsecretKey\x12%\n" +
-	"\x0eschema_version\x18\x02 \x01(\tR\rschemaVersion\"\xd8\x03\n" +
+	"\x0eschema_version\x18\x02 \x01(\tR\rschemaVersion\"\x9f\x04\n" +
 	"\x17WorkspaceGeneralSetting\x12<\n" +
 	"\x1adisallow_user_registration\x18\x01 \x01(\bR\x18disallowUserRegistration\x124\n" +
 	"\x16disallow_password_auth\x18\x02 \x01(\bR\x14disallowPasswordAuth\x12+\n" +
@@ -805,7 +876,8 @@ const file_store_workspace_setting_proto_rawDesc = "" +
 	"\x0ecustom_profile\x18\x05 \x01(\v2#.memos.store.WorkspaceCustomProfileR\rcustomProfile\x121\n" +
 	"\x15week_start_day_offset\x18\x06 \x01(\x05R\x12weekStartDayOffset\x128\n" +
 	"\x18disallow_change_username\x18\a \x01(\bR\x16disallowChangeUsername\x128\n" +
-	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\"\xa3\x01\n" +
+	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\x12E\n" +
+	"\x1fdisallow_search_engine_indexing\x18\t \x01(\bR\x1cdisallowSearchEngineIndexing\"\xa3\x01\n" +
 	"\x16WorkspaceCustomProfile\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
@@ -813,17 +885,23 @@ const file_store_workspace_setting_proto_rawDesc = "" +
 	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x1e\n" +
 	"\n" +
 	"appearance\x18\x05 \x01(\tR\n" +
-	"appearance\"\xd5\x02\n" +
+	"appearance\"\x8b\x04\n" +
 	"\x17WorkspaceStorageSetting\x12S\n" +
 	"\fstorage_type\x18\x01 \x01(\x0e20.memos.store.WorkspaceStorageSetting.StorageTypeR\vstorageType\x12+\n" +
 	"\x11filepath_template\x18\x02 \x01(\tR\x10filepathTemplate\x12/\n" +
 	"\x14upload_size_limit_mb\x18\x03 \x01(\x03R\x11uploadSizeLimitMb\x129\n" +
-	"\ts3_config\x18\x04 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\"L\n" +
+	"\ts3_config\x18\x04 \x01(\v2\x1c.memos.store.StorageS3ConfigR\bs3Config\x12`\n" +
+	"\x11exif_strip_policy\x18\x05 \x01(\x0e24.memos.store.WorkspaceStorageSetting.ExifStripPolicyR\x0fexifStripPolicy\"L\n" +
 	"\vStorageType\x12\x1c\n" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\"\xd3\x01\n" +
+	"\x02S3\x10\x03\"R\n" +
+	"\x0fExifStripPolicy\x12!\n" +
+	"\x1dEXIF_STRIP_POLICY_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06ALWAYS\x10\x01\x12\x10\n" +
+	"\fPUBLIC_MEMOS\x10\x02\"\xd3\x01\n" +
 	"\x0fStorageS3Config\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
 	"\x11access_key_secret\x18\x02 \x01(\tR\x0faccessKeySecret\x12\x1a\n" +
@@ -863,33 +941,35 @@ func file_store_workspace_setting_proto_rawDescGZIP() []byte {
 	return file_store_workspace_setting_proto_rawDescData
 }
 
-var file_store_workspace_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_store_workspace_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
 var file_store_workspace_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_store_workspace_setting_proto_goTypes = []any{
-	(WorkspaceSettingKey)(0),                 // 0: memos.store.WorkspaceSettingKey
-	(WorkspaceStorageSetting_StorageType)(0), // 1: memos.store.WorkspaceStorageSetting.StorageType
-	(*WorkspaceSetting)(nil),                 // 2: memos.store.WorkspaceSetting
-	(*WorkspaceBasicSetting)(nil),            // 3: memos.store.WorkspaceBasicSetting
-	(*WorkspaceGeneralSetting)(nil),          // 4: memos.store.WorkspaceGeneralSetting
-	(*WorkspaceCustomProfile)(nil),           // 5: memos.store.WorkspaceCustomProfile
-	(*WorkspaceStorageSetting)(nil),          // 6: memos.store.WorkspaceStorageSetting
-	(*StorageS3Config)(nil),                  // 7: memos.store.StorageS3Config
-	(*WorkspaceMemoRelatedSetting)(nil),      // 8: memos.store.WorkspaceMemoRelatedSetting
+	(WorkspaceSettingKey)(0),                     // 0: memos.store.WorkspaceSettingKey
+	(WorkspaceStorageSetting_StorageType)(0),     // 1: memos.store.WorkspaceStorageSetting.StorageType
+	(WorkspaceStorageSetting_ExifStripPolicy)(0), // 2: memos.store.WorkspaceStorageSetting.ExifStripPolicy
+	(*WorkspaceSetting)(nil),                     // 3: memos.store.WorkspaceSetting
+	(*WorkspaceBasicSetting)(nil),                // 4: memos.store.WorkspaceBasicSetting
+	(*WorkspaceGeneralSetting)(nil),              // 5: memos.store.WorkspaceGeneralSetting
+	(*WorkspaceCustomProfile)(nil),               // 6: memos.store.WorkspaceCustomProfile
+	(*WorkspaceStorageSetting)(nil),              // 7: memos.store.WorkspaceStorageSetting
+	(*StorageS3Config)(nil),                      // 8: memos.store.StorageS3Config
+	(*WorkspaceMemoRelatedSetting)(nil),          // 9: memos.store.WorkspaceMemoRelatedSetting
 }
 var file_store_workspace_setting_proto_depIdxs = []int32{
 	0, // 0: memos.store.WorkspaceSetting.key:type_name -> memos.store.WorkspaceSettingKey
-	3, // 1: memos.store.WorkspaceSetting.basic_setting:type_name -> memos.store.WorkspaceBasicSetting
-	4, // 2: memos.store.WorkspaceSetting.general_setting:type_name -> memos.store.WorkspaceGeneralSetting
-	6, // 3: memos.store.WorkspaceSetting.storage_setting:type_name -> memos.store.WorkspaceStorageSetting
-	8, // 4: memos.store.WorkspaceSetting.memo_related_setting:type_name -> memos.store.WorkspaceMemoRelatedSetting
-	5, // 5: memos.store.WorkspaceGeneralSetting.custom_profile:type_name -> memos.store.WorkspaceCustomProfile
+	4, // 1: memos.store.WorkspaceSetting.basic_setting:type_name -> memos.store.WorkspaceBasicSetting
+	5, // 2: memos.store.WorkspaceSetting.general_setting:type_name -> memos.store.WorkspaceGeneralSetting
+	7, // 3: memos.store.WorkspaceSetting.storage_setting:type_name -> memos.store.WorkspaceStorageSetting
+	9, // 4: memos.store.WorkspaceSetting.memo_related_setting:type_name -> memos.store.WorkspaceMemoRelatedSetting
+	6, // 5: memos.store.WorkspaceGeneralSetting.custom_profile:type_name -> memos.store.WorkspaceCustomProfile
 	1, // 6: memos.store.WorkspaceStorageSetting.storage_type:type_name -> memos.store.WorkspaceStorageSetting.StorageType
-	7, // 7: memos.store.WorkspaceStorageSetting.s3_config:type_name -> memos.store.StorageS3Config
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	8, // 7: memos.store.WorkspaceStorageSetting.s3_config:type_name -> memos.store.StorageS3Config
+	2, // 8: memos.store.WorkspaceStorageSetting.exif_strip_policy:type_name -> memos.store.WorkspaceStorageSetting.ExifStripPolicy
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_store_workspace_setting_proto_init() }
@@ -908,7 +988,7 @@ func file_store_workspace_setting_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_workspace_setting_proto_rawDesc), len(file_store_workspace_setting_proto_rawDesc)),
-			NumEnums:      2,
+			NumEnums:      3,
 			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
@@ -47,6 +47,34 @@ func NewGRPCAuthInterceptor(store *store.Store, secret string) *GRPCAuthIntercep
 
 // AuthenticationInterceptor is the unary interceptor for gRPC API.
 func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, request any, serverInfo *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := in.authenticate(ctx, serverInfo.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+// StreamAuthenticationInterceptor is the stream interceptor for gRPC API.
+func (in *GRPCAuthInterceptor) StreamAuthenticationInterceptor(srv any, stream grpc.ServerStream, serverInfo *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := in.authenticate(stream.Context(), serverInfo.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedServerStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticatedServerStream is a server stream with the context of the authenticated user.
+type authenticatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate returns the context carrying the user of the request to the method.
+func (in *GRPCAuthInterceptor) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Errorf(codes.Unauthenticated, "failed to parse metadata from incoming context")
@@ -62,8 +90,8 @@ func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, re
 	user, err := in.authenticateByAccessToken(ctx, accessToken)
 	if err != nil {
 		// Check if this method is in the allowlist first
-		if isUnauthorizeAllowedMethod(serverInfo.FullMethod) {
-			return handler(ctx, request)
+		if isUnauthorizeAllowedMethod(fullMethod) {
+			return ctx, nil
 		}
 		return nil, err
 	}
@@ -72,7 +100,7 @@ func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, re
 	if user.RowStatus == store.Archived {
 		return nil, errors.Errorf("user %q is archived", user.Username)
 	}
-	if isOnlyForAdminAllowedMethod(serverInfo.FullMethod) && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
+	if isOnlyForAdminAllowedMethod(fullMethod) && user.Role != store.RoleHost && user.Role != store.RoleAdmin {
 		return nil, errors.Errorf("user %q is not admin", user.Username)
 	}
 
@@ -90,7 +118,7 @@ func (in *GRPCAuthInterceptor) AuthenticationInterceptor(ctx context.Context, re
 		ctx = context.WithValue(ctx, accessTokenContextKey, accessToken)
 	}
 
-	return handler(ctx, request)
+	return ctx, nil
 }
 
 // authenticateByAccessToken authenticates a user using access token from Authorization header or cookie.
@@ -213,3 +241,23 @@ func validateAccessToken(accessTokenString string, userAccessTokens []*storepb.A
 	}
 	return false
 }
+
+// authenticateHTTPRequest returns the user of the access token from the Authorization header or the cookie,
+// or nil for anonymous requests. It authenticates the requests of the endpoints served outside of gRPC.
+func (s *APIV1Service) authenticateHTTPRequest(ctx context.Context, request *http.Request) (*store.User, error) {
+	md := metadata.MD{}
+	if authorization := request.Header.Get("Authorization"); authorization != "" {
+		md.Set("authorization", authorization)
+	}
+	if cookie := request.Header.Get("Cookie"); cookie != "" {
+		md.Set("cookie", cookie)
+	}
+	accessToken, err := getTokenFromMetadata(md)
+	if err != nil {
+		return nil, err
+	}
+	if accessToken == "" {
+		return nil, nil
+	}
+	return NewGRPCAuthInterceptor(s.Store, s.Secret).authenticateByAccessToken(ctx, accessToken)
+}
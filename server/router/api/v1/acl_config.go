@@ -15,7 +15,9 @@ var authenticationAllowlistMethods = map[string]bool{
 	"/memos.api.v1.UserService/SearchUsers":                       true,
 	"/memos.api.v1.MemoService/GetMemo":                           true,
 	"/memos.api.v1.MemoService/ListMemos":                         true,
+	"/memos.api.v1.MemoService/ListMemoWebmentions":               true,
 	"/memos.api.v1.MarkdownService/GetLinkMetadata":               true,
+	"/memos.api.v1.EventService/WatchEvents":                      true,
 	"/memos.api.v1.AttachmentService/GetAttachmentBinary":         true,
 }
 
@@ -25,8 +27,11 @@ func isUnauthorizeAllowedMethod(fullMethodName string) bool {
 }
 
 var allowedMethodsOnlyForAdmin = map[string]bool{
-	"/memos.api.v1.UserService/CreateUser":                  true,
-	"/memos.api.v1.WorkspaceService/UpdateWorkspaceSetting": true,
+	"/memos.api.v1.UserService/CreateUser":                             true,
+	"/memos.api.v1.WorkspaceService/UpdateWorkspaceSetting":            true,
+	"/memos.api.v1.AttachmentService/GetAttachmentDeduplicationReport": true,
+	"/memos.api.v1.AttachmentService/MigrateAttachmentStorage":         true,
+	"/memos.api.v1.AttachmentService/CleanupOrphanedAttachments":       true,
 }
 
 // isOnlyForAdminAllowedMethod returns true if the method is allowed to be called only by admin.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -124,3 +125,62 @@ func (s *APIV1Service) convertActivityPayloadFromStore(ctx context.Context, payl
 	}
 	return v2Payload, nil
 }
+
+// handleActivityEvent records the activities of the events and notifies their owners in the inbox.
+func (s *APIV1Service) handleActivityEvent(ctx context.Context, event *Event) error {
+	// Only the comments of other users on public or protected memos are recorded for now.
+	if event.Type != EventCommentCreated || event.Comment.GetVisibility() == v1pb.Visibility_PRIVATE || event.ActorID == event.OwnerID {
+		return nil
+	}
+	commentUID, err := ExtractMemoUIDFromName(event.Comment.Name)
+	if err != nil {
+		return errors.Wrap(err, "invalid comment name")
+	}
+	comment, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &commentUID, ExcludeContent: true})
+	if err != nil || comment == nil {
+		return errors.Errorf("failed to get comment %s", event.Comment.Name)
+	}
+	memoUID, err := ExtractMemoUIDFromName(event.Memo.GetName())
+	if err != nil {
+		return errors.Wrap(err, "invalid memo name")
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID, ExcludeContent: true})
+	if err != nil || memo == nil {
+		return errors.Errorf("failed to get memo %s", event.Memo.GetName())
+	}
+
+	activity, err := s.Store.CreateActivity(ctx, &store.Activity{
+		CreatorID: event.ActorID,
+		Type:      store.ActivityTypeMemoComment,
+		Level:     store.ActivityLevelInfo,
+		Payload: &storepb.ActivityPayload{
+			MemoComment: &storepb.ActivityMemoCommentPayload{
+				MemoId:        comment.ID,
+				RelatedMemoId: memo.ID,
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create activity")
+	}
+	inbox, err := s.Store.CreateInbox(ctx, &store.Inbox{
+		SenderID:   event.ActorID,
+		ReceiverID: event.OwnerID,
+		Status:     store.UNREAD,
+		Message: &storepb.InboxMessage{
+			Type:       storepb.InboxMessage_MEMO_COMMENT,
+			ActivityId: &activity.ID,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create inbox")
+	}
+	s.EventBus().Publish(ctx, &Event{
+		Type:    EventInboxCreated,
+		ActorID: event.ActorID,
+		OwnerID: event.OwnerID,
+		Memo:    event.Memo,
+		Inbox:   convertInboxFromStore(inbox),
+	})
+	return nil
+}
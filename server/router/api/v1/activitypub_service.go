@@ -0,0 +1,639 @@
+package v1
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/usememos/gomark"
+	"github.com/usememos/gomark/renderer"
+
+	"github.com/usememos/memos/plugin/activitypub"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// ActivityPubPathPrefix is the path prefix of the ActivityPub actors and objects.
+	ActivityPubPathPrefix = "/ap"
+	// WebFingerPath is the path of the WebFinger endpoint.
+	WebFingerPath = "/.well-known/webfinger"
+
+	// activityPubOutboxPageSize is the number of activities on a page of an outbox.
+	activityPubOutboxPageSize = 20
+	// activityPubMaxBodySize is the maximum size of an activity posted to an inbox.
+	activityPubMaxBodySize = 1 << 20
+)
+
+// activityPubKeyMutex prevents concurrent requests from generating different key pairs for the same user.
+var activityPubKeyMutex sync.Mutex
+
+// RegisterActivityPubRoutes registers the WebFinger and ActivityPub endpoints.
+// Users are published as actors whose outbox holds their public memos.
+// Federation is only enabled when the instance URL is configured, since the IDs of actors
+// and objects must be stable absolute URLs.
+func (s *APIV1Service) RegisterActivityPubRoutes(echoServer *echo.Echo) {
+	echoServer.GET(WebFingerPath, s.handleWebFinger)
+	activityPubGroup := echoServer.Group(ActivityPubPathPrefix)
+	activityPubGroup.GET("/users/:username", s.handleActivityPubActor)
+	activityPubGroup.GET("/users/:username/outbox", s.handleActivityPubOutbox)
+	activityPubGroup.GET("/users/:username/followers", s.handleActivityPubFollowers)
+	activityPubGroup.POST("/users/:username/inbox", s.handleActivityPubInbox)
+	activityPubGroup.GET("/memos/:uid", s.handleActivityPubNote)
+}
+
+func (s *APIV1Service) handleWebFinger(c echo.Context) error {
+	ctx := c.Request().Context()
+	baseURL := s.getActivityPubBaseURL()
+	if baseURL == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "ActivityPub is not enabled")
+	}
+
+	resource := c.QueryParam("resource")
+	username := ""
+	if acct, ok := strings.CutPrefix(resource, "acct:"); ok {
+		name, host, ok := strings.Cut(acct, "@")
+		if !ok || !strings.EqualFold(host, getActivityPubHost(baseURL)) {
+			return echo.NewHTTPError(http.StatusNotFound, "Resource not found")
+		}
+		username = name
+	} else if name, ok := strings.CutPrefix(resource, baseURL+ActivityPubPathPrefix+"/users/"); ok {
+		username = name
+	}
+	if username == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid resource")
+	}
+	user, err := s.getActivityPubUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	actorID := getActivityPubActorID(baseURL, user.Username)
+	c.Response().Header().Set(echo.HeaderContentType, activitypub.JRDContentType)
+	return c.JSON(http.StatusOK, &activitypub.WebFinger{
+		Subject: fmt.Sprintf("acct:%s@%s", user.Username, getActivityPubHost(baseURL)),
+		Aliases: []string{actorID, getUserProfileURL(baseURL, user.Username)},
+		Links: []*activitypub.WebFingerLink{
+			{
+				Rel:  "self",
+				Type: activitypub.ContentType,
+				Href: actorID,
+			},
+			{
+				Rel:  "http://webfinger.net/rel/profile-page",
+				Type: "text/html",
+				Href: getUserProfileURL(baseURL, user.Username),
+			},
+		},
+	})
+}
+
+func (s *APIV1Service) handleActivityPubActor(c echo.Context) error {
+	ctx := c.Request().Context()
+	baseURL := s.getActivityPubBaseURL()
+	if baseURL == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "ActivityPub is not enabled")
+	}
+	user, err := s.getActivityPubUser(ctx, c.Param("username"))
+	if err != nil {
+		return err
+	}
+	key, err := s.getOrCreateActivityPubKey(ctx, user.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get ActivityPub key").SetInternal(err)
+	}
+
+	actorID := getActivityPubActorID(baseURL, user.Username)
+	name := user.Nickname
+	if name == "" {
+		name = user.Username
+	}
+	return activityPubJSON(c, &activitypub.Actor{
+		Context:           activitypub.Context,
+		ID:                actorID,
+		Type:              "Person",
+		PreferredUsername: user.Username,
+		Name:              name,
+		Summary:           user.Description,
+		URL:               getUserProfileURL(baseURL, user.Username),
+		Inbox:             actorID + "/inbox",
+		Outbox:            actorID + "/outbox",
+		Followers:         actorID + "/followers",
+		PublicKey: &activitypub.PublicKey{
+			ID:           getActivityPubKeyID(actorID),
+			Owner:        actorID,
+			PublicKeyPem: key.PublicKey,
+		},
+		Published: time.Unix(user.CreatedTs, 0).UTC().Format(time.RFC3339),
+	})
+}
+
+func (s *APIV1Service) handleActivityPubOutbox(c echo.Context) error {
+	ctx := c.Request().Context()
+	baseURL := s.getActivityPubBaseURL()
+	if baseURL == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "ActivityPub is not enabled")
+	}
+	user, err := s.getActivityPubUser(ctx, c.Param("username"))
+	if err != nil {
+		return err
+	}
+
+	outboxID := getActivityPubActorID(baseURL, user.Username) + "/outbox"
+	if c.QueryParam("page") == "" {
+		memos, err := s.Store.ListMemos(ctx, getActivityPubMemoFind(user.ID, true))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list memos").SetInternal(err)
+		}
+		return activityPubJSON(c, &activitypub.OrderedCollection{
+			Context:    activitypub.Context,
+			ID:         outboxID,
+			Type:       "OrderedCollection",
+			TotalItems: len(memos),
+			First:      outboxID + "?page=1",
+		})
+	}
+
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid page")
+	}
+	memoFind := getActivityPubMemoFind(user.ID, false)
+	limit, offset := activityPubOutboxPageSize+1, (page-1)*activityPubOutboxPageSize
+	memoFind.Limit, memoFind.Offset = &limit, &offset
+	memos, err := s.Store.ListMemos(ctx, memoFind)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list memos").SetInternal(err)
+	}
+
+	collectionPage := &activitypub.OrderedCollectionPage{
+		Context:      activitypub.Context,
+		ID:           fmt.Sprintf("%s?page=%d", outboxID, page),
+		Type:         "OrderedCollectionPage",
+		PartOf:       outboxID,
+		OrderedItems: []any{},
+	}
+	if len(memos) > activityPubOutboxPageSize {
+		memos = memos[:activityPubOutboxPageSize]
+		collectionPage.Next = fmt.Sprintf("%s?page=%d", outboxID, page+1)
+	}
+	for _, memo := range memos {
+		note, err := s.convertMemoToActivityPubNote(ctx, memo, user, baseURL)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to convert memo").SetInternal(err)
+		}
+		collectionPage.OrderedItems = append(collectionPage.OrderedItems, &activitypub.Activity{
+			ID:        note.ID + "#create",
+			Type:      activitypub.ActivityTypeCreate,
+			Actor:     note.AttributedTo,
+			Object:    note,
+			To:        note.To,
+			Cc:        note.Cc,
+			Published: note.Published,
+		})
+	}
+	return activityPubJSON(c, collectionPage)
+}
+
+func (s *APIV1Service) handleActivityPubFollowers(c echo.Context) error {
+	ctx := c.Request().Context()
+	baseURL := s.getActivityPubBaseURL()
+	if baseURL == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "ActivityPub is not enabled")
+	}
+	user, err := s.getActivityPubUser(ctx, c.Param("username"))
+	if err != nil {
+		return err
+	}
+	followers, err := s.Store.ListActivityPubFollowers(ctx, &store.FindActivityPubFollower{
+		UserID: &user.ID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list followers").SetInternal(err)
+	}
+
+	// Only the number of followers is published, not who they are.
+	return activityPubJSON(c, &activitypub.OrderedCollection{
+		Context:    activitypub.Context,
+		ID:         getActivityPubActorID(baseURL, user.Username) + "/followers",
+		Type:       "OrderedCollection",
+		TotalItems: len(followers),
+	})
+}
+
+func (s *APIV1Service) handleActivityPubInbox(c echo.Context) error {
+	ctx := c.Request().Context()
+	baseURL := s.getActivityPubBaseURL()
+	if baseURL == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "ActivityPub is not enabled")
+	}
+	user, err := s.getActivityPubUser(ctx, c.Param("username"))
+	if err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, activityPubMaxBodySize+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body").SetInternal(err)
+	}
+	if len(body) > activityPubMaxBodySize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Request body too large")
+	}
+	activity := &activitypub.Activity{}
+	if err := json.Unmarshal(body, activity); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid activity").SetInternal(err)
+	}
+
+	actorID := getActivityPubActorID(baseURL, user.Username)
+	key, err := s.getOrCreateActivityPubKey(ctx, user.ID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get ActivityPub key").SetInternal(err)
+	}
+	privateKey, err := activitypub.ParsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to parse ActivityPub key").SetInternal(err)
+	}
+	signer, err := verifyActivityPubRequest(ctx, c.Request(), body, getActivityPubKeyID(actorID), privateKey)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid signature").SetInternal(err)
+	}
+	if activity.Actor != signer.ID {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Activity actor does not match the signer")
+	}
+
+	switch activity.Type {
+	case activitypub.ActivityTypeFollow:
+		if activitypub.GetObjectID(activity.Object) != actorID {
+			return echo.NewHTTPError(http.StatusBadRequest, "Follow object does not match the actor")
+		}
+		follower := &store.ActivityPubFollower{
+			UserID:  user.ID,
+			ActorID: signer.ID,
+			Inbox:   signer.Inbox,
+		}
+		if signer.Endpoints != nil {
+			follower.SharedInbox = signer.Endpoints.SharedInbox
+		}
+		if _, err := s.Store.UpsertActivityPubFollower(ctx, follower); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save follower").SetInternal(err)
+		}
+		accept := &activitypub.Activity{
+			Context: activitypub.Context,
+			ID:      fmt.Sprintf("%s#accept-%d", actorID, time.Now().UnixNano()),
+			Type:    activitypub.ActivityTypeAccept,
+			Actor:   actorID,
+			Object:  activity,
+		}
+		if err := s.enqueueActivityPubDelivery(ctx, user.ID, getActivityPubKeyID(actorID), signer.Inbox, accept); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to queue accept").SetInternal(err)
+		}
+	case activitypub.ActivityTypeUndo:
+		if activitypub.GetObjectType(activity.Object) == activitypub.ActivityTypeFollow {
+			if err := s.Store.DeleteActivityPubFollower(ctx, &store.DeleteActivityPubFollower{
+				UserID:  user.ID,
+				ActorID: signer.ID,
+			}); err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete follower").SetInternal(err)
+			}
+		}
+	default:
+		// Other activities such as replies and likes are not supported yet and are ignored.
+	}
+	return c.NoContent(http.StatusAccepted)
+}
+
+func (s *APIV1Service) handleActivityPubNote(c echo.Context) error {
+	ctx := c.Request().Context()
+	baseURL := s.getActivityPubBaseURL()
+	if baseURL == "" {
+		return echo.NewHTTPError(http.StatusNotFound, "ActivityPub is not enabled")
+	}
+	uid := c.Param("uid")
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get memo").SetInternal(err)
+	}
+	if memo == nil || !isMemoFederated(memo) {
+		return echo.NewHTTPError(http.StatusNotFound, "Memo not found")
+	}
+	creator, err := s.Store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get memo creator").SetInternal(err)
+	}
+	if creator == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Memo not found")
+	}
+	note, err := s.convertMemoToActivityPubNote(ctx, memo, creator, baseURL)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to convert memo").SetInternal(err)
+	}
+	note.Context = activitypub.Context
+	return activityPubJSON(c, note)
+}
+
+// DispatchMemoActivity delivers an activity about the memo to the ActivityPub followers of its creator.
+// The deliveries are queued, so only failures to build and queue them are returned.
+func (s *APIV1Service) DispatchMemoActivity(ctx context.Context, memo *store.Memo, activityType string) error {
+	baseURL := s.getActivityPubBaseURL()
+	if baseURL == "" {
+		return nil
+	}
+	followers, err := s.Store.ListActivityPubFollowers(ctx, &store.FindActivityPubFollower{
+		UserID: &memo.CreatorID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list followers")
+	}
+	if len(followers) == 0 {
+		return nil
+	}
+	creator, err := s.Store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+	if err != nil {
+		return errors.Wrap(err, "failed to get memo creator")
+	}
+	if creator == nil {
+		return errors.Errorf("memo creator %d not found", memo.CreatorID)
+	}
+	// The key signing the deliveries is created before they are queued.
+	if _, err := s.getOrCreateActivityPubKey(ctx, creator.ID); err != nil {
+		return errors.Wrap(err, "failed to get ActivityPub key")
+	}
+
+	actorID := getActivityPubActorID(baseURL, creator.Username)
+	noteID := getActivityPubNoteID(baseURL, memo.UID)
+	var object any = &activitypub.Tombstone{ID: noteID, Type: "Tombstone"}
+	if activityType != activitypub.ActivityTypeDelete {
+		note, err := s.convertMemoToActivityPubNote(ctx, memo, creator, baseURL)
+		if err != nil {
+			return errors.Wrap(err, "failed to convert memo")
+		}
+		object = note
+	}
+	activity := &activitypub.Activity{
+		Context:   activitypub.Context,
+		ID:        fmt.Sprintf("%s#%s-%d", noteID, strings.ToLower(activityType), time.Now().UnixNano()),
+		Type:      activityType,
+		Actor:     actorID,
+		Object:    object,
+		To:        []string{activitypub.PublicAddress},
+		Cc:        []string{actorID + "/followers"},
+		Published: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	// Followers on the same server share an inbox, so each activity is delivered to it once.
+	inboxes := []string{}
+	for _, follower := range followers {
+		inbox := follower.Inbox
+		if follower.SharedInbox != "" {
+			inbox = follower.SharedInbox
+		}
+		if !slices.Contains(inboxes, inbox) {
+			inboxes = append(inboxes, inbox)
+		}
+	}
+	for _, inbox := range inboxes {
+		if err := s.enqueueActivityPubDelivery(ctx, creator.ID, getActivityPubKeyID(actorID), inbox, activity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueActivityPubDelivery queues the delivery of the activity to the inbox, signed with the ActivityPub key of the user.
+// It is sent by the webhook delivery runner, which retries the failed deliveries.
+func (s *APIV1Service) enqueueActivityPubDelivery(ctx context.Context, userID int32, keyID string, inbox string, activity *activitypub.Activity) error {
+	body, err := json.Marshal(activity)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal activity")
+	}
+	if _, err := s.Store.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: time.Now().Unix(),
+		Payload: &storepb.WebhookDeliveryPayload{
+			Url:               inbox,
+			ActivityType:      activity.Type,
+			RequestBody:       string(body),
+			ActivityPubUserId: userID,
+			ActivityPubKeyId:  keyID,
+		},
+	}); err != nil {
+		return errors.Wrap(err, "failed to enqueue ActivityPub delivery")
+	}
+	return nil
+}
+
+func (s *APIV1Service) convertMemoToActivityPubNote(ctx context.Context, memo *store.Memo, creator *store.User, baseURL string) (*activitypub.Note, error) {
+	nodes, err := gomark.Parse(memo.Content)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse content")
+	}
+	actorID := getActivityPubActorID(baseURL, creator.Username)
+	note := &activitypub.Note{
+		ID:           getActivityPubNoteID(baseURL, memo.UID),
+		Type:         "Note",
+		AttributedTo: actorID,
+		Content:      renderer.NewHTMLRenderer().Render(nodes),
+		Source: &activitypub.Source{
+			Content:   memo.Content,
+			MediaType: "text/markdown",
+		},
+		URL:       fmt.Sprintf("%s/%s%s", baseURL, MemoNamePrefix, memo.UID),
+		Published: time.Unix(memo.CreatedTs, 0).UTC().Format(time.RFC3339),
+		To:        []string{activitypub.PublicAddress},
+		Cc:        []string{actorID + "/followers"},
+	}
+	if memo.UpdatedTs != memo.CreatedTs {
+		note.Updated = time.Unix(memo.UpdatedTs, 0).UTC().Format(time.RFC3339)
+	}
+	if memo.Payload != nil {
+		for _, tag := range memo.Payload.Tags {
+			note.Tag = append(note.Tag, &activitypub.Tag{
+				Type: "Hashtag",
+				Name: "#" + tag,
+			})
+		}
+	}
+
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
+		MemoID: &memo.ID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	for _, attachment := range attachments {
+		// The notes are kept by the remote servers, so the S3 objects are linked through the server rather than by their presigned URLs which expire.
+		attachmentURL := fmt.Sprintf("%s/file/attachments/%s/%s", baseURL, attachment.UID, url.PathEscape(attachment.Filename))
+		if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+			attachmentURL = attachment.Reference
+		}
+		note.Attachment = append(note.Attachment, &activitypub.Document{
+			Type:      "Document",
+			MediaType: attachment.Type,
+			URL:       attachmentURL,
+			Name:      attachment.Filename,
+		})
+	}
+	return note, nil
+}
+
+func (s *APIV1Service) getActivityPubBaseURL() string {
+	return strings.TrimSuffix(s.Profile.InstanceURL, "/")
+}
+
+func (s *APIV1Service) getActivityPubUser(ctx context.Context, username string) (*store.User, error) {
+	user, err := s.Store.GetUser(ctx, &store.FindUser{Username: &username})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
+	}
+	if user == nil || user.RowStatus != store.Normal {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+	return user, nil
+}
+
+// getOrCreateActivityPubKey returns the key pair that the activities of the user are signed with,
+// generating it the first time the user is federated.
+func (s *APIV1Service) getOrCreateActivityPubKey(ctx context.Context, userID int32) (*storepb.ActivityPubUserSetting, error) {
+	activityPubKeyMutex.Lock()
+	defer activityPubKeyMutex.Unlock()
+
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_ACTIVITYPUB,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if key := userSetting.GetActivitypub(); key.GetPrivateKey() != "" {
+		return key, nil
+	}
+
+	privateKey, publicKey, err := activitypub.GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	key := &storepb.ActivityPubUserSetting{
+		PrivateKey: privateKey,
+		PublicKey:  publicKey,
+	}
+	if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_ACTIVITYPUB,
+		Value:  &storepb.UserSetting_Activitypub{Activitypub: key},
+	}); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// verifyActivityPubRequest verifies the HTTP signature of a request posted to an inbox
+// and returns the actor that signed it. The actor, its key and its inboxes must be on the host
+// the actor is fetched from, so that no server can sign for the actors of another one.
+func verifyActivityPubRequest(ctx context.Context, r *http.Request, body []byte, keyID string, privateKey *rsa.PrivateKey) (*activitypub.Actor, error) {
+	signature, err := activitypub.ParseSignature(r)
+	if err != nil {
+		return nil, err
+	}
+	signerURL, err := url.Parse(signature.KeyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid key id")
+	}
+	signerURL.Fragment = ""
+	signer, err := activitypub.FetchActor(ctx, signerURL.String(), keyID, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if signer.PublicKey == nil || signer.PublicKey.ID != signature.KeyID || signer.PublicKey.Owner != signer.ID {
+		return nil, errors.Errorf("actor %s has no key %s", signer.ID, signature.KeyID)
+	}
+	actorURLs := []string{signer.ID, signer.Inbox}
+	if signer.Endpoints != nil && signer.Endpoints.SharedInbox != "" {
+		actorURLs = append(actorURLs, signer.Endpoints.SharedInbox)
+	}
+	for _, actorURL := range actorURLs {
+		u, err := url.Parse(actorURL)
+		if err != nil || u.Host != signerURL.Host {
+			return nil, errors.Errorf("actor %s is not on the host %s", signer.ID, signerURL.Host)
+		}
+	}
+	publicKey, err := activitypub.ParsePublicKey(signer.PublicKey.PublicKeyPem)
+	if err != nil {
+		return nil, err
+	}
+	if err := signature.Verify(r, publicKey, body); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// isMemoFederated returns whether the memo is published over ActivityPub.
+// Only public memos are federated; comments and archived memos are not.
+func isMemoFederated(memo *store.Memo) bool {
+	return memo.Visibility == store.Public && memo.RowStatus == store.Normal && memo.ParentID == nil
+}
+
+// getMemoUpdatedActivityType returns the activity to send after a memo is updated,
+// or an empty string if nothing needs to be sent.
+func getMemoUpdatedActivityType(wasFederated bool, isFederated bool) string {
+	switch {
+	case wasFederated && isFederated:
+		return activitypub.ActivityTypeUpdate
+	case isFederated:
+		return activitypub.ActivityTypeCreate
+	case wasFederated:
+		return activitypub.ActivityTypeDelete
+	default:
+		return ""
+	}
+}
+
+func getActivityPubMemoFind(userID int32, excludeContent bool) *store.FindMemo {
+	rowStatus := store.Normal
+	return &store.FindMemo{
+		CreatorID:       &userID,
+		RowStatus:       &rowStatus,
+		VisibilityList:  []store.Visibility{store.Public},
+		ExcludeComments: true,
+		ExcludeContent:  excludeContent,
+	}
+}
+
+func getActivityPubHost(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+func getActivityPubActorID(baseURL string, username string) string {
+	return fmt.Sprintf("%s%s/users/%s", baseURL, ActivityPubPathPrefix, username)
+}
+
+func getActivityPubKeyID(actorID string) string {
+	return actorID + "#main-key"
+}
+
+func getActivityPubNoteID(baseURL string, memoUID string) string {
+	return fmt.Sprintf("%s%s/memos/%s", baseURL, ActivityPubPathPrefix, memoUID)
+}
+
+func getUserProfileURL(baseURL string, username string) string {
+	return fmt.Sprintf("%s/u/%s", baseURL, username)
+}
+
+func activityPubJSON(c echo.Context, data any) error {
+	c.Response().Header().Set(echo.HeaderContentType, activitypub.ContentType)
+	return c.JSON(http.StatusOK, data)
+}
@@ -7,13 +7,10 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"os"
-	"path/filepath"
-	"regexp"
+	"mime"
 	"strings"
 	"time"
 
-	"github.com/disintegration/imaging"
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/pkg/errors"
 	"google.golang.org/genproto/googleapis/api/httpbody"
@@ -22,11 +19,10 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/usememos/memos/internal/profile"
-	"github.com/usememos/memos/internal/util"
-	"github.com/usememos/memos/plugin/storage/s3"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/attachmentgc"
+	"github.com/usememos/memos/server/runner/thumbnail"
 	"github.com/usememos/memos/store"
 )
 
@@ -36,14 +32,10 @@ const (
 	// This is unrelated to maximum upload size limit, which is now set through system setting.
 	MaxUploadBufferSizeBytes = 32 << 20
 	MebiByte                 = 1024 * 1024
-	// ThumbnailCacheFolder is the folder name where the thumbnail images are stored.
-	ThumbnailCacheFolder = ".thumbnail_cache"
-)
 
-var SupportedThumbnailMimeTypes = []string{
-	"image/png",
-	"image/jpeg",
-}
+	// DefaultAttachmentStorageMigrationLimit is the number of the stored contents moved by a call of MigrateAttachmentStorage.
+	DefaultAttachmentStorageMigrationLimit = 100
+)
 
 func (s *APIV1Service) CreateAttachment(ctx context.Context, request *v1pb.CreateAttachmentRequest) (*v1pb.Attachment, error) {
 	user, err := s.GetCurrentUser(ctx)
@@ -80,40 +72,43 @@ func (s *APIV1Service) CreateAttachment(ctx context.Context, request *v1pb.Creat
 		return nil, status.Errorf(codes.Internal, "failed to get workspace storage setting: %v", err)
 	}
 	size := binary.Size(request.Attachment.Content)
-	uploadSizeLimit := int(workspaceStorageSetting.UploadSizeLimitMb) * MebiByte
-	if uploadSizeLimit == 0 {
-		uploadSizeLimit = MaxUploadBufferSizeBytes
-	}
-	if size > uploadSizeLimit {
+	if int64(size) > getUploadSizeLimit(workspaceStorageSetting) {
 		return nil, status.Errorf(codes.InvalidArgument, "file size exceeds the limit")
 	}
-	create.Size = int64(size)
-	create.Blob = request.Attachment.Content
-
-	if err := SaveAttachmentBlob(ctx, s.Profile, s.Store, create); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to save attachment blob: %v", err)
-	}
 
+	var memo *store.Memo
 	if request.Attachment.Memo != nil {
-		memoUID, err := ExtractMemoUIDFromName(*request.Attachment.Memo)
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
-		}
-		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+		memo, err = s.findAttachmentMemo(ctx, *request.Attachment.Memo)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to find memo: %v", err)
-		}
-		if memo == nil {
-			return nil, status.Errorf(codes.NotFound, "memo not found: %s", *request.Attachment.Memo)
+			return nil, err
 		}
 		create.MemoID = &memo.ID
 	}
+
+	content, err := prepareImageContent(create, request.Attachment.Content, shouldStripExif(workspaceStorageSetting, memo))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid image: %v", err)
+	}
+	create.Size = int64(len(content))
+
+	if err := s.Store.SaveAttachmentContent(ctx, create, bytes.NewReader(content)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save attachment blob: %v", err)
+	}
 	attachment, err := s.Store.CreateAttachment(ctx, create)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create attachment: %v", err)
 	}
+	if request.SetMemoLocation && memo != nil {
+		if err := s.setMemoLocationFromImage(ctx, memo, create.Payload.GetImageMetadata().GetLocation()); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set memo location: %v", err)
+		}
+	}
 
-	return s.convertAttachmentFromStore(ctx, attachment), nil
+	attachmentMessage := s.convertAttachmentFromStore(ctx, attachment)
+	if err := s.publishAttachmentEvent(ctx, EventAttachmentUploaded, attachment, attachmentMessage); err != nil {
+		slog.Warn("Failed to publish attachment uploaded event", slog.Any("err", err))
+	}
+	return attachmentMessage, nil
 }
 
 func (s *APIV1Service) ListAttachments(ctx context.Context, request *v1pb.ListAttachmentsRequest) (*v1pb.ListAttachmentsResponse, error) {
@@ -224,64 +219,90 @@ func (s *APIV1Service) GetAttachmentBinary(ctx context.Context, request *v1pb.Ge
 	if attachment == nil {
 		return nil, status.Errorf(codes.NotFound, "attachment not found")
 	}
-	// Check the related memo visibility.
-	if attachment.MemoID != nil {
-		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
-			ID: attachment.MemoID,
-		})
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to find memo by ID: %v", attachment.MemoID)
-		}
-		if memo != nil && memo.Visibility != store.Public {
-			user, err := s.GetCurrentUser(ctx)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
-			}
-			if user == nil {
-				return nil, status.Errorf(codes.Unauthenticated, "unauthorized access")
-			}
-			if memo.Visibility == store.Private && user.ID != attachment.CreatorID {
-				return nil, status.Errorf(codes.Unauthenticated, "unauthorized access")
-			}
-		}
+	if err := s.checkAttachmentAccess(ctx, attachment); err != nil {
+		return nil, err
 	}
 
-	if request.Thumbnail && util.HasPrefixes(attachment.Type, SupportedThumbnailMimeTypes...) {
-		thumbnailBlob, err := s.getOrGenerateThumbnail(attachment)
+	if request.Thumbnail && thumbnail.IsSupported(attachment.Type) {
+		preset, _ := thumbnail.GetPreset(thumbnail.DefaultPreset)
+		thumbnailBlob, err := s.getThumbnailRunner().GetOrGenerate(ctx, attachment, preset)
 		if err != nil {
 			// thumbnail failures are logged as warnings and not cosidered critical failures as
 			// a attachment image can be used in its place.
 			slog.Warn("failed to get attachment thumbnail image", slog.Any("error", err))
 		} else {
 			return &httpbody.HttpBody{
-				ContentType: attachment.Type,
+				ContentType: thumbnail.GetContentType(attachment),
 				Data:        thumbnailBlob,
 			}, nil
 		}
 	}
 
-	blob, err := s.GetAttachmentBlob(attachment)
+	content, err := s.Store.OpenAttachmentContent(ctx, attachment)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get attachment blob: %v", err)
 	}
-
-	contentType := attachment.Type
-	if strings.HasPrefix(contentType, "text/") {
-		contentType += "; charset=utf-8"
-	}
-	// Prevent XSS attacks by serving potentially unsafe files with a content type that prevents script execution.
-	if strings.EqualFold(contentType, "image/svg+xml") ||
-		strings.EqualFold(contentType, "text/html") ||
-		strings.EqualFold(contentType, "application/xhtml+xml") {
-		contentType = "application/octet-stream"
+	defer content.Close()
+	blob, err := io.ReadAll(content)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read attachment blob: %v", err)
 	}
 
+	contentType := getAttachmentContentType(attachment.Type)
 	return &httpbody.HttpBody{
 		ContentType: contentType,
 		Data:        blob,
 	}, nil
 }
 
+// checkAttachmentAccess checks that the current user can see the memo of the attachment.
+func (s *APIV1Service) checkAttachmentAccess(ctx context.Context, attachment *store.Attachment) error {
+	if attachment.MemoID == nil {
+		return nil
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+		ID: attachment.MemoID,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to find memo by ID: %v", attachment.MemoID)
+	}
+	if memo != nil && memo.Visibility != store.Public {
+		user, err := s.GetCurrentUser(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get current user: %v", err)
+		}
+		if user == nil {
+			return status.Errorf(codes.Unauthenticated, "unauthorized access")
+		}
+		if memo.Visibility == store.Private && user.ID != attachment.CreatorID {
+			return status.Errorf(codes.Unauthenticated, "unauthorized access")
+		}
+	}
+	return nil
+}
+
+// getAttachmentContentType returns the content type the attachment of the type is served with.
+// The media types are compared without the parameters the attachments may be uploaded with.
+func getAttachmentContentType(attachmentType string) string {
+	mediaType, params, err := mime.ParseMediaType(attachmentType)
+	if err != nil {
+		return "application/octet-stream"
+	}
+	// Prevent XSS attacks by serving potentially unsafe files with a content type that prevents script execution.
+	switch mediaType {
+	case "image/svg+xml", "text/html", "application/xhtml+xml":
+		return "application/octet-stream"
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		charset := params["charset"]
+		if charset == "" {
+			charset = "utf-8"
+		}
+		return mime.FormatMediaType(mediaType, map[string]string{"charset": charset})
+	}
+	return mediaType
+}
+
 func (s *APIV1Service) UpdateAttachment(ctx context.Context, request *v1pb.UpdateAttachmentRequest) (*v1pb.Attachment, error) {
 	attachmentUID, err := ExtractAttachmentUIDFromName(request.Attachment.Name)
 	if err != nil {
@@ -333,15 +354,134 @@ func (s *APIV1Service) DeleteAttachment(ctx context.Context, request *v1pb.Delet
 	if attachment == nil {
 		return nil, status.Errorf(codes.NotFound, "attachment not found")
 	}
+	attachmentMessage := s.convertAttachmentFromStore(ctx, attachment)
 	// Delete the attachment from the database.
 	if err := s.Store.DeleteAttachment(ctx, &store.DeleteAttachment{
 		ID: attachment.ID,
 	}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete attachment: %v", err)
 	}
+	if err := s.publishAttachmentEvent(ctx, EventAttachmentDeleted, attachment, attachmentMessage); err != nil {
+		slog.Warn("Failed to publish attachment deleted event", slog.Any("err", err))
+	}
 	return &emptypb.Empty{}, nil
 }
 
+func (s *APIV1Service) GetAttachmentDeduplicationReport(ctx context.Context, _ *v1pb.GetAttachmentDeduplicationReportRequest) (*v1pb.AttachmentDeduplicationReport, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil || !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	attachmentBlobs, err := s.Store.ListAttachmentBlobs(ctx, &store.FindAttachmentBlob{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list attachment blobs: %v", err)
+	}
+	report := &v1pb.AttachmentDeduplicationReport{}
+	for _, attachmentBlob := range attachmentBlobs {
+		if attachmentBlob.ReferenceCount == 0 {
+			continue
+		}
+		report.UniqueContentCount++
+		report.AttachmentCount += attachmentBlob.ReferenceCount
+		report.AttachmentBytes += attachmentBlob.Size * int64(attachmentBlob.ReferenceCount)
+		report.StoredBytes += attachmentBlob.Size
+	}
+	report.SavedBytes = report.AttachmentBytes - report.StoredBytes
+	return report, nil
+}
+
+func (s *APIV1Service) MigrateAttachmentStorage(ctx context.Context, request *v1pb.MigrateAttachmentStorageRequest) (*v1pb.MigrateAttachmentStorageResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil || !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if request.Source == v1pb.WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED || request.Target == v1pb.WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "source and target storages are required")
+	}
+	if request.Source == request.Target {
+		return nil, status.Errorf(codes.InvalidArgument, "source and target storages are the same")
+	}
+	if request.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative")
+	}
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = DefaultAttachmentStorageMigrationLimit
+	}
+
+	migration, err := s.Store.MigrateAttachmentStorage(ctx, &store.MigrateAttachmentStorage{
+		From:  storepb.WorkspaceStorageSetting_StorageType(request.Source),
+		To:    storepb.WorkspaceStorageSetting_StorageType(request.Target),
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to migrate attachment storage: %v", err)
+	}
+	return &v1pb.MigrateAttachmentStorageResponse{
+		MigratedCount:  int32(migration.MigratedCount),
+		FailedCount:    int32(migration.FailedCount),
+		RemainingCount: int32(migration.RemainingCount),
+	}, nil
+}
+
+func (s *APIV1Service) CleanupOrphanedAttachments(ctx context.Context, request *v1pb.CleanupOrphanedAttachmentsRequest) (*v1pb.CleanupOrphanedAttachmentsResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil || !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	report, err := attachmentgc.NewRunner(s.Store, s.Profile).Collect(ctx, request.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to collect orphaned attachments: %v", err)
+	}
+	response := &v1pb.CleanupOrphanedAttachmentsResponse{}
+	for _, attachment := range report.Attachments {
+		response.Attachments = append(response.Attachments, s.convertAttachmentFromStore(ctx, attachment))
+		response.TotalBytes += attachment.Size
+	}
+	for _, attachmentBlob := range report.AttachmentBlobs {
+		response.ContentHashes = append(response.ContentHashes, attachmentBlob.Hash)
+		response.TotalBytes += attachmentBlob.Size
+	}
+	for _, file := range report.Files {
+		response.Files = append(response.Files, file.Path)
+		response.TotalBytes += file.Size
+	}
+	return response, nil
+}
+
+// publishAttachmentEvent publishes the event of the attachment to the event bus, the creator of the attachment owns the event.
+func (s *APIV1Service) publishAttachmentEvent(ctx context.Context, eventType string, attachment *store.Attachment, attachmentMessage *v1pb.Attachment) error {
+	event := &Event{
+		Type:       eventType,
+		OwnerID:    attachment.CreatorID,
+		Attachment: attachmentMessage,
+	}
+	if attachment.MemoID != nil {
+		memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: attachment.MemoID})
+		if err != nil {
+			return errors.Wrap(err, "failed to get memo")
+		}
+		if memo != nil {
+			if event.Memo, err = s.convertMemoFromStore(ctx, memo); err != nil {
+				return errors.Wrap(err, "failed to convert memo")
+			}
+		}
+	}
+	s.EventBus().Publish(ctx, event)
+	return nil
+}
+
 func (s *APIV1Service) convertAttachmentFromStore(ctx context.Context, attachment *store.Attachment) *v1pb.Attachment {
 	attachmentMessage := &v1pb.Attachment{
 		Name:       fmt.Sprintf("%s%s", AttachmentNamePrefix, attachment.UID),
@@ -362,192 +502,65 @@ func (s *APIV1Service) convertAttachmentFromStore(ctx context.Context, attachmen
 			attachmentMessage.Memo = &memoName
 		}
 	}
+	// The location of the photos is only shown to their creator.
+	userID, _ := ctx.Value(userIDContextKey).(int32)
+	attachmentMessage.ImageMetadata = convertImageMetadataFromStore(attachment.Payload.GetImageMetadata(), userID == attachment.CreatorID)
 
 	return attachmentMessage
 }
 
-// SaveAttachmentBlob save the blob of attachment based on the storage config.
-func SaveAttachmentBlob(ctx context.Context, profile *profile.Profile, stores *store.Store, create *store.Attachment) error {
-	workspaceStorageSetting, err := stores.GetWorkspaceStorageSetting(ctx)
-	if err != nil {
-		return errors.Wrap(err, "Failed to find workspace storage setting")
-	}
-
-	if workspaceStorageSetting.StorageType == storepb.WorkspaceStorageSetting_LOCAL {
-		filepathTemplate := "assets/{timestamp}_{filename}"
-		if workspaceStorageSetting.FilepathTemplate != "" {
-			filepathTemplate = workspaceStorageSetting.FilepathTemplate
-		}
-
-		internalPath := filepathTemplate
-		if !strings.Contains(internalPath, "{filename}") {
-			internalPath = filepath.Join(internalPath, "{filename}")
-		}
-		internalPath = replaceFilenameWithPathTemplate(internalPath, create.Filename)
-		internalPath = filepath.ToSlash(internalPath)
-
-		// Ensure the directory exists.
-		osPath := filepath.FromSlash(internalPath)
-		if !filepath.IsAbs(osPath) {
-			osPath = filepath.Join(profile.Data, osPath)
-		}
-		dir := filepath.Dir(osPath)
-		if err = os.MkdirAll(dir, os.ModePerm); err != nil {
-			return errors.Wrap(err, "Failed to create directory")
-		}
-		dst, err := os.Create(osPath)
-		if err != nil {
-			return errors.Wrap(err, "Failed to create file")
-		}
-		defer dst.Close()
-
-		// Write the blob to the file.
-		if err := os.WriteFile(osPath, create.Blob, 0644); err != nil {
-			return errors.Wrap(err, "Failed to write file")
-		}
-		create.Reference = internalPath
-		create.Blob = nil
-		create.StorageType = storepb.AttachmentStorageType_LOCAL
-	} else if workspaceStorageSetting.StorageType == storepb.WorkspaceStorageSetting_S3 {
-		s3Config := workspaceStorageSetting.S3Config
-		if s3Config == nil {
-			return errors.Errorf("No actived external storage found")
-		}
-		s3Client, err := s3.NewClient(ctx, s3Config)
-		if err != nil {
-			return errors.Wrap(err, "Failed to create s3 client")
-		}
-
-		filepathTemplate := workspaceStorageSetting.FilepathTemplate
-		if !strings.Contains(filepathTemplate, "{filename}") {
-			filepathTemplate = filepath.Join(filepathTemplate, "{filename}")
-		}
-		filepathTemplate = replaceFilenameWithPathTemplate(filepathTemplate, create.Filename)
-		key, err := s3Client.UploadObject(ctx, filepathTemplate, create.Type, bytes.NewReader(create.Blob))
-		if err != nil {
-			return errors.Wrap(err, "Failed to upload via s3 client")
-		}
-		presignURL, err := s3Client.PresignGetObject(ctx, key)
-		if err != nil {
-			return errors.Wrap(err, "Failed to presign via s3 client")
-		}
-
-		create.Reference = presignURL
-		create.Blob = nil
-		create.StorageType = storepb.AttachmentStorageType_S3
-		create.Payload = &storepb.AttachmentPayload{
-			Payload: &storepb.AttachmentPayload_S3Object_{
-				S3Object: &storepb.AttachmentPayload_S3Object{
-					S3Config:          s3Config,
-					Key:               key,
-					LastPresignedTime: timestamppb.New(time.Now()),
-				},
-			},
-		}
+// getUploadSizeLimit returns the maximum size in bytes of the uploaded files.
+func getUploadSizeLimit(workspaceStorageSetting *storepb.WorkspaceStorageSetting) int64 {
+	uploadSizeLimit := int64(workspaceStorageSetting.UploadSizeLimitMb) * MebiByte
+	if uploadSizeLimit == 0 {
+		uploadSizeLimit = MaxUploadBufferSizeBytes
 	}
-
-	return nil
+	return uploadSizeLimit
 }
 
-func (s *APIV1Service) GetAttachmentBlob(attachment *store.Attachment) ([]byte, error) {
-	// For local storage, read the file from the local disk.
-	if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
-		attachmentPath := filepath.FromSlash(attachment.Reference)
-		if !filepath.IsAbs(attachmentPath) {
-			attachmentPath = filepath.Join(s.Profile.Data, attachmentPath)
-		}
-
-		file, err := os.Open(attachmentPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return nil, errors.Wrap(err, "file not found")
-			}
-			return nil, errors.Wrap(err, "failed to open the file")
-		}
-		defer file.Close()
-		blob, err := io.ReadAll(file)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to read the file")
-		}
-		return blob, nil
+// findAttachmentMemo returns the memo an attachment is created for.
+func (s *APIV1Service) findAttachmentMemo(ctx context.Context, memoName string) (*store.Memo, error) {
+	memoUID, err := ExtractMemoUIDFromName(memoName)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
 	}
-	// For database storage, return the blob from the database.
-	return attachment.Blob, nil
-}
-
-const (
-	// thumbnailRatio is the ratio of the thumbnail image.
-	thumbnailRatio = 0.8
-)
-
-// getOrGenerateThumbnail returns the thumbnail image of the attachment.
-func (s *APIV1Service) getOrGenerateThumbnail(attachment *store.Attachment) ([]byte, error) {
-	thumbnailCacheFolder := filepath.Join(s.Profile.Data, ThumbnailCacheFolder)
-	if err := os.MkdirAll(thumbnailCacheFolder, os.ModePerm); err != nil {
-		return nil, errors.Wrap(err, "failed to create thumbnail cache folder")
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find memo: %v", err)
 	}
-	filePath := filepath.Join(thumbnailCacheFolder, fmt.Sprintf("%d%s", attachment.ID, filepath.Ext(attachment.Filename)))
-	if _, err := os.Stat(filePath); err != nil {
-		if !os.IsNotExist(err) {
-			return nil, errors.Wrap(err, "failed to check thumbnail image stat")
-		}
-
-		// If thumbnail image does not exist, generate and save the thumbnail image.
-		blob, err := s.GetAttachmentBlob(attachment)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get attachment blob")
-		}
-		img, err := imaging.Decode(bytes.NewReader(blob), imaging.AutoOrientation(true))
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to decode thumbnail image")
-		}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found: %s", memoName)
+	}
+	return memo, nil
+}
 
-		thumbnailWidth := int(float64(img.Bounds().Dx()) * thumbnailRatio)
-		// Resize the image to the thumbnailWidth.
-		thumbnailImage := imaging.Resize(img, thumbnailWidth, 0, imaging.Lanczos)
-		if err := imaging.Save(thumbnailImage, filePath); err != nil {
-			return nil, errors.Wrap(err, "failed to save thumbnail file")
+// getThumbnailRunner returns the thumbnail runner of the service.
+func (s *APIV1Service) getThumbnailRunner() *thumbnail.Runner {
+	s.thumbnailRunnerOnce.Do(func() {
+		if s.ThumbnailRunner == nil {
+			s.ThumbnailRunner = thumbnail.NewRunner(s.Store, s.Profile)
 		}
-	}
+	})
+	return s.ThumbnailRunner
+}
 
-	thumbnailFile, err := os.Open(filePath)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to open thumbnail file")
+// handleThumbnailEvent schedules generating the thumbnails of the uploaded attachments, and removes the thumbnails
+// of the deleted ones.
+func (s *APIV1Service) handleThumbnailEvent(_ context.Context, event *Event) error {
+	if event.Attachment == nil {
+		return nil
 	}
-	defer thumbnailFile.Close()
-	blob, err := io.ReadAll(thumbnailFile)
+	attachmentUID, err := ExtractAttachmentUIDFromName(event.Attachment.Name)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read thumbnail file")
+		return errors.Wrap(err, "invalid attachment name")
 	}
-	return blob, nil
-}
-
-var fileKeyPattern = regexp.MustCompile(`\{[a-z]{1,9}\}`)
-
-func replaceFilenameWithPathTemplate(path, filename string) string {
-	t := time.Now()
-	path = fileKeyPattern.ReplaceAllStringFunc(path, func(s string) string {
-		switch s {
-		case "{filename}":
-			return filename
-		case "{timestamp}":
-			return fmt.Sprintf("%d", t.Unix())
-		case "{year}":
-			return fmt.Sprintf("%d", t.Year())
-		case "{month}":
-			return fmt.Sprintf("%02d", t.Month())
-		case "{day}":
-			return fmt.Sprintf("%02d", t.Day())
-		case "{hour}":
-			return fmt.Sprintf("%02d", t.Hour())
-		case "{minute}":
-			return fmt.Sprintf("%02d", t.Minute())
-		case "{second}":
-			return fmt.Sprintf("%02d", t.Second())
-		case "{uuid}":
-			return util.GenUUID()
+	switch event.Type {
+	case EventAttachmentUploaded:
+		if thumbnail.IsSupported(event.Attachment.Type) {
+			s.getThumbnailRunner().Enqueue(attachmentUID)
 		}
-		return s
-	})
-	return path
+	case EventAttachmentDeleted:
+		s.getThumbnailRunner().Delete(attachmentUID)
+	}
+	return nil
 }
@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/status"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/thumbnail"
+	"github.com/usememos/memos/store"
+)
+
+// AttachmentDownloadPath is the path of the attachment downloads, it takes precedence over the gateway route of GetAttachmentBinary.
+const AttachmentDownloadPath = "/file/attachments/:uid/:filename"
+
+// RegisterAttachmentDownloadRoutes registers the streaming attachment download endpoint.
+// The downloads support range requests, and conditional requests with the ETag and the Last-Modified time.
+func (s *APIV1Service) RegisterAttachmentDownloadRoutes(echoServer *echo.Echo) {
+	echoServer.GET(AttachmentDownloadPath, s.handleAttachmentDownload)
+	echoServer.HEAD(AttachmentDownloadPath, s.handleAttachmentDownload)
+}
+
+func (s *APIV1Service) handleAttachmentDownload(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.authenticateHTTPRequest(ctx, c.Request())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+	if user != nil {
+		ctx = context.WithValue(ctx, userIDContextKey, user.ID)
+	}
+
+	attachmentUID := c.Param("uid")
+	attachment, err := s.Store.GetAttachment(ctx, &store.FindAttachment{
+		GetBlob: true,
+		UID:     &attachmentUID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get attachment").SetInternal(err)
+	}
+	if attachment == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "attachment not found")
+	}
+	if err := s.checkAttachmentAccess(ctx, attachment); err != nil {
+		st := status.Convert(err)
+		return echo.NewHTTPError(runtime.HTTPStatusFromCode(st.Code()), st.Message())
+	}
+	if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+		return c.Redirect(http.StatusFound, attachment.Reference)
+	}
+
+	header := c.Response().Header()
+	modifiedTime := time.Unix(attachment.UpdatedTs, 0)
+	// The checks of the memo visibility are repeated on revalidation.
+	header.Set(echo.HeaderCacheControl, "private, no-cache")
+	header.Set("X-Content-Type-Options", "nosniff")
+	// The documents rendered inline run sandboxed, without scripts and away from the origin.
+	header.Set("Content-Security-Policy", "sandbox")
+
+	// The thumbnail parameter is the name of a preset, or true for the default preset.
+	if presetName := c.QueryParam("thumbnail"); presetName != "" && thumbnail.IsSupported(attachment.Type) {
+		if presetName == "true" {
+			presetName = thumbnail.DefaultPreset
+		}
+		preset, ok := thumbnail.GetPreset(presetName)
+		if !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("unknown thumbnail preset %q", presetName))
+		}
+		thumbnailBlob, err := s.getThumbnailRunner().GetOrGenerate(ctx, attachment, preset)
+		if err != nil {
+			// The attachment is served in place of the thumbnail.
+			slog.Warn("failed to get attachment thumbnail image", slog.Any("error", err))
+		} else {
+			header.Set(echo.HeaderContentType, thumbnail.GetContentType(attachment))
+			header.Set("ETag", getAttachmentETag(attachment, preset.Name))
+			header.Set(echo.HeaderContentDisposition, getAttachmentContentDisposition(attachment, false))
+			http.ServeContent(c.Response(), c.Request(), "", modifiedTime, bytes.NewReader(thumbnailBlob))
+			return nil
+		}
+	}
+
+	content, err := s.Store.OpenAttachmentContent(ctx, attachment)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get attachment blob").SetInternal(err)
+	}
+	defer content.Close()
+	contentType := getAttachmentContentType(attachment.Type)
+	header.Set(echo.HeaderContentType, contentType)
+	header.Set("ETag", getAttachmentETag(attachment, ""))
+	header.Set(echo.HeaderContentDisposition, getAttachmentContentDisposition(attachment, c.QueryParam("download") == "true" || contentType == echo.MIMEOctetStream))
+	// ServeContent answers the range and conditional requests.
+	http.ServeContent(c.Response(), c.Request(), "", modifiedTime, content)
+	return nil
+}
+
+// getAttachmentETag returns the strong entity tag of the attachment, the variant distinguishes the thumbnails.
+func getAttachmentETag(attachment *store.Attachment, variant string) string {
+	etag := attachment.UID + "-" + strconv.FormatInt(attachment.UpdatedTs, 10) + "-" + strconv.FormatInt(attachment.Size, 10)
+	if variant != "" {
+		etag += "-" + variant
+	}
+	return fmt.Sprintf("%q", etag)
+}
+
+// getAttachmentContentDisposition returns the Content-Disposition header with the encoded filename of the attachment.
+func getAttachmentContentDisposition(attachment *store.Attachment, download bool) string {
+	disposition := "inline"
+	if download {
+		disposition = "attachment"
+	}
+	if contentDisposition := mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}); contentDisposition != "" {
+		return contentDisposition
+	}
+	return disposition
+}
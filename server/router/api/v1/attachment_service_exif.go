@@ -0,0 +1,241 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/plugin/exif"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// exifMimeTypes are the types of the images the EXIF data is read from and stripped of.
+var exifMimeTypes = []string{
+	"image/jpeg",
+	"image/png",
+}
+
+func isExifSupported(attachmentType string) bool {
+	return slices.Contains(exifMimeTypes, strings.ToLower(attachmentType))
+}
+
+// shouldStripExif returns whether the EXIF data of the images attached to the memo is stripped by the storage setting.
+// The memo is nil for the attachments without a memo.
+func shouldStripExif(workspaceStorageSetting *storepb.WorkspaceStorageSetting, memo *store.Memo) bool {
+	switch workspaceStorageSetting.ExifStripPolicy {
+	case storepb.WorkspaceStorageSetting_ALWAYS:
+		return true
+	case storepb.WorkspaceStorageSetting_PUBLIC_MEMOS:
+		return memo != nil && memo.Visibility == store.Public
+	default:
+		return false
+	}
+}
+
+// prepareImageContent reads the image metadata of the uploaded content into the payload of the attachment,
+// and returns the content to store, which is stripped of its EXIF data if required.
+func prepareImageContent(create *store.Attachment, content []byte, strip bool) ([]byte, error) {
+	if !isExifSupported(create.Type) {
+		return content, nil
+	}
+	metadata, err := exif.Extract(content)
+	if err != nil {
+		// The images with malformed EXIF data are rejected, as their EXIF data can't be stripped reliably.
+		if strip {
+			return nil, errors.Wrap(err, "failed to read exif data")
+		}
+		metadata = nil
+	}
+	imageMetadata := buildImageMetadata(content, metadata)
+	if imageMetadata == nil {
+		return content, nil
+	}
+	if strip && imageMetadata.HasExif {
+		if content, err = exif.Strip(content); err != nil {
+			return nil, errors.Wrap(err, "failed to strip exif data")
+		}
+		imageMetadata.HasExif = false
+	}
+	if create.Payload == nil {
+		create.Payload = &storepb.AttachmentPayload{}
+	}
+	create.Payload.ImageMetadata = imageMetadata
+	return content, nil
+}
+
+// buildImageMetadata returns the image metadata of the content, the metadata is nil for the images without EXIF data.
+func buildImageMetadata(content []byte, metadata *exif.Metadata) *storepb.AttachmentPayload_ImageMetadata {
+	imageMetadata := &storepb.AttachmentPayload_ImageMetadata{}
+	if config, _, err := image.DecodeConfig(bytes.NewReader(content)); err == nil {
+		imageMetadata.Width, imageMetadata.Height = int32(config.Width), int32(config.Height)
+	}
+	if metadata == nil {
+		if imageMetadata.Width == 0 {
+			return nil
+		}
+		return imageMetadata
+	}
+
+	imageMetadata.HasExif = true
+	if imageMetadata.Width == 0 {
+		imageMetadata.Width, imageMetadata.Height = int32(metadata.Width), int32(metadata.Height)
+	}
+	// The orientations from 5 to 8 are rotated by 90 degrees, so the image is displayed with the sides swapped.
+	if metadata.Orientation >= 5 {
+		imageMetadata.Width, imageMetadata.Height = imageMetadata.Height, imageMetadata.Width
+	}
+	if !metadata.TakenTime.IsZero() {
+		imageMetadata.TakenTime = timestamppb.New(metadata.TakenTime)
+	}
+	imageMetadata.CameraMake = metadata.Make
+	imageMetadata.CameraModel = metadata.Model
+	imageMetadata.LensModel = metadata.LensModel
+	if metadata.Location != nil {
+		imageMetadata.Location = &storepb.AttachmentPayload_Location{
+			Latitude:  metadata.Location.Latitude,
+			Longitude: metadata.Location.Longitude,
+			Altitude:  metadata.Location.Altitude,
+		}
+	}
+	return imageMetadata
+}
+
+// stripAttachmentExif replaces the stored content of the attachment with the content stripped of its EXIF data.
+func (s *APIV1Service) stripAttachmentExif(ctx context.Context, attachment *store.Attachment) error {
+	reader, err := s.Store.OpenAttachmentContent(ctx, attachment)
+	if err != nil {
+		return errors.Wrap(err, "failed to open attachment content")
+	}
+	content, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to read attachment content")
+	}
+	content, err = exif.Strip(content)
+	if err != nil {
+		return errors.Wrap(err, "failed to strip exif data")
+	}
+	attachment.Payload.ImageMetadata.HasExif = false
+	if attachment.ContentHash == "" {
+		return s.Store.ReplaceAttachmentContent(ctx, attachment, content)
+	}
+
+	// The content may be shared with other attachments, so the stripped content is stored as another content.
+	replacement := &store.Attachment{
+		UID:      attachment.UID,
+		Filename: attachment.Filename,
+		Type:     attachment.Type,
+		Size:     int64(len(content)),
+		Payload:  attachment.Payload,
+	}
+	if err := s.Store.SaveAttachmentContent(ctx, replacement, bytes.NewReader(content)); err != nil {
+		return errors.Wrap(err, "failed to save attachment content")
+	}
+	updatedTs := time.Now().Unix()
+	return s.Store.UpdateAttachment(ctx, &store.UpdateAttachment{
+		ID:          attachment.ID,
+		UpdatedTs:   &updatedTs,
+		StorageType: &replacement.StorageType,
+		Reference:   &replacement.Reference,
+		ContentHash: &replacement.ContentHash,
+		Payload:     replacement.Payload,
+		Blob:        replacement.Blob,
+		Size:        &replacement.Size,
+	})
+}
+
+// stripMemoAttachmentsExif strips the EXIF data of the images attached to the memo if required by the storage setting.
+func (s *APIV1Service) stripMemoAttachmentsExif(ctx context.Context, memo *store.Memo) error {
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	if !shouldStripExif(workspaceStorageSetting, memo) {
+		return nil
+	}
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{MemoID: &memo.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to list attachments")
+	}
+	for _, attachment := range attachments {
+		if !attachment.Payload.GetImageMetadata().GetHasExif() || attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+			continue
+		}
+		if err := s.stripAttachmentExif(ctx, attachment); err != nil {
+			return errors.Wrapf(err, "failed to strip exif data of attachment %s", attachment.UID)
+		}
+	}
+	return nil
+}
+
+// setMemoLocationFromImage sets the location of the memo to where the photo was taken, if the memo has no location yet.
+func (s *APIV1Service) setMemoLocationFromImage(ctx context.Context, memo *store.Memo, location *storepb.AttachmentPayload_Location) error {
+	if location == nil || memo.Payload.GetLocation() != nil {
+		return nil
+	}
+	payload := &storepb.MemoPayload{}
+	if memo.Payload != nil {
+		payload = memo.Payload
+	}
+	payload.Location = &storepb.MemoPayload_Location{
+		Placeholder: fmt.Sprintf("%.6f, %.6f", location.Latitude, location.Longitude),
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+	}
+	if err := s.Store.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Payload: payload}); err != nil {
+		return errors.Wrap(err, "failed to update memo")
+	}
+	memo.Payload = payload
+	return nil
+}
+
+// stripMemoImageLocations returns a copy of the memo without the locations of the images of its attachments.
+// The memo is returned as is when none of its images has a location.
+func stripMemoImageLocations(memo *v1pb.Memo) *v1pb.Memo {
+	if !slices.ContainsFunc(memo.GetAttachments(), func(attachment *v1pb.Attachment) bool {
+		return attachment.GetImageMetadata().GetLocation() != nil
+	}) {
+		return memo
+	}
+	memo = proto.Clone(memo).(*v1pb.Memo)
+	for _, attachment := range memo.Attachments {
+		if attachment.ImageMetadata != nil {
+			attachment.ImageMetadata.Location = nil
+		}
+	}
+	return memo
+}
+
+// convertImageMetadataFromStore converts the image metadata, the location is only included for the creator of the attachment.
+func convertImageMetadataFromStore(imageMetadata *storepb.AttachmentPayload_ImageMetadata, includeLocation bool) *v1pb.ImageMetadata {
+	if imageMetadata == nil {
+		return nil
+	}
+	imageMetadataMessage := &v1pb.ImageMetadata{
+		Width:       imageMetadata.Width,
+		Height:      imageMetadata.Height,
+		TakenTime:   imageMetadata.TakenTime,
+		CameraMake:  imageMetadata.CameraMake,
+		CameraModel: imageMetadata.CameraModel,
+		LensModel:   imageMetadata.LensModel,
+	}
+	if includeLocation && imageMetadata.Location != nil {
+		imageMetadataMessage.Location = &v1pb.ImageMetadata_Location{
+			Latitude:  imageMetadata.Location.Latitude,
+			Longitude: imageMetadata.Location.Longitude,
+			Altitude:  imageMetadata.Location.Altitude,
+		}
+	}
+	return imageMetadataMessage
+}
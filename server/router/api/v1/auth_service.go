@@ -155,6 +155,7 @@ func (s *APIV1Service) CreateSession(ctx context.Context, request *v1pb.CreateSe
 			if err != nil {
 				return nil, status.Errorf(codes.Internal, "failed to create user, error: %v", err)
 			}
+			s.publishUserEvent(ctx, EventUserCreated, user)
 		}
 		existingUser = user
 	}
@@ -174,6 +175,7 @@ func (s *APIV1Service) CreateSession(ctx context.Context, request *v1pb.CreateSe
 	if err := s.doSignIn(ctx, existingUser, expireTime); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to sign in, error: %v", err)
 	}
+	s.publishUserEvent(ctx, EventUserSignedIn, existingUser)
 	return convertUserFromStore(existingUser), nil
 }
 
@@ -247,6 +249,7 @@ func (s *APIV1Service) SignUp(ctx context.Context, request *v1pb.SignUpRequest)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create user, error: %v", err)
 	}
+	s.publishUserEvent(ctx, EventUserCreated, user)
 
 	if err := s.doSignIn(ctx, user, time.Now().Add(AccessTokenDuration)); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to sign in, error: %v", err)
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"encoding/base64"
+	"strings"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -68,3 +69,24 @@ func unmarshalPageToken(s string, pageToken *v1pb.PageToken) error {
 func isSuperUser(user *store.User) bool {
 	return user.Role == store.RoleAdmin || user.Role == store.RoleHost
 }
+
+// normalizeMemoTags strips the leading hashes of the tags and joins their words with dashes, dropping empty tags.
+func normalizeMemoTags(tags []string) []string {
+	list := []string{}
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.TrimLeft(strings.TrimSpace(tag), "#")), "-")
+		if tag != "" {
+			list = append(list, tag)
+		}
+	}
+	return list
+}
+
+// buildMemoTagLine renders the tags as a line of hashtags, e.g. "#ci #deploy".
+func buildMemoTagLine(tags []string) string {
+	list := normalizeMemoTags(tags)
+	for i, tag := range list {
+		list[i] = "#" + tag
+	}
+	return strings.Join(list, " ")
+}
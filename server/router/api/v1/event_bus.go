@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// The types of the events published to the event bus.
+const (
+	// EventMemoCreated is the event type of created memos.
+	EventMemoCreated = "memos.memo.created"
+	// EventMemoUpdated is the event type of updated memos.
+	EventMemoUpdated = "memos.memo.updated"
+	// EventMemoDeleted is the event type of deleted memos.
+	EventMemoDeleted = "memos.memo.deleted"
+	// EventCommentCreated is the event type of comments created on memos.
+	EventCommentCreated = "memos.comment.created"
+	// EventReactionAdded is the event type of reactions added to memos.
+	EventReactionAdded = "memos.reaction.added"
+	// EventReactionRemoved is the event type of reactions removed from memos.
+	EventReactionRemoved = "memos.reaction.removed"
+	// EventAttachmentUploaded is the event type of uploaded attachments.
+	EventAttachmentUploaded = "memos.attachment.uploaded"
+	// EventAttachmentDeleted is the event type of deleted attachments.
+	EventAttachmentDeleted = "memos.attachment.deleted"
+	// EventMemoRelationChanged is the event type of memos whose relations are set.
+	EventMemoRelationChanged = "memos.memo.relation.changed"
+	// EventUserCreated is the event type of created users.
+	EventUserCreated = "memos.user.created"
+	// EventUserSignedIn is the event type of users signing in.
+	EventUserSignedIn = "memos.user.signed_in"
+	// EventInboxCreated is the event type of inbox notifications, only their receivers watch them.
+	EventInboxCreated = "memos.inbox.created"
+)
+
+// webhookEventTypes are the event types webhooks can subscribe to, in the order they are documented.
+var webhookEventTypes = []string{
+	EventMemoCreated,
+	EventMemoUpdated,
+	EventMemoDeleted,
+	EventCommentCreated,
+	EventReactionAdded,
+	EventReactionRemoved,
+	EventAttachmentUploaded,
+	EventAttachmentDeleted,
+	EventMemoRelationChanged,
+	EventUserCreated,
+	EventUserSignedIn,
+}
+
+// Event is a change published to the event bus.
+type Event struct {
+	Type       string
+	CreateTime time.Time
+	// ActorID is the id of the user that caused the event, it is zero for anonymous actions.
+	ActorID int32
+	// OwnerID is the id of the user the event belongs to, e.g. the creator of the memo.
+	// The webhooks of the owner receive the event.
+	OwnerID int32
+
+	// Memo is the memo the event is about, it is nil for events without a memo.
+	Memo *v1pb.Memo
+	// The event specific data, at most one of them is set.
+	Comment    *v1pb.Memo
+	Reaction   *v1pb.Reaction
+	Attachment *v1pb.Attachment
+	Relations  []*v1pb.MemoRelation
+	User       *v1pb.User
+	Inbox      *v1pb.Inbox
+}
+
+// EventHandler handles the events published to the event bus.
+type EventHandler func(ctx context.Context, event *Event) error
+
+// EventBus delivers the published events to the subscribed handlers.
+type EventBus struct {
+	mutex    sync.RWMutex
+	handlers []EventHandler
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{}
+}
+
+// Subscribe registers the handler for all the events published after it.
+func (b *EventBus) Subscribe(handler EventHandler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish calls the handlers with the event in the order they subscribed.
+// The failures of handlers are logged, they don't fail the action that published the event.
+func (b *EventBus) Publish(ctx context.Context, event *Event) {
+	if event.CreateTime.IsZero() {
+		event.CreateTime = time.Now()
+	}
+	if event.ActorID == 0 {
+		event.ActorID, _ = ctx.Value(userIDContextKey).(int32)
+	}
+	b.mutex.RLock()
+	handlers := b.handlers
+	b.mutex.RUnlock()
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			slog.Warn("failed to handle event", slog.String("type", event.Type), slog.Any("err", err))
+		}
+	}
+}
+
+// EventBus returns the event bus of the service.
+// The webhooks, the activity log, the thumbnails and the event streams are subscribed to it when it is first used.
+func (s *APIV1Service) EventBus() *EventBus {
+	s.eventBusOnce.Do(func() {
+		s.eventBus = NewEventBus()
+		s.eventBus.Subscribe(s.handleWebhookEvent)
+		s.eventBus.Subscribe(s.handleActivityEvent)
+		s.eventBus.Subscribe(s.handleThumbnailEvent)
+		s.eventHistory = newEventHistory(eventHistorySize)
+		s.eventBus.Subscribe(s.eventHistory.handleEvent)
+	})
+	return s.eventBus
+}
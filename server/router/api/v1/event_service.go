@@ -0,0 +1,383 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// EventStreamPath is the path of the server-sent events endpoint of the event streams.
+	EventStreamPath = "/api/v1/events/stream"
+	// EventHeartbeat is the type of the heartbeats sent to idle event streams.
+	EventHeartbeat = "memos.heartbeat"
+
+	// eventHistorySize is the number of recent events kept to resume event streams from.
+	eventHistorySize = 1024
+	// eventHeartbeatInterval is the interval of the heartbeats of idle event streams.
+	eventHeartbeatInterval = 30 * time.Second
+)
+
+// watchEventTypes are the event types that can be watched.
+var watchEventTypes = []string{
+	EventMemoCreated,
+	EventMemoUpdated,
+	EventMemoDeleted,
+	EventCommentCreated,
+	EventReactionAdded,
+	EventReactionRemoved,
+	EventInboxCreated,
+}
+
+var errResumeTokenExpired = errors.New("resume token expired")
+
+type sequencedEvent struct {
+	sequence uint64
+	event    *Event
+}
+
+// eventHistory keeps the recent events to resume event streams from, and wakes up the watchers of new events.
+type eventHistory struct {
+	mutex sync.Mutex
+	// epoch identifies the history in resume tokens, so that the tokens of previous processes are rejected.
+	epoch    int64
+	size     int
+	sequence uint64
+	events   []sequencedEvent
+	watchers map[chan struct{}]struct{}
+	// done is closed when the server shuts down, to end the event streams.
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newEventHistory(size int) *eventHistory {
+	return &eventHistory{
+		epoch:    time.Now().UnixNano(),
+		size:     size,
+		watchers: map[chan struct{}]struct{}{},
+		done:     make(chan struct{}),
+	}
+}
+
+func (h *eventHistory) close() {
+	h.closeOnce.Do(func() {
+		close(h.done)
+	})
+}
+
+func (h *eventHistory) handleEvent(_ context.Context, event *Event) error {
+	if !slices.Contains(watchEventTypes, event.Type) {
+		return nil
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.sequence++
+	h.events = append(h.events, sequencedEvent{sequence: h.sequence, event: event})
+	if len(h.events) > h.size {
+		h.events = h.events[len(h.events)-h.size:]
+	}
+	for watcher := range h.watchers {
+		// The watcher is already woken up if its channel is full.
+		select {
+		case watcher <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// watch returns a channel that receives a value when new events are added, and the function to stop watching.
+func (h *eventHistory) watch() (<-chan struct{}, func()) {
+	watcher := make(chan struct{}, 1)
+	h.mutex.Lock()
+	h.watchers[watcher] = struct{}{}
+	h.mutex.Unlock()
+	return watcher, func() {
+		h.mutex.Lock()
+		delete(h.watchers, watcher)
+		h.mutex.Unlock()
+	}
+}
+
+// since returns the events after the sequence, it fails if some of them are not kept anymore.
+func (h *eventHistory) since(sequence uint64) ([]sequencedEvent, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if sequence > h.sequence {
+		return nil, errResumeTokenExpired
+	}
+	if len(h.events) > 0 && h.events[0].sequence > sequence+1 {
+		return nil, errResumeTokenExpired
+	}
+	index := sort.Search(len(h.events), func(i int) bool {
+		return h.events[i].sequence > sequence
+	})
+	return slices.Clone(h.events[index:]), nil
+}
+
+func (h *eventHistory) getResumeToken(sequence uint64) string {
+	return fmt.Sprintf("%d-%d", h.epoch, sequence)
+}
+
+// parseResumeToken returns the sequence of the token, an empty token resumes from the latest event.
+func (h *eventHistory) parseResumeToken(token string) (uint64, error) {
+	if token == "" {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+		return h.sequence, nil
+	}
+	epochStr, sequenceStr, ok := strings.Cut(token, "-")
+	if !ok {
+		return 0, errors.Errorf("invalid resume token %q", token)
+	}
+	epoch, err := strconv.ParseInt(epochStr, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid resume token %q", token)
+	}
+	sequence, err := strconv.ParseUint(sequenceStr, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid resume token %q", token)
+	}
+	if epoch != h.epoch {
+		return 0, errResumeTokenExpired
+	}
+	return sequence, nil
+}
+
+// eventStream sends the events after its sequence that the viewer is allowed to see.
+type eventStream struct {
+	history    *eventHistory
+	viewer     *store.User
+	eventTypes []string
+	sequence   uint64
+	watcher    <-chan struct{}
+	stop       func()
+}
+
+// openEventStream validates the request and starts watching the events, the stream must be closed.
+func (s *APIV1Service) openEventStream(viewer *store.User, request *v1pb.WatchEventsRequest) (*eventStream, error) {
+	for _, eventType := range request.EventTypes {
+		if !slices.Contains(watchEventTypes, eventType) {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported event type %q", eventType)
+		}
+	}
+	s.EventBus()
+	history := s.eventHistory
+	select {
+	case <-history.done:
+		return nil, status.Errorf(codes.Unavailable, "server is shutting down")
+	default:
+	}
+	// Watch before reading the history so that no event is missed in between.
+	watcher, stop := history.watch()
+	sequence, err := history.parseResumeToken(request.ResumeToken)
+	if err == nil {
+		_, err = history.since(sequence)
+	}
+	if err != nil {
+		stop()
+		if errors.Is(err, errResumeTokenExpired) {
+			return nil, status.Errorf(codes.OutOfRange, "%v", err)
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return &eventStream{
+		history:    history,
+		viewer:     viewer,
+		eventTypes: request.EventTypes,
+		sequence:   sequence,
+		watcher:    watcher,
+		stop:       stop,
+	}, nil
+}
+
+func (st *eventStream) close() {
+	st.stop()
+}
+
+// run sends the events until the context is done, with heartbeats when the stream is idle.
+func (st *eventStream) run(ctx context.Context, send func(*v1pb.WatchEventsResponse) error) error {
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		events, err := st.history.since(st.sequence)
+		if err != nil {
+			// The stream fell too far behind the events.
+			return status.Errorf(codes.OutOfRange, "%v", err)
+		}
+		for _, sequenced := range events {
+			st.sequence = sequenced.sequence
+			if !canWatchEvent(st.viewer, st.eventTypes, sequenced.event) {
+				continue
+			}
+			if err := send(convertEventToWatchEventsResponse(st.viewer, sequenced.event, st.history.getResumeToken(sequenced.sequence))); err != nil {
+				return err
+			}
+			heartbeat.Reset(eventHeartbeatInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-st.history.done:
+			return status.Errorf(codes.Unavailable, "server is shutting down")
+		case <-st.watcher:
+		case <-heartbeat.C:
+			if err := send(&v1pb.WatchEventsResponse{
+				Type:       EventHeartbeat,
+				CreateTime: timestamppb.Now(),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *APIV1Service) WatchEvents(request *v1pb.WatchEventsRequest, stream grpc.ServerStreamingServer[v1pb.WatchEventsResponse]) error {
+	ctx := stream.Context()
+	viewer, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	eventStream, err := s.openEventStream(viewer, request)
+	if err != nil {
+		return err
+	}
+	defer eventStream.close()
+	return eventStream.run(ctx, stream.Send)
+}
+
+// Shutdown ends the event streams, which would otherwise keep the servers from shutting down gracefully.
+func (s *APIV1Service) Shutdown() {
+	s.EventBus()
+	s.eventHistory.close()
+}
+
+// RegisterEventRoutes registers the server-sent events endpoint of the event streams for browsers.
+func (s *APIV1Service) RegisterEventRoutes(echoServer *echo.Echo) {
+	echoServer.GET(EventStreamPath, s.handleEventStream)
+}
+
+// handleEventStream streams the events as server-sent events, the ids of the events are their resume tokens.
+// The query parameters are `resume_token` and the comma separated `event_types`.
+func (s *APIV1Service) handleEventStream(c echo.Context) error {
+	ctx := c.Request().Context()
+	viewer, err := s.authenticateHTTPRequest(ctx, c.Request())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+	request := &v1pb.WatchEventsRequest{
+		ResumeToken: c.QueryParam("resume_token"),
+	}
+	// Browsers send the id of the last received event when they reconnect.
+	if lastEventID := c.Request().Header.Get("Last-Event-ID"); lastEventID != "" {
+		request.ResumeToken = lastEventID
+	}
+	if eventTypes := c.QueryParam("event_types"); eventTypes != "" {
+		request.EventTypes = strings.Split(eventTypes, ",")
+	}
+	eventStream, err := s.openEventStream(viewer, request)
+	if err != nil {
+		st := status.Convert(err)
+		return echo.NewHTTPError(runtime.HTTPStatusFromCode(st.Code()), st.Message())
+	}
+	defer eventStream.close()
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.Header().Set(echo.HeaderConnection, "keep-alive")
+	// Disable the response buffering of reverse proxies such as nginx.
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	err = eventStream.run(ctx, func(event *v1pb.WatchEventsResponse) error {
+		data, err := protojson.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if event.ResumeToken != "" {
+			fmt.Fprintf(response, "id: %s\n", event.ResumeToken)
+		}
+		if _, err := fmt.Fprintf(response, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+			return err
+		}
+		response.Flush()
+		return nil
+	})
+	if st, ok := status.FromError(err); ok && err != nil {
+		// Tell the client to reload its data, as the headers are already sent.
+		fmt.Fprintf(response, "event: error\ndata: {\"code\":%d,\"message\":%q}\n\n", st.Code(), st.Message())
+		response.Flush()
+	}
+	return nil
+}
+
+// canWatchEvent returns whether the viewer is subscribed to the event and allowed to see it.
+// Inbox events are only seen by their receivers, the other events by the viewers of their memos.
+func canWatchEvent(viewer *store.User, eventTypes []string, event *Event) bool {
+	if len(eventTypes) > 0 && !slices.Contains(eventTypes, event.Type) {
+		return false
+	}
+	if event.Type == EventInboxCreated {
+		return viewer != nil && viewer.ID == event.OwnerID
+	}
+	for _, memo := range []*v1pb.Memo{event.Memo, event.Comment} {
+		if memo == nil || memo.Visibility == v1pb.Visibility_PUBLIC {
+			continue
+		}
+		if viewer == nil {
+			return false
+		}
+		if memo.Visibility == v1pb.Visibility_PRIVATE && memo.Creator != fmt.Sprintf("%s%d", UserNamePrefix, viewer.ID) {
+			return false
+		}
+	}
+	return true
+}
+
+// convertEventToWatchEventsResponse converts the event for the viewer.
+// The memos of the events are converted for their actors, so the locations of their images are only kept for the actors.
+func convertEventToWatchEventsResponse(viewer *store.User, event *Event, resumeToken string) *v1pb.WatchEventsResponse {
+	memo, comment := event.Memo, event.Comment
+	if event.ActorID == 0 || viewer == nil || viewer.ID != event.ActorID {
+		memo, comment = stripMemoImageLocations(memo), stripMemoImageLocations(comment)
+	}
+	response := &v1pb.WatchEventsResponse{
+		Type:        event.Type,
+		ResumeToken: resumeToken,
+		CreateTime:  timestamppb.New(event.CreateTime),
+		Memo:        memo,
+	}
+	if event.ActorID != 0 {
+		response.Actor = fmt.Sprintf("%s%d", UserNamePrefix, event.ActorID)
+	}
+	switch {
+	case comment != nil:
+		response.Event = &v1pb.WatchEventsResponse_Comment{Comment: comment}
+	case event.Reaction != nil:
+		response.Event = &v1pb.WatchEventsResponse_Reaction{Reaction: event.Reaction}
+	case event.Inbox != nil:
+		response.Event = &v1pb.WatchEventsResponse_Inbox{Inbox: event.Inbox}
+	}
+	return response
+}
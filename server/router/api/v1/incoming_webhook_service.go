@@ -0,0 +1,375 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/util"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// IncomingWebhookPathPrefix is the path prefix that incoming webhooks are posted to.
+	IncomingWebhookPathPrefix = "/hooks/in/"
+	// incomingWebhookTokenLength is the length of the secret token of an incoming webhook.
+	incomingWebhookTokenLength = 32
+	// incomingWebhookMaxBodySize is the maximum size of a request body posted to an incoming webhook.
+	incomingWebhookMaxBodySize = 1 << 20
+)
+
+// incomingWebhookTemplateData is the data that the template of an incoming webhook is executed with.
+type incomingWebhookTemplateData struct {
+	// Body is the raw request body.
+	Body string
+	// Data is the decoded JSON or form body, or nil for plain text.
+	Data any
+}
+
+func (s *APIV1Service) CreateIncomingWebhook(ctx context.Context, request *v1pb.CreateIncomingWebhookRequest) (*v1pb.IncomingWebhook, error) {
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if request.IncomingWebhook == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "incoming webhook is required")
+	}
+	displayName := strings.TrimSpace(request.IncomingWebhook.DisplayName)
+	if displayName == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "display name is required")
+	}
+	if _, err := parseIncomingWebhookTemplate(request.IncomingWebhook.Template); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid template: %v", err)
+	}
+
+	token, err := util.RandomString(incomingWebhookTokenLength)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate token: %v", err)
+	}
+	incomingWebhook, err := s.Store.CreateIncomingWebhook(ctx, &store.IncomingWebhook{
+		CreatorID: currentUser.ID,
+		Name:      displayName,
+		Token:     token,
+		Payload: &storepb.IncomingWebhookPayload{
+			Tags:       normalizeMemoTags(request.IncomingWebhook.Tags),
+			Visibility: convertVisibilityToStore(request.IncomingWebhook.Visibility).String(),
+			Template:   request.IncomingWebhook.Template,
+		},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create incoming webhook: %v", err)
+	}
+	return convertIncomingWebhookFromStore(incomingWebhook), nil
+}
+
+func (s *APIV1Service) ListIncomingWebhooks(ctx context.Context, _ *v1pb.ListIncomingWebhooksRequest) (*v1pb.ListIncomingWebhooksResponse, error) {
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	incomingWebhooks, err := s.Store.ListIncomingWebhooks(ctx, &store.FindIncomingWebhook{
+		CreatorID: &currentUser.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list incoming webhooks: %v", err)
+	}
+
+	response := &v1pb.ListIncomingWebhooksResponse{
+		IncomingWebhooks: []*v1pb.IncomingWebhook{},
+	}
+	for _, incomingWebhook := range incomingWebhooks {
+		response.IncomingWebhooks = append(response.IncomingWebhooks, convertIncomingWebhookFromStore(incomingWebhook))
+	}
+	return response, nil
+}
+
+func (s *APIV1Service) GetIncomingWebhook(ctx context.Context, request *v1pb.GetIncomingWebhookRequest) (*v1pb.IncomingWebhook, error) {
+	incomingWebhook, err := s.getIncomingWebhookOfCurrentUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return convertIncomingWebhookFromStore(incomingWebhook), nil
+}
+
+func (s *APIV1Service) UpdateIncomingWebhook(ctx context.Context, request *v1pb.UpdateIncomingWebhookRequest) (*v1pb.IncomingWebhook, error) {
+	if request.IncomingWebhook == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "incoming webhook is required")
+	}
+	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "update_mask is required")
+	}
+
+	incomingWebhook, err := s.getIncomingWebhookOfCurrentUser(ctx, request.IncomingWebhook.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &store.UpdateIncomingWebhook{
+		ID: incomingWebhook.ID,
+	}
+	payload := proto.Clone(incomingWebhook.Payload).(*storepb.IncomingWebhookPayload)
+	for _, field := range request.UpdateMask.Paths {
+		switch field {
+		case "display_name":
+			displayName := strings.TrimSpace(request.IncomingWebhook.DisplayName)
+			if displayName == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "display name is required")
+			}
+			update.Name = &displayName
+		case "tags":
+			payload.Tags = normalizeMemoTags(request.IncomingWebhook.Tags)
+			update.Payload = payload
+		case "visibility":
+			payload.Visibility = convertVisibilityToStore(request.IncomingWebhook.Visibility).String()
+			update.Payload = payload
+		case "template":
+			if _, err := parseIncomingWebhookTemplate(request.IncomingWebhook.Template); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid template: %v", err)
+			}
+			payload.Template = request.IncomingWebhook.Template
+			update.Payload = payload
+		case "path":
+			// Updating the path rotates the secret token.
+			token, err := util.RandomString(incomingWebhookTokenLength)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to generate token: %v", err)
+			}
+			update.Token = &token
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update path: %s", field)
+		}
+	}
+
+	incomingWebhook, err = s.Store.UpdateIncomingWebhook(ctx, update)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update incoming webhook: %v", err)
+	}
+	return convertIncomingWebhookFromStore(incomingWebhook), nil
+}
+
+func (s *APIV1Service) DeleteIncomingWebhook(ctx context.Context, request *v1pb.DeleteIncomingWebhookRequest) (*emptypb.Empty, error) {
+	incomingWebhook, err := s.getIncomingWebhookOfCurrentUser(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteIncomingWebhook(ctx, &store.DeleteIncomingWebhook{
+		ID: incomingWebhook.ID,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete incoming webhook: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *APIV1Service) getIncomingWebhookOfCurrentUser(ctx context.Context, name string) (*store.IncomingWebhook, error) {
+	incomingWebhookID, err := ExtractIncomingWebhookIDFromName(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid incoming webhook name: %v", err)
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	incomingWebhook, err := s.Store.GetIncomingWebhook(ctx, &store.FindIncomingWebhook{
+		ID:        &incomingWebhookID,
+		CreatorID: &currentUser.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get incoming webhook: %v", err)
+	}
+	if incomingWebhook == nil {
+		return nil, status.Errorf(codes.NotFound, "incoming webhook not found")
+	}
+	return incomingWebhook, nil
+}
+
+// RegisterIncomingWebhookRoutes registers the public endpoint that incoming webhooks are posted to.
+// The secret token in the path authenticates the request, so no access token is required.
+func (s *APIV1Service) RegisterIncomingWebhookRoutes(echoServer *echo.Echo) {
+	echoServer.POST(IncomingWebhookPathPrefix+":token", s.handleIncomingWebhook)
+}
+
+func (s *APIV1Service) handleIncomingWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+	token := c.Param("token")
+	incomingWebhook, err := s.Store.GetIncomingWebhook(ctx, &store.FindIncomingWebhook{
+		Token: &token,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find incoming webhook").SetInternal(err)
+	}
+	if incomingWebhook == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Incoming webhook not found")
+	}
+	creator, err := s.Store.GetUser(ctx, &store.FindUser{
+		ID: &incomingWebhook.CreatorID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	if creator == nil || creator.RowStatus == store.Archived {
+		return echo.NewHTTPError(http.StatusNotFound, "Incoming webhook not found")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, incomingWebhookMaxBodySize+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body").SetInternal(err)
+	}
+	if len(body) > incomingWebhookMaxBodySize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Request body too large")
+	}
+	data, err := decodeIncomingWebhookBody(c.Request().Header.Get(echo.HeaderContentType), body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Failed to decode request body: %v", err))
+	}
+	content, err := renderIncomingWebhookContent(incomingWebhook.Payload, &incomingWebhookTemplateData{
+		Body: string(body),
+		Data: data,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Failed to render memo content: %v", err))
+	}
+	if content == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Memo content is empty")
+	}
+
+	// Memos are created on behalf of the creator of the incoming webhook.
+	memo, err := s.CreateMemo(context.WithValue(ctx, userIDContextKey, creator.ID), &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{
+			Content:    content,
+			Visibility: convertVisibilityFromStore(store.Visibility(incomingWebhook.Payload.Visibility)),
+		},
+	})
+	if err != nil {
+		st := status.Convert(err)
+		return echo.NewHTTPError(runtime.HTTPStatusFromCode(st.Code()), st.Message())
+	}
+	return c.JSON(http.StatusCreated, map[string]string{
+		"name": memo.Name,
+	})
+}
+
+// decodeIncomingWebhookBody decodes JSON and form bodies by their content type.
+// Any other body is treated as plain text, for which nil is returned.
+func decodeIncomingWebhookBody(contentType string, body []byte) (any, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, nil
+	}
+	switch {
+	case mediaType == echo.MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json"):
+		var data any
+		if err := json.Unmarshal(body, &data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	case mediaType == echo.MIMEApplicationForm:
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		data := map[string]any{}
+		for key, value := range values {
+			data[key] = value[0]
+		}
+		return data, nil
+	default:
+		return nil, nil
+	}
+}
+
+// renderIncomingWebhookContent renders the memo content with the template of the incoming webhook and
+// appends its tags. Without a template, the "content" or "text" field of a decoded body is used,
+// falling back to the whole body.
+func renderIncomingWebhookContent(payload *storepb.IncomingWebhookPayload, data *incomingWebhookTemplateData) (string, error) {
+	var content string
+	if payload.Template != "" {
+		tmpl, err := parseIncomingWebhookTemplate(payload.Template)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		content = buf.String()
+	} else {
+		content = getDefaultIncomingWebhookContent(data)
+	}
+
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", nil
+	}
+	if tagLine := buildMemoTagLine(payload.Tags); tagLine != "" {
+		content = content + "\n\n" + tagLine
+	}
+	return content, nil
+}
+
+func getDefaultIncomingWebhookContent(data *incomingWebhookTemplateData) string {
+	switch value := data.Data.(type) {
+	case nil:
+		return data.Body
+	case map[string]any:
+		for _, key := range []string{"content", "text"} {
+			if text, ok := value[key].(string); ok && strings.TrimSpace(text) != "" {
+				return text
+			}
+		}
+	}
+
+	// Render the decoded fields as a readable code block.
+	formatted, err := json.MarshalIndent(data.Data, "", "  ")
+	if err != nil {
+		return data.Body
+	}
+	return "```json\n" + string(formatted) + "\n```"
+}
+
+func parseIncomingWebhookTemplate(text string) (*template.Template, error) {
+	return template.New("incoming_webhook").Option("missingkey=zero").Parse(text)
+}
+
+func convertIncomingWebhookFromStore(incomingWebhook *store.IncomingWebhook) *v1pb.IncomingWebhook {
+	return &v1pb.IncomingWebhook{
+		Name:        fmt.Sprintf("%s%d", IncomingWebhookNamePrefix, incomingWebhook.ID),
+		DisplayName: incomingWebhook.Name,
+		Creator:     fmt.Sprintf("%s%d", UserNamePrefix, incomingWebhook.CreatorID),
+		Tags:        incomingWebhook.Payload.Tags,
+		Visibility:  convertVisibilityFromStore(store.Visibility(incomingWebhook.Payload.Visibility)),
+		Template:    incomingWebhook.Payload.Template,
+		Path:        IncomingWebhookPathPrefix + incomingWebhook.Token,
+		CreateTime:  timestamppb.New(time.Unix(incomingWebhook.CreatedTs, 0)),
+		UpdateTime:  timestamppb.New(time.Unix(incomingWebhook.UpdatedTs, 0)),
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -53,6 +54,8 @@ func (s *APIV1Service) SetMemoAttachments(ctx context.Context, request *v1pb.Set
 	}
 
 	slices.Reverse(request.Attachments)
+	// The attachments are reversed, so the location of the first photo is the last one found.
+	var location *storepb.AttachmentPayload_Location
 	// Update attachments' memo_id in the request.
 	for index, attachment := range request.Attachments {
 		attachmentUID, err := ExtractAttachmentUIDFromName(attachment.Name)
@@ -71,8 +74,19 @@ func (s *APIV1Service) SetMemoAttachments(ctx context.Context, request *v1pb.Set
 		}); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to update attachment: %v", err)
 		}
+		if imageLocation := tempAttachment.Payload.GetImageMetadata().GetLocation(); imageLocation != nil {
+			location = imageLocation
+		}
 	}
 
+	if request.SetMemoLocation {
+		if err := s.setMemoLocationFromImage(ctx, memo, location); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to set memo location: %v", err)
+		}
+	}
+	if err := s.stripMemoAttachmentsExif(ctx, memo); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to strip exif data: %v", err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
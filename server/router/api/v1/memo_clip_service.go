@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/plugin/httpgetter"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func (s *APIV1Service) ClipUrl(ctx context.Context, request *v1pb.ClipUrlRequest) (*v1pb.Memo, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+
+	clipURL := strings.TrimSpace(request.Url)
+	if clipURL == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "url is required")
+	}
+	article, err := httpgetter.GetArticle(clipURL)
+	if err != nil {
+		if errors.Is(err, httpgetter.ErrInternalIP) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid url: %v", err)
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to fetch article: %v", err)
+	}
+
+	visibility := request.Visibility
+	if visibility == v1pb.Visibility_VISIBILITY_UNSPECIFIED {
+		visibility, err = s.getUserDefaultMemoVisibility(ctx, user.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get user default memo visibility: %v", err)
+		}
+	}
+
+	memo, err := s.CreateMemo(ctx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{
+			Content:    buildClippedMemoContent(article, request.Tags),
+			Visibility: visibility,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if request.SaveImage && article.Image != "" {
+		if err := s.saveClippedImage(ctx, memo.Name, article.Image); err != nil {
+			// The memo is already created, so failing to save the image is not fatal.
+			slog.Warn("failed to save clipped image", slog.String("image", article.Image), slog.Any("err", err))
+		} else {
+			return s.GetMemo(ctx, &v1pb.GetMemoRequest{Name: memo.Name})
+		}
+	}
+	return memo, nil
+}
+
+func (s *APIV1Service) saveClippedImage(ctx context.Context, memoName string, imageURL string) error {
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	image, err := httpgetter.GetImage(imageURL, getUploadSizeLimit(workspaceStorageSetting))
+	if err != nil {
+		return errors.Wrap(err, "failed to get image")
+	}
+	_, err = s.CreateAttachment(ctx, &v1pb.CreateAttachmentRequest{
+		Attachment: &v1pb.Attachment{
+			Filename: getClippedImageFilename(imageURL, image.Mediatype),
+			Type:     image.Mediatype,
+			Content:  image.Blob,
+			Memo:     &memoName,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create attachment")
+	}
+	return nil
+}
+
+func (s *APIV1Service) getUserDefaultMemoVisibility(ctx context.Context, userID int32) (v1pb.Visibility, error) {
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_MEMO_VISIBILITY,
+	})
+	if err != nil {
+		return v1pb.Visibility_VISIBILITY_UNSPECIFIED, err
+	}
+	if userSetting != nil {
+		if visibility, ok := v1pb.Visibility_value[userSetting.GetMemoVisibility()]; ok && visibility != int32(v1pb.Visibility_VISIBILITY_UNSPECIFIED) {
+			return v1pb.Visibility(visibility), nil
+		}
+	}
+	return v1pb.Visibility_PRIVATE, nil
+}
+
+var (
+	// markdownTextEscaper escapes the characters of the clipped texts which would open or close a markdown link.
+	markdownTextEscaper = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`)
+	// markdownURLEscaper percent-encodes the characters of the clipped URLs which would end the destination of a markdown link.
+	markdownURLEscaper = strings.NewReplacer("(", "%28", ")", "%29", " ", "%20", "<", "%3C", ">", "%3E")
+)
+
+// buildClippedMemoContent renders the clipped article as markdown: the title as a heading,
+// the excerpt as a quote, followed by the source link and tags.
+func buildClippedMemoContent(article *httpgetter.Article, tags []string) string {
+	var builder strings.Builder
+	title := article.Title
+	if title == "" {
+		title = article.URL
+	}
+	builder.WriteString(fmt.Sprintf("# %s\n\n", markdownTextEscaper.Replace(title)))
+	if article.Excerpt != "" {
+		for _, line := range strings.Split(article.Excerpt, "\n") {
+			builder.WriteString(fmt.Sprintf("> %s\n", line))
+		}
+		builder.WriteString("\n")
+	}
+	source := article.SiteName
+	if source == "" {
+		source = article.URL
+	}
+	builder.WriteString(fmt.Sprintf("Source: [%s](%s)", markdownTextEscaper.Replace(source), markdownURLEscaper.Replace(article.URL)))
+
+	if tagLine := buildMemoTagLine(tags); tagLine != "" {
+		builder.WriteString("\n\n")
+		builder.WriteString(tagLine)
+	}
+	return builder.String()
+}
+
+func getClippedImageFilename(imageURL string, mediatype string) string {
+	filename := "image"
+	if u, err := url.Parse(imageURL); err == nil {
+		if base := path.Base(u.Path); base != "" && base != "." && base != "/" {
+			filename = base
+		}
+	}
+	if path.Ext(filename) == "" {
+		if extensions, err := mime.ExtensionsByType(mediatype); err == nil && len(extensions) > 0 {
+			filename += extensions[0]
+		}
+	}
+	return filename
+}
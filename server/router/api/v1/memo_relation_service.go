@@ -3,6 +3,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -58,9 +59,43 @@ func (s *APIV1Service) SetMemoRelations(ctx context.Context, request *v1pb.SetMe
 		}
 	}
 
+	if err := s.publishMemoRelationEvent(ctx, memo); err != nil {
+		slog.Warn("Failed to publish memo relation changed event", slog.Any("err", err))
+	}
 	return &emptypb.Empty{}, nil
 }
 
+// publishMemoRelationEvent publishes the reference relations of the memo to the event bus.
+func (s *APIV1Service) publishMemoRelationEvent(ctx context.Context, memo *store.Memo) error {
+	referenceType := store.MemoRelationReference
+	memoRelations, err := s.Store.ListMemoRelations(ctx, &store.FindMemoRelation{
+		MemoID: &memo.ID,
+		Type:   &referenceType,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list memo relations")
+	}
+	relations := []*v1pb.MemoRelation{}
+	for _, memoRelation := range memoRelations {
+		relation, err := s.convertMemoRelationFromStore(ctx, memoRelation)
+		if err != nil {
+			return errors.Wrap(err, "failed to convert memo relation")
+		}
+		relations = append(relations, relation)
+	}
+	memoMessage, err := s.convertMemoFromStore(ctx, memo)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert memo")
+	}
+	s.EventBus().Publish(ctx, &Event{
+		Type:      EventMemoRelationChanged,
+		OwnerID:   memo.CreatorID,
+		Memo:      memoMessage,
+		Relations: relations,
+	})
+	return nil
+}
+
 func (s *APIV1Service) ListMemoRelations(ctx context.Context, request *v1pb.ListMemoRelationsRequest) (*v1pb.ListMemoRelationsResponse, error) {
 	memoUID, err := ExtractMemoUIDFromName(request.Name)
 	if err != nil {
@@ -18,16 +18,56 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
-	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/usememos/memos/plugin/webhook"
+	"github.com/usememos/memos/plugin/activitypub"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
-	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/server/runner/memopayload"
 	"github.com/usememos/memos/store"
 )
 
 func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoRequest) (*v1pb.Memo, error) {
+	memoMessage, err := s.createMemo(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	// Try to federate the memo and notify the sites it links to when it is public.
+	if memoMessage.Visibility == v1pb.Visibility_PUBLIC {
+		s.publishCreatedMemo(ctx, memoMessage.Name)
+	}
+	return memoMessage, nil
+}
+
+// publishCreatedMemo federates the created memo and sends the webmentions of its links.
+// The memo is already saved, so the failures are only logged.
+func (s *APIV1Service) publishCreatedMemo(ctx context.Context, memoName string) {
+	memoUID, err := ExtractMemoUIDFromName(memoName)
+	if err != nil {
+		slog.Warn("Invalid memo name", slog.String("name", memoName), slog.Any("err", err))
+		return
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		slog.Warn("Failed to get created memo", slog.String("name", memoName), slog.Any("err", err))
+		return
+	}
+	if memo == nil {
+		return
+	}
+	if isMemoFederated(memo) {
+		if err := s.DispatchMemoActivity(ctx, memo, activitypub.ActivityTypeCreate); err != nil {
+			slog.Warn("Failed to dispatch memo created activity", slog.Any("err", err))
+		}
+	}
+	if memo.Payload.GetProperty().GetHasLink() {
+		if err := s.SendMemoWebmentions(memo, ""); err != nil {
+			slog.Warn("Failed to send memo webmentions", slog.Any("err", err))
+		}
+	}
+}
+
+// createMemo creates a memo without federating it or sending webmentions,
+// so that comments are never published as standalone posts.
+func (s *APIV1Service) createMemo(ctx context.Context, request *v1pb.CreateMemoRequest) (*v1pb.Memo, error) {
 	user, err := s.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get user")
@@ -87,9 +127,9 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to convert memo")
 	}
-	// Try to dispatch webhook when memo is created.
-	if err := s.DispatchMemoCreatedWebhook(ctx, memoMessage); err != nil {
-		slog.Warn("Failed to dispatch memo created webhook", slog.Any("err", err))
+	// Publish the event to the webhooks and the other subscribers.
+	if err := s.publishMemoEvent(ctx, EventMemoCreated, memoMessage); err != nil {
+		slog.Warn("Failed to publish memo created event", slog.Any("err", err))
 	}
 
 	return memoMessage, nil
@@ -274,6 +314,11 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
+	wasFederated, previousContent := isMemoFederated(memo), memo.Content
+	// All the links are notified when the memo is made public, only the changed ones when it already was.
+	if memo.Visibility != store.Public || memo.RowStatus != store.Normal {
+		previousContent = ""
+	}
 	update := &store.UpdateMemo{
 		ID: memo.ID,
 	}
@@ -350,6 +395,14 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 		}
 	}
 
+	// The images are stripped of their EXIF data before the memo is made public.
+	if update.Visibility != nil && *update.Visibility != memo.Visibility {
+		updatedMemo := *memo
+		updatedMemo.Visibility = *update.Visibility
+		if err := s.stripMemoAttachmentsExif(ctx, &updatedMemo); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to strip exif data: %v", err)
+		}
+	}
 	if err = s.Store.UpdateMemo(ctx, update); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update memo")
 	}
@@ -364,9 +417,20 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to convert memo")
 	}
-	// Try to dispatch webhook when memo is updated.
-	if err := s.DispatchMemoUpdatedWebhook(ctx, memoMessage); err != nil {
-		slog.Warn("Failed to dispatch memo updated webhook", slog.Any("err", err))
+	// Publish the event to the webhooks and the other subscribers.
+	if err := s.publishMemoEvent(ctx, EventMemoUpdated, memoMessage); err != nil {
+		slog.Warn("Failed to publish memo updated event", slog.Any("err", err))
+	}
+	// Try to federate the change when the memo is or was public.
+	if activityType := getMemoUpdatedActivityType(wasFederated, isMemoFederated(memo)); activityType != "" {
+		if err := s.DispatchMemoActivity(ctx, memo, activityType); err != nil {
+			slog.Warn("Failed to dispatch memo updated activity", slog.Any("err", err))
+		}
+	}
+	if memo.Visibility == store.Public && memo.RowStatus == store.Normal {
+		if err := s.SendMemoWebmentions(memo, previousContent); err != nil {
+			slog.Warn("Failed to send memo webmentions", slog.Any("err", err))
+		}
 	}
 
 	return memoMessage, nil
@@ -397,15 +461,25 @@ func (s *APIV1Service) DeleteMemo(ctx context.Context, request *v1pb.DeleteMemoR
 	}
 
 	if memoMessage, err := s.convertMemoFromStore(ctx, memo); err == nil {
-		// Try to dispatch webhook when memo is deleted.
-		if err := s.DispatchMemoDeletedWebhook(ctx, memoMessage); err != nil {
-			slog.Warn("Failed to dispatch memo deleted webhook", slog.Any("err", err))
+		// Publish the event to the webhooks and the other subscribers.
+		if err := s.publishMemoEvent(ctx, EventMemoDeleted, memoMessage); err != nil {
+			slog.Warn("Failed to publish memo deleted event", slog.Any("err", err))
 		}
 	}
-
 	if err = s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete memo")
 	}
+	// The followers are only told once the memo is deleted, as the deletion can't be undone on their servers.
+	if isMemoFederated(memo) {
+		if err := s.DispatchMemoActivity(ctx, memo, activitypub.ActivityTypeDelete); err != nil {
+			slog.Warn("Failed to dispatch memo deleted activity", slog.Any("err", err))
+		}
+	}
+
+	// Delete memo webmentions
+	if err := s.Store.DeleteWebmention(ctx, &store.DeleteWebmention{MemoID: &memo.ID}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete memo webmentions")
+	}
 
 	// Delete memo relation
 	if err := s.Store.DeleteMemoRelation(ctx, &store.DeleteMemoRelation{MemoID: &memo.ID}); err != nil {
@@ -455,7 +529,7 @@ func (s *APIV1Service) CreateMemoComment(ctx context.Context, request *v1pb.Crea
 	}
 
 	// Create the memo comment first.
-	memoComment, err := s.CreateMemo(ctx, &v1pb.CreateMemoRequest{Memo: request.Comment})
+	memoComment, err := s.createMemo(ctx, &v1pb.CreateMemoRequest{Memo: request.Comment})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create memo")
 	}
@@ -481,33 +555,18 @@ func (s *APIV1Service) CreateMemoComment(ctx context.Context, request *v1pb.Crea
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid memo creator")
 	}
-	if memoComment.Visibility != v1pb.Visibility_PRIVATE && creatorID != relatedMemo.CreatorID {
-		activity, err := s.Store.CreateActivity(ctx, &store.Activity{
-			CreatorID: creatorID,
-			Type:      store.ActivityTypeMemoComment,
-			Level:     store.ActivityLevelInfo,
-			Payload: &storepb.ActivityPayload{
-				MemoComment: &storepb.ActivityMemoCommentPayload{
-					MemoId:        memo.ID,
-					RelatedMemoId: relatedMemo.ID,
-				},
-			},
-		})
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to create activity")
-		}
-		if _, err := s.Store.CreateInbox(ctx, &store.Inbox{
-			SenderID:   creatorID,
-			ReceiverID: relatedMemo.CreatorID,
-			Status:     store.UNREAD,
-			Message: &storepb.InboxMessage{
-				Type:       storepb.InboxMessage_MEMO_COMMENT,
-				ActivityId: &activity.ID,
-			},
-		}); err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to create inbox")
-		}
+	relatedMemoMessage, err := s.convertMemoFromStore(ctx, relatedMemo)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert memo")
 	}
+	// The activity log and the webhooks of the memo creator subscribe to the comment.
+	s.EventBus().Publish(ctx, &Event{
+		Type:    EventCommentCreated,
+		ActorID: creatorID,
+		OwnerID: relatedMemo.CreatorID,
+		Memo:    relatedMemoMessage,
+		Comment: memoComment,
+	})
 
 	return memoComment, nil
 }
@@ -559,8 +618,14 @@ func (s *APIV1Service) ListMemoComments(ctx context.Context, request *v1pb.ListM
 		}
 	}
 
+	webmentions, err := s.listPublicMemoWebmentions(ctx, memo, v1pb.Webmention_REPLY, v1pb.Webmention_MENTION)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webmentions")
+	}
+
 	response := &v1pb.ListMemoCommentsResponse{
-		Memos: memos,
+		Memos:       memos,
+		Webmentions: webmentions,
 	}
 	return response, nil
 }
@@ -669,58 +734,20 @@ func (s *APIV1Service) getContentLengthLimit(ctx context.Context) (int, error) {
 	return int(workspaceMemoRelatedSetting.ContentLengthLimit), nil
 }
 
-// DispatchMemoCreatedWebhook dispatches webhook when memo is created.
-func (s *APIV1Service) DispatchMemoCreatedWebhook(ctx context.Context, memo *v1pb.Memo) error {
-	return s.dispatchMemoRelatedWebhook(ctx, memo, "memos.memo.created")
-}
-
-// DispatchMemoUpdatedWebhook dispatches webhook when memo is updated.
-func (s *APIV1Service) DispatchMemoUpdatedWebhook(ctx context.Context, memo *v1pb.Memo) error {
-	return s.dispatchMemoRelatedWebhook(ctx, memo, "memos.memo.updated")
-}
-
-// DispatchMemoDeletedWebhook dispatches webhook when memo is deleted.
-func (s *APIV1Service) DispatchMemoDeletedWebhook(ctx context.Context, memo *v1pb.Memo) error {
-	return s.dispatchMemoRelatedWebhook(ctx, memo, "memos.memo.deleted")
-}
-
-func (s *APIV1Service) dispatchMemoRelatedWebhook(ctx context.Context, memo *v1pb.Memo, activityType string) error {
+// publishMemoEvent publishes the event of the memo to the event bus, the creator of the memo owns the event.
+func (s *APIV1Service) publishMemoEvent(ctx context.Context, eventType string, memo *v1pb.Memo) error {
 	creatorID, err := ExtractUserIDFromName(memo.Creator)
 	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "invalid memo creator")
+		return errors.Wrap(err, "invalid memo creator")
 	}
-	webhooks, err := s.Store.ListWebhooks(ctx, &store.FindWebhook{
-		CreatorID: &creatorID,
+	s.EventBus().Publish(ctx, &Event{
+		Type:    eventType,
+		OwnerID: creatorID,
+		Memo:    memo,
 	})
-	if err != nil {
-		return err
-	}
-	for _, hook := range webhooks {
-		payload, err := convertMemoToWebhookPayload(memo)
-		if err != nil {
-			return errors.Wrap(err, "failed to convert memo to webhook payload")
-		}
-		payload.ActivityType = activityType
-		payload.Url = hook.URL
-
-		// Use asynchronous webhook dispatch
-		webhook.PostAsync(payload)
-	}
 	return nil
 }
 
-func convertMemoToWebhookPayload(memo *v1pb.Memo) (*v1pb.WebhookRequestPayload, error) {
-	creatorID, err := ExtractUserIDFromName(memo.Creator)
-	if err != nil {
-		return nil, errors.Wrap(err, "invalid memo creator")
-	}
-	return &v1pb.WebhookRequestPayload{
-		Creator:    fmt.Sprintf("%s%d", UserNamePrefix, creatorID),
-		CreateTime: timestamppb.New(time.Now()),
-		Memo:       memo,
-	}, nil
-}
-
 func getMemoContentSnippet(content string) (string, error) {
 	nodes, err := parser.Parse(tokenizer.Tokenize(content))
 	if err != nil {
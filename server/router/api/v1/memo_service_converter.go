@@ -67,11 +67,11 @@ func (s *APIV1Service) convertMemoFromStore(ctx context.Context, memo *store.Mem
 	}
 	memoMessage.Attachments = listMemoAttachmentsResponse.Attachments
 
-	listMemoReactionsResponse, err := s.ListMemoReactions(ctx, &v1pb.ListMemoReactionsRequest{Name: name})
+	reactions, err := s.listMemoReactions(ctx, name)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list memo reactions")
 	}
-	memoMessage.Reactions = listMemoReactionsResponse.Reactions
+	memoMessage.Reactions = reactions
 
 	nodes, err := parser.Parse(tokenizer.Tokenize(memo.Content))
 	if err != nil {
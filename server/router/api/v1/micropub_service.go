@@ -0,0 +1,704 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// MicropubPath is the path of the Micropub endpoint.
+	MicropubPath = "/micropub"
+	// MicropubMediaPath is the path of the Micropub media endpoint.
+	MicropubMediaPath = "/micropub/media"
+)
+
+var micropubHashtagRegexp = regexp.MustCompile(`^#[^#\s]+$`)
+
+// micropubProperties holds the microformats2 properties of a Micropub request, keyed by property name.
+// Values are either strings or nested objects such as `{"html": "..."}` or h-card locations.
+type micropubProperties map[string][]any
+
+// micropubUpdate is the JSON body of a Micropub update or delete request.
+type micropubUpdate struct {
+	Action  string             `json:"action"`
+	URL     string             `json:"url"`
+	Replace micropubProperties `json:"replace"`
+	Add     micropubProperties `json:"add"`
+	// Delete is either a list of property names or properties with the values to remove.
+	Delete json.RawMessage `json:"delete"`
+}
+
+// micropubEntry is the h-entry that a memo is mapped from and to.
+type micropubEntry struct {
+	// Text is the content without the trailing hashtags added for the categories.
+	Text       string
+	Categories []string
+	Location   *v1pb.Location
+	Visibility v1pb.Visibility
+}
+
+// RegisterMicropubRoutes registers the Micropub and media endpoints.
+// Requests are authenticated with the access tokens of users.
+func (s *APIV1Service) RegisterMicropubRoutes(echoServer *echo.Echo) {
+	echoServer.GET(MicropubPath, s.handleMicropubQuery)
+	echoServer.POST(MicropubPath, s.handleMicropubRequest)
+	echoServer.POST(MicropubMediaPath, s.handleMicropubMedia)
+}
+
+func (s *APIV1Service) handleMicropubQuery(c echo.Context) error {
+	ctx, user, err := s.authenticateMicropubRequest(c)
+	if err != nil {
+		return micropubError(c, http.StatusUnauthorized, "unauthorized", err.Error())
+	}
+
+	baseURL := c.Scheme() + "://" + c.Request().Host
+	switch c.QueryParam("q") {
+	case "config":
+		return c.JSON(http.StatusOK, map[string]any{
+			"media-endpoint": baseURL + MicropubMediaPath,
+			"syndicate-to":   []any{},
+			"q":              []string{"config", "source", "syndicate-to"},
+		})
+	case "syndicate-to":
+		return c.JSON(http.StatusOK, map[string]any{
+			"syndicate-to": []any{},
+		})
+	case "source":
+		memo, err := s.getMicropubMemo(ctx, user, c.QueryParam("url"))
+		if err != nil {
+			return micropubErrorFromStatus(c, err)
+		}
+		properties := s.convertMemoToMicropubProperties(ctx, memo, baseURL)
+		requested := slices.Concat(c.QueryParams()["properties[]"], c.QueryParams()["properties"])
+		if len(requested) == 0 {
+			return c.JSON(http.StatusOK, map[string]any{
+				"type":       []string{"h-entry"},
+				"properties": properties,
+			})
+		}
+		filtered := micropubProperties{}
+		for _, name := range requested {
+			if values, ok := properties[name]; ok {
+				filtered[name] = values
+			}
+		}
+		return c.JSON(http.StatusOK, map[string]any{
+			"properties": filtered,
+		})
+	default:
+		return micropubError(c, http.StatusBadRequest, "invalid_request", "unsupported query")
+	}
+}
+
+func (s *APIV1Service) handleMicropubRequest(c echo.Context) error {
+	ctx, user, err := s.authenticateMicropubRequest(c)
+	if err != nil {
+		return micropubError(c, http.StatusUnauthorized, "unauthorized", err.Error())
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if mediaType == echo.MIMEApplicationJSON {
+		body, err := io.ReadAll(io.LimitReader(c.Request().Body, MaxUploadBufferSizeBytes+1))
+		if err != nil {
+			return micropubError(c, http.StatusBadRequest, "invalid_request", "failed to read request body")
+		}
+		if len(body) > MaxUploadBufferSizeBytes {
+			return micropubError(c, http.StatusRequestEntityTooLarge, "invalid_request", "request body too large")
+		}
+		request := struct {
+			micropubUpdate
+			Type       []string           `json:"type"`
+			Properties micropubProperties `json:"properties"`
+		}{}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return micropubError(c, http.StatusBadRequest, "invalid_request", fmt.Sprintf("invalid JSON body: %v", err))
+		}
+		switch request.Action {
+		case "":
+			if len(request.Type) > 0 && request.Type[0] != "h-entry" {
+				return micropubError(c, http.StatusBadRequest, "invalid_request", "only h-entry is supported")
+			}
+			return s.createMicropubMemo(ctx, c, user, request.Properties)
+		case "update":
+			return s.updateMicropubMemo(ctx, c, user, &request.micropubUpdate)
+		case "delete":
+			return s.deleteMicropubMemo(ctx, c, user, request.URL)
+		default:
+			return micropubError(c, http.StatusBadRequest, "invalid_request", fmt.Sprintf("unsupported action %q", request.Action))
+		}
+	}
+
+	form, err := c.FormParams()
+	if err != nil {
+		return micropubError(c, http.StatusBadRequest, "invalid_request", "failed to parse form body")
+	}
+	switch action := form.Get("action"); action {
+	case "":
+		if h := form.Get("h"); h != "" && h != "entry" {
+			return micropubError(c, http.StatusBadRequest, "invalid_request", "only h-entry is supported")
+		}
+		properties := micropubProperties{}
+		for key, values := range form {
+			if key == "h" || key == "access_token" {
+				continue
+			}
+			name := strings.TrimSuffix(key, "[]")
+			for _, value := range values {
+				properties[name] = append(properties[name], value)
+			}
+		}
+		attachments, err := s.uploadMicropubPhotos(ctx, c)
+		if err != nil {
+			return micropubErrorFromStatus(c, err)
+		}
+		for _, attachment := range attachments {
+			properties["photo"] = append(properties["photo"], attachmentURL(c.Scheme()+"://"+c.Request().Host, attachment))
+		}
+		return s.createMicropubMemo(ctx, c, user, properties)
+	case "delete":
+		return s.deleteMicropubMemo(ctx, c, user, form.Get("url"))
+	default:
+		return micropubError(c, http.StatusBadRequest, "invalid_request", fmt.Sprintf("unsupported action %q", action))
+	}
+}
+
+func (s *APIV1Service) handleMicropubMedia(c echo.Context) error {
+	ctx, _, err := s.authenticateMicropubRequest(c)
+	if err != nil {
+		return micropubError(c, http.StatusUnauthorized, "unauthorized", err.Error())
+	}
+	attachment, err := s.createAttachmentFromFormFile(ctx, c, "file")
+	if err != nil {
+		return micropubErrorFromStatus(c, err)
+	}
+	c.Response().Header().Set(echo.HeaderLocation, attachmentURL(c.Scheme()+"://"+c.Request().Host, attachment))
+	return c.NoContent(http.StatusCreated)
+}
+
+func (s *APIV1Service) createMicropubMemo(ctx context.Context, c echo.Context, user *store.User, properties micropubProperties) error {
+	entry, err := parseMicropubEntry(properties)
+	if err != nil {
+		return micropubError(c, http.StatusBadRequest, "invalid_request", err.Error())
+	}
+	if entry.Visibility == v1pb.Visibility_VISIBILITY_UNSPECIFIED {
+		entry.Visibility, err = s.getUserDefaultMemoVisibility(ctx, user.ID)
+		if err != nil {
+			return micropubError(c, http.StatusInternalServerError, "server_error", "failed to get default memo visibility")
+		}
+	}
+
+	// Photos uploaded to the media endpoint are attached, others are embedded as images.
+	attachments := []*v1pb.Attachment{}
+	for _, photo := range properties["photo"] {
+		photoURL, alt := getMicropubValue(photo), ""
+		if object, ok := photo.(map[string]any); ok {
+			alt, _ = object["alt"].(string)
+		}
+		if photoURL == "" {
+			continue
+		}
+		if attachmentUID := extractAttachmentUIDFromURL(photoURL); attachmentUID != "" {
+			attachment, err := s.Store.GetAttachment(ctx, &store.FindAttachment{
+				UID:       &attachmentUID,
+				CreatorID: &user.ID,
+			})
+			if err != nil {
+				return micropubError(c, http.StatusInternalServerError, "server_error", "failed to get attachment")
+			}
+			if attachment != nil {
+				attachments = append(attachments, &v1pb.Attachment{Name: fmt.Sprintf("%s%s", AttachmentNamePrefix, attachment.UID)})
+				continue
+			}
+		}
+		entry.Text = strings.TrimSpace(entry.Text + fmt.Sprintf("\n\n![%s](%s)", alt, photoURL))
+	}
+
+	content := buildMicropubContent(entry.Text, entry.Categories)
+	if content == "" && len(attachments) == 0 {
+		return micropubError(c, http.StatusBadRequest, "invalid_request", "content is required")
+	}
+	memo, err := s.CreateMemo(ctx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{
+			Content:     content,
+			Visibility:  entry.Visibility,
+			Location:    entry.Location,
+			Attachments: attachments,
+		},
+	})
+	if err != nil {
+		return micropubErrorFromStatus(c, err)
+	}
+	c.Response().Header().Set(echo.HeaderLocation, memoURL(c.Scheme()+"://"+c.Request().Host, memo.Name))
+	return c.NoContent(http.StatusCreated)
+}
+
+func (s *APIV1Service) updateMicropubMemo(ctx context.Context, c echo.Context, user *store.User, update *micropubUpdate) error {
+	memo, err := s.getMicropubMemo(ctx, user, update.URL)
+	if err != nil {
+		return micropubErrorFromStatus(c, err)
+	}
+	entry := convertMemoToMicropubEntry(memo)
+	updateMask := []string{}
+
+	if len(update.Replace) > 0 {
+		replaced, err := parseMicropubEntry(update.Replace)
+		if err != nil {
+			return micropubError(c, http.StatusBadRequest, "invalid_request", err.Error())
+		}
+		if _, ok := update.Replace["content"]; ok {
+			entry.Text = replaced.Text
+		}
+		if _, ok := update.Replace["category"]; ok {
+			entry.Categories = replaced.Categories
+		}
+		if _, ok := update.Replace["location"]; ok {
+			entry.Location = replaced.Location
+			updateMask = append(updateMask, "location")
+		}
+		if _, ok := update.Replace["visibility"]; ok {
+			entry.Visibility = replaced.Visibility
+			updateMask = append(updateMask, "visibility")
+		}
+	}
+	for _, category := range update.Add["category"] {
+		entry.Categories = append(entry.Categories, getMicropubValue(category))
+	}
+	if len(update.Delete) > 0 {
+		names, values := []string{}, micropubProperties{}
+		if err := json.Unmarshal(update.Delete, &names); err != nil {
+			if err := json.Unmarshal(update.Delete, &values); err != nil {
+				return micropubError(c, http.StatusBadRequest, "invalid_request", "invalid delete")
+			}
+		}
+		for _, name := range names {
+			switch name {
+			case "content":
+				entry.Text = ""
+			case "category":
+				entry.Categories = nil
+			case "location":
+				entry.Location = nil
+				updateMask = append(updateMask, "location")
+			default:
+			}
+		}
+		for _, category := range values["category"] {
+			entry.Categories = slices.DeleteFunc(entry.Categories, func(c string) bool {
+				return c == getMicropubValue(category)
+			})
+		}
+	}
+
+	content := buildMicropubContent(entry.Text, entry.Categories)
+	if content != memo.Content {
+		updateMask = append(updateMask, "content")
+	}
+	if len(updateMask) == 0 {
+		return c.NoContent(http.StatusNoContent)
+	}
+	if _, err := s.UpdateMemo(ctx, &v1pb.UpdateMemoRequest{
+		Memo: &v1pb.Memo{
+			Name:       fmt.Sprintf("%s%s", MemoNamePrefix, memo.UID),
+			Content:    content,
+			Location:   entry.Location,
+			Visibility: entry.Visibility,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: updateMask},
+	}); err != nil {
+		return micropubErrorFromStatus(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *APIV1Service) deleteMicropubMemo(ctx context.Context, c echo.Context, user *store.User, rawURL string) error {
+	memo, err := s.getMicropubMemo(ctx, user, rawURL)
+	if err != nil {
+		return micropubErrorFromStatus(c, err)
+	}
+	if _, err := s.DeleteMemo(ctx, &v1pb.DeleteMemoRequest{
+		Name: fmt.Sprintf("%s%s", MemoNamePrefix, memo.UID),
+	}); err != nil {
+		return micropubErrorFromStatus(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// authenticateMicropubRequest authenticates the request with the access token from the Authorization
+// header or the `access_token` form field, and returns a context carrying the user.
+func (s *APIV1Service) authenticateMicropubRequest(c echo.Context) (context.Context, *store.User, error) {
+	ctx := c.Request().Context()
+	accessToken := c.Request().PostFormValue("access_token")
+	if authorization := c.Request().Header.Get(echo.HeaderAuthorization); authorization != "" {
+		parts := strings.Fields(authorization)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+			return nil, nil, errors.New("authorization header format must be Bearer {token}")
+		}
+		accessToken = parts[1]
+	}
+	user, err := NewGRPCAuthInterceptor(s.Store, s.Secret).authenticateByAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return context.WithValue(ctx, userIDContextKey, user.ID), user, nil
+}
+
+func (s *APIV1Service) getMicropubMemo(ctx context.Context, user *store.User, rawURL string) (*store.Memo, error) {
+	memoUID := extractMemoUIDFromURL(rawURL)
+	if memoUID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid url %q", rawURL)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{
+		UID: &memoUID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo: %v", err)
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	if memo.CreatorID != user.ID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	return memo, nil
+}
+
+// uploadMicropubPhotos creates attachments for the photos of a multipart create request.
+func (s *APIV1Service) uploadMicropubPhotos(ctx context.Context, c echo.Context) ([]*v1pb.Attachment, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		// Not a multipart request.
+		return nil, nil
+	}
+	attachments := []*v1pb.Attachment{}
+	for _, key := range []string{"photo", "photo[]"} {
+		for i := range form.File[key] {
+			attachment, err := s.createAttachmentFromFileHeader(ctx, form.File[key][i])
+			if err != nil {
+				return nil, err
+			}
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, nil
+}
+
+func (s *APIV1Service) createAttachmentFromFormFile(ctx context.Context, c echo.Context, name string) (*v1pb.Attachment, error) {
+	fileHeader, err := c.FormFile(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is required", name)
+	}
+	return s.createAttachmentFromFileHeader(ctx, fileHeader)
+}
+
+func (s *APIV1Service) createAttachmentFromFileHeader(ctx context.Context, fileHeader *multipart.FileHeader) (*v1pb.Attachment, error) {
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace storage setting: %v", err)
+	}
+	uploadSizeLimit := getUploadSizeLimit(workspaceStorageSetting)
+	if fileHeader.Size > uploadSizeLimit {
+		return nil, status.Errorf(codes.ResourceExhausted, "file size exceeds the limit")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to open file: %v", err)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, uploadSizeLimit+1))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to read file: %v", err)
+	}
+	if int64(len(content)) > uploadSizeLimit {
+		return nil, status.Errorf(codes.ResourceExhausted, "file size exceeds the limit")
+	}
+	contentType := fileHeader.Header.Get(echo.HeaderContentType)
+	if contentType == "" || contentType == echo.MIMEOctetStream {
+		contentType = http.DetectContentType(content)
+	}
+	return s.CreateAttachment(ctx, &v1pb.CreateAttachmentRequest{
+		Attachment: &v1pb.Attachment{
+			Filename: fileHeader.Filename,
+			Type:     contentType,
+			Content:  content,
+		},
+	})
+}
+
+func (s *APIV1Service) convertMemoToMicropubProperties(ctx context.Context, memo *store.Memo, baseURL string) micropubProperties {
+	entry := convertMemoToMicropubEntry(memo)
+	properties := micropubProperties{
+		"content":    {entry.Text},
+		"published":  {time.Unix(memo.CreatedTs, 0).UTC().Format(time.RFC3339)},
+		"updated":    {time.Unix(memo.UpdatedTs, 0).UTC().Format(time.RFC3339)},
+		"url":        {memoURL(baseURL, fmt.Sprintf("%s%s", MemoNamePrefix, memo.UID))},
+		"visibility": {convertVisibilityToMicropub(entry.Visibility)},
+	}
+	for _, category := range entry.Categories {
+		properties["category"] = append(properties["category"], category)
+	}
+	if entry.Location != nil {
+		properties["location"] = []any{fmt.Sprintf("geo:%v,%v", entry.Location.Latitude, entry.Location.Longitude)}
+	}
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
+		MemoID: &memo.ID,
+	})
+	if err == nil {
+		for _, attachment := range attachments {
+			properties["photo"] = append(properties["photo"], attachmentURL(baseURL, s.convertAttachmentFromStore(ctx, attachment)))
+		}
+	}
+	return properties
+}
+
+func convertMemoToMicropubEntry(memo *store.Memo) *micropubEntry {
+	entry := &micropubEntry{
+		Text:       splitMicropubContent(memo.Content),
+		Visibility: convertVisibilityFromStore(memo.Visibility),
+	}
+	if memo.Payload != nil {
+		entry.Categories = slices.Clone(memo.Payload.Tags)
+		if location := memo.Payload.Location; location != nil {
+			entry.Location = &v1pb.Location{
+				Placeholder: location.Placeholder,
+				Latitude:    location.Latitude,
+				Longitude:   location.Longitude,
+			}
+		}
+	}
+	return entry
+}
+
+// parseMicropubEntry maps the h-entry properties to a memo entry.
+func parseMicropubEntry(properties micropubProperties) (*micropubEntry, error) {
+	entry := &micropubEntry{}
+	if contents := properties["content"]; len(contents) > 0 {
+		entry.Text = getMicropubValue(contents[0])
+	}
+	if names := properties["name"]; len(names) > 0 {
+		if name := strings.TrimSpace(getMicropubValue(names[0])); name != "" {
+			entry.Text = fmt.Sprintf("# %s\n\n%s", name, entry.Text)
+		}
+	}
+	for _, category := range properties["category"] {
+		entry.Categories = append(entry.Categories, getMicropubValue(category))
+	}
+	if locations := properties["location"]; len(locations) > 0 {
+		location, err := parseMicropubLocation(locations[0])
+		if err != nil {
+			return nil, err
+		}
+		entry.Location = location
+	}
+	if visibilities := properties["visibility"]; len(visibilities) > 0 {
+		switch visibility := getMicropubValue(visibilities[0]); visibility {
+		case "public":
+			entry.Visibility = v1pb.Visibility_PUBLIC
+		case "unlisted":
+			entry.Visibility = v1pb.Visibility_PROTECTED
+		case "private":
+			entry.Visibility = v1pb.Visibility_PRIVATE
+		default:
+			return nil, errors.Errorf("unsupported visibility %q", visibility)
+		}
+	}
+	return entry, nil
+}
+
+// parseMicropubLocation parses a geo URI such as "geo:37.78,-122.41" or an h-card/h-adr/h-geo object.
+func parseMicropubLocation(value any) (*v1pb.Location, error) {
+	if object, ok := value.(map[string]any); ok {
+		properties, _ := object["properties"].(map[string]any)
+		getProperty := func(name string) string {
+			if values, ok := properties[name].([]any); ok && len(values) > 0 {
+				return fmt.Sprint(values[0])
+			}
+			return ""
+		}
+		latitude, err := strconv.ParseFloat(getProperty("latitude"), 64)
+		if err != nil {
+			return nil, errors.New("invalid location latitude")
+		}
+		longitude, err := strconv.ParseFloat(getProperty("longitude"), 64)
+		if err != nil {
+			return nil, errors.New("invalid location longitude")
+		}
+		placeholder := getProperty("name")
+		if placeholder == "" {
+			placeholder = getProperty("locality")
+		}
+		return &v1pb.Location{
+			Placeholder: placeholder,
+			Latitude:    latitude,
+			Longitude:   longitude,
+		}, nil
+	}
+
+	geo, ok := value.(string)
+	if !ok || !strings.HasPrefix(geo, "geo:") {
+		return nil, errors.New("location must be a geo URI or an h-card")
+	}
+	coordinates := strings.Split(strings.SplitN(strings.TrimPrefix(geo, "geo:"), ";", 2)[0], ",")
+	if len(coordinates) < 2 {
+		return nil, errors.Errorf("invalid geo URI %q", geo)
+	}
+	latitude, err := strconv.ParseFloat(coordinates[0], 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid geo URI %q", geo)
+	}
+	longitude, err := strconv.ParseFloat(coordinates[1], 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid geo URI %q", geo)
+	}
+	return &v1pb.Location{
+		Latitude:  latitude,
+		Longitude: longitude,
+	}, nil
+}
+
+// getMicropubValue returns the string value of a property, which is either a plain string
+// or an object with a "value" or "html" field.
+func getMicropubValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		for _, key := range []string{"value", "html"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+	default:
+	}
+	return ""
+}
+
+// buildMicropubContent appends the categories not mentioned in the text as a line of hashtags.
+func buildMicropubContent(text string, categories []string) string {
+	text = strings.TrimSpace(text)
+	words := strings.Fields(text)
+	missing := []string{}
+	for _, category := range normalizeMemoTags(categories) {
+		if !slices.Contains(words, "#"+category) && !slices.Contains(missing, category) {
+			missing = append(missing, category)
+		}
+	}
+	tagLine := buildMemoTagLine(missing)
+	if tagLine == "" {
+		return text
+	}
+	if text == "" {
+		return tagLine
+	}
+	return text + "\n\n" + tagLine
+}
+
+// splitMicropubContent strips the trailing line of hashtags added by buildMicropubContent.
+func splitMicropubContent(content string) string {
+	content = strings.TrimSpace(content)
+	text, tagLine := "", content
+	if index := strings.LastIndex(content, "\n\n"); index >= 0 {
+		text, tagLine = content[:index], content[index+2:]
+	}
+	words := strings.Fields(tagLine)
+	if len(words) == 0 {
+		return content
+	}
+	for _, word := range words {
+		if !micropubHashtagRegexp.MatchString(word) {
+			return content
+		}
+	}
+	return strings.TrimSpace(text)
+}
+
+func convertVisibilityToMicropub(visibility v1pb.Visibility) string {
+	switch visibility {
+	case v1pb.Visibility_PUBLIC:
+		return "public"
+	case v1pb.Visibility_PROTECTED:
+		return "unlisted"
+	default:
+		return "private"
+	}
+}
+
+// extractMemoUIDFromURL returns the memo UID from a memo URL like "https://example.com/memos/{uid}".
+func extractMemoUIDFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	_, uid, found := strings.Cut(u.Path, "/"+MemoNamePrefix)
+	if !found || uid == "" || strings.Contains(strings.TrimSuffix(uid, "/"), "/") {
+		return ""
+	}
+	return strings.TrimSuffix(uid, "/")
+}
+
+// extractAttachmentUIDFromURL returns the attachment UID from a URL like "https://example.com/file/attachments/{uid}/{filename}".
+func extractAttachmentUIDFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	_, rest, found := strings.Cut(u.Path, "/file/"+AttachmentNamePrefix)
+	if !found {
+		return ""
+	}
+	uid, _, _ := strings.Cut(rest, "/")
+	return uid
+}
+
+func memoURL(baseURL string, memoName string) string {
+	return fmt.Sprintf("%s/%s", baseURL, memoName)
+}
+
+func attachmentURL(baseURL string, attachment *v1pb.Attachment) string {
+	if attachment.ExternalLink != "" {
+		return attachment.ExternalLink
+	}
+	return fmt.Sprintf("%s/file/%s/%s", baseURL, attachment.Name, url.PathEscape(attachment.Filename))
+}
+
+func micropubError(c echo.Context, code int, errorCode string, description string) error {
+	return c.JSON(code, map[string]string{
+		"error":             errorCode,
+		"error_description": description,
+	})
+}
+
+func micropubErrorFromStatus(c echo.Context, err error) error {
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.InvalidArgument, codes.NotFound:
+		return micropubError(c, http.StatusBadRequest, "invalid_request", st.Message())
+	case codes.PermissionDenied:
+		return micropubError(c, http.StatusForbidden, "forbidden", st.Message())
+	case codes.ResourceExhausted:
+		return micropubError(c, http.StatusRequestEntityTooLarge, "invalid_request", st.Message())
+	case codes.Unauthenticated:
+		return micropubError(c, http.StatusUnauthorized, "unauthorized", st.Message())
+	default:
+		return micropubError(c, http.StatusInternalServerError, "server_error", st.Message())
+	}
+}
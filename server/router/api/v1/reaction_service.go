@@ -3,8 +3,10 @@ package v1
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -15,24 +17,46 @@ import (
 )
 
 func (s *APIV1Service) ListMemoReactions(ctx context.Context, request *v1pb.ListMemoReactionsRequest) (*v1pb.ListMemoReactionsResponse, error) {
+	reactions, err := s.listMemoReactions(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	response := &v1pb.ListMemoReactionsResponse{
+		Reactions: reactions,
+	}
+
+	memoUID, err := ExtractMemoUIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo")
+	}
+	if response.Webmentions, err = s.listPublicMemoWebmentions(ctx, memo, v1pb.Webmention_LIKE, v1pb.Webmention_REPOST, v1pb.Webmention_BOOKMARK); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webmentions")
+	}
+	return response, nil
+}
+
+// listMemoReactions returns the reactions of the memo, without its webmentions.
+func (s *APIV1Service) listMemoReactions(ctx context.Context, memoName string) ([]*v1pb.Reaction, error) {
 	reactions, err := s.Store.ListReactions(ctx, &store.FindReaction{
-		ContentID: &request.Name,
+		ContentID: &memoName,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list reactions")
 	}
 
-	response := &v1pb.ListMemoReactionsResponse{
-		Reactions: []*v1pb.Reaction{},
-	}
+	reactionMessages := []*v1pb.Reaction{}
 	for _, reaction := range reactions {
 		reactionMessage, err := s.convertReactionFromStore(ctx, reaction)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to convert reaction")
 		}
-		response.Reactions = append(response.Reactions, reactionMessage)
+		reactionMessages = append(reactionMessages, reactionMessage)
 	}
-	return response, nil
+	return reactionMessages, nil
 }
 
 func (s *APIV1Service) UpsertMemoReaction(ctx context.Context, request *v1pb.UpsertMemoReactionRequest) (*v1pb.Reaction, error) {
@@ -53,6 +77,9 @@ func (s *APIV1Service) UpsertMemoReaction(ctx context.Context, request *v1pb.Ups
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to convert reaction")
 	}
+	if err := s.publishReactionEvent(ctx, EventReactionAdded, reactionMessage); err != nil {
+		slog.Warn("Failed to publish reaction added event", slog.Any("err", err))
+	}
 	return reactionMessage, nil
 }
 
@@ -62,11 +89,27 @@ func (s *APIV1Service) DeleteMemoReaction(ctx context.Context, request *v1pb.Del
 		return nil, status.Errorf(codes.InvalidArgument, "invalid reaction name: %v", err)
 	}
 
+	reactions, err := s.Store.ListReactions(ctx, &store.FindReaction{
+		ID: &reactionID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get reaction")
+	}
+
 	if err := s.Store.DeleteReaction(ctx, &store.DeleteReaction{
 		ID: reactionID,
 	}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete reaction")
 	}
+	if len(reactions) > 0 {
+		reactionMessage, err := s.convertReactionFromStore(ctx, reactions[0])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert reaction")
+		}
+		if err := s.publishReactionEvent(ctx, EventReactionRemoved, reactionMessage); err != nil {
+			slog.Warn("Failed to publish reaction removed event", slog.Any("err", err))
+		}
+	}
 
 	return &emptypb.Empty{}, nil
 }
@@ -89,3 +132,29 @@ func (s *APIV1Service) convertReactionFromStore(ctx context.Context, reaction *s
 		CreateTime:   timestamppb.New(time.Unix(reaction.CreatedTs, 0)),
 	}, nil
 }
+
+// publishReactionEvent publishes the event of the reaction to the event bus, the creator of the memo owns the event.
+func (s *APIV1Service) publishReactionEvent(ctx context.Context, eventType string, reaction *v1pb.Reaction) error {
+	memoUID, err := ExtractMemoUIDFromName(reaction.ContentId)
+	if err != nil {
+		return errors.Wrap(err, "invalid memo name")
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return errors.Wrap(err, "failed to get memo")
+	}
+	if memo == nil {
+		return errors.Errorf("memo %s not found", reaction.ContentId)
+	}
+	memoMessage, err := s.convertMemoFromStore(ctx, memo)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert memo")
+	}
+	s.EventBus().Publish(ctx, &Event{
+		Type:     eventType,
+		OwnerID:  memo.CreatorID,
+		Memo:     memoMessage,
+		Reaction: reaction,
+	})
+	return nil
+}
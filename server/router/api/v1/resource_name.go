@@ -19,6 +19,8 @@ const (
 	IdentityProviderNamePrefix = "identityProviders/"
 	ActivityNamePrefix         = "activities/"
 	WebhookNamePrefix          = "webhooks/"
+	WebhookDeliveryNamePrefix  = "deliveries/"
+	IncomingWebhookNamePrefix  = "incomingWebhooks/"
 )
 
 // GetNameParentTokens returns the tokens from a resource name.
@@ -157,3 +159,33 @@ func ExtractWebhookIDFromName(name string) (int32, error) {
 	}
 	return id, nil
 }
+
+// ExtractWebhookDeliveryIDFromName returns the webhook ID and the delivery ID from a resource name.
+func ExtractWebhookDeliveryIDFromName(name string) (int32, int32, error) {
+	tokens, err := GetNameParentTokens(name, WebhookNamePrefix, WebhookDeliveryNamePrefix)
+	if err != nil {
+		return 0, 0, err
+	}
+	webhookID, err := util.ConvertStringToInt32(tokens[0])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid webhook ID %q", tokens[0])
+	}
+	deliveryID, err := util.ConvertStringToInt32(tokens[1])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid webhook delivery ID %q", tokens[1])
+	}
+	return webhookID, deliveryID, nil
+}
+
+// ExtractIncomingWebhookIDFromName returns the incoming webhook ID from a resource name.
+func ExtractIncomingWebhookIDFromName(name string) (int32, error) {
+	tokens, err := GetNameParentTokens(name, IncomingWebhookNamePrefix)
+	if err != nil {
+		return 0, err
+	}
+	id, err := util.ConvertStringToInt32(tokens[0])
+	if err != nil {
+		return 0, errors.Errorf("invalid incoming webhook ID %q", tokens[0])
+	}
+	return id, nil
+}
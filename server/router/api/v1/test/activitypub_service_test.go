@@ -0,0 +1,264 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/usememos/memos/plugin/activitypub"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/webhookdelivery"
+	"github.com/usememos/memos/store"
+)
+
+func TestActivityPub(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+	user, err := ts.CreateRegularUser(ctx, "alice")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	e := echo.New()
+	ts.Service.RegisterActivityPubRoutes(e)
+
+	serve := func(method string, target string, body []byte, sign *activitypub.Actor, remotePrivateKeyPem string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, bytes.NewReader(body))
+		if sign != nil {
+			privateKey, err := activitypub.ParsePrivateKey(remotePrivateKeyPem)
+			require.NoError(t, err)
+			require.NoError(t, activitypub.SignRequest(req, sign.PublicKey.ID, privateKey, body))
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// WebFinger resolves the account to the actor.
+	rec := serve(http.MethodGet, "/.well-known/webfinger?resource=acct:alice@localhost:8080", nil, nil, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, activitypub.JRDContentType, rec.Header().Get(echo.HeaderContentType))
+	webFinger := &activitypub.WebFinger{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), webFinger))
+	require.Equal(t, "acct:alice@localhost:8080", webFinger.Subject)
+	require.Equal(t, "http://localhost:8080/ap/users/alice", webFinger.Links[0].Href)
+	rec = serve(http.MethodGet, "/.well-known/webfinger?resource=acct:alice@other.example", nil, nil, "")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	// The actor publishes the key its activities are signed with.
+	rec = serve(http.MethodGet, "/ap/users/alice", nil, nil, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, activitypub.ContentType, rec.Header().Get(echo.HeaderContentType))
+	actor := &activitypub.Actor{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), actor))
+	require.Equal(t, "http://localhost:8080/ap/users/alice", actor.ID)
+	require.Equal(t, "http://localhost:8080/ap/users/alice/inbox", actor.Inbox)
+	require.Equal(t, actor.ID+"#main-key", actor.PublicKey.ID)
+	publicKey, err := activitypub.ParsePublicKey(actor.PublicKey.PublicKeyPem)
+	require.NoError(t, err)
+	rec = serve(http.MethodGet, "/ap/users/unknown", nil, nil, "")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	// A stub remote server with an actor that follows alice and receives her activities.
+	remotePrivateKeyPem, remotePublicKeyPem, err := activitypub.GenerateKeyPair()
+	require.NoError(t, err)
+	received := make(chan *activitypub.Activity, 10)
+	var unavailable atomic.Bool
+	remote := &activitypub.Actor{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/bob":
+			w.Header().Set("Content-Type", activitypub.ContentType)
+			_ = json.NewEncoder(w).Encode(remote)
+		case "/inbox", "/users/bob/inbox":
+			if unavailable.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			signature, err := activitypub.ParseSignature(r)
+			if err != nil || signature.KeyID != actor.PublicKey.ID || signature.Verify(r, publicKey, body) != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			activity := &activitypub.Activity{}
+			if err := json.Unmarshal(body, activity); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			received <- activity
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	defaultClient := activitypub.HTTPClient
+	activitypub.HTTPClient = server.Client()
+	defer func() { activitypub.HTTPClient = defaultClient }()
+	*remote = activitypub.Actor{
+		ID:        server.URL + "/users/bob",
+		Type:      "Person",
+		Inbox:     server.URL + "/users/bob/inbox",
+		Endpoints: &activitypub.Endpoints{SharedInbox: server.URL + "/inbox"},
+		PublicKey: &activitypub.PublicKey{
+			ID:           server.URL + "/users/bob#main-key",
+			Owner:        server.URL + "/users/bob",
+			PublicKeyPem: remotePublicKeyPem,
+		},
+	}
+	// The activities are queued and delivered by the webhook delivery runner.
+	deliveryRunner := webhookdelivery.NewRunner(ts.Store)
+	receive := func() *activitypub.Activity {
+		deliveryRunner.DeliverPending(ctx)
+		select {
+		case activity := <-received:
+			return activity
+		default:
+			require.FailNow(t, "no activity received")
+			return nil
+		}
+	}
+
+	// Unsigned activities are rejected.
+	follow, err := json.Marshal(&activitypub.Activity{
+		ID:     server.URL + "/follows/1",
+		Type:   activitypub.ActivityTypeFollow,
+		Actor:  remote.ID,
+		Object: actor.ID,
+	})
+	require.NoError(t, err)
+	rec = serve(http.MethodPost, "http://localhost:8080/ap/users/alice/inbox", follow, nil, "")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// An actor of another host, or a key of another actor, can't be signed for.
+	remote.ID = "https://example.com/users/bob"
+	rec = serve(http.MethodPost, "http://localhost:8080/ap/users/alice/inbox", follow, remote, remotePrivateKeyPem)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	remote.ID = server.URL + "/users/bob"
+	remote.PublicKey.Owner = server.URL + "/users/carol"
+	rec = serve(http.MethodPost, "http://localhost:8080/ap/users/alice/inbox", follow, remote, remotePrivateKeyPem)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	remote.PublicKey.Owner = remote.ID
+
+	// A signed follow is stored and accepted.
+	rec = serve(http.MethodPost, "http://localhost:8080/ap/users/alice/inbox", follow, remote, remotePrivateKeyPem)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	accept := receive()
+	require.Equal(t, activitypub.ActivityTypeAccept, accept.Type)
+	require.Equal(t, actor.ID, accept.Actor)
+	require.Equal(t, server.URL+"/follows/1", activitypub.GetObjectID(accept.Object))
+	rec = serve(http.MethodGet, "/ap/users/alice/followers", nil, nil, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	followers := &activitypub.OrderedCollection{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), followers))
+	require.Equal(t, 1, followers.TotalItems)
+
+	// Public memos are delivered to the followers, other memos are not.
+	_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "private memo", Visibility: v1pb.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+	// The failed deliveries are retried.
+	unavailable.Store(true)
+	memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "Hello **fediverse** #memos", Visibility: v1pb.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+	deliveryRunner.DeliverPending(ctx)
+	require.Empty(t, received)
+	pendingStatus := store.WebhookDeliveryPending
+	deliveries, err := ts.Store.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{Status: &pendingStatus})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, int32(1), deliveries[0].Attempts)
+	require.Equal(t, activitypub.ActivityTypeCreate, deliveries[0].Payload.ActivityType)
+	now := time.Now().Unix()
+	_, err = ts.Store.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDelivery{ID: deliveries[0].ID, NextAttemptTs: &now})
+	require.NoError(t, err)
+	unavailable.Store(false)
+	noteID := "http://localhost:8080/ap/" + memo.Name
+	create := receive()
+	require.Equal(t, activitypub.ActivityTypeCreate, create.Type)
+	require.Equal(t, noteID, activitypub.GetObjectID(create.Object))
+	require.Contains(t, create.Object.(map[string]any)["content"], "<strong>fediverse</strong>")
+	require.Equal(t, []string{activitypub.PublicAddress}, create.To)
+
+	// The outbox and the note are public.
+	rec = serve(http.MethodGet, "/ap/users/alice/outbox", nil, nil, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	outbox := &activitypub.OrderedCollection{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), outbox))
+	require.Equal(t, 1, outbox.TotalItems)
+	rec = serve(http.MethodGet, "/ap/users/alice/outbox?page=1", nil, nil, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	outboxPage := &activitypub.OrderedCollectionPage{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), outboxPage))
+	require.Len(t, outboxPage.OrderedItems, 1)
+	require.Empty(t, outboxPage.Next)
+	// The S3 objects are linked through the server, their presigned URLs expire.
+	memoUID := strings.TrimPrefix(memo.Name, "memos/")
+	storeMemo, err := ts.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	require.NoError(t, err)
+	_, err = ts.Store.CreateAttachment(ctx, &store.Attachment{
+		UID:         "photo",
+		CreatorID:   user.ID,
+		Filename:    "peak.jpg",
+		Type:        "image/jpeg",
+		StorageType: storepb.AttachmentStorageType_S3,
+		Reference:   "https://bucket.s3.amazonaws.com/peak.jpg?X-Amz-Expires=432000",
+		MemoID:      &storeMemo.ID,
+	})
+	require.NoError(t, err)
+	rec = serve(http.MethodGet, "/ap/"+memo.Name, nil, nil, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	note := &activitypub.Note{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), note))
+	require.Equal(t, noteID, note.ID)
+	require.Equal(t, "Hello **fediverse** #memos", note.Source.Content)
+	require.Equal(t, "#memos", note.Tag[0].Name)
+	require.Len(t, note.Attachment, 1)
+	require.Equal(t, "http://localhost:8080/file/attachments/photo/peak.jpg", note.Attachment[0].URL)
+
+	// Making the memo private deletes it from the followers.
+	_, err = ts.Service.UpdateMemo(userCtx, &v1pb.UpdateMemoRequest{
+		Memo:       &v1pb.Memo{Name: memo.Name, Visibility: v1pb.Visibility_PRIVATE},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"visibility"}},
+	})
+	require.NoError(t, err)
+	deleteActivity := receive()
+	require.Equal(t, activitypub.ActivityTypeDelete, deleteActivity.Type)
+	require.Equal(t, noteID, activitypub.GetObjectID(deleteActivity.Object))
+	rec = serve(http.MethodGet, "/ap/"+memo.Name, nil, nil, "")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Undoing the follow removes the follower.
+	undo, err := json.Marshal(&activitypub.Activity{
+		ID:    server.URL + "/follows/1#undo",
+		Type:  activitypub.ActivityTypeUndo,
+		Actor: remote.ID,
+		Object: &activitypub.Activity{
+			ID:     server.URL + "/follows/1",
+			Type:   activitypub.ActivityTypeFollow,
+			Actor:  remote.ID,
+			Object: actor.ID,
+		},
+	})
+	require.NoError(t, err)
+	rec = serve(http.MethodPost, "http://localhost:8080/ap/users/alice/inbox", undo, remote, remotePrivateKeyPem)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	rec = serve(http.MethodGet, "/ap/users/alice/followers", nil, nil, "")
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), followers))
+	require.Equal(t, 0, followers.TotalItems)
+}
@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/attachmentgc"
+	"github.com/usememos/memos/server/runner/thumbnail"
+	"github.com/usememos/memos/store"
+)
+
+func TestCleanupOrphanedAttachments(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+	ts.Profile.Data = t.TempDir()
+	assetsFolder := filepath.Join(ts.Profile.Data, "assets")
+	_, err := ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType: storepb.WorkspaceStorageSetting_LOCAL,
+			// The store of the test service has its own data folder, the absolute path works for both.
+			FilepathTemplate: filepath.Join(assetsFolder, "{uuid}_{filename}"),
+		}},
+	})
+	require.NoError(t, err)
+	host, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	hostCtx, userCtx := ts.CreateUserContext(ctx, host.ID), ts.CreateUserContext(ctx, user.ID)
+	expired := time.Now().Add(-8 * 24 * time.Hour)
+
+	upload := func(content string, memoName *string, updatedTime time.Time) *store.Attachment {
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "note.txt", Type: "text/plain", Content: []byte(content), Memo: memoName},
+		})
+		require.NoError(t, err)
+		attachmentUID := attachment.Name[len("attachments/"):]
+		stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+		require.NoError(t, err)
+		updatedTs := updatedTime.Unix()
+		require.NoError(t, ts.Store.UpdateAttachment(ctx, &store.UpdateAttachment{ID: stored.ID, UpdatedTs: &updatedTs}))
+		require.NoError(t, os.Chtimes(stored.Reference, updatedTime, updatedTime))
+		return stored
+	}
+	writeFile := func(filePath string, modifiedTime time.Time) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), os.ModePerm))
+		require.NoError(t, os.WriteFile(filePath, []byte("stray"), 0644))
+		require.NoError(t, os.Chtimes(filePath, modifiedTime, modifiedTime))
+	}
+
+	memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "memo"}})
+	require.NoError(t, err)
+	orphan := upload("orphan", nil, expired)
+	recent := upload("recent", nil, time.Now())
+	attached := upload("attached", &memo.Name, expired)
+	writeFile(filepath.Join(assetsFolder, "stray.txt"), expired)
+	writeFile(filepath.Join(assetsFolder, "uploading.txt"), time.Now())
+	writeFile(filepath.Join(ts.Profile.Data, thumbnail.CacheFolder, "missing_small.jpg"), expired)
+	writeFile(filepath.Join(ts.Profile.Data, thumbnail.CacheFolder, attached.UID+"_small.jpg"), expired)
+	// The files out of the local storage folder are never collected.
+	writeFile(filepath.Join(ts.Profile.Data, "memos_prod.db"), expired)
+
+	_, err = ts.Service.CleanupOrphanedAttachments(userCtx, &v1pb.CleanupOrphanedAttachmentsRequest{DryRun: true})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The orphans are only reported in the dry run.
+	response, err := ts.Service.CleanupOrphanedAttachments(hostCtx, &v1pb.CleanupOrphanedAttachmentsRequest{DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, response.Attachments, 1)
+	require.Equal(t, "attachments/"+orphan.UID, response.Attachments[0].Name)
+	require.ElementsMatch(t, []string{"assets/stray.txt", thumbnail.CacheFolder + "/missing_small.jpg"}, response.Files)
+	require.Equal(t, int64(len("orphan")+2*len("stray")), response.TotalBytes)
+	_, err = os.Stat(filepath.Join(assetsFolder, "stray.txt"))
+	require.NoError(t, err)
+
+	_, err = ts.Service.CleanupOrphanedAttachments(hostCtx, &v1pb.CleanupOrphanedAttachmentsRequest{})
+	require.NoError(t, err)
+	deleted, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{ID: &orphan.ID})
+	require.NoError(t, err)
+	require.Nil(t, deleted)
+	for _, filePath := range []string{orphan.Reference, filepath.Join(assetsFolder, "stray.txt"), filepath.Join(ts.Profile.Data, thumbnail.CacheFolder, "missing_small.jpg")} {
+		_, err = os.Stat(filePath)
+		require.True(t, os.IsNotExist(err), filePath)
+	}
+	for _, filePath := range []string{recent.Reference, attached.Reference, filepath.Join(assetsFolder, "uploading.txt"), filepath.Join(ts.Profile.Data, "memos_prod.db")} {
+		_, err = os.Stat(filePath)
+		require.NoError(t, err, filePath)
+	}
+
+	response, err = ts.Service.CleanupOrphanedAttachments(hostCtx, &v1pb.CleanupOrphanedAttachmentsRequest{DryRun: true})
+	require.NoError(t, err)
+	require.Empty(t, response.Attachments)
+	require.Empty(t, response.Files)
+}
+
+func TestCleanupUnreferencedAttachmentBlobs(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+	ts.Profile.Data = t.TempDir()
+	_, err := ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType:      storepb.WorkspaceStorageSetting_LOCAL,
+			FilepathTemplate: filepath.Join(ts.Profile.Data, "assets", "{uuid}_{filename}"),
+		}},
+	})
+	require.NoError(t, err)
+	host, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hostCtx := ts.CreateUserContext(ctx, host.ID)
+
+	memo, err := ts.Service.CreateMemo(hostCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "memo"}})
+	require.NoError(t, err)
+	_, err = ts.Service.CreateAttachment(hostCtx, &v1pb.CreateAttachmentRequest{
+		Attachment: &v1pb.Attachment{Filename: "kept.txt", Type: "text/plain", Content: []byte("kept"), Memo: &memo.Name},
+	})
+	require.NoError(t, err)
+	// The content of an upload failing to create its attachment is stored without an attachment.
+	failed := &store.Attachment{Filename: "failed.txt", Type: "text/plain", Size: int64(len("failed"))}
+	require.NoError(t, ts.Store.SaveAttachmentContent(ctx, failed, strings.NewReader("failed")))
+	require.NotEmpty(t, failed.ContentHash)
+
+	// The contents are kept for the grace period.
+	response, err := ts.Service.CleanupOrphanedAttachments(hostCtx, &v1pb.CleanupOrphanedAttachmentsRequest{DryRun: true})
+	require.NoError(t, err)
+	require.Empty(t, response.ContentHashes)
+
+	// The timestamps are in seconds, the contents are older than a grace period of zero a second later.
+	time.Sleep(time.Second)
+	runner := attachmentgc.NewRunner(ts.Store, ts.Profile)
+	runner.GracePeriod = 0
+	report, err := runner.Collect(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, report.AttachmentBlobs, 1)
+	require.Equal(t, failed.ContentHash, report.AttachmentBlobs[0].Hash)
+	require.Empty(t, report.Attachments)
+	require.Empty(t, report.Files)
+
+	_, err = runner.Collect(ctx, false)
+	require.NoError(t, err)
+	attachmentBlob, err := ts.Store.GetAttachmentBlob(ctx, &store.FindAttachmentBlob{Hash: &failed.ContentHash})
+	require.NoError(t, err)
+	require.Nil(t, attachmentBlob)
+	_, err = os.Stat(failed.Reference)
+	require.True(t, os.IsNotExist(err))
+	attachmentBlobs, err := ts.Store.ListAttachmentBlobs(ctx, &store.FindAttachmentBlob{})
+	require.NoError(t, err)
+	require.Len(t, attachmentBlobs, 1)
+	_, err = os.Stat(attachmentBlobs[0].Reference)
+	require.NoError(t, err)
+}
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestAttachmentDeduplication(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+	ts.Profile.Data = t.TempDir()
+	_, err := ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType: storepb.WorkspaceStorageSetting_LOCAL,
+			// The store of the test service has its own data folder, the absolute path works for both.
+			FilepathTemplate: filepath.Join(ts.Profile.Data, "assets", "{uuid}_{filename}"),
+		}},
+	})
+	require.NoError(t, err)
+	host, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	hostCtx, userCtx := ts.CreateUserContext(ctx, host.ID), ts.CreateUserContext(ctx, user.ID)
+
+	upload := func(userCtx context.Context, filename string, content string) *store.Attachment {
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: filename, Type: "image/png", Content: []byte(content)},
+		})
+		require.NoError(t, err)
+		attachmentUID := attachment.Name[len("attachments/"):]
+		stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+		require.NoError(t, err)
+		return stored
+	}
+	countFiles := func() int {
+		entries, err := os.ReadDir(filepath.Join(ts.Profile.Data, "assets"))
+		require.NoError(t, err)
+		return len(entries)
+	}
+
+	logo := upload(userCtx, "logo.png", "logo")
+	logoCopy := upload(hostCtx, "copy.png", "logo")
+	upload(userCtx, "other.png", "other")
+	require.Equal(t, logo.ContentHash, logoCopy.ContentHash)
+	require.Equal(t, logo.Reference, logoCopy.Reference)
+	require.Equal(t, 2, countFiles())
+
+	_, err = ts.Service.GetAttachmentDeduplicationReport(userCtx, &v1pb.GetAttachmentDeduplicationReportRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	report, err := ts.Service.GetAttachmentDeduplicationReport(hostCtx, &v1pb.GetAttachmentDeduplicationReportRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), report.UniqueContentCount)
+	require.Equal(t, int32(3), report.AttachmentCount)
+	require.Equal(t, int64(13), report.AttachmentBytes)
+	require.Equal(t, int64(9), report.StoredBytes)
+	require.Equal(t, int64(4), report.SavedBytes)
+
+	// The shared file is kept until its last attachment is deleted.
+	_, err = ts.Service.DeleteAttachment(userCtx, &v1pb.DeleteAttachmentRequest{Name: "attachments/" + logo.UID})
+	require.NoError(t, err)
+	require.Equal(t, 2, countFiles())
+	_, err = ts.Service.GetAttachmentBinary(hostCtx, &v1pb.GetAttachmentBinaryRequest{Name: "attachments/" + logoCopy.UID})
+	require.NoError(t, err)
+	// The shared file reused by an upload is kept for the grace period once its last attachment is deleted.
+	_, err = ts.Service.DeleteAttachment(hostCtx, &v1pb.DeleteAttachmentRequest{Name: "attachments/" + logoCopy.UID})
+	require.NoError(t, err)
+	require.Equal(t, 2, countFiles())
+
+	report, err = ts.Service.GetAttachmentDeduplicationReport(hostCtx, &v1pb.GetAttachmentDeduplicationReportRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), report.UniqueContentCount)
+	require.Equal(t, int64(0), report.SavedBytes)
+}
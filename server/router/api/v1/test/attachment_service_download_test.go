@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+func TestAttachmentDownload(t *testing.T) {
+	ctx := context.Background()
+
+	serve := func(e *echo.Echo, target string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, storageType := range []storepb.WorkspaceStorageSetting_StorageType{storepb.WorkspaceStorageSetting_DATABASE, storepb.WorkspaceStorageSetting_LOCAL} {
+		t.Run(storageType.String(), func(t *testing.T) {
+			ts := NewTestService(t)
+			defer ts.Cleanup()
+			ts.Profile.Data = t.TempDir()
+			_, err := ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+				Key: storepb.WorkspaceSettingKey_STORAGE,
+				Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+					StorageType: storageType,
+					// The store of the test service has its own data folder, the absolute path works for both.
+					FilepathTemplate: filepath.Join(ts.Profile.Data, "assets", "{timestamp}_{filename}"),
+				}},
+			})
+			require.NoError(t, err)
+			user, err := ts.CreateRegularUser(ctx, "user")
+			require.NoError(t, err)
+			userCtx := ts.CreateUserContext(ctx, user.ID)
+			e := echo.New()
+			ts.Service.RegisterAttachmentDownloadRoutes(e)
+
+			attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+				Attachment: &v1pb.Attachment{Filename: "résumé.txt", Type: "text/plain", Content: []byte("0123456789")},
+			})
+			require.NoError(t, err)
+			target := "/file/" + attachment.Name + "/resume.txt"
+
+			rec := serve(e, target, nil)
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, "0123456789", rec.Body.String())
+			require.Equal(t, "text/plain; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+			require.Equal(t, "bytes", rec.Header().Get("Accept-Ranges"))
+			lastModified := rec.Header().Get("Last-Modified")
+			require.NotEmpty(t, lastModified)
+			require.Equal(t, "inline; filename*=utf-8''r%C3%A9sum%C3%A9.txt", rec.Header().Get(echo.HeaderContentDisposition))
+			require.Equal(t, "sandbox", rec.Header().Get("Content-Security-Policy"))
+			etag := rec.Header().Get("ETag")
+			require.NotEmpty(t, etag)
+
+			rec = serve(e, target, map[string]string{"Range": "bytes=2-5"})
+			require.Equal(t, http.StatusPartialContent, rec.Code)
+			require.Equal(t, "2345", rec.Body.String())
+			require.Equal(t, "bytes 2-5/10", rec.Header().Get("Content-Range"))
+
+			rec = serve(e, target, map[string]string{"If-None-Match": etag})
+			require.Equal(t, http.StatusNotModified, rec.Code)
+			rec = serve(e, target, map[string]string{"If-Modified-Since": lastModified})
+			require.Equal(t, http.StatusNotModified, rec.Code)
+
+			rec = serve(e, target+"?download=true", nil)
+			require.True(t, strings.HasPrefix(rec.Header().Get(echo.HeaderContentDisposition), "attachment;"))
+		})
+	}
+
+	t.Run("Unsafe types are downloaded whatever their parameters", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+		e := echo.New()
+		ts.Service.RegisterAttachmentDownloadRoutes(e)
+
+		for _, attachmentType := range []string{"text/html; charset=utf-8", "Image/SVG+XML", "application/xhtml+xml;charset=UTF-8"} {
+			attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+				Attachment: &v1pb.Attachment{Filename: "page.html", Type: attachmentType, Content: []byte("<script>alert(1)</script>")},
+			})
+			require.NoError(t, err)
+
+			rec := serve(e, "/file/"+attachment.Name+"/page.html", nil)
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, echo.MIMEOctetStream, rec.Header().Get(echo.HeaderContentType), attachmentType)
+			require.True(t, strings.HasPrefix(rec.Header().Get(echo.HeaderContentDisposition), "attachment;"), attachmentType)
+		}
+	})
+
+	t.Run("Private memo attachments require their creator", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+		e := echo.New()
+		ts.Service.RegisterAttachmentDownloadRoutes(e)
+
+		memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "secret", Visibility: v1pb.Visibility_PRIVATE}})
+		require.NoError(t, err)
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "secret.txt", Type: "text/plain", Content: []byte("secret"), Memo: &memo.Name},
+		})
+		require.NoError(t, err)
+		target := "/file/" + attachment.Name + "/secret.txt"
+
+		rec := serve(e, target, nil)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		rec = serve(e, target, map[string]string{echo.HeaderAuthorization: "Bearer " + createAccessToken(t, ts, user)})
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "secret", rec.Body.String())
+	})
+}
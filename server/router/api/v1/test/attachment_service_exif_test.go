@@ -0,0 +1,157 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/usememos/memos/plugin/exif"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestAttachmentExif(t *testing.T) {
+	ctx := context.Background()
+
+	photo := newGeotaggedPhoto(t)
+
+	setup := func(t *testing.T, policy storepb.WorkspaceStorageSetting_ExifStripPolicy) (*TestService, context.Context) {
+		ts := NewTestService(t)
+		_, err := ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+			Key: storepb.WorkspaceSettingKey_STORAGE,
+			Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+				StorageType:     storepb.WorkspaceStorageSetting_DATABASE,
+				ExifStripPolicy: policy,
+			}},
+		})
+		require.NoError(t, err)
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		return ts, ts.CreateUserContext(ctx, user.ID)
+	}
+	getStoredExif := func(t *testing.T, ts *TestService, attachment *v1pb.Attachment) *exif.Metadata {
+		attachmentUID := attachment.Name[len("attachments/"):]
+		stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID, GetBlob: true})
+		require.NoError(t, err)
+		require.Equal(t, int64(len(stored.Blob)), stored.Size)
+		metadata, err := exif.Extract(stored.Blob)
+		require.NoError(t, err)
+		return metadata
+	}
+
+	t.Run("Metadata is extracted and the location is only shown to the creator", func(t *testing.T) {
+		ts, userCtx := setup(t, storepb.WorkspaceStorageSetting_EXIF_STRIP_POLICY_UNSPECIFIED)
+		defer ts.Cleanup()
+		memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "trip", Visibility: v1pb.Visibility_PROTECTED}})
+		require.NoError(t, err)
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment:      &v1pb.Attachment{Filename: "photo.jpg", Type: "image/jpeg", Content: photo, Memo: &memo.Name},
+			SetMemoLocation: true,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, attachment.ImageMetadata)
+		require.Equal(t, int32(60), attachment.ImageMetadata.Width)
+		require.Equal(t, int32(40), attachment.ImageMetadata.Height)
+		require.Equal(t, "Pixel", attachment.ImageMetadata.CameraMake)
+		require.NotNil(t, attachment.ImageMetadata.Location)
+		require.InDelta(t, 35.683, attachment.ImageMetadata.Location.Latitude, 0.001)
+		require.InDelta(t, 139.7, attachment.ImageMetadata.Location.Longitude, 0.001)
+		require.NotNil(t, getStoredExif(t, ts, attachment))
+
+		memo, err = ts.Service.GetMemo(userCtx, &v1pb.GetMemoRequest{Name: memo.Name})
+		require.NoError(t, err)
+		require.NotNil(t, memo.Location)
+		require.InDelta(t, 35.683, memo.Location.Latitude, 0.001)
+
+		other, err := ts.CreateRegularUser(ctx, "other")
+		require.NoError(t, err)
+		attachment, err = ts.Service.GetAttachment(ts.CreateUserContext(ctx, other.ID), &v1pb.GetAttachmentRequest{Name: attachment.Name})
+		require.NoError(t, err)
+		require.Equal(t, "Pixel", attachment.ImageMetadata.CameraMake)
+		require.Nil(t, attachment.ImageMetadata.Location)
+	})
+
+	t.Run("EXIF data is always stripped", func(t *testing.T) {
+		ts, userCtx := setup(t, storepb.WorkspaceStorageSetting_ALWAYS)
+		defer ts.Cleanup()
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "photo.jpg", Type: "image/jpeg", Content: photo},
+		})
+		require.NoError(t, err)
+		require.Less(t, attachment.Size, int64(len(photo)))
+		require.Equal(t, "Pixel", attachment.ImageMetadata.CameraMake)
+		require.Nil(t, getStoredExif(t, ts, attachment))
+	})
+
+	t.Run("EXIF data is stripped when the memo becomes public", func(t *testing.T) {
+		ts, userCtx := setup(t, storepb.WorkspaceStorageSetting_PUBLIC_MEMOS)
+		defer ts.Cleanup()
+		memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "trip", Visibility: v1pb.Visibility_PRIVATE}})
+		require.NoError(t, err)
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "photo.jpg", Type: "image/jpeg", Content: photo, Memo: &memo.Name},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, getStoredExif(t, ts, attachment))
+
+		memo.Visibility = v1pb.Visibility_PUBLIC
+		_, err = ts.Service.UpdateMemo(userCtx, &v1pb.UpdateMemoRequest{Memo: memo, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"visibility"}}})
+		require.NoError(t, err)
+		require.Nil(t, getStoredExif(t, ts, attachment))
+		attachment, err = ts.Service.GetAttachment(userCtx, &v1pb.GetAttachmentRequest{Name: attachment.Name})
+		require.NoError(t, err)
+		require.Less(t, attachment.Size, int64(len(photo)))
+		// The metadata is kept after the EXIF data is stripped.
+		require.NotNil(t, attachment.ImageMetadata.Location)
+
+		// The photos attached to public memos are stripped on upload.
+		attachment, err = ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "photo.jpg", Type: "image/jpeg", Content: photo, Memo: &memo.Name},
+		})
+		require.NoError(t, err)
+		require.Nil(t, getStoredExif(t, ts, attachment))
+	})
+
+	t.Run("Memo isn't made public when its EXIF data can't be stripped", func(t *testing.T) {
+		ts, userCtx := setup(t, storepb.WorkspaceStorageSetting_PUBLIC_MEMOS)
+		defer ts.Cleanup()
+		memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "trip", Visibility: v1pb.Visibility_PRIVATE}})
+		require.NoError(t, err)
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "photo.jpg", Type: "image/jpeg", Content: photo, Memo: &memo.Name},
+		})
+		require.NoError(t, err)
+		attachmentUID := attachment.Name[len("attachments/"):]
+		stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+		require.NoError(t, err)
+		// The stored content is truncated, so its EXIF data can't be read.
+		require.NoError(t, ts.Store.UpdateAttachmentBlob(ctx, &store.UpdateAttachmentBlob{Hash: stored.ContentHash, Blob: photo[:30]}))
+
+		memo.Visibility = v1pb.Visibility_PUBLIC
+		_, err = ts.Service.UpdateMemo(userCtx, &v1pb.UpdateMemoRequest{Memo: memo, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"visibility"}}})
+		require.Error(t, err)
+		memo, err = ts.Service.GetMemo(userCtx, &v1pb.GetMemoRequest{Name: memo.Name})
+		require.NoError(t, err)
+		require.Equal(t, v1pb.Visibility_PRIVATE, memo.Visibility)
+	})
+}
+
+// newGeotaggedPhoto returns a JPEG photo with the EXIF data of a "Pixel" camera at 35°41'N 139°42'E.
+func newGeotaggedPhoto(t *testing.T) []byte {
+	tiff, err := base64.StdEncoding.DecodeString("TU0AKgAAAAgAAgEPAAIAAAAGAAAAJoglAAQAAAABAAAALAAAAABQaXhlbAAABAABAAIAAAACTgAAAAACAAUAAAADAAAAYgADAAIAAAACRQAAAAAEAAUAAAADAAAAegAAAAAAAAAjAAAAAQAAACkAAAABAAAAAAAAAAEAAACLAAAAAQAAACoAAAABAAAAAAAAAAE=")
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(buffer, image.NewNRGBA(image.Rect(0, 0, 60, 40)), nil))
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xff, 0xe1, byte((len(segment) + 2) >> 8), byte(len(segment) + 2)}
+	return append(append(append([]byte{0xff, 0xd8}, app1...), segment...), buffer.Bytes()[2:]...)
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestMigrateAttachmentStorage(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+	ts.Profile.Data = t.TempDir()
+	host, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	hostCtx, userCtx := ts.CreateUserContext(ctx, host.ID), ts.CreateUserContext(ctx, user.ID)
+
+	// The attachments are uploaded to the database, then the storage is switched to the local storage.
+	var attachmentNames []string
+	for _, content := range []string{"first", "second", "first"} {
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "note.txt", Type: "text/plain", Content: []byte(content)},
+		})
+		require.NoError(t, err)
+		attachmentNames = append(attachmentNames, attachment.Name)
+	}
+	_, err = ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType: storepb.WorkspaceStorageSetting_LOCAL,
+			// The store of the test service has its own data folder, the absolute path works for both.
+			FilepathTemplate: filepath.Join(ts.Profile.Data, "assets", "{uuid}_{filename}"),
+		}},
+	})
+	require.NoError(t, err)
+
+	request := &v1pb.MigrateAttachmentStorageRequest{
+		Source: v1pb.WorkspaceStorageSetting_DATABASE,
+		Target: v1pb.WorkspaceStorageSetting_LOCAL,
+		Limit:  1,
+	}
+	_, err = ts.Service.MigrateAttachmentStorage(userCtx, request)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The migration is called again until no attachment remains in the source storage.
+	migratedCount := int32(0)
+	for range 3 {
+		response, err := ts.Service.MigrateAttachmentStorage(hostCtx, request)
+		require.NoError(t, err)
+		require.Zero(t, response.FailedCount)
+		migratedCount += response.MigratedCount
+		if response.RemainingCount == 0 {
+			break
+		}
+		require.Equal(t, 3-migratedCount, response.RemainingCount)
+	}
+	require.Equal(t, int32(3), migratedCount)
+
+	entries, err := os.ReadDir(filepath.Join(ts.Profile.Data, "assets"))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for i, content := range []string{"first", "second", "first"} {
+		attachmentUID := attachmentNames[i][len("attachments/"):]
+		attachment, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+		require.NoError(t, err)
+		require.Equal(t, storepb.AttachmentStorageType_LOCAL, attachment.StorageType)
+		stored, err := os.ReadFile(attachment.Reference)
+		require.NoError(t, err)
+		require.Equal(t, content, string(stored))
+	}
+
+	_, err = ts.Service.MigrateAttachmentStorage(hostCtx, &v1pb.MigrateAttachmentStorageRequest{
+		Source: v1pb.WorkspaceStorageSetting_LOCAL,
+		Target: v1pb.WorkspaceStorageSetting_LOCAL,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
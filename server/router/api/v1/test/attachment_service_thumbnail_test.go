@@ -0,0 +1,152 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/runner/thumbnail"
+	"github.com/usememos/memos/store"
+)
+
+func TestAttachmentThumbnails(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*TestService, *echo.Echo, context.Context) {
+		ts := NewTestService(t)
+		ts.Profile.Data = t.TempDir()
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		e := echo.New()
+		ts.Service.RegisterAttachmentDownloadRoutes(e)
+		return ts, e, ts.CreateUserContext(ctx, user.ID)
+	}
+	upload := func(t *testing.T, ts *TestService, userCtx context.Context, filename string, attachmentType string, content []byte) *v1pb.Attachment {
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: filename, Type: attachmentType, Content: content},
+		})
+		require.NoError(t, err)
+		return attachment
+	}
+	getThumbnail := func(t *testing.T, e *echo.Echo, attachment *v1pb.Attachment, preset string) (image.Image, string) {
+		req := httptest.NewRequest(http.MethodGet, "/file/"+attachment.Name+"/"+attachment.Filename+"?thumbnail="+preset, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		img, _, err := image.Decode(rec.Body)
+		require.NoError(t, err)
+		return img, rec.Header().Get(echo.HeaderContentType)
+	}
+	encode := func(t *testing.T, encode func(*bytes.Buffer, image.Image) error, width int, height int) []byte {
+		buffer := &bytes.Buffer{}
+		require.NoError(t, encode(buffer, image.NewNRGBA(image.Rect(0, 0, width, height))))
+		return buffer.Bytes()
+	}
+	encodePNG := func(buffer *bytes.Buffer, img image.Image) error { return png.Encode(buffer, img) }
+	encodeJPEG := func(buffer *bytes.Buffer, img image.Image) error { return jpeg.Encode(buffer, img, nil) }
+	encodeGIF := func(buffer *bytes.Buffer, img image.Image) error { return gif.Encode(buffer, img, nil) }
+
+	t.Run("Presets scale down the images", func(t *testing.T) {
+		ts, e, userCtx := setup(t)
+		defer ts.Cleanup()
+		attachment := upload(t, ts, userCtx, "photo.png", "image/png", encode(t, encodePNG, 2000, 1000))
+
+		img, contentType := getThumbnail(t, e, attachment, "small")
+		require.Equal(t, "image/png", contentType)
+		require.Equal(t, image.Pt(256, 128), img.Bounds().Size())
+		img, _ = getThumbnail(t, e, attachment, "true")
+		require.Equal(t, image.Pt(640, 320), img.Bounds().Size())
+		img, _ = getThumbnail(t, e, attachment, "large")
+		require.Equal(t, image.Pt(1280, 640), img.Bounds().Size())
+
+		req := httptest.NewRequest(http.MethodGet, "/file/"+attachment.Name+"/photo.png?thumbnail=huge", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("GIF and WebP images are supported", func(t *testing.T) {
+		ts, e, userCtx := setup(t)
+		defer ts.Cleanup()
+
+		attachment := upload(t, ts, userCtx, "animation.gif", "image/gif", encode(t, encodeGIF, 100, 50))
+		img, contentType := getThumbnail(t, e, attachment, "small")
+		require.Equal(t, "image/png", contentType)
+		// Small images are not scaled up.
+		require.Equal(t, image.Pt(100, 50), img.Bounds().Size())
+
+		// A 1x1 lossless WebP image.
+		webp, err := base64.StdEncoding.DecodeString("UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA==")
+		require.NoError(t, err)
+		attachment = upload(t, ts, userCtx, "pixel.webp", "image/webp", webp)
+		img, _ = getThumbnail(t, e, attachment, "small")
+		require.Equal(t, image.Pt(1, 1), img.Bounds().Size())
+	})
+
+	t.Run("JPEG images are rotated by their EXIF orientation", func(t *testing.T) {
+		ts, e, userCtx := setup(t)
+		defer ts.Cleanup()
+
+		// An APP1 segment with the orientation 6, which is rotated by 90 degrees clockwise.
+		exif := []byte("Exif\x00\x00MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00\x00\x00\x00\x00")
+		app1 := append([]byte{0xff, 0xe1, byte((len(exif) + 2) >> 8), byte(len(exif) + 2)}, exif...)
+		content := encode(t, encodeJPEG, 200, 100)
+		content = append(append([]byte{0xff, 0xd8}, app1...), content[2:]...)
+		attachment := upload(t, ts, userCtx, "photo.jpg", "image/jpeg", content)
+
+		img, contentType := getThumbnail(t, e, attachment, "small")
+		require.Equal(t, "image/jpeg", contentType)
+		require.Equal(t, image.Pt(100, 200), img.Bounds().Size())
+	})
+
+	t.Run("Runner generates and cleans up the thumbnails", func(t *testing.T) {
+		ts, _, userCtx := setup(t)
+		defer ts.Cleanup()
+		runner := thumbnail.NewRunner(ts.Store, ts.Profile)
+		ts.Service.ThumbnailRunner = runner
+		attachment := upload(t, ts, userCtx, "photo.png", "image/png", encode(t, encodePNG, 800, 600))
+		upload(t, ts, userCtx, "note.txt", "text/plain", []byte("note"))
+
+		cacheFolder := filepath.Join(ts.Profile.Data, thumbnail.CacheFolder)
+		require.NoError(t, os.MkdirAll(cacheFolder, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(cacheFolder, "deleted_small.png"), []byte("orphan"), 0644))
+		broken := upload(t, ts, userCtx, "broken.png", "image/png", []byte("not an image"))
+		runner.RunOnce(ctx)
+		entries, err := os.ReadDir(cacheFolder)
+		require.NoError(t, err)
+		require.Len(t, entries, len(thumbnail.Presets)+1)
+
+		// The images failing to be decoded aren't decoded again.
+		brokenUID := strings.TrimPrefix(broken.Name, "attachments/")
+		failurePath := filepath.Join(cacheFolder, brokenUID+"_failed")
+		require.FileExists(t, failurePath)
+		require.NoError(t, os.WriteFile(failurePath, []byte("cached"), 0644))
+		brokenAttachment, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &brokenUID})
+		require.NoError(t, err)
+		preset, _ := thumbnail.GetPreset(thumbnail.DefaultPreset)
+		_, err = runner.GetOrGenerate(ctx, brokenAttachment, preset)
+		require.ErrorContains(t, err, "cached")
+
+		for _, name := range []string{attachment.Name, broken.Name} {
+			_, err = ts.Service.DeleteAttachment(userCtx, &v1pb.DeleteAttachmentRequest{Name: name})
+			require.NoError(t, err)
+		}
+		entries, err = os.ReadDir(cacheFolder)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestEventBus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Actions publish typed events", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		var events []*apiv1.Event
+		ts.Service.EventBus().Subscribe(func(_ context.Context, event *apiv1.Event) error {
+			events = append(events, event)
+			return nil
+		})
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		regularUser, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, regularUser.ID)
+
+		memo, err := ts.Service.CreateMemo(hostCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "hello", Visibility: v1pb.Visibility_PUBLIC}})
+		require.NoError(t, err)
+		other, err := ts.Service.CreateMemo(hostCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "other", Visibility: v1pb.Visibility_PUBLIC}})
+		require.NoError(t, err)
+		events = nil
+
+		comment, err := ts.Service.CreateMemoComment(userCtx, &v1pb.CreateMemoCommentRequest{
+			Name:    memo.Name,
+			Comment: &v1pb.Memo{Content: "nice", Visibility: v1pb.Visibility_PUBLIC},
+		})
+		require.NoError(t, err)
+		reaction, err := ts.Service.UpsertMemoReaction(userCtx, &v1pb.UpsertMemoReactionRequest{
+			Reaction: &v1pb.Reaction{ContentId: memo.Name, ReactionType: "👍"},
+		})
+		require.NoError(t, err)
+		_, err = ts.Service.DeleteMemoReaction(userCtx, &v1pb.DeleteMemoReactionRequest{Name: reaction.Name})
+		require.NoError(t, err)
+		_, err = ts.Service.SetMemoRelations(hostCtx, &v1pb.SetMemoRelationsRequest{
+			Name:      memo.Name,
+			Relations: []*v1pb.MemoRelation{{RelatedMemo: &v1pb.MemoRelation_Memo{Name: other.Name}, Type: v1pb.MemoRelation_REFERENCE}},
+		})
+		require.NoError(t, err)
+		attachment, err := ts.Service.CreateAttachment(hostCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "note.txt", Type: "text/plain", Content: []byte("note"), Memo: &memo.Name},
+		})
+		require.NoError(t, err)
+		_, err = ts.Service.DeleteAttachment(hostCtx, &v1pb.DeleteAttachmentRequest{Name: attachment.Name})
+		require.NoError(t, err)
+		_, err = ts.Service.CreateUser(hostCtx, &v1pb.CreateUserRequest{User: &v1pb.User{Username: "newuser", Password: "password", Role: v1pb.User_USER}})
+		require.NoError(t, err)
+
+		var eventTypes []string
+		for _, event := range events {
+			eventTypes = append(eventTypes, event.Type)
+		}
+		// The comment itself is a memo created by the commenter, and the activity log notifies the memo creator
+		// while the comment event is handled.
+		require.Equal(t, []string{
+			apiv1.EventMemoCreated,
+			apiv1.EventInboxCreated,
+			apiv1.EventCommentCreated,
+			apiv1.EventReactionAdded,
+			apiv1.EventReactionRemoved,
+			apiv1.EventMemoRelationChanged,
+			apiv1.EventAttachmentUploaded,
+			apiv1.EventAttachmentDeleted,
+			apiv1.EventUserCreated,
+		}, eventTypes)
+
+		require.Equal(t, hostUser.ID, events[1].OwnerID)
+		commentEvent := events[2]
+		require.Equal(t, regularUser.ID, commentEvent.ActorID)
+		require.Equal(t, hostUser.ID, commentEvent.OwnerID)
+		require.Equal(t, memo.Name, commentEvent.Memo.Name)
+		require.Equal(t, comment.Name, commentEvent.Comment.Name)
+		require.Equal(t, "👍", events[4].Reaction.ReactionType)
+		require.Len(t, events[5].Relations, 1)
+		require.Equal(t, other.Name, events[5].Relations[0].RelatedMemo.Name)
+		require.Equal(t, memo.Name, events[6].Memo.Name)
+		require.Equal(t, "newuser", events[8].User.Username)
+
+		// The activity log subscribes to the comments of other users.
+		inboxes, err := ts.Store.ListInboxes(ctx, &store.FindInbox{ReceiverID: &hostUser.ID})
+		require.NoError(t, err)
+		require.Len(t, inboxes, 1)
+	})
+
+	t.Run("Webhooks receive the event specific payload", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		regularUser, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, regularUser.ID)
+		webhook, err := ts.Service.CreateWebhook(hostCtx, &v1pb.CreateWebhookRequest{
+			Webhook: &v1pb.Webhook{
+				Url:        "https://example.com/webhook",
+				EventTypes: []string{apiv1.EventReactionAdded},
+			},
+		})
+		require.NoError(t, err)
+
+		memo, err := ts.Service.CreateMemo(hostCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "hello", Visibility: v1pb.Visibility_PUBLIC}})
+		require.NoError(t, err)
+		_, err = ts.Service.UpsertMemoReaction(userCtx, &v1pb.UpsertMemoReactionRequest{
+			Reaction: &v1pb.Reaction{ContentId: memo.Name, ReactionType: "🎉"},
+		})
+		require.NoError(t, err)
+
+		resp, err := ts.Service.ListWebhookDeliveries(hostCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: webhook.Name})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, 1)
+		payload := &v1pb.WebhookRequestPayload{}
+		require.NoError(t, protojson.Unmarshal([]byte(resp.Deliveries[0].RequestBody), payload))
+		require.Equal(t, apiv1.EventReactionAdded, payload.ActivityType)
+		require.Equal(t, fmt.Sprintf("users/%d", regularUser.ID), payload.Creator)
+		require.Equal(t, memo.Name, payload.Memo.Name)
+		require.Equal(t, "🎉", payload.GetReaction().ReactionType)
+	})
+}
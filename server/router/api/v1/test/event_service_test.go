@@ -0,0 +1,226 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+)
+
+type serverSentEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+func TestEventStream(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*TestService, *httptest.Server) {
+		ts := NewTestService(t)
+		e := echo.New()
+		ts.Service.RegisterEventRoutes(e)
+		server := httptest.NewServer(e)
+		t.Cleanup(server.Close)
+		return ts, server
+	}
+	connect := func(t *testing.T, ctx context.Context, server *httptest.Server, query string, header http.Header) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+apiv1.EventStreamPath+query, nil)
+		require.NoError(t, err)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+	read := func(t *testing.T, scanner *bufio.Scanner, count int) []serverSentEvent {
+		var events []serverSentEvent
+		event := serverSentEvent{}
+		for len(events) < count && scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				events = append(events, event)
+				event = serverSentEvent{}
+			case strings.HasPrefix(line, "id: "):
+				event.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				event.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+		require.NoError(t, scanner.Err())
+		require.Len(t, events, count)
+		return events
+	}
+	getMemoName := func(t *testing.T, event serverSentEvent) string {
+		response := &v1pb.WatchEventsResponse{}
+		require.NoError(t, protojson.Unmarshal([]byte(event.data), response))
+		return response.Memo.GetName()
+	}
+
+	t.Run("Anonymous viewers watch public memos and resume", func(t *testing.T) {
+		ts, server := setup(t)
+		defer ts.Cleanup()
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		resp := connect(t, streamCtx, server, "", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get(echo.HeaderContentType))
+
+		first, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "first", Visibility: v1pb.Visibility_PUBLIC}})
+		require.NoError(t, err)
+		_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "secret", Visibility: v1pb.Visibility_PRIVATE}})
+		require.NoError(t, err)
+		second, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "second", Visibility: v1pb.Visibility_PUBLIC}})
+		require.NoError(t, err)
+
+		events := read(t, bufio.NewScanner(resp.Body), 2)
+		require.Equal(t, apiv1.EventMemoCreated, events[0].event)
+		require.Equal(t, first.Name, getMemoName(t, events[0]))
+		require.Equal(t, second.Name, getMemoName(t, events[1]))
+		require.NotEmpty(t, events[0].id)
+		cancel()
+
+		// Reconnecting with the id of the last received event sends the missed events.
+		resumeCtx, resumeCancel := context.WithCancel(ctx)
+		defer resumeCancel()
+		resp = connect(t, resumeCtx, server, "", http.Header{"Last-Event-Id": {events[0].id}})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		events = read(t, bufio.NewScanner(resp.Body), 1)
+		require.Equal(t, second.Name, getMemoName(t, events[0]))
+	})
+
+	t.Run("Users watch their private memos and inbox", func(t *testing.T) {
+		ts, server := setup(t)
+		defer ts.Cleanup()
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+		accessToken, err := ts.Service.CreateUserAccessToken(hostCtx, &v1pb.CreateUserAccessTokenRequest{
+			Parent:      fmt.Sprintf("users/%d", hostUser.ID),
+			AccessToken: &v1pb.UserAccessToken{Description: "events"},
+		})
+		require.NoError(t, err)
+
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		resp := connect(t, streamCtx, server, "?event_types="+apiv1.EventMemoCreated+","+apiv1.EventInboxCreated, http.Header{
+			echo.HeaderAuthorization: {"Bearer " + accessToken.AccessToken},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		memo, err := ts.Service.CreateMemo(hostCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "secret", Visibility: v1pb.Visibility_PRIVATE}})
+		require.NoError(t, err)
+		_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "other secret", Visibility: v1pb.Visibility_PRIVATE}})
+		require.NoError(t, err)
+		public, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "public", Visibility: v1pb.Visibility_PUBLIC}})
+		require.NoError(t, err)
+		comment, err := ts.Service.CreateMemoComment(userCtx, &v1pb.CreateMemoCommentRequest{
+			Name:    memo.Name,
+			Comment: &v1pb.Memo{Content: "nice", Visibility: v1pb.Visibility_PUBLIC},
+		})
+		require.NoError(t, err)
+
+		events := read(t, bufio.NewScanner(resp.Body), 4)
+		require.Equal(t, apiv1.EventMemoCreated, events[0].event)
+		require.Equal(t, memo.Name, getMemoName(t, events[0]))
+		require.Equal(t, apiv1.EventMemoCreated, events[1].event)
+		require.Equal(t, public.Name, getMemoName(t, events[1]))
+		require.Equal(t, comment.Name, getMemoName(t, events[2]))
+		require.Equal(t, apiv1.EventInboxCreated, events[3].event)
+	})
+
+	t.Run("Image locations are only streamed to their creators", func(t *testing.T) {
+		ts, server := setup(t)
+		defer ts.Cleanup()
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+		viewer, err := ts.CreateRegularUser(ctx, "viewer")
+		require.NoError(t, err)
+
+		getMemo := func(t *testing.T, event serverSentEvent) *v1pb.Memo {
+			response := &v1pb.WatchEventsResponse{}
+			require.NoError(t, protojson.Unmarshal([]byte(event.data), response))
+			return response.Memo
+		}
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		creatorResp := connect(t, streamCtx, server, "?event_types="+apiv1.EventMemoCreated, http.Header{
+			echo.HeaderAuthorization: {"Bearer " + createAccessToken(t, ts, user)},
+		})
+		require.Equal(t, http.StatusOK, creatorResp.StatusCode)
+		viewerResp := connect(t, streamCtx, server, "?event_types="+apiv1.EventMemoCreated, http.Header{
+			echo.HeaderAuthorization: {"Bearer " + createAccessToken(t, ts, viewer)},
+		})
+		require.Equal(t, http.StatusOK, viewerResp.StatusCode)
+
+		attachment, err := ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "photo.jpg", Type: "image/jpeg", Content: newGeotaggedPhoto(t)},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, attachment.ImageMetadata.Location)
+		_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{
+			Content:     "trip",
+			Visibility:  v1pb.Visibility_PUBLIC,
+			Attachments: []*v1pb.Attachment{{Name: attachment.Name}},
+		}})
+		require.NoError(t, err)
+
+		memo := getMemo(t, read(t, bufio.NewScanner(creatorResp.Body), 1)[0])
+		require.Len(t, memo.Attachments, 1)
+		require.NotNil(t, memo.Attachments[0].ImageMetadata.Location)
+		memo = getMemo(t, read(t, bufio.NewScanner(viewerResp.Body), 1)[0])
+		require.Len(t, memo.Attachments, 1)
+		require.NotNil(t, memo.Attachments[0].ImageMetadata)
+		require.Nil(t, memo.Attachments[0].ImageMetadata.Location)
+	})
+
+	t.Run("Streams end when the service shuts down", func(t *testing.T) {
+		ts, server := setup(t)
+		defer ts.Cleanup()
+
+		resp := connect(t, ctx, server, "", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		ts.Service.Shutdown()
+		events := read(t, bufio.NewScanner(resp.Body), 1)
+		require.Equal(t, "error", events[0].event)
+		resp = connect(t, ctx, server, "", nil)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	})
+
+	t.Run("Invalid requests are rejected", func(t *testing.T) {
+		ts, server := setup(t)
+		defer ts.Cleanup()
+
+		resp := connect(t, ctx, server, "?resume_token=invalid", nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp = connect(t, ctx, server, "?resume_token=1-1", nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp = connect(t, ctx, server, "?event_types=memos.user.created", nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp = connect(t, ctx, server, "", http.Header{echo.HeaderAuthorization: {"Bearer invalid"}})
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
@@ -0,0 +1,223 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestIncomingWebhookService(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("CreateIncomingWebhook success", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		incomingWebhook, err := ts.Service.CreateIncomingWebhook(userCtx, &v1pb.CreateIncomingWebhookRequest{
+			IncomingWebhook: &v1pb.IncomingWebhook{
+				DisplayName: "CI",
+				Tags:        []string{"#ci", " build status "},
+				Visibility:  v1pb.Visibility_PROTECTED,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "CI", incomingWebhook.DisplayName)
+		require.Equal(t, []string{"ci", "build-status"}, incomingWebhook.Tags)
+		require.Equal(t, v1pb.Visibility_PROTECTED, incomingWebhook.Visibility)
+		require.True(t, strings.HasPrefix(incomingWebhook.Path, "/hooks/in/"))
+		require.Contains(t, incomingWebhook.Name, "incomingWebhooks/")
+
+		response, err := ts.Service.ListIncomingWebhooks(userCtx, &v1pb.ListIncomingWebhooksRequest{})
+		require.NoError(t, err)
+		require.Len(t, response.IncomingWebhooks, 1)
+	})
+
+	t.Run("CreateIncomingWebhook with invalid template", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		_, err = ts.Service.CreateIncomingWebhook(userCtx, &v1pb.CreateIncomingWebhookRequest{
+			IncomingWebhook: &v1pb.IncomingWebhook{
+				DisplayName: "CI",
+				Template:    "{{ .Body",
+			},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid template")
+	})
+
+	t.Run("UpdateIncomingWebhook rotates path", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+
+		incomingWebhook, err := ts.Service.CreateIncomingWebhook(userCtx, &v1pb.CreateIncomingWebhookRequest{
+			IncomingWebhook: &v1pb.IncomingWebhook{DisplayName: "CI"},
+		})
+		require.NoError(t, err)
+
+		updated, err := ts.Service.UpdateIncomingWebhook(userCtx, &v1pb.UpdateIncomingWebhookRequest{
+			IncomingWebhook: &v1pb.IncomingWebhook{
+				Name:        incomingWebhook.Name,
+				DisplayName: "Monitoring",
+				Template:    "Alert: {{ .Data.title }}",
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"display_name", "template", "path"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Monitoring", updated.DisplayName)
+		require.Equal(t, "Alert: {{ .Data.title }}", updated.Template)
+		require.NotEqual(t, incomingWebhook.Path, updated.Path)
+	})
+
+	t.Run("Incoming webhooks are scoped to their creator", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		otherUser, err := ts.CreateRegularUser(ctx, "other")
+		require.NoError(t, err)
+
+		incomingWebhook, err := ts.Service.CreateIncomingWebhook(ts.CreateUserContext(ctx, user.ID), &v1pb.CreateIncomingWebhookRequest{
+			IncomingWebhook: &v1pb.IncomingWebhook{DisplayName: "CI"},
+		})
+		require.NoError(t, err)
+
+		otherCtx := ts.CreateUserContext(ctx, otherUser.ID)
+		_, err = ts.Service.GetIncomingWebhook(otherCtx, &v1pb.GetIncomingWebhookRequest{Name: incomingWebhook.Name})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found")
+		_, err = ts.Service.DeleteIncomingWebhook(otherCtx, &v1pb.DeleteIncomingWebhookRequest{Name: incomingWebhook.Name})
+		require.Error(t, err)
+	})
+}
+
+func TestIncomingWebhookEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	postToHook := func(e *echo.Echo, path string, contentType string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	getCreatedMemo := func(t *testing.T, ts *TestService, userCtx context.Context, rec *httptest.ResponseRecorder) *v1pb.Memo {
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		response := map[string]string{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		memo, err := ts.Service.GetMemo(userCtx, &v1pb.GetMemoRequest{Name: response["name"]})
+		require.NoError(t, err)
+		return memo
+	}
+
+	t.Run("Post plain text, JSON and form bodies", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+		incomingWebhook, err := ts.Service.CreateIncomingWebhook(userCtx, &v1pb.CreateIncomingWebhookRequest{
+			IncomingWebhook: &v1pb.IncomingWebhook{
+				DisplayName: "CI",
+				Tags:        []string{"ci"},
+			},
+		})
+		require.NoError(t, err)
+
+		e := echo.New()
+		ts.Service.RegisterIncomingWebhookRoutes(e)
+
+		memo := getCreatedMemo(t, ts, userCtx, postToHook(e, incomingWebhook.Path, "text/plain", "Build passed"))
+		require.Equal(t, "Build passed\n\n#ci", memo.Content)
+		require.Equal(t, v1pb.Visibility_PRIVATE, memo.Visibility)
+		require.Equal(t, fmt.Sprintf("users/%d", user.ID), memo.Creator)
+
+		memo = getCreatedMemo(t, ts, userCtx, postToHook(e, incomingWebhook.Path, "application/json", `{"text": "Deploy finished"}`))
+		require.Equal(t, "Deploy finished\n\n#ci", memo.Content)
+
+		memo = getCreatedMemo(t, ts, userCtx, postToHook(e, incomingWebhook.Path, "application/x-www-form-urlencoded", "content=Backup+done"))
+		require.Equal(t, "Backup done\n\n#ci", memo.Content)
+
+		memo = getCreatedMemo(t, ts, userCtx, postToHook(e, incomingWebhook.Path, "application/json", `{"status": "ok"}`))
+		require.Equal(t, "```json\n{\n  \"status\": \"ok\"\n}\n```\n\n#ci", memo.Content)
+	})
+
+	t.Run("Post with template", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+		incomingWebhook, err := ts.Service.CreateIncomingWebhook(userCtx, &v1pb.CreateIncomingWebhookRequest{
+			IncomingWebhook: &v1pb.IncomingWebhook{
+				DisplayName: "Monitoring",
+				Visibility:  v1pb.Visibility_PROTECTED,
+				Template:    "**{{ .Data.alert.name }}** is {{ .Data.alert.state }}",
+			},
+		})
+		require.NoError(t, err)
+
+		e := echo.New()
+		ts.Service.RegisterIncomingWebhookRoutes(e)
+
+		rec := postToHook(e, incomingWebhook.Path, "application/json", `{"alert": {"name": "disk", "state": "firing"}}`)
+		memo := getCreatedMemo(t, ts, userCtx, rec)
+		require.Equal(t, "**disk** is firing", memo.Content)
+		require.Equal(t, v1pb.Visibility_PROTECTED, memo.Visibility)
+	})
+
+	t.Run("Post with invalid requests", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, user.ID)
+		incomingWebhook, err := ts.Service.CreateIncomingWebhook(userCtx, &v1pb.CreateIncomingWebhookRequest{
+			IncomingWebhook: &v1pb.IncomingWebhook{DisplayName: "CI"},
+		})
+		require.NoError(t, err)
+
+		e := echo.New()
+		ts.Service.RegisterIncomingWebhookRoutes(e)
+
+		rec := postToHook(e, "/hooks/in/unknown", "text/plain", "hello")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		rec = postToHook(e, incomingWebhook.Path, "text/plain", "   ")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		rec = postToHook(e, incomingWebhook.Path, "application/json", `{"text":`)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		rec = postToHook(e, incomingWebhook.Path, "text/plain", strings.Repeat("a", 1<<20+1))
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+		_, err = ts.Service.DeleteIncomingWebhook(userCtx, &v1pb.DeleteIncomingWebhookRequest{Name: incomingWebhook.Name})
+		require.NoError(t, err)
+		rec = postToHook(e, incomingWebhook.Path, "text/plain", "hello")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
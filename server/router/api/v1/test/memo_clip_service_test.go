@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/plugin/httpgetter"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestClipUrl(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ClipUrl requires authentication", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		_, err := ts.Service.ClipUrl(ctx, &v1pb.ClipUrlRequest{Url: "https://example.com"})
+		require.Error(t, err)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("ClipUrl with empty url", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+		_, err = ts.Service.ClipUrl(userCtx, &v1pb.ClipUrlRequest{Url: "  "})
+		require.Error(t, err)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ClipUrl rejects internal addresses", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+		_, err = ts.Service.ClipUrl(userCtx, &v1pb.ClipUrlRequest{Url: "http://127.0.0.1/article"})
+		require.Error(t, err)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ClipUrl saves the page as a memo", func(t *testing.T) {
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+		photo := &bytes.Buffer{}
+		require.NoError(t, png.Encode(photo, image.NewNRGBA(image.Rect(0, 0, 2, 2))))
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/photo.png" {
+				w.Header().Set("Content-Type", "image/png")
+				_, _ = w.Write(photo.Bytes())
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(`<html><head>
+<title>A [tricky] title</title>
+<meta property="og:site_name" content="Evil](javascript:alert(1))">
+<meta property="og:description" content="What the page is about.">
+<meta property="og:image" content="/photo.png">
+</head><body><p>The body of the page.</p></body></html>`))
+		}))
+		defer server.Close()
+		// The test server is on a loopback address, which the default client refuses.
+		defaultClient := httpgetter.HTTPClient
+		httpgetter.HTTPClient = server.Client()
+		defer func() { httpgetter.HTTPClient = defaultClient }()
+
+		memo, err := ts.Service.ClipUrl(userCtx, &v1pb.ClipUrlRequest{
+			Url:        server.URL + "/post(1)",
+			Tags:       []string{"reading"},
+			Visibility: v1pb.Visibility_PRIVATE,
+			SaveImage:  true,
+		})
+		require.NoError(t, err)
+		// The texts and the URL of the page can't break out of the markdown link.
+		require.Equal(t, "# A \\[tricky\\] title\n\n> What the page is about.\n\nSource: [Evil\\](javascript:alert(1))]("+server.URL+"/post%281%29)\n\n#reading", memo.Content)
+		require.Equal(t, v1pb.Visibility_PRIVATE, memo.Visibility)
+		require.Len(t, memo.Attachments, 1)
+		require.Equal(t, "photo.png", memo.Attachments[0].Filename)
+		require.Equal(t, "image/png", memo.Attachments[0].Type)
+	})
+}
@@ -0,0 +1,201 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestMicropub(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*TestService, *echo.Echo, *store.User, string) {
+		ts := NewTestService(t)
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		accessToken, err := ts.Service.CreateUserAccessToken(ts.CreateUserContext(ctx, user.ID), &v1pb.CreateUserAccessTokenRequest{
+			Parent:      fmt.Sprintf("users/%d", user.ID),
+			AccessToken: &v1pb.UserAccessToken{Description: "micropub"},
+		})
+		require.NoError(t, err)
+		e := echo.New()
+		ts.Service.RegisterMicropubRoutes(e)
+		return ts, e, user, accessToken.AccessToken
+	}
+	serve := func(e *echo.Echo, method string, target string, token string, contentType string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		if contentType != "" {
+			req.Header.Set(echo.HeaderContentType, contentType)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	getMemo := func(t *testing.T, ts *TestService, user *store.User, location string) *v1pb.Memo {
+		u, err := url.Parse(location)
+		require.NoError(t, err)
+		memo, err := ts.Service.GetMemo(ts.CreateUserContext(ctx, user.ID), &v1pb.GetMemoRequest{Name: strings.TrimPrefix(u.Path, "/")})
+		require.NoError(t, err)
+		return memo
+	}
+
+	t.Run("Requests require an access token", func(t *testing.T) {
+		ts, e, _, _ := setup(t)
+		defer ts.Cleanup()
+
+		rec := serve(e, http.MethodGet, "/micropub?q=config", "", "", "")
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		rec = serve(e, http.MethodPost, "/micropub", "invalid", echo.MIMEApplicationForm, "h=entry&content=hello")
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("Query config", func(t *testing.T) {
+		ts, e, _, token := setup(t)
+		defer ts.Cleanup()
+
+		rec := serve(e, http.MethodGet, "/micropub?q=config", token, "", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		config := map[string]any{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &config))
+		require.Equal(t, "http://example.com/micropub/media", config["media-endpoint"])
+	})
+
+	t.Run("Create, query, update and delete", func(t *testing.T) {
+		ts, e, user, token := setup(t)
+		defer ts.Cleanup()
+
+		form := url.Values{
+			"h":          {"entry"},
+			"content":    {"Hello from Micropub"},
+			"category[]": {"indieweb", "micro blog"},
+			"location":   {"geo:37.78,-122.41"},
+			"visibility": {"public"},
+		}
+		rec := serve(e, http.MethodPost, "/micropub", token, echo.MIMEApplicationForm, form.Encode())
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		location := rec.Header().Get(echo.HeaderLocation)
+		require.True(t, strings.HasPrefix(location, "http://example.com/memos/"))
+		memo := getMemo(t, ts, user, location)
+		require.Equal(t, "Hello from Micropub\n\n#indieweb #micro-blog", memo.Content)
+		require.Equal(t, v1pb.Visibility_PUBLIC, memo.Visibility)
+		require.NotNil(t, memo.Location)
+		require.Equal(t, 37.78, memo.Location.Latitude)
+		require.Equal(t, -122.41, memo.Location.Longitude)
+
+		rec = serve(e, http.MethodGet, "/micropub?q=source&url="+url.QueryEscape(location), token, "", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		source := struct {
+			Type       []string            `json:"type"`
+			Properties map[string][]string `json:"properties"`
+		}{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &source))
+		require.Equal(t, []string{"h-entry"}, source.Type)
+		require.Equal(t, []string{"Hello from Micropub"}, source.Properties["content"])
+		require.Equal(t, []string{"indieweb", "micro-blog"}, source.Properties["category"])
+		require.Equal(t, []string{"geo:37.78,-122.41"}, source.Properties["location"])
+
+		update := fmt.Sprintf(`{"action": "update", "url": %q, "replace": {"content": ["Updated"]}, "add": {"category": ["news"]}, "delete": ["location"]}`, location)
+		rec = serve(e, http.MethodPost, "/micropub", token, echo.MIMEApplicationJSON, update)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+		memo = getMemo(t, ts, user, location)
+		require.Equal(t, "Updated\n\n#indieweb #micro-blog #news", memo.Content)
+		require.Nil(t, memo.Location)
+
+		update = fmt.Sprintf(`{"action": "update", "url": %q, "delete": {"category": ["indieweb"]}}`, location)
+		rec = serve(e, http.MethodPost, "/micropub", token, echo.MIMEApplicationJSON, update)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+		memo = getMemo(t, ts, user, location)
+		require.Equal(t, "Updated\n\n#micro-blog #news", memo.Content)
+
+		rec = serve(e, http.MethodPost, "/micropub", token, echo.MIMEApplicationForm, url.Values{"action": {"delete"}, "url": {location}}.Encode())
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+		_, err := ts.Service.GetMemo(ts.CreateUserContext(ctx, user.ID), &v1pb.GetMemoRequest{Name: strings.TrimPrefix(location, "http://example.com/")})
+		require.Error(t, err)
+	})
+
+	t.Run("Create with JSON and media endpoint", func(t *testing.T) {
+		ts, e, user, token := setup(t)
+		defer ts.Cleanup()
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "photo.png")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("\x89PNG\r\n\x1a\n"))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		rec := serve(e, http.MethodPost, "/micropub/media", token, writer.FormDataContentType(), body.String())
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		photoURL := rec.Header().Get(echo.HeaderLocation)
+		require.Contains(t, photoURL, "/file/attachments/")
+
+		entry := fmt.Sprintf(`{"type": ["h-entry"], "properties": {"name": ["Title"], "content": [{"html": "<p>Body</p>"}], "photo": [%q, {"value": "https://example.org/a.jpg", "alt": "A"}]}}`, photoURL)
+		rec = serve(e, http.MethodPost, "/micropub", token, echo.MIMEApplicationJSON, entry)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		memo := getMemo(t, ts, user, rec.Header().Get(echo.HeaderLocation))
+		require.Equal(t, "# Title\n\n<p>Body</p>\n\n![A](https://example.org/a.jpg)", memo.Content)
+		require.Len(t, memo.Attachments, 1)
+		require.Equal(t, "photo.png", memo.Attachments[0].Filename)
+	})
+
+	t.Run("Oversized requests are rejected", func(t *testing.T) {
+		ts, e, _, token := setup(t)
+		defer ts.Cleanup()
+		_, err := ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+			Key: storepb.WorkspaceSettingKey_STORAGE,
+			Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+				StorageType:       storepb.WorkspaceStorageSetting_DATABASE,
+				UploadSizeLimitMb: 1,
+			}},
+		})
+		require.NoError(t, err)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "large.bin")
+		require.NoError(t, err)
+		_, err = part.Write(make([]byte, apiv1.MebiByte+1))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		rec := serve(e, http.MethodPost, "/micropub/media", token, writer.FormDataContentType(), body.String())
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, rec.Body.String())
+
+		entry := fmt.Sprintf(`{"type": ["h-entry"], "properties": {"content": [%q]}}`, strings.Repeat("a", apiv1.MaxUploadBufferSizeBytes))
+		rec = serve(e, http.MethodPost, "/micropub", token, echo.MIMEApplicationJSON, entry)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, rec.Body.String())
+	})
+
+	t.Run("Cannot update memos of other users", func(t *testing.T) {
+		ts, e, _, token := setup(t)
+		defer ts.Cleanup()
+
+		otherUser, err := ts.CreateRegularUser(ctx, "other")
+		require.NoError(t, err)
+		memo, err := ts.Service.CreateMemo(ts.CreateUserContext(ctx, otherUser.ID), &v1pb.CreateMemoRequest{
+			Memo: &v1pb.Memo{Content: "Not yours", Visibility: v1pb.Visibility_PUBLIC},
+		})
+		require.NoError(t, err)
+
+		update := fmt.Sprintf(`{"action": "update", "url": "http://example.com/%s", "replace": {"content": ["Mine"]}}`, memo.Name)
+		rec := serve(e, http.MethodPost, "/micropub", token, echo.MIMEApplicationJSON, update)
+		require.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/store"
+)
+
+func TestUploads(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*TestService, *echo.Echo, *store.User, string) {
+		ts := NewTestService(t)
+		ts.Profile.Data = t.TempDir()
+		user, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		e := echo.New()
+		ts.Service.RegisterUploadRoutes(e)
+		return ts, e, user, createAccessToken(t, ts, user)
+	}
+	serve := func(e *echo.Echo, method string, target string, token string, header map[string]string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Tus-Resumable", "1.0.0")
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	create := func(t *testing.T, e *echo.Echo, token string, length int, filename string) string {
+		rec := serve(e, http.MethodPost, apiv1.UploadPath, token, map[string]string{
+			"Upload-Length":   fmt.Sprint(length),
+			"Upload-Metadata": "filename " + base64.StdEncoding.EncodeToString([]byte(filename)) + ",filetype " + base64.StdEncoding.EncodeToString([]byte("text/plain")),
+		}, "")
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		location := rec.Header().Get(echo.HeaderLocation)
+		require.True(t, strings.HasPrefix(location, apiv1.UploadPath+"/"))
+		return location
+	}
+	patch := func(e *echo.Echo, location string, token string, offset int, chunk string) *httptest.ResponseRecorder {
+		return serve(e, http.MethodPatch, location, token, map[string]string{
+			echo.HeaderContentType: "application/offset+octet-stream",
+			"Upload-Offset":        fmt.Sprint(offset),
+		}, chunk)
+	}
+
+	t.Run("Chunks are resumed and finalized into an attachment", func(t *testing.T) {
+		ts, e, user, token := setup(t)
+		defer ts.Cleanup()
+
+		rec := serve(e, http.MethodOptions, apiv1.UploadPath, "", nil, "")
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Equal(t, "1.0.0", rec.Header().Get("Tus-Version"))
+		require.Contains(t, rec.Header().Get("Tus-Extension"), "creation")
+
+		location := create(t, e, token, 11, "hello.txt")
+		rec = patch(e, location, token, 0, "hello")
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Equal(t, "5", rec.Header().Get("Upload-Offset"))
+
+		rec = serve(e, http.MethodHead, location, token, nil, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "5", rec.Header().Get("Upload-Offset"))
+		require.Equal(t, "11", rec.Header().Get("Upload-Length"))
+
+		rec = patch(e, location, token, 0, "hello")
+		require.Equal(t, http.StatusConflict, rec.Code)
+
+		rec = patch(e, location, token, 5, " world")
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Equal(t, "11", rec.Header().Get("Upload-Offset"))
+		attachmentName := rec.Header().Get("Memos-Attachment")
+		require.NotEmpty(t, attachmentName)
+
+		attachment, err := ts.Service.GetAttachment(ts.CreateUserContext(ctx, user.ID), &v1pb.GetAttachmentRequest{Name: attachmentName})
+		require.NoError(t, err)
+		require.Equal(t, "hello.txt", attachment.Filename)
+		require.Equal(t, "text/plain", attachment.Type)
+		require.Equal(t, int64(11), attachment.Size)
+		attachmentUID := strings.TrimPrefix(attachmentName, "attachments/")
+		stored, err := ts.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID, GetBlob: true})
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(stored.Blob))
+
+		// The completed upload is removed.
+		rec = serve(e, http.MethodHead, location, token, nil, "")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Size limit is enforced", func(t *testing.T) {
+		ts, e, _, token := setup(t)
+		defer ts.Cleanup()
+		_, err := ts.Store.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+			Key: storepb.WorkspaceSettingKey_STORAGE,
+			Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+				StorageType:       storepb.WorkspaceStorageSetting_DATABASE,
+				UploadSizeLimitMb: 1,
+			}},
+		})
+		require.NoError(t, err)
+
+		rec := serve(e, http.MethodPost, apiv1.UploadPath, token, map[string]string{
+			"Upload-Length":   fmt.Sprint(2 * apiv1.MebiByte),
+			"Upload-Metadata": "filename " + base64.StdEncoding.EncodeToString([]byte("large.bin")),
+		}, "")
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+		location := create(t, e, token, 5, "small.txt")
+		rec = patch(e, location, token, 0, "too long")
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		rec = serve(e, http.MethodHead, location, token, nil, "")
+		require.Equal(t, "0", rec.Header().Get("Upload-Offset"))
+	})
+
+	t.Run("Uploads belong to their creator", func(t *testing.T) {
+		ts, e, _, token := setup(t)
+		defer ts.Cleanup()
+		other, err := ts.CreateRegularUser(ctx, "other")
+		require.NoError(t, err)
+		otherToken := createAccessToken(t, ts, other)
+
+		rec := serve(e, http.MethodPost, apiv1.UploadPath, "", map[string]string{"Upload-Length": "5"}, "")
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		req := httptest.NewRequest(http.MethodPost, apiv1.UploadPath, nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+		location := create(t, e, token, 5, "note.txt")
+		rec = serve(e, http.MethodHead, location, otherToken, nil, "")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		rec = patch(e, location, otherToken, 0, "hello")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+
+		rec = serve(e, http.MethodDelete, location, token, nil, "")
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		rec = serve(e, http.MethodHead, location, token, nil, "")
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func createAccessToken(t *testing.T, ts *TestService, user *store.User) string {
+	accessToken, err := ts.Service.CreateUserAccessToken(ts.CreateUserContext(context.Background(), user.ID), &v1pb.CreateUserAccessTokenRequest{
+		Parent:      fmt.Sprintf("users/%d", user.ID),
+		AccessToken: &v1pb.UserAccessToken{Description: "test"},
+	})
+	require.NoError(t, err)
+	return accessToken.AccessToken
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestUserFeedTokens(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+	user, err := ts.CreateRegularUser(ctx, "alice")
+	require.NoError(t, err)
+	other, err := ts.CreateRegularUser(ctx, "bob")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	parent := fmt.Sprintf("users/%d", user.ID)
+
+	// The token is only returned when it is created.
+	feedToken, err := ts.Service.CreateUserFeedToken(userCtx, &v1pb.CreateUserFeedTokenRequest{
+		Parent:    parent,
+		FeedToken: &v1pb.UserFeedToken{Description: "reader"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, feedToken.Token)
+	require.Equal(t, "reader", feedToken.Description)
+	storeFeedToken, err := ts.Store.GetUserFeedToken(ctx, user.ID, feedToken.Token)
+	require.NoError(t, err)
+	require.NotNil(t, storeFeedToken)
+	require.NotEqual(t, feedToken.Token, storeFeedToken.TokenHash)
+
+	response, err := ts.Service.ListUserFeedTokens(userCtx, &v1pb.ListUserFeedTokensRequest{Parent: parent})
+	require.NoError(t, err)
+	require.Len(t, response.FeedTokens, 1)
+	require.Equal(t, feedToken.Name, response.FeedTokens[0].Name)
+	require.Empty(t, response.FeedTokens[0].Token)
+
+	// Other users can not manage the tokens.
+	_, err = ts.Service.ListUserFeedTokens(ts.CreateUserContext(ctx, other.ID), &v1pb.ListUserFeedTokensRequest{Parent: parent})
+	require.Error(t, err)
+	_, err = ts.Service.DeleteUserFeedToken(ts.CreateUserContext(ctx, other.ID), &v1pb.DeleteUserFeedTokenRequest{Name: feedToken.Name})
+	require.Error(t, err)
+
+	// Deleting the token revokes it.
+	_, err = ts.Service.DeleteUserFeedToken(userCtx, &v1pb.DeleteUserFeedTokenRequest{Name: feedToken.Name})
+	require.NoError(t, err)
+	storeFeedToken, err = ts.Store.GetUserFeedToken(ctx, user.ID, feedToken.Token)
+	require.NoError(t, err)
+	require.Nil(t, storeFeedToken)
+}
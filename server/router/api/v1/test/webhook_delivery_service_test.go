@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/webhook"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	apiv1 "github.com/usememos/memos/server/router/api/v1"
+	"github.com/usememos/memos/server/runner/webhookdelivery"
+	"github.com/usememos/memos/store"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	// The endpoint fails the first request and accepts the following ones.
+	var requestCount atomic.Int32
+	var signature, deliveryID atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature.Store(r.Header.Get(webhook.SignatureHeader))
+		deliveryID.Store(r.Header.Get(webhook.DeliveryHeader))
+		if requestCount.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, hostUser.ID)
+	hook, err := ts.Service.CreateWebhook(userCtx, &v1pb.CreateWebhookRequest{
+		Webhook: &v1pb.Webhook{
+			DisplayName: "Test Webhook",
+			Url:         server.URL,
+		},
+	})
+	require.NoError(t, err)
+
+	// Creating a memo queues a delivery instead of posting right away.
+	_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{
+			Content:    "hello",
+			Visibility: v1pb.Visibility_PRIVATE,
+		},
+	})
+	require.NoError(t, err)
+	resp, err := ts.Service.ListWebhookDeliveries(userCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: hook.Name})
+	require.NoError(t, err)
+	require.Len(t, resp.Deliveries, 1)
+	require.Equal(t, v1pb.WebhookDelivery_PENDING, resp.Deliveries[0].Status)
+	require.Equal(t, "memos.memo.created", resp.Deliveries[0].ActivityType)
+	require.Contains(t, resp.Deliveries[0].RequestBody, "hello")
+	require.Zero(t, requestCount.Load())
+
+	// A failed attempt is scheduled for a retry.
+	runner := webhookdelivery.NewRunner(ts.Store)
+	runner.DeliverPending(ctx)
+	resp, err = ts.Service.ListWebhookDeliveries(userCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: hook.Name})
+	require.NoError(t, err)
+	delivery := resp.Deliveries[0]
+	require.Equal(t, v1pb.WebhookDelivery_PENDING, delivery.Status)
+	require.Equal(t, int32(1), delivery.Attempts)
+	require.Equal(t, int32(http.StatusServiceUnavailable), delivery.ResponseStatus)
+	require.Contains(t, delivery.ResponseBody, "unavailable")
+	require.NotEmpty(t, delivery.Error)
+	require.Equal(t, fmt.Sprintf("%d", deliveryIDFromName(t, delivery.Name)), deliveryID.Load())
+	require.NoError(t, webhook.Verify(hook.SigningSecret, signature.Load().(string), []byte(delivery.RequestBody), time.Minute))
+	require.True(t, delivery.NextAttemptTime.AsTime().After(delivery.UpdateTime.AsTime()))
+
+	// The retry is not due yet.
+	runner.DeliverPending(ctx)
+	require.Equal(t, int32(1), requestCount.Load())
+
+	// Once due, the retry succeeds.
+	storedID := deliveryIDFromName(t, delivery.Name)
+	stored, err := ts.Store.GetWebhookDelivery(ctx, &store.FindWebhookDelivery{ID: &storedID})
+	require.NoError(t, err)
+	require.NoError(t, runner.Deliver(ctx, stored))
+	resp, err = ts.Service.ListWebhookDeliveries(userCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: hook.Name})
+	require.NoError(t, err)
+	delivery = resp.Deliveries[0]
+	require.Equal(t, v1pb.WebhookDelivery_SUCCEEDED, delivery.Status)
+	require.Equal(t, int32(2), delivery.Attempts)
+	require.Equal(t, int32(http.StatusOK), delivery.ResponseStatus)
+	require.Empty(t, delivery.Error)
+	require.NotNil(t, delivery.Latency)
+
+	// Redelivering queues a new delivery with the same request body.
+	redelivery, err := ts.Service.RedeliverWebhook(userCtx, &v1pb.RedeliverWebhookRequest{Name: delivery.Name})
+	require.NoError(t, err)
+	require.NotEqual(t, delivery.Name, redelivery.Name)
+	require.Equal(t, v1pb.WebhookDelivery_PENDING, redelivery.Status)
+	require.Equal(t, delivery.RequestBody, redelivery.RequestBody)
+	runner.DeliverPending(ctx)
+	require.Equal(t, int32(3), requestCount.Load())
+
+	// Other users can not see the deliveries.
+	regularUser, err := ts.CreateRegularUser(ctx, "user1")
+	require.NoError(t, err)
+	_, err = ts.Service.ListWebhookDeliveries(ts.CreateUserContext(ctx, regularUser.ID), &v1pb.ListWebhookDeliveriesRequest{Parent: hook.Name})
+	require.Error(t, err)
+}
+
+func TestWebhookDeliveryDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	hostUser, err := ts.CreateHostUser(ctx, "admin")
+	require.NoError(t, err)
+	hook, err := ts.Store.CreateWebhook(ctx, &store.Webhook{
+		CreatorID: hostUser.ID,
+		Name:      "Test Webhook",
+		URL:       server.URL,
+	})
+	require.NoError(t, err)
+	delivery, err := ts.Store.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		WebhookID: hook.ID,
+		Status:    store.WebhookDeliveryPending,
+		Attempts:  webhookdelivery.MaxAttempts - 1,
+		Payload: &storepb.WebhookDeliveryPayload{
+			ActivityType: "memos.memo.created",
+			RequestBody:  "{}",
+		},
+	})
+	require.NoError(t, err)
+
+	// The last failed attempt moves the delivery to the dead letters.
+	runner := webhookdelivery.NewRunner(ts.Store)
+	require.NoError(t, runner.Deliver(ctx, delivery))
+	delivery, err = ts.Store.GetWebhookDelivery(ctx, &store.FindWebhookDelivery{ID: &delivery.ID})
+	require.NoError(t, err)
+	require.Equal(t, store.WebhookDeliveryDead, delivery.Status)
+	require.Equal(t, int32(webhookdelivery.MaxAttempts), delivery.Attempts)
+	require.Equal(t, int32(http.StatusInternalServerError), delivery.Payload.ResponseStatus)
+
+	require.Equal(t, webhookdelivery.GetBackoff(1)*2, webhookdelivery.GetBackoff(2))
+}
+
+func deliveryIDFromName(t *testing.T, name string) int32 {
+	_, deliveryID, err := apiv1.ExtractWebhookDeliveryIDFromName(name)
+	require.NoError(t, err)
+	return deliveryID
+}
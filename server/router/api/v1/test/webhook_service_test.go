@@ -6,9 +6,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
 )
 
 func TestCreateWebhook(t *testing.T) {
@@ -406,3 +409,319 @@ func TestDeleteWebhook(t *testing.T) {
 		require.Contains(t, err.Error(), "not found")
 	})
 }
+
+func TestRotateWebhookSecret(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RotateWebhookSecret replaces the secret", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, hostUser.ID)
+
+		// The secret is only shown on creation
+		webhook, err := ts.Service.CreateWebhook(userCtx, &v1pb.CreateWebhookRequest{
+			Webhook: &v1pb.Webhook{
+				DisplayName: "Test Webhook",
+				Url:         "https://example.com/webhook",
+			},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, webhook.SigningSecret)
+		got, err := ts.Service.GetWebhook(userCtx, &v1pb.GetWebhookRequest{Name: webhook.Name})
+		require.NoError(t, err)
+		require.Empty(t, got.SigningSecret)
+
+		rotated, err := ts.Service.RotateWebhookSecret(userCtx, &v1pb.RotateWebhookSecretRequest{Name: webhook.Name})
+		require.NoError(t, err)
+		require.NotEmpty(t, rotated.SigningSecret)
+		require.NotEqual(t, webhook.SigningSecret, rotated.SigningSecret)
+		require.Equal(t, webhook.Url, rotated.Url)
+	})
+
+	t.Run("RotateWebhookSecret fails for other users", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		webhook, err := ts.Service.CreateWebhook(ts.CreateUserContext(ctx, hostUser.ID), &v1pb.CreateWebhookRequest{
+			Webhook: &v1pb.Webhook{
+				DisplayName: "Test Webhook",
+				Url:         "https://example.com/webhook",
+			},
+		})
+		require.NoError(t, err)
+
+		regularUser, err := ts.CreateRegularUser(ctx, "user1")
+		require.NoError(t, err)
+		_, err = ts.Service.RotateWebhookSecret(ts.CreateUserContext(ctx, regularUser.ID), &v1pb.RotateWebhookSecretRequest{Name: webhook.Name})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestWebhookSubscriptions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Webhooks only receive the subscribed events of matching memos", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		webhook, err := ts.Service.CreateWebhook(userCtx, &v1pb.CreateWebhookRequest{
+			Webhook: &v1pb.Webhook{
+				DisplayName: "Deploys",
+				Url:         "https://example.com/webhook",
+				EventTypes:  []string{"memos.memo.created", "memos.memo.updated"},
+				Filter:      `tag in ["deploy"] && visibility == "PUBLIC"`,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"memos.memo.created", "memos.memo.updated"}, webhook.EventTypes)
+
+		for _, memo := range []*v1pb.Memo{
+			{Content: "#deploy v1", Visibility: v1pb.Visibility_PUBLIC},
+			{Content: "#deploy v2", Visibility: v1pb.Visibility_PRIVATE},
+			{Content: "#release v3", Visibility: v1pb.Visibility_PUBLIC},
+		} {
+			_, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: memo})
+			require.NoError(t, err)
+		}
+		resp, err := ts.Service.ListWebhookDeliveries(userCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: webhook.Name})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, 1)
+		require.Contains(t, resp.Deliveries[0].RequestBody, "#deploy v1")
+
+		// Unsubscribing from created memos stops their deliveries.
+		_, err = ts.Service.UpdateWebhook(userCtx, &v1pb.UpdateWebhookRequest{
+			Webhook:    &v1pb.Webhook{Name: webhook.Name, EventTypes: []string{"memos.memo.deleted"}},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"event_types"}},
+		})
+		require.NoError(t, err)
+		_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "#deploy v4", Visibility: v1pb.Visibility_PUBLIC}})
+		require.NoError(t, err)
+		resp, err = ts.Service.ListWebhookDeliveries(userCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: webhook.Name})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, 1)
+	})
+
+	t.Run("Webhooks with invalid subscriptions are rejected", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		for _, webhook := range []*v1pb.Webhook{
+			{Url: "https://example.com/webhook", EventTypes: []string{"memos.memo.archived"}},
+			{Url: "https://example.com/webhook", Filter: `tag in [`},
+			{Url: "https://example.com/webhook", Filter: `content > "a"`},
+		} {
+			_, err := ts.Service.CreateWebhook(userCtx, &v1pb.CreateWebhookRequest{Webhook: webhook})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid webhook subscription")
+		}
+	})
+}
+
+func TestWebhookFormats(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Deliveries are rendered in the webhook format", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		webhook, err := ts.Service.CreateWebhook(userCtx, &v1pb.CreateWebhookRequest{
+			Webhook: &v1pb.Webhook{
+				DisplayName: "Slack",
+				Url:         "https://hooks.slack.com/services/test",
+				Format:      v1pb.Webhook_SLACK,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, v1pb.Webhook_SLACK, webhook.Format)
+
+		_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "hello", Visibility: v1pb.Visibility_PRIVATE}})
+		require.NoError(t, err)
+		resp, err := ts.Service.ListWebhookDeliveries(userCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: webhook.Name})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, 1)
+		require.Contains(t, resp.Deliveries[0].RequestBody, `"blocks"`)
+		require.Contains(t, resp.Deliveries[0].RequestBody, "http://localhost:8080/memos/")
+
+		// Switching to a custom template changes the following deliveries.
+		webhook, err = ts.Service.UpdateWebhook(userCtx, &v1pb.UpdateWebhookRequest{
+			Webhook: &v1pb.Webhook{
+				Name:     webhook.Name,
+				Format:   v1pb.Webhook_TEMPLATE,
+				Template: `{"content": {{json .Payload.Memo.Content}}}`,
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"format", "template"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, v1pb.Webhook_TEMPLATE, webhook.Format)
+		_, err = ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{Content: "world", Visibility: v1pb.Visibility_PRIVATE}})
+		require.NoError(t, err)
+		resp, err = ts.Service.ListWebhookDeliveries(userCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: webhook.Name})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, 2)
+		require.JSONEq(t, `{"content": "world"}`, resp.Deliveries[0].RequestBody)
+	})
+
+	t.Run("Webhooks with invalid templates are rejected", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		for _, template := range []string{"", "{{.Payload"} {
+			_, err := ts.Service.CreateWebhook(userCtx, &v1pb.CreateWebhookRequest{
+				Webhook: &v1pb.Webhook{Url: "https://example.com/webhook", Format: v1pb.Webhook_TEMPLATE, Template: template},
+			})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid webhook format")
+		}
+	})
+}
+
+func TestWorkspaceWebhooks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Workspace webhooks receive the events of all users", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		regularUser, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, regularUser.ID)
+
+		webhook, err := ts.Service.CreateWebhook(hostCtx, &v1pb.CreateWebhookRequest{
+			Webhook: &v1pb.Webhook{
+				DisplayName:  "Workspace",
+				Url:          "https://example.com/webhook",
+				Scope:        v1pb.Webhook_WORKSPACE,
+				Visibilities: []v1pb.Visibility{v1pb.Visibility_PUBLIC, v1pb.Visibility_PROTECTED},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, v1pb.Webhook_WORKSPACE, webhook.Scope)
+		require.Equal(t, []v1pb.Visibility{v1pb.Visibility_PUBLIC, v1pb.Visibility_PROTECTED}, webhook.Visibilities)
+
+		for _, visibility := range []v1pb.Visibility{v1pb.Visibility_PUBLIC, v1pb.Visibility_PROTECTED, v1pb.Visibility_PRIVATE} {
+			_, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{
+				Content:    visibility.String(),
+				Visibility: visibility,
+				Location:   &v1pb.Location{Placeholder: "Home", Latitude: 48.8566, Longitude: 2.3522},
+			}})
+			require.NoError(t, err)
+		}
+		// The attachments without a memo are private.
+		_, err = ts.Service.CreateAttachment(userCtx, &v1pb.CreateAttachmentRequest{
+			Attachment: &v1pb.Attachment{Filename: "notes.txt", Type: "text/plain", Content: []byte("notes")},
+		})
+		require.NoError(t, err)
+		_, err = ts.Service.CreateUser(hostCtx, &v1pb.CreateUserRequest{User: &v1pb.User{Username: "newuser", Password: "password", Role: v1pb.User_USER}})
+		require.NoError(t, err)
+
+		resp, err := ts.Service.ListWebhookDeliveries(hostCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: webhook.Name})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, 3)
+		require.Equal(t, "memos.user.created", resp.Deliveries[0].ActivityType)
+		require.Contains(t, resp.Deliveries[1].RequestBody, "PROTECTED")
+		require.Contains(t, resp.Deliveries[2].RequestBody, "PUBLIC")
+		// The locations are not sent.
+		require.NotContains(t, resp.Deliveries[2].RequestBody, "48.8566")
+	})
+
+	t.Run("User webhooks receive the locations of their memos", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "admin")
+		require.NoError(t, err)
+		hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		webhook, err := ts.Service.CreateWebhook(hostCtx, &v1pb.CreateWebhookRequest{
+			Webhook: &v1pb.Webhook{DisplayName: "User", Url: "https://example.com/webhook"},
+		})
+		require.NoError(t, err)
+
+		_, err = ts.Service.CreateMemo(hostCtx, &v1pb.CreateMemoRequest{Memo: &v1pb.Memo{
+			Content:    "trip",
+			Visibility: v1pb.Visibility_PRIVATE,
+			Location:   &v1pb.Location{Placeholder: "Home", Latitude: 48.8566, Longitude: 2.3522},
+		}})
+		require.NoError(t, err)
+
+		resp, err := ts.Service.ListWebhookDeliveries(hostCtx, &v1pb.ListWebhookDeliveriesRequest{Parent: webhook.Name})
+		require.NoError(t, err)
+		require.Len(t, resp.Deliveries, 1)
+		require.Contains(t, resp.Deliveries[0].RequestBody, "48.8566")
+	})
+
+	t.Run("Workspace webhooks are managed by hosts and admins", func(t *testing.T) {
+		// Create test service for this specific test
+		ts := NewTestService(t)
+		defer ts.Cleanup()
+
+		hostUser, err := ts.CreateHostUser(ctx, "host")
+		require.NoError(t, err)
+		hostCtx := ts.CreateUserContext(ctx, hostUser.ID)
+		adminUser, err := ts.Store.CreateUser(ctx, &store.User{Username: "admin", Role: store.RoleAdmin, Email: "admin@example.com"})
+		require.NoError(t, err)
+		adminCtx := ts.CreateUserContext(ctx, adminUser.ID)
+		regularUser, err := ts.CreateRegularUser(ctx, "user")
+		require.NoError(t, err)
+		userCtx := ts.CreateUserContext(ctx, regularUser.ID)
+
+		webhook, err := ts.Service.CreateWebhook(hostCtx, &v1pb.CreateWebhookRequest{
+			Webhook: &v1pb.Webhook{Url: "https://example.com/webhook", Scope: v1pb.Webhook_WORKSPACE},
+		})
+		require.NoError(t, err)
+
+		// Other admins manage the webhook, regular users don't see it.
+		resp, err := ts.Service.ListWebhooks(adminCtx, &v1pb.ListWebhooksRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.Webhooks, 1)
+		_, err = ts.Service.UpdateWebhook(adminCtx, &v1pb.UpdateWebhookRequest{
+			Webhook:    &v1pb.Webhook{Name: webhook.Name, Visibilities: []v1pb.Visibility{v1pb.Visibility_PROTECTED}},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"visibilities"}},
+		})
+		require.NoError(t, err)
+		resp, err = ts.Service.ListWebhooks(userCtx, &v1pb.ListWebhooksRequest{})
+		require.NoError(t, err)
+		require.Empty(t, resp.Webhooks)
+		_, err = ts.Service.GetWebhook(userCtx, &v1pb.GetWebhookRequest{Name: webhook.Name})
+		require.Error(t, err)
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		// The visibilities are only supported by workspace webhooks.
+		_, err = ts.Service.CreateWebhook(hostCtx, &v1pb.CreateWebhookRequest{
+			Webhook: &v1pb.Webhook{Url: "https://example.com/webhook", Visibilities: []v1pb.Visibility{v1pb.Visibility_PUBLIC}},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid webhook visibilities")
+
+		_, err = ts.Service.DeleteWebhook(adminCtx, &v1pb.DeleteWebhookRequest{Name: webhook.Name})
+		require.NoError(t, err)
+	})
+}
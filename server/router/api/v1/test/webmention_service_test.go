@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/usememos/memos/plugin/httpgetter"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestWebmention(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestService(t)
+	defer ts.Cleanup()
+	user, err := ts.CreateRegularUser(ctx, "user")
+	require.NoError(t, err)
+	otherUser, err := ts.CreateRegularUser(ctx, "other")
+	require.NoError(t, err)
+	userCtx := ts.CreateUserContext(ctx, user.ID)
+	publicMemo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "public memo", Visibility: v1pb.Visibility_PUBLIC},
+	})
+	require.NoError(t, err)
+	privateMemo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+		Memo: &v1pb.Memo{Content: "private memo", Visibility: v1pb.Visibility_PRIVATE},
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	ts.Service.RegisterWebmentionRoutes(e)
+	send := func(source string, target string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webmention", strings.NewReader(url.Values{"source": {source}, "target": {target}}.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("Only the changed links are notified", func(t *testing.T) {
+		requested := make(chan string, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested <- r.URL.Path
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		defaultClient := httpgetter.HTTPClient
+		httpgetter.HTTPClient = server.Client()
+		defer func() { httpgetter.HTTPClient = defaultClient }()
+		receive := func() string {
+			select {
+			case path := <-requested:
+				return path
+			case <-time.After(10 * time.Second):
+				require.FailNow(t, "no link notified")
+				return ""
+			}
+		}
+
+		memo, err := ts.Service.CreateMemo(userCtx, &v1pb.CreateMemoRequest{
+			Memo: &v1pb.Memo{Content: server.URL + "/first", Visibility: v1pb.Visibility_PUBLIC},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "/first", receive())
+		memo.Content = server.URL + "/first " + server.URL + "/second"
+		_, err = ts.Service.UpdateMemo(userCtx, &v1pb.UpdateMemoRequest{Memo: memo, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}}})
+		require.NoError(t, err)
+		require.Equal(t, "/second", receive())
+		memo.Content = server.URL + "/second"
+		_, err = ts.Service.UpdateMemo(userCtx, &v1pb.UpdateMemoRequest{Memo: memo, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}}})
+		require.NoError(t, err)
+		require.Equal(t, "/first", receive())
+	})
+
+	t.Run("Receiver validates the request", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, send("ftp://example.org/post", "http://localhost:8080/"+publicMemo.Name))
+		require.Equal(t, http.StatusBadRequest, send("http://localhost:8080/"+publicMemo.Name, "http://localhost:8080/"+publicMemo.Name))
+		require.Equal(t, http.StatusBadRequest, send("https://example.org/post", "https://other.example/"+publicMemo.Name))
+		require.Equal(t, http.StatusBadRequest, send("https://example.org/post", "http://localhost:8080/memos/unknown"))
+		require.Equal(t, http.StatusBadRequest, send("https://example.org/post", "http://localhost:8080/"+privateMemo.Name))
+	})
+
+	t.Run("Receiver accepts mentions of public memos", func(t *testing.T) {
+		// The source is verified in the background; an internal source is never fetched.
+		require.Equal(t, http.StatusAccepted, send("http://127.0.0.1/post", "http://localhost:8080/"+publicMemo.Name))
+	})
+
+	t.Run("List and delete webmentions", func(t *testing.T) {
+		memoUID := strings.TrimPrefix(publicMemo.Name, "memos/")
+		memo, err := ts.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+		require.NoError(t, err)
+		_, err = ts.Store.UpsertWebmention(ctx, &store.Webmention{
+			MemoID: memo.ID,
+			Source: "https://example.org/replies/1",
+			Type:   "reply",
+			Payload: &storepb.WebmentionPayload{
+				Content:    "Nice memo",
+				AuthorName: "Jane",
+				AuthorUrl:  "https://example.org",
+			},
+		})
+		require.NoError(t, err)
+
+		// Mentions of public memos are visible to visitors.
+		response, err := ts.Service.ListMemoWebmentions(ctx, &v1pb.ListMemoWebmentionsRequest{Name: publicMemo.Name})
+		require.NoError(t, err)
+		require.Len(t, response.Webmentions, 1)
+		webmention := response.Webmentions[0]
+		require.Equal(t, v1pb.Webmention_REPLY, webmention.Type)
+		require.Equal(t, "Nice memo", webmention.Content)
+		require.Equal(t, "Jane", webmention.Author.Name)
+		require.True(t, strings.HasPrefix(webmention.Name, publicMemo.Name+"/webmentions/"))
+
+		// Replies are shown with the comments, likes with the reactions.
+		_, err = ts.Store.UpsertWebmention(ctx, &store.Webmention{
+			MemoID:  memo.ID,
+			Source:  "https://example.org/likes/1",
+			Type:    "like",
+			Payload: &storepb.WebmentionPayload{AuthorName: "John"},
+		})
+		require.NoError(t, err)
+		comments, err := ts.Service.ListMemoComments(ctx, &v1pb.ListMemoCommentsRequest{Name: publicMemo.Name})
+		require.NoError(t, err)
+		require.Len(t, comments.Webmentions, 1)
+		require.Equal(t, "https://example.org/replies/1", comments.Webmentions[0].Source)
+		reactions, err := ts.Service.ListMemoReactions(ctx, &v1pb.ListMemoReactionsRequest{Name: publicMemo.Name})
+		require.NoError(t, err)
+		require.Len(t, reactions.Webmentions, 1)
+		require.Equal(t, v1pb.Webmention_LIKE, reactions.Webmentions[0].Type)
+		likeSource := "https://example.org/likes/1"
+		require.NoError(t, ts.Store.DeleteWebmention(ctx, &store.DeleteWebmention{MemoID: &memo.ID, Source: &likeSource}))
+
+		_, err = ts.Service.ListMemoWebmentions(ts.CreateUserContext(ctx, otherUser.ID), &v1pb.ListMemoWebmentionsRequest{Name: privateMemo.Name})
+		require.Error(t, err)
+
+		// Only the creator can delete the mentions of a memo.
+		_, err = ts.Service.DeleteMemoWebmention(ts.CreateUserContext(ctx, otherUser.ID), &v1pb.DeleteMemoWebmentionRequest{Name: webmention.Name})
+		require.Error(t, err)
+		_, err = ts.Service.DeleteMemoWebmention(userCtx, &v1pb.DeleteMemoWebmentionRequest{Name: webmention.Name})
+		require.NoError(t, err)
+		response, err = ts.Service.ListMemoWebmentions(ctx, &v1pb.ListMemoWebmentionsRequest{Name: publicMemo.Name})
+		require.NoError(t, err)
+		require.Len(t, response.Webmentions, 0)
+	})
+}
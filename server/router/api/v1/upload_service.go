@@ -0,0 +1,455 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// UploadPath is the path of the tus resumable upload endpoint.
+	// Reference: https://tus.io/protocols/resumable-upload
+	UploadPath = "/api/v1/uploads"
+	// UploadFolder is the folder name where the incomplete uploads are stored.
+	UploadFolder = ".uploads"
+
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,termination,expiration"
+	// tusContentType is the content type of the chunks of uploads.
+	tusContentType = "application/offset+octet-stream"
+	// uploadExpiration is the duration after which incomplete uploads are removed.
+	uploadExpiration = 24 * time.Hour
+	// uploadAttachmentHeader is the header of the name of the attachment created when the upload is complete.
+	uploadAttachmentHeader = "Memos-Attachment"
+)
+
+// tusUpload is the state of a resumable upload, it is stored next to the received content.
+// The offset of the upload is the size of the received content.
+type tusUpload struct {
+	ID         string    `json:"id"`
+	CreatorID  int32     `json:"creatorId"`
+	Filename   string    `json:"filename"`
+	Type       string    `json:"type"`
+	Memo       string    `json:"memo,omitempty"`
+	Length     int64     `json:"length"`
+	ExpireTime time.Time `json:"expireTime"`
+}
+
+// RegisterUploadRoutes registers the tus resumable upload endpoint.
+// Uploads are created with the `filename`, `filetype` and optional `memo` metadata,
+// and the attachment is created when all of their content is received.
+func (s *APIV1Service) RegisterUploadRoutes(echoServer *echo.Echo) {
+	middlewares := []echo.MiddlewareFunc{
+		middleware.CORSWithConfig(middleware.CORSConfig{
+			// The OPTIONS requests of tus clients are not preflight requests.
+			Skipper: func(c echo.Context) bool {
+				return c.Request().Method == http.MethodOptions && c.Request().Header.Get(echo.HeaderAccessControlRequestMethod) == ""
+			},
+			AllowHeaders:  []string{"*"},
+			ExposeHeaders: []string{echo.HeaderLocation, "Tus-Resumable", "Tus-Version", "Tus-Extension", "Tus-Max-Size", "Upload-Offset", "Upload-Length", "Upload-Expires", uploadAttachmentHeader},
+		}),
+		tusResumableMiddleware,
+	}
+	echoServer.OPTIONS(UploadPath, s.handleUploadOptions, middlewares...)
+	echoServer.OPTIONS(UploadPath+"/:id", s.handleUploadOptions, middlewares...)
+	echoServer.POST(UploadPath, s.handleCreateUpload, middlewares...)
+	echoServer.HEAD(UploadPath+"/:id", s.handleGetUploadOffset, middlewares...)
+	echoServer.PATCH(UploadPath+"/:id", s.handleUploadChunk, middlewares...)
+	echoServer.DELETE(UploadPath+"/:id", s.handleDeleteUpload, middlewares...)
+}
+
+// tusResumableMiddleware sets the protocol version of the responses, and rejects the requests of other versions.
+func tusResumableMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set("Tus-Resumable", tusVersion)
+		if c.Request().Method != http.MethodOptions && c.Request().Header.Get("Tus-Resumable") != tusVersion {
+			c.Response().Header().Set("Tus-Version", tusVersion)
+			return c.NoContent(http.StatusPreconditionFailed)
+		}
+		return next(c)
+	}
+}
+
+func (s *APIV1Service) handleUploadOptions(c echo.Context) error {
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get workspace storage setting").SetInternal(err)
+	}
+	c.Response().Header().Set("Tus-Version", tusVersion)
+	c.Response().Header().Set("Tus-Extension", tusExtensions)
+	c.Response().Header().Set("Tus-Max-Size", strconv.FormatInt(getUploadSizeLimit(workspaceStorageSetting), 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *APIV1Service) handleCreateUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.authenticateHTTPRequest(ctx, c.Request())
+	if err != nil || user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	}
+
+	length, err := strconv.ParseInt(c.Request().Header.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid Upload-Length header")
+	}
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get workspace storage setting").SetInternal(err)
+	}
+	if length > getUploadSizeLimit(workspaceStorageSetting) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "file size exceeds the limit")
+	}
+	metadata, err := parseUploadMetadata(c.Request().Header.Get("Upload-Metadata"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	upload := &tusUpload{
+		ID:         shortuuid.New(),
+		CreatorID:  user.ID,
+		Filename:   getUploadMetadata(metadata, "filename", "name"),
+		Type:       getUploadMetadata(metadata, "filetype", "type"),
+		Memo:       metadata["memo"],
+		Length:     length,
+		ExpireTime: time.Now().Add(uploadExpiration),
+	}
+	if upload.Filename == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "filename metadata is required")
+	}
+	if upload.Type == "" {
+		upload.Type = echo.MIMEOctetStream
+	}
+	if upload.Memo != "" {
+		if _, err := s.findAttachmentMemo(ctx, upload.Memo); err != nil {
+			return uploadErrorFromStatus(err)
+		}
+	}
+
+	s.removeExpiredUploads()
+	if err := s.saveUpload(upload); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create upload").SetInternal(err)
+	}
+	file, err := os.Create(s.getUploadContentPath(upload.ID))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create upload").SetInternal(err)
+	}
+	file.Close()
+
+	c.Response().Header().Set(echo.HeaderLocation, UploadPath+"/"+upload.ID)
+	c.Response().Header().Set("Upload-Expires", upload.ExpireTime.UTC().Format(http.TimeFormat))
+	if upload.Length == 0 {
+		// Empty files are complete without any chunk.
+		return s.completeUpload(c, user, upload, http.StatusCreated)
+	}
+	return c.NoContent(http.StatusCreated)
+}
+
+func (s *APIV1Service) handleGetUploadOffset(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.authenticateHTTPRequest(ctx, c.Request())
+	if err != nil || user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	}
+	upload, offset, err := s.getUpload(user, c.Param("id"))
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	c.Response().Header().Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	c.Response().Header().Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	c.Response().Header().Set("Upload-Expires", upload.ExpireTime.UTC().Format(http.TimeFormat))
+	return c.NoContent(http.StatusOK)
+}
+
+// handleUploadChunk appends the chunk to the content of the upload.
+// The received bytes are kept when the request is interrupted, so that the client resumes from them.
+func (s *APIV1Service) handleUploadChunk(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.authenticateHTTPRequest(ctx, c.Request())
+	if err != nil || user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	}
+	if c.Request().Header.Get(echo.HeaderContentType) != tusContentType {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "content type must be "+tusContentType)
+	}
+	requestOffset, err := strconv.ParseInt(c.Request().Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || requestOffset < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid Upload-Offset header")
+	}
+
+	id := c.Param("id")
+	lock, _ := s.uploadLocks.LoadOrStore(id, &sync.Mutex{})
+	if !lock.(*sync.Mutex).TryLock() {
+		return echo.NewHTTPError(http.StatusLocked, "upload is in progress")
+	}
+	defer lock.(*sync.Mutex).Unlock()
+
+	upload, offset, err := s.getUpload(user, id)
+	if err != nil {
+		return err
+	}
+	if requestOffset != offset {
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("upload offset is %d", offset))
+	}
+	workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get workspace storage setting").SetInternal(err)
+	}
+	// The limit is checked again in case it is lowered during the upload.
+	if upload.Length > getUploadSizeLimit(workspaceStorageSetting) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "file size exceeds the limit")
+	}
+
+	file, err := os.OpenFile(s.getUploadContentPath(id), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to open upload").SetInternal(err)
+	}
+	defer file.Close()
+	// Read one more byte than the remaining length to detect chunks exceeding it.
+	written, copyErr := io.Copy(file, io.LimitReader(c.Request().Body, upload.Length-offset+1))
+	if offset+written > upload.Length {
+		if err := file.Truncate(offset); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to truncate upload").SetInternal(err)
+		}
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "chunk exceeds the upload length")
+	}
+	offset += written
+	c.Response().Header().Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	c.Response().Header().Set("Upload-Expires", upload.ExpireTime.UTC().Format(http.TimeFormat))
+	if copyErr != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "failed to read chunk").SetInternal(copyErr)
+	}
+	if offset == upload.Length {
+		return s.completeUpload(c, user, upload, http.StatusNoContent)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *APIV1Service) handleDeleteUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, err := s.authenticateHTTPRequest(ctx, c.Request())
+	if err != nil || user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	}
+	id := c.Param("id")
+	lock, _ := s.uploadLocks.LoadOrStore(id, &sync.Mutex{})
+	if !lock.(*sync.Mutex).TryLock() {
+		return echo.NewHTTPError(http.StatusLocked, "upload is in progress")
+	}
+	defer lock.(*sync.Mutex).Unlock()
+	if _, _, err := s.getUpload(user, id); err != nil {
+		return err
+	}
+	s.removeUpload(id)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// completeUpload creates the attachment from the received content through the storage of the storage config,
+// and removes the upload.
+func (s *APIV1Service) completeUpload(c echo.Context, user *store.User, upload *tusUpload, statusCode int) error {
+	ctx := context.WithValue(c.Request().Context(), userIDContextKey, user.ID)
+	create := &store.Attachment{
+		UID:       shortuuid.New(),
+		CreatorID: user.ID,
+		Filename:  upload.Filename,
+		Type:      upload.Type,
+		Size:      upload.Length,
+	}
+	var memo *store.Memo
+	if upload.Memo != "" {
+		var err error
+		memo, err = s.findAttachmentMemo(ctx, upload.Memo)
+		if err != nil {
+			return uploadErrorFromStatus(err)
+		}
+		create.MemoID = &memo.ID
+	}
+
+	file, err := os.Open(s.getUploadContentPath(upload.ID))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to open upload").SetInternal(err)
+	}
+	defer file.Close()
+	var content io.ReadSeeker = file
+	// The images are read into memory to read and strip their EXIF data, their size is bounded by the upload size limit.
+	if isExifSupported(create.Type) {
+		workspaceStorageSetting, err := s.Store.GetWorkspaceStorageSetting(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to get workspace storage setting").SetInternal(err)
+		}
+		blob, err := io.ReadAll(file)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to read upload").SetInternal(err)
+		}
+		if blob, err = prepareImageContent(create, blob, shouldStripExif(workspaceStorageSetting, memo)); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid image: %v", err))
+		}
+		create.Size = int64(len(blob))
+		content = bytes.NewReader(blob)
+	}
+	if err := s.Store.SaveAttachmentContent(ctx, create, content); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to save attachment blob").SetInternal(err)
+	}
+	attachment, err := s.Store.CreateAttachment(ctx, create)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create attachment").SetInternal(err)
+	}
+	s.removeUpload(upload.ID)
+
+	attachmentMessage := s.convertAttachmentFromStore(ctx, attachment)
+	if err := s.publishAttachmentEvent(ctx, EventAttachmentUploaded, attachment, attachmentMessage); err != nil {
+		slog.Warn("Failed to publish attachment uploaded event", slog.Any("err", err))
+	}
+	c.Response().Header().Set(uploadAttachmentHeader, attachmentMessage.Name)
+	return c.NoContent(statusCode)
+}
+
+// getUpload returns the upload of the user and its offset.
+func (s *APIV1Service) getUpload(user *store.User, id string) (*tusUpload, int64, error) {
+	upload, err := s.readUpload(id)
+	if err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			return nil, 0, echo.NewHTTPError(http.StatusNotFound, "upload not found")
+		}
+		return nil, 0, echo.NewHTTPError(http.StatusInternalServerError, "failed to get upload").SetInternal(err)
+	}
+	// The uploads of other users are hidden.
+	if upload.CreatorID != user.ID {
+		return nil, 0, echo.NewHTTPError(http.StatusNotFound, "upload not found")
+	}
+	if time.Now().After(upload.ExpireTime) {
+		s.removeUpload(id)
+		return nil, 0, echo.NewHTTPError(http.StatusGone, "upload expired")
+	}
+	fileInfo, err := os.Stat(s.getUploadContentPath(id))
+	if err != nil {
+		return nil, 0, echo.NewHTTPError(http.StatusInternalServerError, "failed to get upload").SetInternal(err)
+	}
+	return upload, fileInfo.Size(), nil
+}
+
+func (s *APIV1Service) getUploadFolder() string {
+	return filepath.Join(s.Profile.Data, UploadFolder)
+}
+
+func (s *APIV1Service) getUploadInfoPath(id string) string {
+	return filepath.Join(s.getUploadFolder(), id+".info")
+}
+
+func (s *APIV1Service) getUploadContentPath(id string) string {
+	return filepath.Join(s.getUploadFolder(), id+".bin")
+}
+
+func (s *APIV1Service) saveUpload(upload *tusUpload) error {
+	if err := os.MkdirAll(s.getUploadFolder(), os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create upload folder")
+	}
+	data, err := json.Marshal(upload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal upload")
+	}
+	if err := os.WriteFile(s.getUploadInfoPath(upload.ID), data, 0644); err != nil {
+		return errors.Wrap(err, "failed to write upload")
+	}
+	return nil
+}
+
+func (s *APIV1Service) readUpload(id string) (*tusUpload, error) {
+	// The ids are generated short uuids, reject the others before building paths from them.
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return nil, errors.Wrap(os.ErrNotExist, "invalid upload id")
+	}
+	data, err := os.ReadFile(s.getUploadInfoPath(id))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read upload")
+	}
+	upload := &tusUpload{}
+	if err := json.Unmarshal(data, upload); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal upload")
+	}
+	return upload, nil
+}
+
+func (s *APIV1Service) removeUpload(id string) {
+	for _, path := range []string{s.getUploadInfoPath(id), s.getUploadContentPath(id)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			slog.Warn("failed to remove upload", slog.String("path", path), slog.Any("err", err))
+		}
+	}
+	s.uploadLocks.Delete(id)
+}
+
+// removeExpiredUploads removes the incomplete uploads that expired.
+func (s *APIV1Service) removeExpiredUploads() {
+	entries, err := os.ReadDir(s.getUploadFolder())
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".info")
+		if !ok {
+			continue
+		}
+		upload, err := s.readUpload(id)
+		if err != nil || time.Now().After(upload.ExpireTime) {
+			s.removeUpload(id)
+		}
+	}
+}
+
+// parseUploadMetadata parses the Upload-Metadata header, the comma separated key and base64 encoded value pairs.
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encodedValue, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(encodedValue)
+		if err != nil {
+			return nil, errors.Errorf("invalid Upload-Metadata value of %q", key)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+// getUploadMetadata returns the first non-empty value of the keys, clients use different keys for the same values.
+func getUploadMetadata(metadata map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := metadata[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func uploadErrorFromStatus(err error) error {
+	st := status.Convert(err)
+	if st.Code() == codes.NotFound {
+		// The memo of the upload is missing, not the upload itself.
+		return echo.NewHTTPError(http.StatusBadRequest, st.Message())
+	}
+	return echo.NewHTTPError(runtime.HTTPStatusFromCode(st.Code()), st.Message())
+}
@@ -22,6 +22,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/usememos/memos/internal/base"
+	"github.com/usememos/memos/internal/util"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
@@ -185,6 +186,7 @@ func (s *APIV1Service) CreateUser(ctx context.Context, request *v1pb.CreateUserR
 		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
 	}
 
+	s.publishUserEvent(ctx, EventUserCreated, user)
 	return convertUserFromStore(user), nil
 }
 
@@ -588,6 +590,112 @@ func (s *APIV1Service) DeleteUserAccessToken(ctx context.Context, request *v1pb.
 	return &emptypb.Empty{}, nil
 }
 
+func (s *APIV1Service) ListUserFeedTokens(ctx context.Context, request *v1pb.ListUserFeedTokensRequest) (*v1pb.ListUserFeedTokensResponse, error) {
+	userID, err := ExtractUserIDFromName(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if currentUser.ID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	userFeedTokens, err := s.Store.GetUserFeedTokens(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list feed tokens: %v", err)
+	}
+	feedTokens := []*v1pb.UserFeedToken{}
+	for _, userFeedToken := range userFeedTokens {
+		feedTokens = append(feedTokens, &v1pb.UserFeedToken{
+			Name:        fmt.Sprintf("users/%d/feedTokens/%s", userID, userFeedToken.Id),
+			Description: userFeedToken.Description,
+			CreateTime:  userFeedToken.CreateTime,
+		})
+	}
+	return &v1pb.ListUserFeedTokensResponse{
+		FeedTokens: feedTokens,
+	}, nil
+}
+
+func (s *APIV1Service) CreateUserFeedToken(ctx context.Context, request *v1pb.CreateUserFeedTokenRequest) (*v1pb.UserFeedToken, error) {
+	userID, err := ExtractUserIDFromName(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if currentUser.ID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	token, err := util.RandomString(32)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate feed token: %v", err)
+	}
+	// Only the hash of the token is stored, the token itself is returned once.
+	userFeedToken := &storepb.FeedTokensUserSetting_FeedToken{
+		Id:          util.GenUUID(),
+		TokenHash:   store.HashFeedToken(token),
+		Description: request.GetFeedToken().GetDescription(),
+		CreateTime:  timestamppb.Now(),
+	}
+	if err := s.Store.AddUserFeedToken(ctx, userID, userFeedToken); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to add feed token: %v", err)
+	}
+
+	return &v1pb.UserFeedToken{
+		Name:        fmt.Sprintf("users/%d/feedTokens/%s", userID, userFeedToken.Id),
+		Token:       token,
+		Description: userFeedToken.Description,
+		CreateTime:  userFeedToken.CreateTime,
+	}, nil
+}
+
+func (s *APIV1Service) DeleteUserFeedToken(ctx context.Context, request *v1pb.DeleteUserFeedTokenRequest) (*emptypb.Empty, error) {
+	// Extract user ID and feed token ID from the feed token resource name
+	// Format: users/{user}/feedTokens/{feed_token}
+	parts := strings.Split(request.Name, "/")
+	if len(parts) != 4 || parts[0] != "users" || parts[2] != "feedTokens" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid feed token name format: %s", request.Name)
+	}
+
+	userID, err := ExtractUserIDFromName(fmt.Sprintf("users/%s", parts[1]))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	feedTokenID := parts[3]
+
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if currentUser.ID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	if err := s.Store.RemoveUserFeedToken(ctx, userID, feedTokenID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete feed token: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 func (s *APIV1Service) ListUserSessions(ctx context.Context, request *v1pb.ListUserSessionsRequest) (*v1pb.ListUserSessionsResponse, error) {
 	userID, err := ExtractUserIDFromName(request.Parent)
 	if err != nil {
@@ -715,6 +823,15 @@ func (s *APIV1Service) UpsertAccessTokenToStore(ctx context.Context, user *store
 	return nil
 }
 
+// publishUserEvent publishes the event of the user to the event bus, the user owns the event.
+func (s *APIV1Service) publishUserEvent(ctx context.Context, eventType string, user *store.User) {
+	s.EventBus().Publish(ctx, &Event{
+		Type:    eventType,
+		OwnerID: user.ID,
+		User:    convertUserFromStore(user),
+	})
+}
+
 func convertUserFromStore(user *store.User) *v1pb.User {
 	// Generate etag based on user data
 	etagData := fmt.Sprintf("%d-%d-%s-%s-%s", user.ID, user.UpdatedTs, user.Username, user.Email, user.Nickname)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/usememos/memos/internal/profile"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/runner/thumbnail"
 	"github.com/usememos/memos/store"
 )
 
@@ -33,12 +35,24 @@ type APIV1Service struct {
 	v1pb.UnimplementedWebhookServiceServer
 	v1pb.UnimplementedMarkdownServiceServer
 	v1pb.UnimplementedIdentityProviderServiceServer
+	v1pb.UnimplementedEventServiceServer
 
 	Secret  string
 	Profile *profile.Profile
 	Store   *store.Store
+	// ThumbnailRunner generates the thumbnails of the attachments, it is created on first use if not set.
+	ThumbnailRunner *thumbnail.Runner
 
 	grpcServer *grpc.Server
+
+	eventBus     *EventBus
+	eventBusOnce sync.Once
+	eventHistory *eventHistory
+
+	thumbnailRunnerOnce sync.Once
+
+	// uploadLocks holds a mutex per resumable upload, so that its chunks are written one at a time.
+	uploadLocks sync.Map
 }
 
 func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store, grpcServer *grpc.Server) *APIV1Service {
@@ -61,6 +75,7 @@ func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store
 	v1pb.RegisterWebhookServiceServer(grpcServer, apiv1Service)
 	v1pb.RegisterMarkdownServiceServer(grpcServer, apiv1Service)
 	v1pb.RegisterIdentityProviderServiceServer(grpcServer, apiv1Service)
+	v1pb.RegisterEventServiceServer(grpcServer, apiv1Service)
 	reflection.Register(grpcServer)
 	return apiv1Service
 }
@@ -122,6 +137,13 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 
 	gwGroup.Any("/api/v1/*", handler)
 	gwGroup.Any("/file/*", handler)
+	s.RegisterIncomingWebhookRoutes(echoServer)
+	s.RegisterMicropubRoutes(echoServer)
+	s.RegisterActivityPubRoutes(echoServer)
+	s.RegisterWebmentionRoutes(echoServer)
+	s.RegisterEventRoutes(echoServer)
+	s.RegisterUploadRoutes(echoServer)
+	s.RegisterAttachmentDownloadRoutes(echoServer)
 
 	// GRPC web proxy.
 	options := []grpcweb.Option{
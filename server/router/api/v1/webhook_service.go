@@ -4,18 +4,30 @@ import (
 	"context"
 	"crypto/md5"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/filter"
+	webhookplugin "github.com/usememos/memos/plugin/webhook"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
+// webhookSigningSecretLength is the length of the generated signing secrets of webhooks.
+const webhookSigningSecretLength = 32
+
 func (s *APIV1Service) CreateWebhook(ctx context.Context, request *v1pb.CreateWebhookRequest) (*v1pb.Webhook, error) {
 	currentUser, err := s.GetCurrentUser(ctx)
 	if err != nil {
@@ -37,6 +49,21 @@ func (s *APIV1Service) CreateWebhook(ctx context.Context, request *v1pb.CreateWe
 	if strings.TrimSpace(request.Webhook.Url) == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "webhook URL is required")
 	}
+	if err := validateWebhookSubscription(request.Webhook.EventTypes, request.Webhook.Filter); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook subscription: %v", err)
+	}
+	format := convertWebhookFormatToStore(request.Webhook.Format)
+	if err := validateWebhookFormat(format, request.Webhook.Template); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook format: %v", err)
+	}
+	scope := store.WebhookScopeUser
+	if request.Webhook.Scope == v1pb.Webhook_WORKSPACE {
+		scope = store.WebhookScopeWorkspace
+	}
+	visibilities := convertWebhookVisibilitiesToStore(request.Webhook.Visibilities)
+	if err := validateWebhookVisibilities(scope, visibilities); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook visibilities: %v", err)
+	}
 
 	// TODO: Handle webhook_id, validate_only, and request_id fields
 	if request.ValidateOnly {
@@ -49,15 +76,31 @@ func (s *APIV1Service) CreateWebhook(ctx context.Context, request *v1pb.CreateWe
 		}, nil
 	}
 
+	signingSecret, err := util.RandomString(webhookSigningSecretLength)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate signing secret: %v", err)
+	}
 	webhook, err := s.Store.CreateWebhook(ctx, &store.Webhook{
 		CreatorID: currentUser.ID,
 		Name:      request.Webhook.DisplayName,
 		URL:       strings.TrimSpace(request.Webhook.Url),
+		Scope:     scope,
+		Payload: &storepb.WebhookPayload{
+			SigningSecret: signingSecret,
+			EventTypes:    request.Webhook.EventTypes,
+			Filter:        request.Webhook.Filter,
+			Format:        format,
+			Template:      request.Webhook.Template,
+			Visibilities:  visibilities,
+		},
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create webhook, error: %+v", err)
 	}
-	return convertWebhookFromStore(webhook), nil
+	webhookPb := convertWebhookFromStore(webhook)
+	// The secret is only shown once.
+	webhookPb.SigningSecret = signingSecret
+	return webhookPb, nil
 }
 
 func (s *APIV1Service) ListWebhooks(ctx context.Context, _ *v1pb.ListWebhooksRequest) (*v1pb.ListWebhooksResponse, error) {
@@ -70,13 +113,25 @@ func (s *APIV1Service) ListWebhooks(ctx context.Context, _ *v1pb.ListWebhooksReq
 	}
 
 	// TODO: Implement proper filtering, ordering, and pagination
-	// For now, list webhooks for the current user
+	// For now, list the webhooks of the current user, and the workspace webhooks for hosts and admins.
+	userScope := store.WebhookScopeUser
 	webhooks, err := s.Store.ListWebhooks(ctx, &store.FindWebhook{
 		CreatorID: &currentUser.ID,
+		Scope:     &userScope,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list webhooks, error: %+v", err)
 	}
+	if isSuperUser(currentUser) {
+		workspaceScope := store.WebhookScopeWorkspace
+		workspaceWebhooks, err := s.Store.ListWebhooks(ctx, &store.FindWebhook{
+			Scope: &workspaceScope,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list webhooks, error: %+v", err)
+		}
+		webhooks = append(workspaceWebhooks, webhooks...)
+	}
 
 	response := &v1pb.ListWebhooksResponse{
 		Webhooks:  []*v1pb.Webhook{},
@@ -93,24 +148,9 @@ func (s *APIV1Service) GetWebhook(ctx context.Context, request *v1pb.GetWebhookR
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook name: %v", err)
 	}
-
-	currentUser, err := s.GetCurrentUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
-	}
-	if currentUser == nil {
-		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
-	}
-
-	webhook, err := s.Store.GetWebhook(ctx, &store.FindWebhook{
-		ID:        &webhookID,
-		CreatorID: &currentUser.ID,
-	})
+	webhook, err := s.getCurrentUserWebhook(ctx, webhookID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get webhook, error: %+v", err)
-	}
-	if webhook == nil {
-		return nil, status.Errorf(codes.NotFound, "webhook not found")
+		return nil, err
 	}
 
 	webhookPb := convertWebhookFromStore(webhook)
@@ -130,43 +170,50 @@ func (s *APIV1Service) UpdateWebhook(ctx context.Context, request *v1pb.UpdateWe
 		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook name: %v", err)
 	}
 
-	currentUser, err := s.GetCurrentUser(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
-	}
-	if currentUser == nil {
-		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
-	}
-
 	// Check if webhook exists and user has permission
-	existingWebhook, err := s.Store.GetWebhook(ctx, &store.FindWebhook{
-		ID:        &webhookID,
-		CreatorID: &currentUser.ID,
-	})
+	existingWebhook, err := s.getCurrentUserWebhook(ctx, webhookID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get webhook: %v", err)
-	}
-	if existingWebhook == nil {
-		if request.AllowMissing {
-			// Could create webhook if missing, but for now return not found
-			return nil, status.Errorf(codes.NotFound, "webhook not found")
-		}
-		return nil, status.Errorf(codes.NotFound, "webhook not found")
+		return nil, err
 	}
 
 	update := &store.UpdateWebhook{
 		ID: webhookID,
 	}
+	payload := existingWebhook.Payload
 	for _, field := range request.UpdateMask.Paths {
 		switch field {
 		case "display_name":
 			update.Name = &request.Webhook.DisplayName
 		case "url":
 			update.URL = &request.Webhook.Url
+		case "event_types":
+			payload.EventTypes = request.Webhook.EventTypes
+			update.Payload = payload
+		case "filter":
+			payload.Filter = request.Webhook.Filter
+			update.Payload = payload
+		case "format":
+			payload.Format = convertWebhookFormatToStore(request.Webhook.Format)
+			update.Payload = payload
+		case "template":
+			payload.Template = request.Webhook.Template
+			update.Payload = payload
+		case "visibilities":
+			payload.Visibilities = convertWebhookVisibilitiesToStore(request.Webhook.Visibilities)
+			update.Payload = payload
 		default:
 			return nil, status.Errorf(codes.InvalidArgument, "invalid update path: %s", field)
 		}
 	}
+	if err := validateWebhookSubscription(payload.EventTypes, payload.Filter); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook subscription: %v", err)
+	}
+	if err := validateWebhookFormat(payload.Format, payload.Template); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook format: %v", err)
+	}
+	if err := validateWebhookVisibilities(existingWebhook.Scope, payload.Visibilities); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook visibilities: %v", err)
+	}
 
 	webhook, err := s.Store.UpdateWebhook(ctx, update)
 	if err != nil {
@@ -181,6 +228,144 @@ func (s *APIV1Service) DeleteWebhook(ctx context.Context, request *v1pb.DeleteWe
 		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook name: %v", err)
 	}
 
+	// Check if webhook exists and user has permission
+	if _, err := s.getCurrentUserWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	// TODO: Handle force field properly
+
+	err = s.Store.DeleteWebhook(ctx, &store.DeleteWebhook{
+		ID: webhookID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete webhook, error: %+v", err)
+	}
+	if err := s.Store.DeleteWebhookDelivery(ctx, &store.DeleteWebhookDelivery{
+		WebhookID: &webhookID,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete webhook deliveries, error: %+v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *APIV1Service) RotateWebhookSecret(ctx context.Context, request *v1pb.RotateWebhookSecretRequest) (*v1pb.Webhook, error) {
+	webhookID, err := ExtractWebhookIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook name: %v", err)
+	}
+	webhook, err := s.getCurrentUserWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	signingSecret, err := util.RandomString(webhookSigningSecretLength)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate signing secret: %v", err)
+	}
+	payload := webhook.Payload
+	payload.SigningSecret = signingSecret
+	webhook, err = s.Store.UpdateWebhook(ctx, &store.UpdateWebhook{
+		ID:      webhook.ID,
+		Payload: payload,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update webhook: %v", err)
+	}
+	webhookPb := convertWebhookFromStore(webhook)
+	webhookPb.SigningSecret = signingSecret
+	return webhookPb, nil
+}
+
+func (s *APIV1Service) ListWebhookDeliveries(ctx context.Context, request *v1pb.ListWebhookDeliveriesRequest) (*v1pb.ListWebhookDeliveriesResponse, error) {
+	webhookID, err := ExtractWebhookIDFromName(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook name: %v", err)
+	}
+	webhook, err := s.getCurrentUserWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	var limit, offset int
+	if request.PageToken != "" {
+		var pageToken v1pb.PageToken
+		if err := unmarshalPageToken(request.PageToken, &pageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+		limit = int(pageToken.Limit)
+		offset = int(pageToken.Offset)
+	} else {
+		limit = int(request.PageSize)
+	}
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limitPlusOne := limit + 1
+	deliveries, err := s.Store.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		WebhookID: &webhook.ID,
+		Limit:     &limitPlusOne,
+		Offset:    &offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook deliveries, error: %+v", err)
+	}
+
+	response := &v1pb.ListWebhookDeliveriesResponse{}
+	if len(deliveries) == limitPlusOne {
+		deliveries = deliveries[:limit]
+		nextPageToken, err := getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+		response.NextPageToken = nextPageToken
+	}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, convertWebhookDeliveryFromStore(delivery))
+	}
+	return response, nil
+}
+
+func (s *APIV1Service) RedeliverWebhook(ctx context.Context, request *v1pb.RedeliverWebhookRequest) (*v1pb.WebhookDelivery, error) {
+	webhookID, deliveryID, err := ExtractWebhookDeliveryIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook delivery name: %v", err)
+	}
+	webhook, err := s.getCurrentUserWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := s.Store.GetWebhookDelivery(ctx, &store.FindWebhookDelivery{
+		ID:        &deliveryID,
+		WebhookID: &webhook.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get webhook delivery: %v", err)
+	}
+	if delivery == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook delivery not found")
+	}
+
+	// Redelivering queues a new delivery so that the history of the previous one is kept.
+	redelivery, err := s.Store.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: time.Now().Unix(),
+		Payload: &storepb.WebhookDeliveryPayload{
+			Url:          webhook.URL,
+			ActivityType: delivery.Payload.ActivityType,
+			RequestBody:  delivery.Payload.RequestBody,
+		},
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create webhook delivery: %v", err)
+	}
+	return convertWebhookDeliveryFromStore(redelivery), nil
+}
+
+// getCurrentUserWebhook returns the webhook the current user manages, or a gRPC error if there is none.
+// Users manage the webhooks they created, and hosts and admins also manage the workspace webhooks.
+func (s *APIV1Service) getCurrentUserWebhook(ctx context.Context, webhookID int32) (*store.Webhook, error) {
 	currentUser, err := s.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
@@ -188,11 +373,8 @@ func (s *APIV1Service) DeleteWebhook(ctx context.Context, request *v1pb.DeleteWe
 	if currentUser == nil {
 		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
 	}
-
-	// Check if webhook exists and user has permission
 	webhook, err := s.Store.GetWebhook(ctx, &store.FindWebhook{
-		ID:        &webhookID,
-		CreatorID: &currentUser.ID,
+		ID: &webhookID,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get webhook: %v", err)
@@ -200,16 +382,33 @@ func (s *APIV1Service) DeleteWebhook(ctx context.Context, request *v1pb.DeleteWe
 	if webhook == nil {
 		return nil, status.Errorf(codes.NotFound, "webhook not found")
 	}
+	if webhook.Scope == store.WebhookScopeWorkspace {
+		if !isSuperUser(currentUser) {
+			return nil, status.Errorf(codes.NotFound, "webhook not found")
+		}
+	} else if webhook.CreatorID != currentUser.ID {
+		return nil, status.Errorf(codes.NotFound, "webhook not found")
+	}
+	return webhook, nil
+}
 
-	// TODO: Handle force field properly
-
-	err = s.Store.DeleteWebhook(ctx, &store.DeleteWebhook{
-		ID: webhookID,
-	})
+// enqueueWebhookDelivery queues the delivery of the payload to the webhook, it is sent by the webhook delivery runner.
+func (s *APIV1Service) enqueueWebhookDelivery(ctx context.Context, webhook *store.Webhook, payload *v1pb.WebhookRequestPayload) (*store.WebhookDelivery, error) {
+	message := webhookplugin.NewMessage(payload, s.Profile.InstanceURL)
+	body, err := webhookplugin.Render(webhook.Payload.GetFormat(), webhook.Payload.GetTemplate(), message)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to delete webhook, error: %+v", err)
-	}
-	return &emptypb.Empty{}, nil
+		return nil, errors.Wrap(err, "failed to render webhook payload")
+	}
+	return s.Store.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: time.Now().Unix(),
+		Payload: &storepb.WebhookDeliveryPayload{
+			Url:          webhook.URL,
+			ActivityType: payload.ActivityType,
+			RequestBody:  string(body),
+		},
+	})
 }
 
 func convertWebhookFromStore(webhook *store.Webhook) *v1pb.Webhook {
@@ -217,7 +416,7 @@ func convertWebhookFromStore(webhook *store.Webhook) *v1pb.Webhook {
 	etag := fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprintf("%d-%d-%s-%s",
 		webhook.ID, webhook.UpdatedTs, webhook.Name, webhook.URL))))
 
-	return &v1pb.Webhook{
+	webhookPb := &v1pb.Webhook{
 		Name:        fmt.Sprintf("webhooks/%d", webhook.ID),
 		Uid:         fmt.Sprintf("%d", webhook.ID),
 		DisplayName: webhook.Name,
@@ -227,5 +426,252 @@ func convertWebhookFromStore(webhook *store.Webhook) *v1pb.Webhook {
 		CreateTime:  timestamppb.New(time.Unix(webhook.CreatedTs, 0)),
 		UpdateTime:  timestamppb.New(time.Unix(webhook.UpdatedTs, 0)),
 		Etag:        etag,
+		EventTypes:  webhook.Payload.GetEventTypes(),
+		Filter:      webhook.Payload.GetFilter(),
+		Format:      v1pb.Webhook_Format(v1pb.Webhook_Format_value[webhook.Payload.GetFormat().String()]),
+		Template:    webhook.Payload.GetTemplate(),
+		Scope:       v1pb.Webhook_Scope(v1pb.Webhook_Scope_value[webhook.Scope.String()]),
+	}
+	for _, visibility := range webhook.Payload.GetVisibilities() {
+		webhookPb.Visibilities = append(webhookPb.Visibilities, v1pb.Visibility(v1pb.Visibility_value[visibility]))
+	}
+	return webhookPb
+}
+
+func convertWebhookVisibilitiesToStore(visibilities []v1pb.Visibility) []string {
+	list := []string{}
+	for _, visibility := range visibilities {
+		list = append(list, visibility.String())
+	}
+	return list
+}
+
+// validateWebhookVisibilities checks that only workspace webhooks set the visibilities, to known values.
+func validateWebhookVisibilities(scope store.WebhookScope, visibilities []string) error {
+	if len(visibilities) > 0 && scope != store.WebhookScopeWorkspace {
+		return errors.New("visibilities are only supported by workspace webhooks")
+	}
+	for _, visibility := range visibilities {
+		if v1pb.Visibility_value[visibility] == int32(v1pb.Visibility_VISIBILITY_UNSPECIFIED) {
+			return errors.Errorf("unsupported visibility %q", visibility)
+		}
+	}
+	return nil
+}
+
+func convertWebhookFormatToStore(format v1pb.Webhook_Format) storepb.WebhookPayload_Format {
+	return storepb.WebhookPayload_Format(storepb.WebhookPayload_Format_value[format.String()])
+}
+
+// validateWebhookFormat checks that the template of the TEMPLATE format is set and valid.
+func validateWebhookFormat(format storepb.WebhookPayload_Format, template string) error {
+	if format != storepb.WebhookPayload_TEMPLATE {
+		return nil
+	}
+	if strings.TrimSpace(template) == "" {
+		return errors.New("template is required")
 	}
+	_, err := webhookplugin.ParseTemplate(template)
+	return err
+}
+
+// validateWebhookSubscription checks the event types and the filter of a webhook.
+func validateWebhookSubscription(eventTypes []string, filterStr string) error {
+	for _, eventType := range eventTypes {
+		if !slices.Contains(webhookEventTypes, eventType) {
+			return errors.Errorf("unsupported event type %q", eventType)
+		}
+	}
+	if filterStr == "" {
+		return nil
+	}
+	parsedExpr, err := filter.Parse(filterStr, filter.MemoFilterCELAttributes...)
+	if err != nil {
+		return err
+	}
+	// Evaluating against an empty memo reports the expressions that are not supported.
+	if _, err := filter.Evaluate(parsedExpr.GetExpr(), &filter.Memo{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// handleWebhookEvent queues the deliveries of the event to the subscribed webhooks of its owner,
+// and to the subscribed workspace webhooks that receive the visibility of its memo.
+func (s *APIV1Service) handleWebhookEvent(ctx context.Context, event *Event) error {
+	if !slices.Contains(webhookEventTypes, event.Type) {
+		return nil
+	}
+	workspaceScope := store.WebhookScopeWorkspace
+	webhooks, err := s.Store.ListWebhooks(ctx, &store.FindWebhook{
+		Scope: &workspaceScope,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list workspace webhooks")
+	}
+	webhooks = slices.DeleteFunc(webhooks, func(hook *store.Webhook) bool {
+		return !matchWorkspaceWebhookVisibility(hook, event)
+	})
+	if event.OwnerID != 0 {
+		userScope := store.WebhookScopeUser
+		userWebhooks, err := s.Store.ListWebhooks(ctx, &store.FindWebhook{
+			CreatorID: &event.OwnerID,
+			Scope:     &userScope,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to list webhooks")
+		}
+		webhooks = append(webhooks, userWebhooks...)
+	}
+	for _, hook := range webhooks {
+		matched, err := matchWebhookEvent(hook, event)
+		if err != nil {
+			slog.Warn("failed to match webhook", slog.Int("webhook", int(hook.ID)), slog.Any("err", err))
+			continue
+		}
+		if !matched {
+			continue
+		}
+		payload := convertEventToWebhookPayload(event)
+		if hook.Scope == store.WebhookScopeWorkspace {
+			payload = stripWebhookPayloadLocations(payload)
+		} else if event.ActorID != 0 && event.ActorID != hook.CreatorID {
+			// The memos of the event are converted for its actor, so the locations of their images are only kept for the actor.
+			payload.Memo = stripMemoImageLocations(payload.Memo)
+			if comment := payload.GetComment(); comment != nil {
+				payload.Event = &v1pb.WebhookRequestPayload_Comment{Comment: stripMemoImageLocations(comment)}
+			}
+		}
+		payload.Url = hook.URL
+		if _, err := s.enqueueWebhookDelivery(ctx, hook, payload); err != nil {
+			return errors.Wrap(err, "failed to enqueue webhook delivery")
+		}
+	}
+	return nil
+}
+
+// matchWebhookEvent returns whether the webhook is subscribed to the event.
+// The events without a memo never match webhooks with a filter.
+func matchWebhookEvent(webhook *store.Webhook, event *Event) (bool, error) {
+	eventTypes := webhook.Payload.GetEventTypes()
+	if len(eventTypes) > 0 && !slices.Contains(eventTypes, event.Type) {
+		return false, nil
+	}
+	filterStr := webhook.Payload.GetFilter()
+	if filterStr == "" {
+		return true, nil
+	}
+	memo := event.Memo
+	if memo == nil {
+		return false, nil
+	}
+	parsedExpr, err := filter.Parse(filterStr, filter.MemoFilterCELAttributes...)
+	if err != nil {
+		return false, err
+	}
+	creatorID, err := ExtractUserIDFromName(memo.Creator)
+	if err != nil {
+		return false, err
+	}
+	return filter.Evaluate(parsedExpr.GetExpr(), &filter.Memo{
+		Content:     memo.Content,
+		CreatorID:   int64(creatorID),
+		CreatedTs:   memo.CreateTime.GetSeconds(),
+		UpdatedTs:   memo.UpdateTime.GetSeconds(),
+		Pinned:      memo.Pinned,
+		Tags:        memo.Tags,
+		Visibility:  memo.Visibility.String(),
+		HasTaskList: memo.Property.GetHasTaskList(),
+	})
+}
+
+// matchWorkspaceWebhookVisibility returns whether the workspace webhook receives the memos of the event.
+// The memos of the webhook creator are always received. The events without a memo, such as the uploaded attachments,
+// are received as private memos, except for the user events since the users are visible to everyone.
+func matchWorkspaceWebhookVisibility(webhook *store.Webhook, event *Event) bool {
+	visibilities := webhook.Payload.GetVisibilities()
+	if len(visibilities) == 0 {
+		visibilities = []string{v1pb.Visibility_PUBLIC.String()}
+	}
+	if event.Memo == nil && event.Comment == nil && event.User == nil {
+		return webhook.CreatorID == event.OwnerID || slices.Contains(visibilities, v1pb.Visibility_PRIVATE.String())
+	}
+	for _, memo := range []*v1pb.Memo{event.Memo, event.Comment} {
+		if memo == nil || memo.Creator == fmt.Sprintf("%s%d", UserNamePrefix, webhook.CreatorID) {
+			continue
+		}
+		if !slices.Contains(visibilities, memo.Visibility.String()) {
+			return false
+		}
+	}
+	return true
+}
+
+// convertEventToWebhookPayload returns the webhook payload of the event, the creator is the user that caused it.
+func convertEventToWebhookPayload(event *Event) *v1pb.WebhookRequestPayload {
+	creatorID := event.ActorID
+	if creatorID == 0 {
+		creatorID = event.OwnerID
+	}
+	payload := &v1pb.WebhookRequestPayload{
+		ActivityType: event.Type,
+		Creator:      fmt.Sprintf("%s%d", UserNamePrefix, creatorID),
+		CreateTime:   timestamppb.New(event.CreateTime),
+		Memo:         event.Memo,
+	}
+	switch {
+	case event.Comment != nil:
+		payload.Event = &v1pb.WebhookRequestPayload_Comment{Comment: event.Comment}
+	case event.Reaction != nil:
+		payload.Event = &v1pb.WebhookRequestPayload_Reaction{Reaction: event.Reaction}
+	case event.Attachment != nil:
+		payload.Event = &v1pb.WebhookRequestPayload_Attachment{Attachment: event.Attachment}
+	case event.Type == EventMemoRelationChanged:
+		payload.Event = &v1pb.WebhookRequestPayload_Relations{
+			Relations: &v1pb.WebhookRequestPayload_MemoRelations{Relations: event.Relations},
+		}
+	case event.User != nil:
+		payload.Event = &v1pb.WebhookRequestPayload_User{User: event.User}
+	}
+	return payload
+}
+
+// stripWebhookPayloadLocations returns a copy of the payload without the locations of the memos and the images,
+// as the workspace webhooks may be received by services which shouldn't learn where the users are.
+func stripWebhookPayloadLocations(payload *v1pb.WebhookRequestPayload) *v1pb.WebhookRequestPayload {
+	payload = proto.Clone(payload).(*v1pb.WebhookRequestPayload)
+	attachments := []*v1pb.Attachment{payload.GetAttachment()}
+	for _, memo := range []*v1pb.Memo{payload.Memo, payload.GetComment()} {
+		if memo != nil {
+			memo.Location = nil
+			attachments = append(attachments, memo.Attachments...)
+		}
+	}
+	for _, attachment := range attachments {
+		if attachment.GetImageMetadata() != nil {
+			attachment.ImageMetadata.Location = nil
+		}
+	}
+	return payload
+}
+
+func convertWebhookDeliveryFromStore(delivery *store.WebhookDelivery) *v1pb.WebhookDelivery {
+	webhookDelivery := &v1pb.WebhookDelivery{
+		Name:           fmt.Sprintf("%s%d/%s%d", WebhookNamePrefix, delivery.WebhookID, WebhookDeliveryNamePrefix, delivery.ID),
+		Status:         v1pb.WebhookDelivery_Status(v1pb.WebhookDelivery_Status_value[delivery.Status.String()]),
+		ActivityType:   delivery.Payload.ActivityType,
+		Url:            delivery.Payload.Url,
+		Attempts:       delivery.Attempts,
+		RequestBody:    delivery.Payload.RequestBody,
+		ResponseStatus: delivery.Payload.ResponseStatus,
+		ResponseBody:   delivery.Payload.ResponseBody,
+		Latency:        durationpb.New(time.Duration(delivery.Payload.LatencyMs) * time.Millisecond),
+		Error:          delivery.Payload.Error,
+		CreateTime:     timestamppb.New(time.Unix(delivery.CreatedTs, 0)),
+		UpdateTime:     timestamppb.New(time.Unix(delivery.UpdatedTs, 0)),
+	}
+	if delivery.Status == store.WebhookDeliveryPending {
+		webhookDelivery.NextAttemptTime = timestamppb.New(time.Unix(delivery.NextAttemptTs, 0))
+	}
+	return webhookDelivery
 }
@@ -0,0 +1,346 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/parser"
+	"github.com/usememos/gomark/parser/tokenizer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/plugin/httpgetter"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/memopayload"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// WebmentionPath is the path of the Webmention receiver.
+	WebmentionPath = "/webmention"
+)
+
+// RegisterWebmentionRoutes registers the Webmention receiver.
+// Mentions are verified in the background and stored against the target memo once the source is found to link to it.
+func (s *APIV1Service) RegisterWebmentionRoutes(echoServer *echo.Echo) {
+	echoServer.POST(WebmentionPath, s.handleWebmention)
+}
+
+func (s *APIV1Service) handleWebmention(c echo.Context) error {
+	ctx := c.Request().Context()
+	source, target := c.FormValue("source"), c.FormValue("target")
+	if !isWebmentionURL(source) || !isWebmentionURL(target) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Source and target must be http or https URLs")
+	}
+	if source == target {
+		return echo.NewHTTPError(http.StatusBadRequest, "Source and target must be different")
+	}
+
+	// Only public memos of this instance can be mentioned.
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid target").SetInternal(err)
+	}
+	host := c.Request().Host
+	if baseURL := strings.TrimSuffix(s.Profile.InstanceURL, "/"); baseURL != "" {
+		host = getActivityPubHost(baseURL)
+	}
+	memoUID := extractMemoUIDFromURL(target)
+	if !strings.EqualFold(targetURL.Host, host) || memoUID == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Target is not a memo of this site")
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get memo").SetInternal(err)
+	}
+	if memo == nil || memo.Visibility != store.Public || memo.RowStatus != store.Normal {
+		return echo.NewHTTPError(http.StatusBadRequest, "Target does not accept webmentions")
+	}
+
+	go s.verifyWebmention(context.Background(), memo.ID, source, target)
+	return c.NoContent(http.StatusAccepted)
+}
+
+// verifyWebmention fetches the source of a Webmention and stores the mention if the source links to the target.
+// An existing mention is deleted when its source no longer links to the target.
+func (s *APIV1Service) verifyWebmention(ctx context.Context, memoID int32, source string, target string) {
+	webmentionSource, err := httpgetter.GetWebmentionSource(source, target)
+	if err != nil {
+		if errors.Is(err, httpgetter.ErrWebmentionTargetNotFound) || errors.Is(err, httpgetter.ErrWebmentionSourceGone) {
+			if err := s.Store.DeleteWebmention(ctx, &store.DeleteWebmention{
+				MemoID: &memoID,
+				Source: &source,
+			}); err != nil {
+				slog.Warn("Failed to delete webmention", slog.String("source", source), slog.Any("err", err))
+			}
+			return
+		}
+		slog.Warn("Failed to verify webmention", slog.String("source", source), slog.Any("err", err))
+		return
+	}
+
+	payload := &storepb.WebmentionPayload{
+		Content:     webmentionSource.Content,
+		AuthorName:  webmentionSource.AuthorName,
+		AuthorUrl:   webmentionSource.AuthorURL,
+		AuthorPhoto: webmentionSource.AuthorPhoto,
+	}
+	if published, err := time.Parse(time.RFC3339, webmentionSource.Published); err == nil {
+		payload.PublishedTs = published.Unix()
+	}
+	if _, err := s.Store.UpsertWebmention(ctx, &store.Webmention{
+		MemoID:  memoID,
+		Source:  source,
+		Type:    webmentionSource.Type,
+		Payload: payload,
+	}); err != nil {
+		slog.Warn("Failed to save webmention", slog.String("source", source), slog.Any("err", err))
+	}
+}
+
+func (s *APIV1Service) ListMemoWebmentions(ctx context.Context, request *v1pb.ListMemoWebmentionsRequest) (*v1pb.ListMemoWebmentionsResponse, error) {
+	memoUID, err := ExtractMemoUIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo")
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+	if memo.Visibility != store.Public {
+		user, err := s.GetCurrentUser(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get user")
+		}
+		if user == nil {
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		}
+		if memo.Visibility == store.Private && memo.CreatorID != user.ID {
+			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		}
+	}
+
+	webmentions, err := s.Store.ListWebmentions(ctx, &store.FindWebmention{
+		MemoID: &memo.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webmentions")
+	}
+	response := &v1pb.ListMemoWebmentionsResponse{
+		Webmentions: []*v1pb.Webmention{},
+	}
+	for _, webmention := range webmentions {
+		response.Webmentions = append(response.Webmentions, convertWebmentionFromStore(webmention, memo))
+	}
+	return response, nil
+}
+
+// listPublicMemoWebmentions returns the webmentions of the types of the memo, which are shown along with its comments
+// and reactions. The webmentions are only received by public memos, and are hidden once the memo is no longer public.
+func (s *APIV1Service) listPublicMemoWebmentions(ctx context.Context, memo *store.Memo, types ...v1pb.Webmention_Type) ([]*v1pb.Webmention, error) {
+	if memo == nil || memo.Visibility != store.Public {
+		return nil, nil
+	}
+	webmentions, err := s.Store.ListWebmentions(ctx, &store.FindWebmention{
+		MemoID: &memo.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	webmentionMessages := []*v1pb.Webmention{}
+	for _, webmention := range webmentions {
+		webmentionMessage := convertWebmentionFromStore(webmention, memo)
+		if slices.Contains(types, webmentionMessage.Type) {
+			webmentionMessages = append(webmentionMessages, webmentionMessage)
+		}
+	}
+	return webmentionMessages, nil
+}
+
+func (s *APIV1Service) DeleteMemoWebmention(ctx context.Context, request *v1pb.DeleteMemoWebmentionRequest) (*emptypb.Empty, error) {
+	memoName, webmentionID, found := strings.Cut(request.Name, "/webmentions/")
+	if !found {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webmention name")
+	}
+	id, err := strconv.ParseInt(webmentionID, 10, 32)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webmention id: %v", err)
+	}
+	memoUID, err := ExtractMemoUIDFromName(memoName)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid memo name: %v", err)
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &memoUID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get memo")
+	}
+	if memo == nil {
+		return nil, status.Errorf(codes.NotFound, "memo not found")
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user")
+	}
+	// Only the creator or admin can moderate the mentions of the memo.
+	if memo.CreatorID != user.ID && !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	webmentionIDInt32 := int32(id)
+	webmention, err := s.Store.GetWebmention(ctx, &store.FindWebmention{
+		ID:     &webmentionIDInt32,
+		MemoID: &memo.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get webmention")
+	}
+	if webmention == nil {
+		return nil, status.Errorf(codes.NotFound, "webmention not found")
+	}
+	if err := s.Store.DeleteWebmention(ctx, &store.DeleteWebmention{
+		ID: &webmention.ID,
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete webmention")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// SendMemoWebmentions notifies the sites linked from the memo that the memo mentions them.
+// When the memo was already public with the previous content, only the links added to or removed from it are notified,
+// so that those sites can add or remove the mention. The notifications are sent in the background.
+func (s *APIV1Service) SendMemoWebmentions(memo *store.Memo, previousContent string) error {
+	baseURL := strings.TrimSuffix(s.Profile.InstanceURL, "/")
+	if baseURL == "" {
+		return nil
+	}
+	host := getActivityPubHost(baseURL)
+	targets, err := getWebmentionTargets(memo.Content, host)
+	if err != nil {
+		return err
+	}
+	if previousContent != "" {
+		previousTargets, err := getWebmentionTargets(previousContent, host)
+		if err != nil {
+			return err
+		}
+		changedTargets := []string{}
+		for _, target := range targets {
+			if !slices.Contains(previousTargets, target) {
+				changedTargets = append(changedTargets, target)
+			}
+		}
+		for _, target := range previousTargets {
+			if !slices.Contains(targets, target) {
+				changedTargets = append(changedTargets, target)
+			}
+		}
+		targets = changedTargets
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	source := fmt.Sprintf("%s/%s%s", baseURL, MemoNamePrefix, memo.UID)
+	go func() {
+		for _, target := range targets {
+			endpoint, err := httpgetter.GetWebmentionEndpoint(target)
+			if err != nil {
+				slog.Warn("Failed to discover webmention endpoint", slog.String("target", target), slog.Any("err", err))
+				continue
+			}
+			if endpoint == "" {
+				continue
+			}
+			if err := httpgetter.SendWebmention(endpoint, source, target); err != nil {
+				slog.Warn("Failed to send webmention", slog.String("target", target), slog.Any("err", err))
+			}
+		}
+	}()
+	return nil
+}
+
+// getWebmentionTargets returns the external links of the content, skipping links to the host itself.
+func getWebmentionTargets(content string, host string) ([]string, error) {
+	targets := []string{}
+	nodes, err := parser.Parse(tokenizer.Tokenize(content))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse content")
+	}
+	memopayload.TraverseASTNodes(nodes, func(node ast.Node) {
+		target := ""
+		switch n := node.(type) {
+		case *ast.Link:
+			target = n.URL
+		case *ast.AutoLink:
+			target = n.URL
+		}
+		if !isWebmentionURL(target) || slices.Contains(targets, target) {
+			return
+		}
+		if u, err := url.Parse(target); err != nil || strings.EqualFold(u.Host, host) {
+			return
+		}
+		targets = append(targets, target)
+	})
+	return targets, nil
+}
+
+func isWebmentionURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func convertWebmentionFromStore(webmention *store.Webmention, memo *store.Memo) *v1pb.Webmention {
+	webmentionMessage := &v1pb.Webmention{
+		Name:    fmt.Sprintf("%s%s/webmentions/%d", MemoNamePrefix, memo.UID, webmention.ID),
+		Source:  webmention.Source,
+		Type:    convertWebmentionTypeFromStore(webmention.Type),
+		Content: webmention.Payload.GetContent(),
+		Author: &v1pb.Webmention_Author{
+			Name:  webmention.Payload.GetAuthorName(),
+			Url:   webmention.Payload.GetAuthorUrl(),
+			Photo: webmention.Payload.GetAuthorPhoto(),
+		},
+		CreateTime: timestamppb.New(time.Unix(webmention.CreatedTs, 0)),
+		UpdateTime: timestamppb.New(time.Unix(webmention.UpdatedTs, 0)),
+	}
+	if publishedTs := webmention.Payload.GetPublishedTs(); publishedTs != 0 {
+		webmentionMessage.PublishTime = timestamppb.New(time.Unix(publishedTs, 0))
+	}
+	return webmentionMessage
+}
+
+func convertWebmentionTypeFromStore(webmentionType string) v1pb.Webmention_Type {
+	switch webmentionType {
+	case httpgetter.WebmentionTypeReply:
+		return v1pb.Webmention_REPLY
+	case httpgetter.WebmentionTypeLike:
+		return v1pb.Webmention_LIKE
+	case httpgetter.WebmentionTypeRepost:
+		return v1pb.Webmention_REPOST
+	case httpgetter.WebmentionTypeBookmark:
+		return v1pb.Webmention_BOOKMARK
+	default:
+		return v1pb.Webmention_MENTION
+	}
+}
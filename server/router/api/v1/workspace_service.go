@@ -150,13 +150,14 @@ func convertWorkspaceGeneralSettingFromStore(setting *storepb.WorkspaceGeneralSe
 		return nil
 	}
 	generalSetting := &v1pb.WorkspaceGeneralSetting{
-		DisallowUserRegistration: setting.DisallowUserRegistration,
-		DisallowPasswordAuth:     setting.DisallowPasswordAuth,
-		AdditionalScript:         setting.AdditionalScript,
-		AdditionalStyle:          setting.AdditionalStyle,
-		WeekStartDayOffset:       setting.WeekStartDayOffset,
-		DisallowChangeUsername:   setting.DisallowChangeUsername,
-		DisallowChangeNickname:   setting.DisallowChangeNickname,
+		DisallowUserRegistration:     setting.DisallowUserRegistration,
+		DisallowPasswordAuth:         setting.DisallowPasswordAuth,
+		AdditionalScript:             setting.AdditionalScript,
+		AdditionalStyle:              setting.AdditionalStyle,
+		WeekStartDayOffset:           setting.WeekStartDayOffset,
+		DisallowChangeUsername:       setting.DisallowChangeUsername,
+		DisallowChangeNickname:       setting.DisallowChangeNickname,
+		DisallowSearchEngineIndexing: setting.DisallowSearchEngineIndexing,
 	}
 	if setting.CustomProfile != nil {
 		generalSetting.CustomProfile = &v1pb.WorkspaceCustomProfile{
@@ -175,13 +176,14 @@ func convertWorkspaceGeneralSettingToStore(setting *v1pb.WorkspaceGeneralSetting
 		return nil
 	}
 	generalSetting := &storepb.WorkspaceGeneralSetting{
-		DisallowUserRegistration: setting.DisallowUserRegistration,
-		DisallowPasswordAuth:     setting.DisallowPasswordAuth,
-		AdditionalScript:         setting.AdditionalScript,
-		AdditionalStyle:          setting.AdditionalStyle,
-		WeekStartDayOffset:       setting.WeekStartDayOffset,
-		DisallowChangeUsername:   setting.DisallowChangeUsername,
-		DisallowChangeNickname:   setting.DisallowChangeNickname,
+		DisallowUserRegistration:     setting.DisallowUserRegistration,
+		DisallowPasswordAuth:         setting.DisallowPasswordAuth,
+		AdditionalScript:             setting.AdditionalScript,
+		AdditionalStyle:              setting.AdditionalStyle,
+		WeekStartDayOffset:           setting.WeekStartDayOffset,
+		DisallowChangeUsername:       setting.DisallowChangeUsername,
+		DisallowChangeNickname:       setting.DisallowChangeNickname,
+		DisallowSearchEngineIndexing: setting.DisallowSearchEngineIndexing,
 	}
 	if setting.CustomProfile != nil {
 		generalSetting.CustomProfile = &storepb.WorkspaceCustomProfile{
@@ -203,6 +205,7 @@ func convertWorkspaceStorageSettingFromStore(settingpb *storepb.WorkspaceStorage
 		StorageType:       v1pb.WorkspaceStorageSetting_StorageType(settingpb.StorageType),
 		FilepathTemplate:  settingpb.FilepathTemplate,
 		UploadSizeLimitMb: settingpb.UploadSizeLimitMb,
+		ExifStripPolicy:   v1pb.WorkspaceStorageSetting_ExifStripPolicy(settingpb.ExifStripPolicy),
 	}
 	if settingpb.S3Config != nil {
 		setting.S3Config = &v1pb.WorkspaceStorageSetting_S3Config{
@@ -225,6 +228,7 @@ func convertWorkspaceStorageSettingToStore(setting *v1pb.WorkspaceStorageSetting
 		StorageType:       storepb.WorkspaceStorageSetting_StorageType(setting.StorageType),
 		FilepathTemplate:  setting.FilepathTemplate,
 		UploadSizeLimitMb: setting.UploadSizeLimitMb,
+		ExifStripPolicy:   storepb.WorkspaceStorageSetting_ExifStripPolicy(setting.ExifStripPolicy),
 	}
 	if setting.S3Config != nil {
 		settingpb.S3Config = &storepb.StorageS3Config{
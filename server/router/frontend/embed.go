@@ -0,0 +1,293 @@
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/usememos/gomark"
+	"github.com/usememos/gomark/renderer"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// OEmbedPath is the path of the oEmbed endpoint.
+	OEmbedPath = "/api/oembed"
+
+	defaultEmbedWidth  = 550
+	defaultEmbedHeight = 400
+)
+
+// OEmbed is an oEmbed response of the rich type, see https://oembed.com.
+type OEmbed struct {
+	Version      string `json:"version"`
+	Type         string `json:"type"`
+	Title        string `json:"title,omitempty"`
+	AuthorName   string `json:"author_name,omitempty"`
+	AuthorURL    string `json:"author_url,omitempty"`
+	ProviderName string `json:"provider_name"`
+	ProviderURL  string `json:"provider_url"`
+	CacheAge     int    `json:"cache_age,omitempty"`
+	HTML         string `json:"html"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+// registerEmbedRoutes registers the oEmbed endpoint and the embeddable memo pages.
+func (s *FrontendService) registerEmbedRoutes(e *echo.Echo) {
+	e.GET(OEmbedPath, s.getOEmbed)
+	e.GET("/embed/memos/:uid", s.serveMemoEmbed)
+}
+
+func (s *FrontendService) getOEmbed(c echo.Context) error {
+	ctx := c.Request().Context()
+	if format := c.QueryParam("format"); format != "" && format != "json" {
+		return echo.NewHTTPError(http.StatusNotImplemented, "Only the json format is supported")
+	}
+	rawURL := c.QueryParam("url")
+	if rawURL == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing url")
+	}
+	baseURL := s.getBaseURL(c)
+	uid, err := getMemoUIDFromURL(rawURL, baseURL)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Not a memo of this site").SetInternal(err)
+	}
+	memo, err := s.getEmbeddableMemo(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	width, height := defaultEmbedWidth, defaultEmbedHeight
+	if maxWidth, err := strconv.Atoi(c.QueryParam("maxwidth")); err == nil && maxWidth > 0 {
+		width = min(width, maxWidth)
+	}
+	if maxHeight, err := strconv.Atoi(c.QueryParam("maxheight")); err == nil && maxHeight > 0 {
+		height = min(height, maxHeight)
+	}
+	embedURL := baseURL + "/embed/memos/" + url.PathEscape(memo.UID)
+	if theme := c.QueryParam("theme"); theme != "" {
+		embedURL += "?theme=" + url.QueryEscape(getEmbedTheme(theme))
+	}
+
+	metadata, err := s.getMemoMetadata(ctx, memo, baseURL)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate memo metadata").SetInternal(err)
+	}
+	oembed := &OEmbed{
+		Version:      "1.0",
+		Type:         "rich",
+		Title:        metadata.Title,
+		AuthorName:   metadata.Author,
+		ProviderName: metadata.SiteName,
+		ProviderURL:  baseURL,
+		CacheAge:     3600,
+		HTML: fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" style="border: 0; max-width: 100%%;" loading="lazy" title="%s"></iframe>`,
+			template.HTMLEscapeString(embedURL), width, height, template.HTMLEscapeString(metadata.Title)),
+		Width:  width,
+		Height: height,
+	}
+	if creator, err := s.Store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID}); err == nil && creator != nil {
+		oembed.AuthorURL = baseURL + "/u/" + url.PathEscape(creator.Username)
+	}
+	return c.JSON(http.StatusOK, oembed)
+}
+
+type embedAttachment struct {
+	URL      string
+	Filename string
+	IsImage  bool
+}
+
+type embedReaction struct {
+	Type  string
+	Count int
+}
+
+type embedData struct {
+	Theme       string
+	Title       string
+	Content     template.HTML
+	URL         string
+	AuthorName  string
+	AuthorURL   string
+	Time        time.Time
+	Attachments []*embedAttachment
+	Reactions   []*embedReaction
+}
+
+var embedTemplate = template.Must(template.New("embed").Parse(`<!DOCTYPE html>
+<html lang="en" class="{{.Theme}}">
+<head>
+<meta charset="UTF-8" />
+<meta name="viewport" content="width=device-width, initial-scale=1" />
+<title>{{.Title}}</title>
+<style>
+html { --background: #ffffff; --foreground: #27272a; --muted: #71717a; --border: #e4e4e7; }
+html.dark { --background: #18181b; --foreground: #e4e4e7; --muted: #a1a1aa; --border: #3f3f46; }
+body { margin: 0; padding: 16px; font: 15px/1.6 -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; background: var(--background); color: var(--foreground); }
+a { color: inherit; }
+img { max-width: 100%; border-radius: 6px; }
+pre { overflow-x: auto; padding: 8px; border: 1px solid var(--border); border-radius: 6px; }
+.content > :first-child { margin-top: 0; }
+.attachments { display: flex; flex-wrap: wrap; gap: 8px; margin-top: 12px; }
+.attachments img { max-height: 200px; }
+.reactions { display: flex; flex-wrap: wrap; gap: 6px; margin-top: 12px; }
+.reactions span { padding: 0 8px; border: 1px solid var(--border); border-radius: 12px; font-size: 13px; }
+footer { display: flex; justify-content: space-between; margin-top: 12px; padding-top: 8px; border-top: 1px solid var(--border); color: var(--muted); font-size: 13px; }
+</style>
+</head>
+<body>
+<article>
+<div class="content">{{.Content}}</div>
+{{- if .Attachments}}
+<div class="attachments">
+{{- range .Attachments}}
+{{- if .IsImage}}
+<a href="{{.URL}}" target="_blank" rel="noopener"><img src="{{.URL}}" alt="{{.Filename}}" loading="lazy" /></a>
+{{- else}}
+<a href="{{.URL}}" target="_blank" rel="noopener">{{.Filename}}</a>
+{{- end}}
+{{- end}}
+</div>
+{{- end}}
+{{- if .Reactions}}
+<div class="reactions">
+{{- range .Reactions}}
+<span>{{.Type}} {{.Count}}</span>
+{{- end}}
+</div>
+{{- end}}
+<footer>
+<a href="{{.AuthorURL}}" target="_blank" rel="noopener">{{.AuthorName}}</a>
+<a href="{{.URL}}" target="_blank" rel="noopener"><time datetime="{{.Time.Format "2006-01-02T15:04:05Z07:00"}}">{{.Time.Format "Jan 2, 2006"}}</time></a>
+</footer>
+</article>
+</body>
+</html>
+`))
+
+// serveMemoEmbed serves a public memo as a standalone page to be embedded in an iframe.
+// The `theme` query parameter is either `light` or `dark`.
+func (s *FrontendService) serveMemoEmbed(c echo.Context) error {
+	ctx := c.Request().Context()
+	memo, err := s.getEmbeddableMemo(ctx, c.Param("uid"))
+	if err != nil {
+		return err
+	}
+
+	baseURL := s.getBaseURL(c)
+	nodes, err := gomark.Parse(memo.Content)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to parse memo content").SetInternal(err)
+	}
+	data := &embedData{
+		Theme:   getEmbedTheme(c.QueryParam("theme")),
+		Title:   truncate(getMemoTitle(nodes), maxMetadataTitleLength),
+		Content: template.HTML(renderer.NewHTMLRenderer().Render(nodes)),
+		URL:     baseURL + "/memos/" + url.PathEscape(memo.UID),
+		Time:    time.Unix(memo.CreatedTs, 0).UTC(),
+	}
+	creator, err := s.Store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find creator").SetInternal(err)
+	}
+	if creator != nil {
+		data.AuthorName = getUserDisplayName(creator)
+		data.AuthorURL = baseURL + "/u/" + url.PathEscape(creator.Username)
+	}
+
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{MemoID: &memo.ID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list attachments").SetInternal(err)
+	}
+	for _, attachment := range attachments {
+		embedAttachment := &embedAttachment{
+			Filename: attachment.Filename,
+			IsImage:  strings.HasPrefix(attachment.Type, "image/"),
+		}
+		// The embeds stay on the pages of other sites, so the S3 objects are linked through the server rather than by their presigned URLs.
+		if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+			embedAttachment.URL = attachment.Reference
+		} else {
+			embedAttachment.URL = fmt.Sprintf("%s/file/attachments/%s/%s", baseURL, attachment.UID, url.PathEscape(attachment.Filename))
+		}
+		data.Attachments = append(data.Attachments, embedAttachment)
+	}
+
+	contentID := "memos/" + memo.UID
+	reactions, err := s.Store.ListReactions(ctx, &store.FindReaction{ContentID: &contentID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list reactions").SetInternal(err)
+	}
+	reactionCounts := map[string]*embedReaction{}
+	for _, reaction := range reactions {
+		if embedReaction, ok := reactionCounts[reaction.ReactionType]; ok {
+			embedReaction.Count++
+			continue
+		}
+		reactionCounts[reaction.ReactionType] = &embedReaction{Type: reaction.ReactionType, Count: 1}
+		data.Reactions = append(data.Reactions, reactionCounts[reaction.ReactionType])
+	}
+
+	var buf bytes.Buffer
+	if err := embedTemplate.Execute(&buf, data); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render memo").SetInternal(err)
+	}
+	header := c.Response().Header()
+	// The page is meant to be framed by other sites, but never runs scripts.
+	header.Set("Content-Security-Policy", "default-src 'none'; img-src * data:; style-src 'unsafe-inline'; frame-ancestors *")
+	header.Set(echo.HeaderCacheControl, "public, max-age=300")
+	return c.HTMLBlob(http.StatusOK, buf.Bytes())
+}
+
+// getEmbeddableMemo returns the memo if it is public, and a not found error otherwise.
+func (s *FrontendService) getEmbeddableMemo(ctx context.Context, uid string) (*store.Memo, error) {
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo").SetInternal(err)
+	}
+	if memo == nil || memo.Visibility != store.Public || memo.RowStatus != store.Normal {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Memo not found")
+	}
+	return memo, nil
+}
+
+// getMemoUIDFromURL returns the memo UID from a memo or embed URL of the site.
+func getMemoUIDFromURL(rawURL string, baseURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(u.Host, base.Host) {
+		return "", errors.Errorf("unexpected host %q", u.Host)
+	}
+	path := strings.TrimPrefix(u.Path, strings.TrimSuffix(base.Path, "/"))
+	path = strings.TrimPrefix(path, "/embed")
+	uid, found := strings.CutPrefix(path, "/memos/")
+	if !found || uid == "" || strings.Contains(uid, "/") {
+		return "", errors.Errorf("unexpected path %q", u.Path)
+	}
+	return uid, nil
+}
+
+func getEmbedTheme(theme string) string {
+	if theme == "dark" {
+		return "dark"
+	}
+	return "light"
+}
@@ -0,0 +1,117 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestEmbed(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "alice",
+		Role:     store.RoleUser,
+		Email:    "alice@example.com",
+	})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "public",
+		CreatorID:  user.ID,
+		Content:    "Hello **embeds**",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateAttachment(ctx, &store.Attachment{
+		UID:       "photo",
+		CreatorID: user.ID,
+		Filename:  "cat.png",
+		// The presigned URLs of the S3 objects expire, the objects are linked through the server.
+		StorageType: storepb.AttachmentStorageType_S3,
+		Reference:   "https://bucket.s3.amazonaws.com/cat.png?X-Amz-Expires=432000",
+		Type:        "image/png",
+		MemoID:      &memo.ID,
+	})
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{
+		Username: "bob",
+		Role:     store.RoleUser,
+		Email:    "bob@example.com",
+	})
+	require.NoError(t, err)
+	for _, reaction := range []*store.Reaction{
+		{CreatorID: user.ID, ReactionType: "👍"},
+		{CreatorID: other.ID, ReactionType: "👍"},
+		{CreatorID: other.ID, ReactionType: "🎉"},
+	} {
+		_, err = ts.UpsertReaction(ctx, &store.Reaction{
+			CreatorID:    reaction.CreatorID,
+			ContentID:    "memos/public",
+			ReactionType: reaction.ReactionType,
+		})
+		require.NoError(t, err)
+	}
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "private",
+		CreatorID:  user.ID,
+		Content:    "secret",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	NewFrontendService(&profile.Profile{InstanceURL: "https://memos.example.com"}, ts).Serve(ctx, e)
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	// The memo page advertises its oEmbed endpoint.
+	rec := serve("/memos/public")
+	require.Contains(t, rec.Body.String(), `type="application/json+oembed" href="https://memos.example.com/api/oembed?url=https%3A%2F%2Fmemos.example.com%2Fmemos%2Fpublic"`)
+
+	rec = serve("/api/oembed?maxwidth=400&theme=dark&url=" + url.QueryEscape("https://memos.example.com/memos/public"))
+	require.Equal(t, http.StatusOK, rec.Code)
+	oembed := &OEmbed{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), oembed))
+	require.Equal(t, "rich", oembed.Type)
+	require.Equal(t, 400, oembed.Width)
+	require.Equal(t, "alice", oembed.AuthorName)
+	require.Contains(t, oembed.HTML, `<iframe src="https://memos.example.com/embed/memos/public?theme=dark"`)
+
+	for _, target := range []string{
+		"https://memos.example.com/memos/private",
+		"https://other.example.com/memos/public",
+	} {
+		rec = serve("/api/oembed?url=" + url.QueryEscape(target))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	}
+	rec = serve("/api/oembed?format=xml&url=" + url.QueryEscape("https://memos.example.com/memos/public"))
+	require.Equal(t, http.StatusNotImplemented, rec.Code)
+
+	// The embed page renders the memo with its attachments and reactions.
+	rec = serve("/embed/memos/public?theme=dark")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	require.Contains(t, body, `<html lang="en" class="dark">`)
+	require.Contains(t, body, "<strong>embeds</strong>")
+	require.Contains(t, body, `<img src="https://memos.example.com/file/attachments/photo/cat.png"`)
+	require.Contains(t, body, "<span>👍 2</span>")
+	require.Contains(t, body, "<span>🎉 1</span>")
+	require.Contains(t, rec.Header().Get("Content-Security-Policy"), "frame-ancestors *")
+	rec = serve("/embed/memos/private")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
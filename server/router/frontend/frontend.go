@@ -29,10 +29,10 @@ func NewFrontendService(profile *profile.Profile, store *store.Store) *FrontendS
 	}
 }
 
-func (*FrontendService) Serve(_ context.Context, e *echo.Echo) {
+func (s *FrontendService) Serve(_ context.Context, e *echo.Echo) {
 	skipper := func(c echo.Context) bool {
-		// Skip API routes.
-		if util.HasPrefixes(c.Path(), "/api", "/memos.api.v1") {
+		// Skip API routes, embeddable pages and the files for crawlers.
+		if util.HasPrefixes(c.Path(), "/api", "/memos.api.v1", "/embed", "/sitemap", "/robots.txt") {
 			return true
 		}
 		// Skip setting cache headers for index.html
@@ -50,6 +50,9 @@ func (*FrontendService) Serve(_ context.Context, e *echo.Echo) {
 		HTML5:      true, // Enable fallback to index.html
 		Skipper:    skipper,
 	}))
+	s.registerMetadataRoutes(e)
+	s.registerEmbedRoutes(e)
+	s.registerSitemapRoutes(e)
 }
 
 func getFileSystem(path string) http.FileSystem {
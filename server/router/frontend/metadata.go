@@ -0,0 +1,239 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/usememos/gomark"
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/renderer"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// metadataHeadPlaceholder marks where the metadata is injected into index.html.
+	metadataHeadPlaceholder = "<!-- memos.metadata.head -->"
+	// maxMetadataTitleLength is the maximum length in runes of the title of a preview.
+	maxMetadataTitleLength = 70
+	// maxMetadataDescriptionLength is the maximum length in runes of the description of a preview.
+	maxMetadataDescriptionLength = 200
+)
+
+// Metadata is the OpenGraph metadata of a page, as shown in link previews.
+type Metadata struct {
+	Type        string
+	Title       string
+	Description string
+	URL         string
+	SiteName    string
+	Image       string
+	Author      string
+	// OEmbedURL is the oEmbed endpoint of the page, advertised for discovery.
+	OEmbedURL string
+}
+
+// registerMetadataRoutes registers the pages whose index.html is served with the metadata of their content,
+// so that link previews of shared memos and profiles show more than the app shell.
+func (s *FrontendService) registerMetadataRoutes(e *echo.Echo) {
+	e.GET("/memos/:uid", s.serveMemoPage)
+	e.GET("/u/:username", s.serveUserPage)
+}
+
+func (s *FrontendService) serveMemoPage(c echo.Context) error {
+	ctx := c.Request().Context()
+	uid := c.Param("uid")
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo").SetInternal(err)
+	}
+	// Only public memos are previewed, the app handles everything else.
+	if memo == nil || memo.Visibility != store.Public || memo.RowStatus != store.Normal {
+		return s.serveIndex(c, nil)
+	}
+
+	baseURL := s.getBaseURL(c)
+	metadata, err := s.getMemoMetadata(ctx, memo, baseURL)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate memo metadata").SetInternal(err)
+	}
+	return s.serveIndex(c, metadata)
+}
+
+func (s *FrontendService) serveUserPage(c echo.Context) error {
+	ctx := c.Request().Context()
+	username := c.Param("username")
+	user, err := s.Store.GetUser(ctx, &store.FindUser{Username: &username})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	if user == nil || user.RowStatus != store.Normal {
+		return s.serveIndex(c, nil)
+	}
+
+	baseURL := s.getBaseURL(c)
+	metadata := &Metadata{
+		Type:        "profile",
+		Title:       getUserDisplayName(user),
+		Description: truncate(user.Description, maxMetadataDescriptionLength),
+		URL:         baseURL + "/u/" + url.PathEscape(user.Username),
+		SiteName:    s.getSiteName(ctx),
+	}
+	// Avatars may be data URIs, which previews can not load.
+	if strings.HasPrefix(user.AvatarURL, "http://") || strings.HasPrefix(user.AvatarURL, "https://") {
+		metadata.Image = user.AvatarURL
+	}
+	return s.serveIndex(c, metadata)
+}
+
+func (s *FrontendService) getMemoMetadata(ctx context.Context, memo *store.Memo, baseURL string) (*Metadata, error) {
+	nodes, err := gomark.Parse(memo.Content)
+	if err != nil {
+		return nil, err
+	}
+	plainText := strings.Join(strings.Fields(renderer.NewStringRenderer().Render(nodes)), " ")
+	metadata := &Metadata{
+		Type:        "article",
+		Title:       truncate(getMemoTitle(nodes), maxMetadataTitleLength),
+		Description: truncate(plainText, maxMetadataDescriptionLength),
+		URL:         baseURL + "/memos/" + memo.UID,
+		SiteName:    s.getSiteName(ctx),
+	}
+	metadata.OEmbedURL = baseURL + OEmbedPath + "?" + url.Values{"url": {metadata.URL}}.Encode()
+	if metadata.Title == "" {
+		metadata.Title = metadata.SiteName
+	}
+
+	creator, err := s.Store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+	if err != nil {
+		return nil, err
+	}
+	if creator != nil {
+		metadata.Author = getUserDisplayName(creator)
+	}
+
+	attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{MemoID: &memo.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		if !strings.HasPrefix(attachment.Type, "image/") {
+			continue
+		}
+		// The link previews are cached for long, so the S3 objects are linked through the server rather than by their presigned URLs.
+		if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+			metadata.Image = attachment.Reference
+		} else {
+			metadata.Image = fmt.Sprintf("%s/file/attachments/%s/%s", baseURL, attachment.UID, url.PathEscape(attachment.Filename))
+		}
+		break
+	}
+	return metadata, nil
+}
+
+// serveIndex serves index.html with the metadata injected into its head.
+func (*FrontendService) serveIndex(c echo.Context, metadata *Metadata) error {
+	file, err := getFileSystem("dist").Open("index.html")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open index.html").SetInternal(err)
+	}
+	defer file.Close()
+	index, err := io.ReadAll(file)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to read index.html").SetInternal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+	if metadata == nil {
+		return c.HTMLBlob(http.StatusOK, index)
+	}
+	content := string(index)
+	if strings.Contains(content, metadataHeadPlaceholder) {
+		content = strings.Replace(content, metadataHeadPlaceholder, metadata.render(), 1)
+	} else {
+		content = strings.Replace(content, "</head>", metadata.render()+"</head>", 1)
+	}
+	return c.HTML(http.StatusOK, content)
+}
+
+// render returns the meta tags of the metadata.
+func (m *Metadata) render() string {
+	var sb strings.Builder
+	writeMeta := func(attribute, key, value string) {
+		if value == "" {
+			return
+		}
+		fmt.Fprintf(&sb, "<meta %s=\"%s\" content=\"%s\" />\n", attribute, key, html.EscapeString(value))
+	}
+	writeMeta("name", "description", m.Description)
+	writeMeta("name", "author", m.Author)
+	writeMeta("property", "og:type", m.Type)
+	writeMeta("property", "og:title", m.Title)
+	writeMeta("property", "og:description", m.Description)
+	writeMeta("property", "og:url", m.URL)
+	writeMeta("property", "og:site_name", m.SiteName)
+	writeMeta("property", "og:image", m.Image)
+	if m.Type == "article" {
+		writeMeta("property", "article:author", m.Author)
+	}
+	if m.Image != "" {
+		writeMeta("name", "twitter:card", "summary_large_image")
+	} else {
+		writeMeta("name", "twitter:card", "summary")
+	}
+	if m.OEmbedURL != "" {
+		fmt.Fprintf(&sb, "<link rel=\"alternate\" type=\"application/json+oembed\" href=\"%s\" title=\"%s\" />\n", html.EscapeString(m.OEmbedURL), html.EscapeString(m.Title))
+	}
+	return sb.String()
+}
+
+func (s *FrontendService) getBaseURL(c echo.Context) string {
+	if s.Profile.InstanceURL != "" {
+		return strings.TrimSuffix(s.Profile.InstanceURL, "/")
+	}
+	return c.Scheme() + "://" + c.Request().Host
+}
+
+func (s *FrontendService) getSiteName(ctx context.Context) string {
+	generalSetting, err := s.Store.GetWorkspaceGeneralSetting(ctx)
+	if err == nil && generalSetting.GetCustomProfile().GetTitle() != "" {
+		return generalSetting.GetCustomProfile().GetTitle()
+	}
+	return "Memos"
+}
+
+// getMemoTitle returns the text of the first heading of the memo, falling back to its first line.
+func getMemoTitle(nodes []ast.Node) string {
+	stringRenderer := renderer.NewStringRenderer()
+	for _, node := range nodes {
+		if heading, ok := node.(*ast.Heading); ok {
+			if title := strings.TrimSpace(stringRenderer.Render(heading.Children)); title != "" {
+				return title
+			}
+		}
+	}
+	plainText := strings.TrimSpace(stringRenderer.Render(nodes))
+	title, _, _ := strings.Cut(plainText, "\n")
+	return strings.TrimSpace(title)
+}
+
+func getUserDisplayName(user *store.User) string {
+	if user.Nickname != "" {
+		return user.Nickname
+	}
+	return user.Username
+}
+
+func truncate(s string, length int) string {
+	if runes := []rune(s); len(runes) > length {
+		return strings.TrimSpace(string(runes[:length])) + "..."
+	}
+	return s
+}
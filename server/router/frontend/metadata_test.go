@@ -0,0 +1,88 @@
+package frontend
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestMetadata(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "alice",
+		Role:     store.RoleUser,
+		Email:    "alice@example.com",
+		Nickname: "Alice",
+	})
+	require.NoError(t, err)
+	description := "Writing things down"
+	_, err = ts.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, Description: &description})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "public",
+		CreatorID:  user.ID,
+		Content:    "# Trip & notes\n\nWe went to the **mountains**.",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateAttachment(ctx, &store.Attachment{
+		UID:       "photo",
+		CreatorID: user.ID,
+		Filename:  "peak.jpg",
+		// The presigned URLs of the S3 objects expire, the objects are linked through the server.
+		StorageType: storepb.AttachmentStorageType_S3,
+		Reference:   "https://bucket.s3.amazonaws.com/peak.jpg?X-Amz-Expires=432000",
+		Type:        "image/jpeg",
+		MemoID:      &memo.ID,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "private",
+		CreatorID:  user.ID,
+		Content:    "# Secret plans",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	NewFrontendService(&profile.Profile{InstanceURL: "https://memos.example.com"}, ts).Serve(ctx, e)
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	// Public memos are previewed with their title, description, image and author.
+	rec := serve("/memos/public")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	require.Contains(t, body, `<meta property="og:title" content="Trip &amp; notes" />`)
+	require.Contains(t, body, `<meta property="og:description" content="Trip &amp; notes We went to the mountains." />`)
+	require.Contains(t, body, `<meta property="og:url" content="https://memos.example.com/memos/public" />`)
+	require.Contains(t, body, `<meta property="og:image" content="https://memos.example.com/file/attachments/photo/peak.jpg" />`)
+	require.Contains(t, body, `<meta property="article:author" content="Alice" />`)
+
+	// Private and unknown memos are served without metadata.
+	for _, target := range []string{"/memos/private", "/memos/unknown"} {
+		rec = serve(target)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NotContains(t, rec.Body.String(), "og:")
+		require.NotContains(t, rec.Body.String(), "Secret")
+	}
+
+	rec = serve("/u/alice")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `<meta property="og:type" content="profile" />`)
+	require.Contains(t, rec.Body.String(), `<meta property="og:description" content="Writing things down" />`)
+}
@@ -0,0 +1,193 @@
+package frontend
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// maxSitemapURLCount is the number of URLs in a sitemap, well below the limit of 50,000 of the protocol.
+	maxSitemapURLCount = 10000
+)
+
+type sitemapIndex struct {
+	XMLName  xml.Name          `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []*sitemapPointer `xml:"sitemap"`
+}
+
+type sitemapPointer struct {
+	Loc string `xml:"loc"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name      `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []*sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// registerSitemapRoutes registers robots.txt and the sitemaps of the public memos and of the pages of their creators.
+// The sitemap at /sitemap.xml is an index of the user sitemap and of the pages of the memo sitemap.
+func (s *FrontendService) registerSitemapRoutes(e *echo.Echo) {
+	e.GET("/robots.txt", s.getRobotsTxt)
+	e.GET("/sitemap.xml", s.getSitemapIndex)
+	e.GET("/sitemaps/users.xml", s.getUserSitemap)
+	e.GET("/sitemaps/memos/:page", s.getMemoSitemap)
+}
+
+func (s *FrontendService) getRobotsTxt(c echo.Context) error {
+	generalSetting, err := s.Store.GetWorkspaceGeneralSetting(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace setting").SetInternal(err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("User-agent: *\n")
+	if generalSetting.GetDisallowSearchEngineIndexing() {
+		sb.WriteString("Disallow: /\n")
+	} else {
+		sb.WriteString("Disallow: /api/\n")
+		sb.WriteString("Disallow: /embed/\n")
+		fmt.Fprintf(&sb, "\nSitemap: %s/sitemap.xml\n", s.getBaseURL(c))
+	}
+	return c.String(http.StatusOK, sb.String())
+}
+
+func (s *FrontendService) getSitemapIndex(c echo.Context) error {
+	ctx := c.Request().Context()
+	if err := s.checkIndexingAllowed(c); err != nil {
+		return err
+	}
+
+	baseURL := s.getBaseURL(c)
+	index := &sitemapIndex{
+		Sitemaps: []*sitemapPointer{{Loc: baseURL + "/sitemaps/users.xml"}},
+	}
+	// Probe the first memo of every page instead of counting all public memos.
+	for page := 1; ; page++ {
+		limit, offset := 1, (page-1)*maxSitemapURLCount
+		memos, err := s.Store.ListMemos(ctx, s.getSitemapMemoFind(&limit, &offset))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list memos").SetInternal(err)
+		}
+		if len(memos) == 0 {
+			break
+		}
+		index.Sitemaps = append(index.Sitemaps, &sitemapPointer{Loc: fmt.Sprintf("%s/sitemaps/memos/%d.xml", baseURL, page)})
+	}
+	return serveSitemap(c, index)
+}
+
+func (s *FrontendService) getUserSitemap(c echo.Context) error {
+	ctx := c.Request().Context()
+	if err := s.checkIndexingAllowed(c); err != nil {
+		return err
+	}
+
+	normalStatus := store.Normal
+	users, err := s.Store.ListUsers(ctx, &store.FindUser{RowStatus: &normalStatus})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list users").SetInternal(err)
+	}
+	baseURL := s.getBaseURL(c)
+	urlSet := &sitemapURLSet{}
+	for _, user := range users {
+		// Only the users with a public memo have a public page to index.
+		limit := 1
+		find := s.getSitemapMemoFind(&limit, nil)
+		find.CreatorID = &user.ID
+		memos, err := s.Store.ListMemos(ctx, find)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list memos").SetInternal(err)
+		}
+		if len(memos) == 0 {
+			continue
+		}
+		urlSet.URLs = append(urlSet.URLs, &sitemapURL{
+			Loc:     baseURL + "/u/" + url.PathEscape(user.Username),
+			LastMod: formatSitemapTime(user.UpdatedTs),
+		})
+	}
+	return serveSitemap(c, urlSet)
+}
+
+func (s *FrontendService) getMemoSitemap(c echo.Context) error {
+	ctx := c.Request().Context()
+	if err := s.checkIndexingAllowed(c); err != nil {
+		return err
+	}
+	pageStr, found := strings.CutSuffix(c.Param("page"), ".xml")
+	page, err := strconv.Atoi(pageStr)
+	if !found || err != nil || page <= 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Sitemap not found")
+	}
+
+	limit, offset := maxSitemapURLCount, (page-1)*maxSitemapURLCount
+	memos, err := s.Store.ListMemos(ctx, s.getSitemapMemoFind(&limit, &offset))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list memos").SetInternal(err)
+	}
+	if len(memos) == 0 && page > 1 {
+		return echo.NewHTTPError(http.StatusNotFound, "Sitemap not found")
+	}
+	baseURL := s.getBaseURL(c)
+	urlSet := &sitemapURLSet{}
+	for _, memo := range memos {
+		urlSet.URLs = append(urlSet.URLs, &sitemapURL{
+			Loc:     baseURL + "/memos/" + url.PathEscape(memo.UID),
+			LastMod: formatSitemapTime(memo.UpdatedTs),
+		})
+	}
+	return serveSitemap(c, urlSet)
+}
+
+// getSitemapMemoFind returns the query of the public memos in the sitemap.
+// The memos are listed from the oldest, so that new memos only change the last page.
+func (*FrontendService) getSitemapMemoFind(limit, offset *int) *store.FindMemo {
+	normalStatus := store.Normal
+	return &store.FindMemo{
+		RowStatus:       &normalStatus,
+		VisibilityList:  []store.Visibility{store.Public},
+		ExcludeContent:  true,
+		ExcludeComments: true,
+		OrderByTimeAsc:  true,
+		Limit:           limit,
+		Offset:          offset,
+	}
+}
+
+func (s *FrontendService) checkIndexingAllowed(c echo.Context) error {
+	generalSetting, err := s.Store.GetWorkspaceGeneralSetting(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace setting").SetInternal(err)
+	}
+	if generalSetting.GetDisallowSearchEngineIndexing() {
+		return echo.NewHTTPError(http.StatusNotFound, "Sitemap not found")
+	}
+	return nil
+}
+
+func serveSitemap(c echo.Context, v any) error {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate sitemap").SetInternal(err)
+	}
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=3600")
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, append([]byte(xml.Header), body...))
+}
+
+func formatSitemapTime(ts int64) string {
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
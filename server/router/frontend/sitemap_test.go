@@ -0,0 +1,115 @@
+package frontend
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestSitemap(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "alice",
+		Role:     store.RoleUser,
+		Email:    "alice@example.com",
+	})
+	require.NoError(t, err)
+	// The users without a public memo have no page to index.
+	bob, err := ts.CreateUser(ctx, &store.User{
+		Username: "bob",
+		Role:     store.RoleUser,
+		Email:    "bob@example.com",
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "bob-private",
+		CreatorID:  bob.ID,
+		Content:    "bob",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+	_, err = ts.CreateUser(ctx, &store.User{
+		Username: "carol",
+		Role:     store.RoleUser,
+		Email:    "carol@example.com",
+	})
+	require.NoError(t, err)
+	for uid, visibility := range map[string]store.Visibility{
+		"public":    store.Public,
+		"protected": store.Protected,
+		"private":   store.Private,
+	} {
+		_, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        uid,
+			CreatorID:  user.ID,
+			Content:    uid,
+			Visibility: visibility,
+		})
+		require.NoError(t, err)
+	}
+
+	e := echo.New()
+	NewFrontendService(&profile.Profile{InstanceURL: "https://memos.example.com"}, ts).Serve(ctx, e)
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := serve("/robots.txt")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "Sitemap: https://memos.example.com/sitemap.xml")
+
+	rec = serve("/sitemap.xml")
+	require.Equal(t, http.StatusOK, rec.Code)
+	index := &sitemapIndex{}
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), index))
+	require.Len(t, index.Sitemaps, 2)
+	require.Equal(t, "https://memos.example.com/sitemaps/users.xml", index.Sitemaps[0].Loc)
+	require.Equal(t, "https://memos.example.com/sitemaps/memos/1.xml", index.Sitemaps[1].Loc)
+
+	// Only public memos are listed.
+	rec = serve("/sitemaps/memos/1.xml")
+	require.Equal(t, http.StatusOK, rec.Code)
+	urlSet := &sitemapURLSet{}
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), urlSet))
+	require.Len(t, urlSet.URLs, 1)
+	require.Equal(t, "https://memos.example.com/memos/public", urlSet.URLs[0].Loc)
+	require.NotEmpty(t, urlSet.URLs[0].LastMod)
+	rec = serve("/sitemaps/memos/2.xml")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serve("/sitemaps/users.xml")
+	require.Equal(t, http.StatusOK, rec.Code)
+	urlSet = &sitemapURLSet{}
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), urlSet))
+	require.Len(t, urlSet.URLs, 1)
+	require.Equal(t, "https://memos.example.com/u/alice", urlSet.URLs[0].Loc)
+
+	// Admins can opt out of indexing entirely.
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_GENERAL,
+		Value: &storepb.WorkspaceSetting_GeneralSetting{
+			GeneralSetting: &storepb.WorkspaceGeneralSetting{DisallowSearchEngineIndexing: true},
+		},
+	})
+	require.NoError(t, err)
+	rec = serve("/robots.txt")
+	require.Equal(t, "User-agent: *\nDisallow: /\n", rec.Body.String())
+	rec = serve("/sitemap.xml")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	rec = serve("/sitemaps/memos/1.xml")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,327 @@
+package rss
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+	"time"
+)
+
+// FeedFormat is the syndication format a feed is rendered in.
+type FeedFormat string
+
+const (
+	FeedFormatRSS  FeedFormat = "rss"
+	FeedFormatAtom FeedFormat = "atom"
+	FeedFormatJSON FeedFormat = "json"
+)
+
+// ContentType returns the media type of the format.
+func (f FeedFormat) ContentType() string {
+	switch f {
+	case FeedFormatAtom:
+		return "application/atom+xml; charset=UTF-8"
+	case FeedFormatJSON:
+		return "application/feed+json; charset=UTF-8"
+	default:
+		return "application/rss+xml; charset=UTF-8"
+	}
+}
+
+// Feed is a format independent feed of memos.
+type Feed struct {
+	Title       string
+	Description string
+	// HomeURL is the URL of the site the feed belongs to.
+	HomeURL string
+	// Links to the current, first, next and previous pages of the feed, as described by RFC 5005.
+	SelfURL     string
+	FirstURL    string
+	NextURL     string
+	PreviousURL string
+	Updated     time.Time
+	Items       []*FeedItem
+}
+
+// FeedItem is a memo in a feed.
+type FeedItem struct {
+	ID          string
+	URL         string
+	Title       string
+	ContentHTML string
+	Published   time.Time
+	Updated     time.Time
+	Tags        []string
+	AuthorName  string
+	AuthorURL   string
+	Attachments []*FeedAttachment
+}
+
+// FeedAttachment is an attachment of a memo, published as an enclosure.
+type FeedAttachment struct {
+	URL      string
+	Type     string
+	Size     int64
+	Filename string
+}
+
+// Render renders the feed in the format.
+func (f *Feed) Render(format FeedFormat) ([]byte, error) {
+	switch format {
+	case FeedFormatAtom:
+		return f.renderAtom()
+	case FeedFormatJSON:
+		return f.renderJSON()
+	default:
+		return f.renderRSS()
+	}
+}
+
+type rssDocument struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	AtomNS  string      `xml:"xmlns:atom,attr"`
+	Channel *rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	AtomLinks     []*atomLink `xml:"atom:link"`
+	Items         []*rssItem  `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title,omitempty"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	Author      string        `xml:"author,omitempty"`
+	Categories  []string      `xml:"category"`
+	GUID        *rssGUID      `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+func (f *Feed) renderRSS() ([]byte, error) {
+	channel := &rssChannel{
+		Title:         f.Title,
+		Link:          f.HomeURL,
+		Description:   f.Description,
+		LastBuildDate: f.Updated.UTC().Format(time.RFC1123Z),
+		AtomLinks:     f.getPagingLinks(FeedFormatRSS),
+	}
+	for _, item := range f.Items {
+		rssItem := &rssItem{
+			Title:       item.Title,
+			Link:        item.URL,
+			Description: item.ContentHTML,
+			Categories:  item.Tags,
+			GUID:        &rssGUID{IsPermaLink: true, Value: item.ID},
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+		}
+		// RSS allows a single enclosure per item, so only the first attachment is published.
+		if len(item.Attachments) > 0 {
+			attachment := item.Attachments[0]
+			rssItem.Enclosure = &rssEnclosure{
+				URL:    attachment.URL,
+				Length: strconv.FormatInt(attachment.Size, 10),
+				Type:   attachment.Type,
+			}
+		}
+		channel.Items = append(channel.Items, rssItem)
+	}
+	return marshalXML(&rssDocument{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: channel,
+	})
+}
+
+type atomFeed struct {
+	XMLName  xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string       `xml:"title"`
+	Subtitle string       `xml:"subtitle,omitempty"`
+	ID       string       `xml:"id"`
+	Updated  string       `xml:"updated"`
+	Links    []*atomLink  `xml:"link"`
+	Entries  []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Title  string `xml:"title,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string          `xml:"title"`
+	ID         string          `xml:"id"`
+	Updated    string          `xml:"updated"`
+	Published  string          `xml:"published"`
+	Author     *atomAuthor     `xml:"author"`
+	Links      []*atomLink     `xml:"link"`
+	Categories []*atomCategory `xml:"category"`
+	Content    *atomContent    `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (f *Feed) renderAtom() ([]byte, error) {
+	feed := &atomFeed{
+		Title:    f.Title,
+		Subtitle: f.Description,
+		ID:       f.SelfURL,
+		Updated:  f.Updated.UTC().Format(time.RFC3339),
+		Links: append([]*atomLink{
+			{Href: f.HomeURL, Rel: "alternate", Type: "text/html"},
+		}, f.getPagingLinks(FeedFormatAtom)...),
+	}
+	for _, item := range f.Items {
+		entry := &atomEntry{
+			Title:     item.Title,
+			ID:        item.ID,
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Author:    &atomAuthor{Name: item.AuthorName, URI: item.AuthorURL},
+			Links: []*atomLink{
+				{Href: item.URL, Rel: "alternate", Type: "text/html"},
+			},
+			Content: &atomContent{Type: "html", Value: item.ContentHTML},
+		}
+		for _, tag := range item.Tags {
+			entry.Categories = append(entry.Categories, &atomCategory{Term: tag})
+		}
+		for _, attachment := range item.Attachments {
+			entry.Links = append(entry.Links, &atomLink{
+				Href:   attachment.URL,
+				Rel:    "enclosure",
+				Type:   attachment.Type,
+				Title:  attachment.Filename,
+				Length: strconv.FormatInt(attachment.Size, 10),
+			})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return marshalXML(feed)
+}
+
+type jsonFeed struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"home_page_url"`
+	FeedURL     string          `json:"feed_url"`
+	Description string          `json:"description,omitempty"`
+	NextURL     string          `json:"next_url,omitempty"`
+	Items       []*jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string                `json:"id"`
+	URL           string                `json:"url"`
+	Title         string                `json:"title,omitempty"`
+	ContentHTML   string                `json:"content_html"`
+	DatePublished string                `json:"date_published"`
+	DateModified  string                `json:"date_modified"`
+	Tags          []string              `json:"tags,omitempty"`
+	Authors       []*jsonFeedAuthor     `json:"authors,omitempty"`
+	Attachments   []*jsonFeedAttachment `json:"attachments,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type jsonFeedAttachment struct {
+	URL         string `json:"url"`
+	MimeType    string `json:"mime_type"`
+	Title       string `json:"title,omitempty"`
+	SizeInBytes int64  `json:"size_in_bytes,omitempty"`
+}
+
+func (f *Feed) renderJSON() ([]byte, error) {
+	feed := &jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.HomeURL,
+		FeedURL:     f.SelfURL,
+		Description: f.Description,
+		NextURL:     f.NextURL,
+		Items:       []*jsonFeedItem{},
+	}
+	for _, item := range f.Items {
+		jsonItem := &jsonFeedItem{
+			ID:            item.ID,
+			URL:           item.URL,
+			Title:         item.Title,
+			ContentHTML:   item.ContentHTML,
+			DatePublished: item.Published.UTC().Format(time.RFC3339),
+			DateModified:  item.Updated.UTC().Format(time.RFC3339),
+			Tags:          item.Tags,
+			Authors:       []*jsonFeedAuthor{{Name: item.AuthorName, URL: item.AuthorURL}},
+		}
+		for _, attachment := range item.Attachments {
+			jsonItem.Attachments = append(jsonItem.Attachments, &jsonFeedAttachment{
+				URL:         attachment.URL,
+				MimeType:    attachment.Type,
+				Title:       attachment.Filename,
+				SizeInBytes: attachment.Size,
+			})
+		}
+		feed.Items = append(feed.Items, jsonItem)
+	}
+	return json.MarshalIndent(feed, "", "  ")
+}
+
+// getPagingLinks returns the RFC 5005 links of the feed.
+func (f *Feed) getPagingLinks(format FeedFormat) []*atomLink {
+	contentType := format.ContentType()
+	links := []*atomLink{
+		{Href: f.SelfURL, Rel: "self", Type: contentType},
+	}
+	if f.FirstURL != "" {
+		links = append(links, &atomLink{Href: f.FirstURL, Rel: "first", Type: contentType})
+	}
+	if f.PreviousURL != "" {
+		links = append(links, &atomLink{Href: f.PreviousURL, Rel: "previous", Type: contentType})
+	}
+	if f.NextURL != "" {
+		links = append(links, &atomLink{Href: f.NextURL, Rel: "next", Type: contentType})
+	}
+	return links
+}
+
+func marshalXML(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -2,23 +2,30 @@ package rss
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/gorilla/feeds"
 	"github.com/labstack/echo/v4"
 	"github.com/usememos/gomark"
+	"github.com/usememos/gomark/ast"
 	"github.com/usememos/gomark/renderer"
 
 	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/plugin/filter"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
 const (
-	maxRSSItemCount = 100
+	defaultFeedItemCount = 20
+	maxFeedItemCount     = 100
+	// maxFeedItemTitleLength is the maximum length in runes of the title of a feed item.
+	maxFeedItemTitleLength = 64
 )
 
 type RSSService struct {
@@ -39,125 +46,354 @@ func NewRSSService(profile *profile.Profile, store *store.Store) *RSSService {
 }
 
 func (s *RSSService) RegisterRoutes(g *echo.Group) {
-	g.GET("/explore/rss.xml", s.GetExploreRSS)
-	g.GET("/u/:username/rss.xml", s.GetUserRSS)
+	g.GET("/explore/rss.xml", s.GetExploreFeed(FeedFormatRSS))
+	g.GET("/explore/atom.xml", s.GetExploreFeed(FeedFormatAtom))
+	g.GET("/explore/feed.json", s.GetExploreFeed(FeedFormatJSON))
+	g.GET("/u/:username/rss.xml", s.GetUserFeed(FeedFormatRSS))
+	g.GET("/u/:username/atom.xml", s.GetUserFeed(FeedFormatAtom))
+	g.GET("/u/:username/feed.json", s.GetUserFeed(FeedFormatJSON))
+	g.GET("/u/:username/feed/attachments/:uid/:filename", s.GetFeedAttachment)
 }
 
-func (s *RSSService) GetExploreRSS(c echo.Context) error {
-	ctx := c.Request().Context()
-	normalStatus := store.Normal
-	memoFind := store.FindMemo{
-		RowStatus:      &normalStatus,
-		VisibilityList: []store.Visibility{store.Public},
-	}
-	memoList, err := s.Store.ListMemos(ctx, &memoFind)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo list").SetInternal(err)
+// GetExploreFeed returns the handler of the feed of all public memos.
+func (s *RSSService) GetExploreFeed(format FeedFormat) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		normalStatus := store.Normal
+		return s.serveFeed(c, format, &store.FindMemo{
+			RowStatus:      &normalStatus,
+			VisibilityList: []store.Visibility{store.Public},
+		}, "")
 	}
+}
 
-	baseURL := c.Scheme() + "://" + c.Request().Host
-	rss, err := s.generateRSSFromMemoList(ctx, memoList, baseURL)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate rss").SetInternal(err)
+// GetUserFeed returns the handler of the feed of the public memos of a user.
+// A feed token of the user passed as the `token` query parameter authenticates the request,
+// and the feed then includes the protected and private memos of the user.
+func (s *RSSService) GetUserFeed(format FeedFormat) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		username := c.Param("username")
+		user, err := s.Store.GetUser(ctx, &store.FindUser{
+			Username: &username,
+		})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+		}
+		if user == nil {
+			return echo.NewHTTPError(http.StatusNotFound, "User not found")
+		}
+
+		normalStatus := store.Normal
+		memoFind := &store.FindMemo{
+			CreatorID:      &user.ID,
+			RowStatus:      &normalStatus,
+			VisibilityList: []store.Visibility{store.Public},
+		}
+		token := c.QueryParam("token")
+		if token != "" {
+			if err := s.checkFeedToken(ctx, user, token); err != nil {
+				return err
+			}
+			memoFind.VisibilityList = []store.Visibility{store.Public, store.Protected, store.Private}
+		}
+		return s.serveFeed(c, format, memoFind, token)
 	}
-	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationXMLCharsetUTF8)
-	return c.String(http.StatusOK, rss)
 }
 
-func (s *RSSService) GetUserRSS(c echo.Context) error {
+// serveFeed lists the memos of the feed page requested by the query parameters and renders them in the format.
+// The supported query parameters are `tag`, `filter` (a CEL memo filter), `limit` and `page`.
+// The token is the feed token that authenticated the request, if any.
+func (s *RSSService) serveFeed(c echo.Context, format FeedFormat, memoFind *store.FindMemo, token string) error {
 	ctx := c.Request().Context()
-	username := c.Param("username")
-	user, err := s.Store.GetUser(ctx, &store.FindUser{
-		Username: &username,
-	})
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	query := c.QueryParams()
+	if tag := query.Get("tag"); tag != "" {
+		memoFind.PayloadFind = &store.FindMemoPayload{TagSearch: []string{tag}}
 	}
-	if user == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	if filterStr := query.Get("filter"); filterStr != "" {
+		if err := s.validateFilter(filterStr); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid filter").SetInternal(err)
+		}
+		memoFind.Filter = &filterStr
 	}
-
-	normalStatus := store.Normal
-	memoFind := store.FindMemo{
-		CreatorID:      &user.ID,
-		RowStatus:      &normalStatus,
-		VisibilityList: []store.Visibility{store.Public},
+	limit := defaultFeedItemCount
+	if limitStr := query.Get("limit"); limitStr != "" {
+		value, err := strconv.Atoi(limitStr)
+		if err != nil || value <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid limit")
+		}
+		limit = min(value, maxFeedItemCount)
 	}
-	memoList, err := s.Store.ListMemos(ctx, &memoFind)
+	page := 1
+	if pageStr := query.Get("page"); pageStr != "" {
+		value, err := strconv.Atoi(pageStr)
+		if err != nil || value <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid page")
+		}
+		page = value
+	}
+	// Fetch one more memo than requested to know whether there is a next page.
+	limitPlusOne, offset := limit+1, (page-1)*limit
+	memoFind.Limit = &limitPlusOne
+	memoFind.Offset = &offset
+	memoList, err := s.Store.ListMemos(ctx, memoFind)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo list").SetInternal(err)
 	}
+	hasNextPage := len(memoList) > limit
+	if hasNextPage {
+		memoList = memoList[:limit]
+	}
 
 	baseURL := c.Scheme() + "://" + c.Request().Host
-	rss, err := s.generateRSSFromMemoList(ctx, memoList, baseURL)
+	getPageURL := func(page int) string {
+		pageQuery := url.Values{}
+		for key, values := range query {
+			pageQuery[key] = values
+		}
+		pageQuery.Del("page")
+		if page > 1 {
+			pageQuery.Set("page", strconv.Itoa(page))
+		}
+		pageURL := baseURL + c.Request().URL.Path
+		if encoded := pageQuery.Encode(); encoded != "" {
+			pageURL += "?" + encoded
+		}
+		return pageURL
+	}
+	feed, err := s.generateFeedFromMemoList(ctx, memoList, baseURL, token)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate rss").SetInternal(err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate feed").SetInternal(err)
+	}
+	feed.SelfURL = getPageURL(page)
+	feed.FirstURL = getPageURL(1)
+	if page > 1 {
+		feed.PreviousURL = getPageURL(page - 1)
+	}
+	if hasNextPage {
+		feed.NextURL = getPageURL(page + 1)
 	}
-	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationXMLCharsetUTF8)
-	return c.String(http.StatusOK, rss)
+
+	body, err := feed.Render(format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render feed").SetInternal(err)
+	}
+
+	// Feed readers poll feeds regularly, so let them revalidate with conditional requests.
+	etag := fmt.Sprintf(`W/"%x"`, sha256.Sum256(body))
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	if token != "" {
+		header.Set("Cache-Control", "private, no-cache")
+	} else {
+		header.Set("Cache-Control", "no-cache")
+	}
+	if len(memoList) > 0 {
+		header.Set("Last-Modified", feed.Updated.UTC().Format(http.TimeFormat))
+	}
+	if isFeedNotModified(c.Request(), etag, feed.Updated, len(memoList) > 0) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Blob(http.StatusOK, format.ContentType(), body)
 }
 
-func (s *RSSService) generateRSSFromMemoList(ctx context.Context, memoList []*store.Memo, baseURL string) (string, error) {
+func (s *RSSService) generateFeedFromMemoList(ctx context.Context, memoList []*store.Memo, baseURL string, token string) (*Feed, error) {
 	rssHeading, err := getRSSHeading(ctx, s.Store)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	feed := &feeds.Feed{
+	feed := &Feed{
 		Title:       rssHeading.Title,
-		Link:        &feeds.Link{Href: baseURL},
 		Description: rssHeading.Description,
-		Created:     time.Now(),
+		HomeURL:     baseURL,
+		Updated:     time.Now(),
+		Items:       []*FeedItem{},
 	}
 
-	var itemCountLimit = min(len(memoList), maxRSSItemCount)
-	feed.Items = make([]*feeds.Item, itemCountLimit)
-	for i := 0; i < itemCountLimit; i++ {
-		memo := memoList[i]
-		description, err := getRSSItemDescription(memo.Content)
+	var lastUpdatedTs int64
+	creators := map[int32]*store.User{}
+	for _, memo := range memoList {
+		lastUpdatedTs = max(lastUpdatedTs, memo.UpdatedTs)
+		nodes, err := gomark.Parse(memo.Content)
 		if err != nil {
-			return "", err
+			return nil, err
+		}
+		link := baseURL + "/memos/" + memo.UID
+		item := &FeedItem{
+			ID:          link,
+			URL:         link,
+			Title:       getFeedItemTitle(nodes),
+			ContentHTML: renderer.NewHTMLRenderer().Render(nodes),
+			Published:   time.Unix(memo.CreatedTs, 0),
+			Updated:     time.Unix(memo.UpdatedTs, 0),
+			Tags:        memo.Payload.GetTags(),
+		}
+
+		creator, ok := creators[memo.CreatorID]
+		if !ok {
+			creator, err = s.Store.GetUser(ctx, &store.FindUser{ID: &memo.CreatorID})
+			if err != nil {
+				return nil, err
+			}
+			creators[memo.CreatorID] = creator
 		}
-		link := &feeds.Link{Href: baseURL + "/memos/" + memo.UID}
-		feed.Items[i] = &feeds.Item{
-			Link:        link,
-			Description: description,
-			Created:     time.Unix(memo.CreatedTs, 0),
-			Id:          link.Href,
+		if creator != nil {
+			item.AuthorName = creator.Nickname
+			if item.AuthorName == "" {
+				item.AuthorName = creator.Username
+			}
+			item.AuthorURL = baseURL + "/u/" + creator.Username
 		}
+
 		attachments, err := s.Store.ListAttachments(ctx, &store.FindAttachment{
 			MemoID: &memo.ID,
 		})
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		if len(attachments) > 0 {
-			attachment := attachments[0]
-			enclosure := feeds.Enclosure{}
-			if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL || attachment.StorageType == storepb.AttachmentStorageType_S3 {
-				enclosure.Url = attachment.Reference
+		for _, attachment := range attachments {
+			feedAttachment := &FeedAttachment{
+				Type:     attachment.Type,
+				Size:     attachment.Size,
+				Filename: attachment.Filename,
+			}
+			if token != "" && creator != nil {
+				// Private feeds are kept by feed readers for long, so link attachments through the feed
+				// instead of publishing presigned URLs that expire or grant access without the token.
+				feedAttachment.URL = fmt.Sprintf("%s/u/%s/feed/attachments/%s/%s?token=%s", baseURL, creator.Username, attachment.UID, url.PathEscape(attachment.Filename), url.QueryEscape(token))
+			} else if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+				feedAttachment.URL = attachment.Reference
 			} else {
-				enclosure.Url = fmt.Sprintf("%s/file/attachments/%s/%s", baseURL, attachment.UID, attachment.Filename)
+				// The S3 objects are served by the server too, as their presigned URLs expire.
+				feedAttachment.URL = fmt.Sprintf("%s/file/attachments/%s/%s", baseURL, attachment.UID, url.PathEscape(attachment.Filename))
 			}
-			enclosure.Length = strconv.Itoa(int(attachment.Size))
-			enclosure.Type = attachment.Type
-			feed.Items[i].Enclosure = &enclosure
+			item.Attachments = append(item.Attachments, feedAttachment)
+		}
+		feed.Items = append(feed.Items, item)
+	}
+	if lastUpdatedTs > 0 {
+		feed.Updated = time.Unix(lastUpdatedTs, 0)
+	}
+	return feed, nil
+}
+
+// GetFeedAttachment serves an attachment of a private feed, authenticated by the feed token of its creator.
+// S3 attachments are redirected to a freshly presigned URL.
+func (s *RSSService) GetFeedAttachment(c echo.Context) error {
+	ctx := c.Request().Context()
+	username := c.Param("username")
+	user, err := s.Store.GetUser(ctx, &store.FindUser{
+		Username: &username,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
+	}
+	if user == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+	if err := s.checkFeedToken(ctx, user, c.QueryParam("token")); err != nil {
+		return err
+	}
+
+	uid := c.Param("uid")
+	attachment, err := s.Store.GetAttachment(ctx, &store.FindAttachment{
+		UID:     &uid,
+		GetBlob: true,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find attachment").SetInternal(err)
+	}
+	// Only the attachments of the memos of the user are served.
+	if attachment == nil || attachment.MemoID == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Attachment not found")
+	}
+	memo, err := s.Store.GetMemo(ctx, &store.FindMemo{ID: attachment.MemoID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find memo").SetInternal(err)
+	}
+	if memo == nil || memo.CreatorID != user.ID {
+		return echo.NewHTTPError(http.StatusNotFound, "Attachment not found")
+	}
+
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	switch attachment.StorageType {
+	case storepb.AttachmentStorageType_S3:
+		presignURL, err := s.Store.PresignAttachmentContent(ctx, attachment)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to presign URL").SetInternal(err)
 		}
+		return c.Redirect(http.StatusFound, presignURL)
+	case storepb.AttachmentStorageType_EXTERNAL:
+		return c.Redirect(http.StatusFound, attachment.Reference)
 	}
 
-	rss, err := feed.ToRss()
+	// Serve potentially unsafe files with a content type that prevents script execution.
+	contentType := attachment.Type
+	if strings.EqualFold(contentType, "image/svg+xml") ||
+		strings.EqualFold(contentType, "text/html") ||
+		strings.EqualFold(contentType, "application/xhtml+xml") {
+		contentType = "application/octet-stream"
+	}
+	content, err := s.Store.OpenAttachmentContent(ctx, attachment)
 	if err != nil {
-		return "", err
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to open attachment").SetInternal(err)
 	}
-	return rss, nil
+	defer content.Close()
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	http.ServeContent(c.Response(), c.Request(), "", time.Unix(attachment.UpdatedTs, 0), content)
+	return nil
 }
 
-func getRSSItemDescription(content string) (string, error) {
-	nodes, err := gomark.Parse(content)
+// checkFeedToken returns an error if the token is not a feed token of the user.
+func (s *RSSService) checkFeedToken(ctx context.Context, user *store.User, token string) error {
+	if token == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Missing feed token")
+	}
+	feedToken, err := s.Store.GetUserFeedToken(ctx, user.ID, token)
 	if err != nil {
-		return "", err
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get feed token").SetInternal(err)
+	}
+	if feedToken == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid feed token")
+	}
+	return nil
+}
+
+func (s *RSSService) validateFilter(filterStr string) error {
+	parsedExpr, err := filter.Parse(filterStr, filter.MemoFilterCELAttributes...)
+	if err != nil {
+		return err
+	}
+	return s.Store.GetDriver().ConvertExprToSQL(filter.NewConvertContext(), parsedExpr.GetExpr())
+}
+
+// isFeedNotModified reports whether the conditional headers of the request match the feed.
+// If-Modified-Since is only considered when the request has no If-None-Match header.
+func isFeedNotModified(r *http.Request, etag string, lastModified time.Time, hasLastModified bool) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && hasLastModified {
+		since, err := http.ParseTime(ifModifiedSince)
+		return err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+// getFeedItemTitle returns the plain text of the first line of the memo, truncated to a title length.
+func getFeedItemTitle(nodes []ast.Node) string {
+	plainText := strings.TrimSpace(renderer.NewStringRenderer().Render(nodes))
+	title, _, _ := strings.Cut(plainText, "\n")
+	title = strings.TrimSpace(title)
+	if runes := []rune(title); len(runes) > maxFeedItemTitleLength {
+		return string(runes[:maxFeedItemTitleLength]) + "..."
 	}
-	result := renderer.NewHTMLRenderer().Render(nodes)
-	return result, nil
+	return title
 }
 
 func getRSSHeading(ctx context.Context, stores *store.Store) (RSSHeading, error) {
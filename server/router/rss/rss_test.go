@@ -0,0 +1,228 @@
+package rss
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestFeeds(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "alice",
+		Role:     store.RoleUser,
+		Email:    "alice@example.com",
+		Nickname: "Alice",
+	})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		tags := []string{"daily"}
+		if i == 0 {
+			tags = []string{"go"}
+		}
+		_, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    fmt.Sprintf("Memo **%d**\nsecond line", i),
+			Visibility: store.Public,
+			Payload:    &storepb.MemoPayload{Tags: tags},
+		})
+		require.NoError(t, err)
+	}
+	_, err = ts.CreateMemo(ctx, &store.Memo{
+		UID:        "private",
+		CreatorID:  user.ID,
+		Content:    "private memo",
+		Visibility: store.Private,
+	})
+	require.NoError(t, err)
+	memo, err := ts.GetMemo(ctx, &store.FindMemo{UID: stringPtr("memo-0")})
+	require.NoError(t, err)
+	_, err = ts.CreateAttachment(ctx, &store.Attachment{
+		UID:       "attachment",
+		CreatorID: user.ID,
+		Filename:  "photo.png",
+		Type:      "image/png",
+		Size:      1024,
+		// The presigned URLs of the S3 objects expire, the objects are linked through the server.
+		StorageType: storepb.AttachmentStorageType_S3,
+		Reference:   "https://bucket.s3.amazonaws.com/photo.png?X-Amz-Expires=432000",
+		MemoID:      &memo.ID,
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	NewRSSService(&profile.Profile{}, ts).RegisterRoutes(e.Group(""))
+	serve := func(target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// RSS is paginated with RFC 5005 links.
+	rec := serve("/u/alice/rss.xml?limit=2", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get(echo.HeaderContentType), "application/rss+xml")
+	rss := &rssDocument{}
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), rss))
+	require.Len(t, rss.Channel.Items, 2)
+	require.Contains(t, rec.Body.String(), `rel="next"`)
+	require.Contains(t, rec.Body.String(), "http://example.com/u/alice/rss.xml?limit=2&amp;page=2")
+	rec = serve("/u/alice/rss.xml?limit=2&page=2", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rss = &rssDocument{}
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), rss))
+	require.Len(t, rss.Channel.Items, 1)
+	require.Contains(t, rec.Body.String(), `rel="previous"`)
+	require.NotContains(t, rec.Body.String(), `rel="next"`)
+
+	// Atom publishes every attachment as an enclosure.
+	rec = serve("/explore/atom.xml?tag=go", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	atom := &atomFeed{}
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), atom))
+	require.Len(t, atom.Entries, 1)
+	entry := atom.Entries[0]
+	require.Equal(t, "Memo 0", entry.Title)
+	require.Equal(t, "Alice", entry.Author.Name)
+	require.Contains(t, entry.Content.Value, "<strong>0</strong>")
+	require.Equal(t, "enclosure", entry.Links[1].Rel)
+	require.Equal(t, "image/png", entry.Links[1].Type)
+	require.Equal(t, "1024", entry.Links[1].Length)
+	require.Equal(t, "http://example.com/file/attachments/attachment/photo.png", entry.Links[1].Href)
+
+	// JSON Feed filtered with a CEL expression.
+	rec = serve("/explore/feed.json?filter="+url.QueryEscape(`tag in ["daily"]`), nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	jsonFeed := &jsonFeed{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), jsonFeed))
+	require.Equal(t, "https://jsonfeed.org/version/1.1", jsonFeed.Version)
+	require.Len(t, jsonFeed.Items, 2)
+	rec = serve("/explore/feed.json?filter="+url.QueryEscape(`unknown == 1`), nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve("/explore/feed.json?limit=abc", nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Conditional requests are answered with 304 Not Modified.
+	rec = serve("/explore/rss.xml", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag, lastModified := rec.Header().Get("ETag"), rec.Header().Get("Last-Modified")
+	require.NotEmpty(t, etag)
+	require.NotEmpty(t, lastModified)
+	rec = serve("/explore/rss.xml", http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	rec = serve("/explore/rss.xml", http.Header{"If-Modified-Since": {lastModified}})
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	rec = serve("/explore/rss.xml", http.Header{"If-None-Match": {`W/"other"`}, "If-Modified-Since": {lastModified}})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve("/u/unknown/atom.xml", nil)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestPrivateFeed(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{
+		Username: "alice",
+		Role:     store.RoleUser,
+		Email:    "alice@example.com",
+	})
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{
+		Username: "bob",
+		Role:     store.RoleUser,
+		Email:    "bob@example.com",
+	})
+	require.NoError(t, err)
+	for _, visibility := range []store.Visibility{store.Public, store.Protected, store.Private} {
+		_, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        string(visibility),
+			CreatorID:  user.ID,
+			Content:    string(visibility) + " memo",
+			Visibility: visibility,
+		})
+		require.NoError(t, err)
+	}
+	memo, err := ts.GetMemo(ctx, &store.FindMemo{UID: stringPtr(string(store.Private))})
+	require.NoError(t, err)
+	_, err = ts.CreateAttachment(ctx, &store.Attachment{
+		UID:       "attachment",
+		CreatorID: user.ID,
+		Filename:  "note.txt",
+		Type:      "text/plain",
+		Blob:      []byte("secret"),
+		Size:      6,
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+	err = ts.AddUserFeedToken(ctx, user.ID, &storepb.FeedTokensUserSetting_FeedToken{Id: "reader", TokenHash: store.HashFeedToken("alice-token")})
+	require.NoError(t, err)
+	err = ts.AddUserFeedToken(ctx, other.ID, &storepb.FeedTokensUserSetting_FeedToken{Id: "reader", TokenHash: store.HashFeedToken("bob-token")})
+	require.NoError(t, err)
+
+	e := echo.New()
+	NewRSSService(&profile.Profile{}, ts).RegisterRoutes(e.Group(""))
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	// Without a token only public memos are listed.
+	rec := serve("/u/alice/feed.json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	feed := &jsonFeed{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), feed))
+	require.Len(t, feed.Items, 1)
+
+	// The tokens of other users are rejected.
+	rec = serve("/u/alice/feed.json?token=bob-token")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// The token of the user lists all of their memos and proxies the attachments.
+	rec = serve("/u/alice/feed.json?token=alice-token")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get("Cache-Control"), "private")
+	feed = &jsonFeed{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), feed))
+	require.Len(t, feed.Items, 3)
+	var attachmentURL string
+	for _, item := range feed.Items {
+		for _, attachment := range item.Attachments {
+			attachmentURL = attachment.URL
+		}
+	}
+	require.Equal(t, "http://example.com/u/alice/feed/attachments/attachment/note.txt?token=alice-token", attachmentURL)
+	rec = serve("/u/alice/feed/attachments/attachment/note.txt?token=alice-token")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "secret", rec.Body.String())
+	rec = serve("/u/alice/feed/attachments/attachment/note.txt")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = serve("/u/bob/feed/attachments/attachment/note.txt?token=bob-token")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
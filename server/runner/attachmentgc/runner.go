@@ -0,0 +1,264 @@
+package attachmentgc
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/profile"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/thumbnail"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// DefaultGracePeriod is how long the attachments stay unlinked from any memo, and the files and the stored contents stay
+	// without an attachment, before they are deleted. It leaves time to attach the attachments uploaded in the editor, and to
+	// create the attachments of the files being uploaded.
+	DefaultGracePeriod = 7 * 24 * time.Hour
+
+	// runnerInterval is the interval of collecting the garbage.
+	runnerInterval = 24 * time.Hour
+)
+
+var localStorageType = storepb.AttachmentStorageType_LOCAL
+
+// Runner deletes the orphaned attachments, the stored contents without an attachment, and the files of the local storage
+// and the thumbnail cache without an attachment.
+type Runner struct {
+	Store       *store.Store
+	Profile     *profile.Profile
+	GracePeriod time.Duration
+}
+
+func NewRunner(store *store.Store, profile *profile.Profile) *Runner {
+	return &Runner{
+		Store:       store,
+		Profile:     profile,
+		GracePeriod: DefaultGracePeriod,
+	}
+}
+
+// Report is the garbage found by a collection.
+type Report struct {
+	// Attachments are the attachments unlinked from any memo for longer than the grace period.
+	Attachments []*store.Attachment
+	// AttachmentBlobs are the stored contents no attachment has had for longer than the grace period,
+	// such as the ones of the uploads failing to create their attachments.
+	AttachmentBlobs []*store.AttachmentBlob
+	// Files are the orphaned files, the paths of the files in the data folder are relative to it.
+	Files []*File
+}
+
+// File is a file without an attachment.
+type File struct {
+	Path string
+	Size int64
+}
+
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(runnerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) RunOnce(ctx context.Context) {
+	report, err := r.Collect(ctx, false)
+	if err != nil {
+		slog.Error("failed to collect orphaned attachments", "error", err)
+		return
+	}
+	if len(report.Attachments) > 0 || len(report.AttachmentBlobs) > 0 || len(report.Files) > 0 {
+		slog.Info("deleted orphaned attachments", "attachments", len(report.Attachments), "blobs", len(report.AttachmentBlobs), "files", len(report.Files))
+	}
+}
+
+// Collect finds the orphaned attachments and files, and deletes them unless it is a dry run.
+func (r *Runner) Collect(ctx context.Context, dryRun bool) (*Report, error) {
+	deadline := time.Now().Add(-r.GracePeriod)
+	report := &Report{}
+	// The attachments are listed at once, as the pages of a listing would shift with the attachments updated meanwhile.
+	attachments, err := r.Store.ListAttachments(ctx, &store.FindAttachment{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	attachmentUIDs := map[string]bool{}
+	for _, attachment := range attachments {
+		if attachment.MemoID == nil && time.Unix(attachment.UpdatedTs, 0).Before(deadline) {
+			report.Attachments = append(report.Attachments, attachment)
+			continue
+		}
+		attachmentUIDs[attachment.UID] = true
+	}
+	attachmentBlobs, err := r.Store.ListAttachmentBlobs(ctx, &store.FindAttachmentBlob{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachment blobs")
+	}
+	for _, attachmentBlob := range attachmentBlobs {
+		if attachmentBlob.ReferenceCount == 0 && time.Unix(attachmentBlob.CreatedTs, 0).Before(deadline) && time.Unix(attachmentBlob.UsedTs, 0).Before(deadline) {
+			report.AttachmentBlobs = append(report.AttachmentBlobs, attachmentBlob)
+		}
+	}
+	// The stored files of the orphaned attachments and the unreferenced blobs are deleted along with them.
+	storedFiles := r.getStoredFiles(attachments, attachmentBlobs)
+
+	localStorageFolder, err := r.getLocalStorageFolder(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if localStorageFolder != "" {
+		if err := r.collectFiles(report, localStorageFolder, deadline, func(filePath string) bool {
+			return storedFiles[filePath]
+		}); err != nil {
+			return nil, err
+		}
+	}
+	// The thumbnails are named after the UIDs of their attachments.
+	if err := r.collectFiles(report, filepath.Join(r.Profile.Data, thumbnail.CacheFolder), deadline, func(filePath string) bool {
+		attachmentUID, _, _ := strings.Cut(filepath.Base(filePath), "_")
+		return attachmentUIDs[attachmentUID]
+	}); err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	for _, attachment := range report.Attachments {
+		if err := r.Store.DeleteAttachment(ctx, &store.DeleteAttachment{ID: attachment.ID}); err != nil {
+			slog.Warn("failed to delete orphaned attachment", "attachment", attachment.UID, "error", err)
+		}
+	}
+	deadlineTs := deadline.Unix()
+	for _, attachmentBlob := range report.AttachmentBlobs {
+		if _, err := r.Store.DeleteAttachmentBlob(ctx, &store.DeleteAttachmentBlob{
+			Hash:          attachmentBlob.Hash,
+			CreatedBefore: &deadlineTs,
+			UsedBefore:    &deadlineTs,
+		}); err != nil {
+			slog.Warn("failed to delete unreferenced attachment blob", "hash", attachmentBlob.Hash, "error", err)
+		}
+	}
+	// The stored files are listed again, so that the files stored meanwhile are kept.
+	attachments, err = r.Store.ListAttachments(ctx, &store.FindAttachment{StorageType: &localStorageType})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	attachmentBlobs, err = r.Store.ListAttachmentBlobs(ctx, &store.FindAttachmentBlob{StorageType: &localStorageType})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachment blobs")
+	}
+	storedFiles = r.getStoredFiles(attachments, attachmentBlobs)
+	files := []*File{}
+	for _, file := range report.Files {
+		if storedFiles[r.getFilePath(file.Path)] {
+			continue
+		}
+		if err := os.Remove(r.getFilePath(file.Path)); err != nil && !os.IsNotExist(err) {
+			slog.Warn("failed to delete orphaned file", "path", file.Path, "error", err)
+		}
+		files = append(files, file)
+	}
+	report.Files = files
+	return report, nil
+}
+
+// getStoredFiles returns the paths of the files of the local storage of the attachments and the attachment blobs.
+func (r *Runner) getStoredFiles(attachments []*store.Attachment, attachmentBlobs []*store.AttachmentBlob) map[string]bool {
+	storedFiles := map[string]bool{}
+	for _, attachment := range attachments {
+		if attachment.StorageType == localStorageType {
+			storedFiles[r.getFilePath(attachment.Reference)] = true
+		}
+	}
+	for _, attachmentBlob := range attachmentBlobs {
+		if attachmentBlob.StorageType == localStorageType {
+			storedFiles[r.getFilePath(attachmentBlob.Reference)] = true
+		}
+	}
+	return storedFiles
+}
+
+// collectFiles adds the files in the folder which aren't owned and weren't modified within the grace period to the report.
+func (r *Runner) collectFiles(report *Report, folder string, deadline time.Time, isOwned func(filePath string) bool) error {
+	err := filepath.WalkDir(folder, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() || isOwned(filepath.Clean(filePath)) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.ModTime().Before(deadline) {
+			return nil
+		}
+		report.Files = append(report.Files, &File{
+			Path: r.getReference(filePath),
+			Size: info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to walk folder %s", folder)
+	}
+	return nil
+}
+
+// getLocalStorageFolder returns the folder of the local storage, which is the folder of the filepath template before its
+// first placeholder. It is empty when the folder is the data folder or contains it, so that no other file of the data
+// folder is taken as orphaned.
+func (r *Runner) getLocalStorageFolder(ctx context.Context) (string, error) {
+	workspaceStorageSetting, err := r.Store.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get workspace storage setting")
+	}
+	prefix, _, found := strings.Cut(workspaceStorageSetting.FilepathTemplate, "{")
+	if !found {
+		prefix += "/"
+	}
+	folder := filepath.Dir(r.getFilePath(prefix + "_"))
+	if relativePath, err := filepath.Rel(folder, r.Profile.Data); err == nil && !strings.HasPrefix(relativePath, "..") {
+		return "", nil
+	}
+	return folder, nil
+}
+
+// getFilePath returns the path of the file of the reference, relative references are in the data folder.
+func (r *Runner) getFilePath(reference string) string {
+	filePath := filepath.FromSlash(reference)
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(r.Profile.Data, filePath)
+	}
+	return filepath.Clean(filePath)
+}
+
+// getReference returns the path of the file relative to the data folder if it is in it.
+func (r *Runner) getReference(filePath string) string {
+	if relativePath, err := filepath.Rel(r.Profile.Data, filePath); err == nil && !strings.HasPrefix(relativePath, "..") {
+		return filepath.ToSlash(relativePath)
+	}
+	return filepath.ToSlash(filePath)
+}
@@ -7,7 +7,6 @@ import (
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/usememos/memos/plugin/storage/s3"
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
@@ -88,37 +87,27 @@ func (r *Runner) CheckAndPresign(ctx context.Context) {
 				}
 			}
 
-			s3Config := workspaceStorageSetting.GetS3Config()
-			if s3ObjectPayload.S3Config != nil {
-				s3Config = s3ObjectPayload.S3Config
+			// The attachments without an S3 config are presigned with the one of the workspace storage setting.
+			if s3ObjectPayload.S3Config == nil {
+				s3ObjectPayload.S3Config = workspaceStorageSetting.GetS3Config()
 			}
-			if s3Config == nil {
+			if s3ObjectPayload.S3Config == nil {
 				slog.Error("S3 config is not found")
 				continue
 			}
 
-			s3Client, err := s3.NewClient(ctx, s3Config)
-			if err != nil {
-				slog.Error("Failed to create S3 client", "error", err)
-				continue
-			}
-
-			presignURL, err := s3Client.PresignGetObject(ctx, s3ObjectPayload.Key)
+			presignURL, err := r.Store.PresignAttachmentContent(ctx, attachment)
 			if err != nil {
 				slog.Error("Failed to presign URL", "error", err, "attachmentID", attachment.ID)
 				continue
 			}
 
-			s3ObjectPayload.S3Config = s3Config
+			// The rest of the payload, such as the image metadata, is kept.
 			s3ObjectPayload.LastPresignedTime = timestamppb.New(time.Now())
 			if err := r.Store.UpdateAttachment(ctx, &store.UpdateAttachment{
 				ID:        attachment.ID,
 				Reference: &presignURL,
-				Payload: &storepb.AttachmentPayload{
-					Payload: &storepb.AttachmentPayload_S3Object_{
-						S3Object: s3ObjectPayload,
-					},
-				},
+				Payload:   attachment.Payload,
 			}); err != nil {
 				slog.Error("Failed to update attachment", "error", err, "attachmentID", attachment.ID)
 				continue
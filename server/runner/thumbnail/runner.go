@@ -0,0 +1,328 @@
+package thumbnail
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"github.com/pkg/errors"
+	// Register the WebP decoder of the images, the PNG, JPEG and GIF decoders are registered by imaging.
+	_ "golang.org/x/image/webp"
+
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// CacheFolder is the folder name where the thumbnail images are stored.
+	CacheFolder = ".thumbnail_cache"
+	// DefaultPreset is the name of the preset of the thumbnails requested without a preset.
+	DefaultPreset = "medium"
+
+	// cleanupInterval is the interval of generating the missing thumbnails and removing the orphaned ones.
+	cleanupInterval = 12 * time.Hour
+	// queueSize is the number of attachments waiting for their thumbnails, the others are generated by the next cleanup.
+	queueSize = 256
+	// batchSize is the number of attachments listed at once by the cleanup.
+	batchSize = 100
+	// maxSourcePixels is the maximum number of pixels of the images thumbnails are generated for,
+	// so that decoding a huge image doesn't exhaust the memory.
+	maxSourcePixels = 64 * 1024 * 1024
+	jpegQuality     = 85
+	// failureSuffix is the suffix of the files remembering the attachments failing to generate their thumbnails.
+	failureSuffix = "_failed"
+)
+
+// Preset is a size of the thumbnails, the images are scaled down to fit in a square of the size.
+type Preset struct {
+	Name string
+	Size int
+}
+
+// Presets are the sizes the thumbnails are generated with.
+var Presets = []Preset{
+	{Name: "small", Size: 256},
+	{Name: "medium", Size: 640},
+	{Name: "large", Size: 1280},
+}
+
+// SupportedMimeTypes are the types of the attachments thumbnails are generated for.
+var SupportedMimeTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/webp",
+	"image/gif",
+}
+
+// GetPreset returns the preset of the name.
+func GetPreset(name string) (Preset, bool) {
+	index := slices.IndexFunc(Presets, func(preset Preset) bool {
+		return preset.Name == name
+	})
+	if index < 0 {
+		return Preset{}, false
+	}
+	return Presets[index], true
+}
+
+// IsSupported returns whether thumbnails are generated for the attachments of the type.
+func IsSupported(attachmentType string) bool {
+	return slices.Contains(SupportedMimeTypes, strings.ToLower(attachmentType))
+}
+
+// Runner generates the thumbnails of the uploaded images in the background, and removes the thumbnails
+// of the deleted attachments.
+type Runner struct {
+	Store   *store.Store
+	Profile *profile.Profile
+
+	queue chan string
+	// locks holds a mutex per attachment, so that the thumbnails of an attachment are generated one at a time
+	// while the ones of different attachments are generated concurrently.
+	locks sync.Map
+}
+
+func NewRunner(store *store.Store, profile *profile.Profile) *Runner {
+	return &Runner{
+		Store:   store,
+		Profile: profile,
+		queue:   make(chan string, queueSize),
+	}
+}
+
+func (r *Runner) Run(ctx context.Context) {
+	// The cleanup runs apart from the queue, so that the thumbnails of the uploads aren't waiting for a whole pass.
+	go r.runCleanup(ctx)
+
+	for {
+		select {
+		case attachmentUID := <-r.queue:
+			r.generate(ctx, attachmentUID)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) runCleanup(ctx context.Context) {
+	r.RunOnce(ctx)
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RunOnce generates the missing thumbnails of the attachments, and removes the thumbnails without an attachment.
+func (r *Runner) RunOnce(ctx context.Context) {
+	existing := map[string]bool{}
+	for offset := 0; ; offset += batchSize {
+		limit := batchSize
+		attachments, err := r.Store.ListAttachments(ctx, &store.FindAttachment{
+			Limit:  &limit,
+			Offset: &offset,
+		})
+		if err != nil {
+			slog.Error("failed to list attachments", "error", err)
+			return
+		}
+		for _, attachment := range attachments {
+			existing[attachment.UID] = true
+			if !IsSupported(attachment.Type) || r.getFailure(attachment) != nil {
+				continue
+			}
+			for _, preset := range Presets {
+				if _, err := r.GetOrGenerate(ctx, attachment, preset); err != nil {
+					slog.Warn("failed to generate thumbnail", "attachment", attachment.UID, "error", err)
+					break
+				}
+			}
+		}
+		if len(attachments) < batchSize {
+			break
+		}
+	}
+
+	entries, err := os.ReadDir(r.getCacheFolder())
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		attachmentUID, _, _ := strings.Cut(entry.Name(), "_")
+		if !existing[attachmentUID] {
+			if err := os.Remove(filepath.Join(r.getCacheFolder(), entry.Name())); err != nil {
+				slog.Warn("failed to remove orphaned thumbnail", "name", entry.Name(), "error", err)
+			}
+		}
+	}
+}
+
+// Enqueue schedules generating the thumbnails of the attachment.
+// The attachment is left to the next cleanup if the queue is full.
+func (r *Runner) Enqueue(attachmentUID string) {
+	select {
+	case r.queue <- attachmentUID:
+	default:
+	}
+}
+
+func (r *Runner) generate(ctx context.Context, attachmentUID string) {
+	attachment, err := r.Store.GetAttachment(ctx, &store.FindAttachment{UID: &attachmentUID})
+	if err != nil {
+		slog.Error("failed to get attachment", "error", err)
+		return
+	}
+	if attachment == nil || !IsSupported(attachment.Type) || r.getFailure(attachment) != nil {
+		return
+	}
+	for _, preset := range Presets {
+		if _, err := r.GetOrGenerate(ctx, attachment, preset); err != nil {
+			slog.Warn("failed to generate thumbnail", "attachment", attachmentUID, "error", err)
+			return
+		}
+	}
+}
+
+// GetOrGenerate returns the thumbnail of the attachment with the preset, it is generated if it isn't cached yet.
+// The images are rotated by their EXIF orientation and scaled down, but never scaled up.
+// The images failing to be decoded are remembered, and never generated again.
+func (r *Runner) GetOrGenerate(ctx context.Context, attachment *store.Attachment, preset Preset) ([]byte, error) {
+	filePath := r.getCachePath(attachment, preset)
+	if blob, err := os.ReadFile(filePath); err == nil {
+		return blob, nil
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to read thumbnail file")
+	}
+	if err := r.getFailure(attachment); err != nil {
+		return nil, err
+	}
+
+	lock, _ := r.locks.LoadOrStore(attachment.UID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+	// The thumbnail may have been generated, or failed, while waiting.
+	if blob, err := os.ReadFile(filePath); err == nil {
+		return blob, nil
+	}
+	if err := r.getFailure(attachment); err != nil {
+		return nil, err
+	}
+	content, err := r.Store.OpenAttachmentContent(ctx, attachment)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open attachment content")
+	}
+	defer content.Close()
+	config, _, err := image.DecodeConfig(content)
+	if err != nil {
+		return nil, r.saveFailure(attachment, errors.Wrap(err, "failed to decode image config"))
+	}
+	if config.Width*config.Height > maxSourcePixels {
+		return nil, r.saveFailure(attachment, errors.Errorf("image of %dx%d is too large", config.Width, config.Height))
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrap(err, "failed to seek attachment content")
+	}
+	img, err := imaging.Decode(content, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, r.saveFailure(attachment, errors.Wrap(err, "failed to decode image"))
+	}
+	thumbnailImage := imaging.Fit(img, preset.Size, preset.Size, imaging.Lanczos)
+
+	buffer := &bytes.Buffer{}
+	if GetContentType(attachment) == "image/jpeg" {
+		err = jpeg.Encode(buffer, thumbnailImage, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(buffer, thumbnailImage)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode thumbnail")
+	}
+	if err := os.MkdirAll(r.getCacheFolder(), os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "failed to create thumbnail cache folder")
+	}
+	// Write to a temporary file first, so that incomplete thumbnails are never served.
+	tempPath := filePath + ".tmp"
+	if err := os.WriteFile(tempPath, buffer.Bytes(), 0644); err != nil {
+		return nil, errors.Wrap(err, "failed to write thumbnail file")
+	}
+	if err := os.Rename(tempPath, filePath); err != nil {
+		return nil, errors.Wrap(err, "failed to save thumbnail file")
+	}
+	return buffer.Bytes(), nil
+}
+
+// Delete removes the thumbnails of the attachment, along with its failure.
+func (r *Runner) Delete(attachmentUID string) {
+	filePaths := []string{filepath.Join(r.getCacheFolder(), attachmentUID+failureSuffix)}
+	for _, preset := range Presets {
+		for _, extension := range []string{".jpg", ".png"} {
+			filePaths = append(filePaths, filepath.Join(r.getCacheFolder(), attachmentUID+"_"+preset.Name+extension))
+		}
+	}
+	for _, filePath := range filePaths {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			slog.Warn("failed to remove thumbnail", "path", filePath, "error", err)
+		}
+	}
+	r.locks.Delete(attachmentUID)
+}
+
+// getFailure returns the error the thumbnails of the attachment failed to be generated with, if they did.
+func (r *Runner) getFailure(attachment *store.Attachment) error {
+	message, err := os.ReadFile(filepath.Join(r.getCacheFolder(), attachment.UID+failureSuffix))
+	if err != nil {
+		return nil
+	}
+	return errors.Errorf("thumbnail generation failed: %s", message)
+}
+
+// saveFailure remembers the error the thumbnails of the attachment failed to be generated with, and returns it.
+// The failures are kept in the cache folder, so that they are removed along with the thumbnails of the attachment.
+func (r *Runner) saveFailure(attachment *store.Attachment, failure error) error {
+	if err := os.MkdirAll(r.getCacheFolder(), os.ModePerm); err != nil {
+		slog.Warn("failed to create thumbnail cache folder", "error", err)
+		return failure
+	}
+	if err := os.WriteFile(filepath.Join(r.getCacheFolder(), attachment.UID+failureSuffix), []byte(failure.Error()), 0644); err != nil {
+		slog.Warn("failed to save thumbnail failure", "attachment", attachment.UID, "error", err)
+	}
+	return failure
+}
+
+// GetContentType returns the content type of the thumbnails of the attachment.
+// The thumbnails of JPEG images are JPEG images, the others are PNG images to keep their transparency.
+func GetContentType(attachment *store.Attachment) string {
+	if strings.EqualFold(attachment.Type, "image/jpeg") {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+func (r *Runner) getCacheFolder() string {
+	return filepath.Join(r.Profile.Data, CacheFolder)
+}
+
+func (r *Runner) getCachePath(attachment *store.Attachment, preset Preset) string {
+	extension := ".png"
+	if GetContentType(attachment) == "image/jpeg" {
+		extension = ".jpg"
+	}
+	return filepath.Join(r.getCacheFolder(), attachment.UID+"_"+preset.Name+extension)
+}
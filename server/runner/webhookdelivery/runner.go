@@ -0,0 +1,209 @@
+package webhookdelivery
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/activitypub"
+	"github.com/usememos/memos/plugin/webhook"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+const (
+	// runnerInterval is the interval of polling for due deliveries.
+	runnerInterval = 5 * time.Second
+	// workerCount is the number of deliveries sent concurrently.
+	workerCount = 4
+	// batchSize is the maximum number of deliveries sent per run.
+	batchSize = 100
+	// MaxAttempts is the number of attempts after which a delivery is dead.
+	MaxAttempts = 10
+	// baseBackoff is the delay before the first retry, doubled on every following retry.
+	baseBackoff = 30 * time.Second
+	// retentionPeriod is how long deliveries are kept before they are cleaned up.
+	retentionPeriod = 30 * 24 * time.Hour
+	// cleanupInterval is the interval of cleaning up old deliveries.
+	cleanupInterval = time.Hour
+	// maxResponseBodyLength is the maximum length of the response body recorded in a delivery.
+	maxResponseBodyLength = 4096
+)
+
+// Runner sends the pending webhook deliveries and ActivityPub activities, retrying failed ones with exponential backoff
+// until they succeed or run out of attempts.
+type Runner struct {
+	Store *store.Store
+
+	lastCleanup time.Time
+}
+
+func NewRunner(store *store.Store) *Runner {
+	return &Runner{
+		Store: store,
+	}
+}
+
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(runnerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) RunOnce(ctx context.Context) {
+	r.DeliverPending(ctx)
+	if time.Since(r.lastCleanup) > cleanupInterval {
+		r.lastCleanup = time.Now()
+		createdTsBefore := time.Now().Add(-retentionPeriod).Unix()
+		if err := r.Store.DeleteWebhookDelivery(ctx, &store.DeleteWebhookDelivery{CreatedTsBefore: &createdTsBefore}); err != nil {
+			slog.Error("failed to clean up webhook deliveries", "error", err)
+		}
+	}
+}
+
+// DeliverPending sends the pending deliveries that are due with a pool of workers.
+func (r *Runner) DeliverPending(ctx context.Context) {
+	pendingStatus := store.WebhookDeliveryPending
+	now := time.Now().Unix()
+	limit := batchSize
+	deliveries, err := r.Store.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		Status:              &pendingStatus,
+		NextAttemptTsBefore: &now,
+		Limit:               &limit,
+	})
+	if err != nil {
+		slog.Error("failed to list pending webhook deliveries", "error", err)
+		return
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+
+	queue := make(chan *store.WebhookDelivery)
+	var wg sync.WaitGroup
+	for range min(workerCount, len(deliveries)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range queue {
+				if err := r.Deliver(ctx, delivery); err != nil {
+					slog.Error("failed to deliver webhook", "id", delivery.ID, "error", err)
+				}
+			}
+		}()
+	}
+	for _, delivery := range deliveries {
+		queue <- delivery
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// Deliver makes an attempt to send the delivery and records its outcome.
+// The returned error is about recording the outcome, failed requests are recorded in the delivery.
+func (r *Runner) Deliver(ctx context.Context, delivery *store.WebhookDelivery) error {
+	payload := delivery.Payload
+	attempts := delivery.Attempts + 1
+	update := &store.UpdateWebhookDelivery{
+		ID:       delivery.ID,
+		Attempts: &attempts,
+		Payload:  payload,
+	}
+
+	var response *webhook.Response
+	var sendErr error
+	if delivery.WebhookID == 0 {
+		// The deliveries without a webhook are the activities sent to ActivityPub inboxes.
+		sendErr = r.deliverActivity(ctx, payload)
+	} else {
+		hook, err := r.Store.GetWebhook(ctx, &store.FindWebhook{ID: &delivery.WebhookID})
+		if err != nil {
+			return err
+		}
+		if hook == nil {
+			// Nothing to retry once the webhook is gone.
+			deadStatus := store.WebhookDeliveryDead
+			update.Status = &deadStatus
+			payload.Error = "webhook not found"
+			_, err := r.Store.UpdateWebhookDelivery(ctx, update)
+			return err
+		}
+
+		payload.Url = hook.URL
+		response, sendErr = webhook.Post(ctx, &webhook.Request{
+			URL:        hook.URL,
+			Body:       []byte(payload.RequestBody),
+			Secret:     hook.Payload.GetSigningSecret(),
+			DeliveryID: strconv.Itoa(int(delivery.ID)),
+			Format:     hook.Payload.GetFormat(),
+		})
+	}
+	payload.ResponseStatus, payload.ResponseBody, payload.LatencyMs, payload.Error = 0, "", 0, ""
+	if response != nil {
+		payload.ResponseStatus = int32(response.StatusCode)
+		payload.ResponseBody = truncate(string(response.Body), maxResponseBodyLength)
+		payload.LatencyMs = response.Latency.Milliseconds()
+	}
+
+	var status store.WebhookDeliveryStatus
+	switch {
+	case sendErr == nil:
+		status = store.WebhookDeliverySucceeded
+	case attempts >= MaxAttempts:
+		status = store.WebhookDeliveryDead
+		payload.Error = sendErr.Error()
+	default:
+		status = store.WebhookDeliveryPending
+		payload.Error = sendErr.Error()
+		nextAttemptTs := time.Now().Add(GetBackoff(attempts)).Unix()
+		update.NextAttemptTs = &nextAttemptTs
+	}
+	update.Status = &status
+	_, err := r.Store.UpdateWebhookDelivery(ctx, update)
+	return err
+}
+
+// deliverActivity posts the activity of the delivery to its inbox, signed with the ActivityPub key of its user.
+func (r *Runner) deliverActivity(ctx context.Context, payload *storepb.WebhookDeliveryPayload) error {
+	userSetting, err := r.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &payload.ActivityPubUserId,
+		Key:    storepb.UserSettingKey_ACTIVITYPUB,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to get ActivityPub key")
+	}
+	privateKey, err := activitypub.ParsePrivateKey(userSetting.GetActivitypub().GetPrivateKey())
+	if err != nil {
+		return err
+	}
+	return activitypub.Deliver(ctx, payload.Url, payload.ActivityPubKeyId, privateKey, []byte(payload.RequestBody))
+}
+
+// GetBackoff returns the delay before the next attempt of a delivery after the given number of attempts.
+func GetBackoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	return baseBackoff << (attempts - 1)
+}
+
+// truncate truncates s to at most length bytes, dropping invalid UTF-8 that can not be stored.
+func truncate(s string, length int) string {
+	if len(s) > length {
+		s = s[:length]
+	}
+	return strings.ToValidUTF8(s, "")
+}
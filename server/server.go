@@ -24,7 +24,10 @@ import (
 	apiv1 "github.com/usememos/memos/server/router/api/v1"
 	"github.com/usememos/memos/server/router/frontend"
 	"github.com/usememos/memos/server/router/rss"
+	"github.com/usememos/memos/server/runner/attachmentgc"
 	"github.com/usememos/memos/server/runner/s3presign"
+	"github.com/usememos/memos/server/runner/thumbnail"
+	"github.com/usememos/memos/server/runner/webhookdelivery"
 	"github.com/usememos/memos/store"
 )
 
@@ -35,7 +38,9 @@ type Server struct {
 
 	echoServer        *echo.Echo
 	grpcServer        *grpc.Server
+	apiV1Service      *apiv1.APIV1Service
 	profiler          *profiler.Profiler
+	thumbnailRunner   *thumbnail.Runner
 	runnerCancelFuncs []context.CancelFunc
 }
 
@@ -88,10 +93,18 @@ func NewServer(ctx context.Context, profile *profile.Profile, store *store.Store
 			apiv1.NewLoggerInterceptor().LoggerInterceptor,
 			grpcrecovery.UnaryServerInterceptor(),
 			apiv1.NewGRPCAuthInterceptor(store, secret).AuthenticationInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			grpcrecovery.StreamServerInterceptor(),
+			apiv1.NewGRPCAuthInterceptor(store, secret).StreamAuthenticationInterceptor,
 		))
 	s.grpcServer = grpcServer
 
 	apiV1Service := apiv1.NewAPIV1Service(s.Secret, profile, store, grpcServer)
+	s.apiV1Service = apiV1Service
+	// The uploaded attachments are queued to the thumbnail runner started with the background runners.
+	s.thumbnailRunner = thumbnail.NewRunner(store, profile)
+	apiV1Service.ThumbnailRunner = s.thumbnailRunner
 	// Register gRPC gateway as api v1.
 	if err := apiV1Service.RegisterGateway(ctx, echoServer); err != nil {
 		return nil, errors.Wrap(err, "failed to register gRPC gateway")
@@ -151,13 +164,25 @@ func (s *Server) Shutdown(ctx context.Context) {
 		}
 	}
 
+	// End the event streams, which are kept open by the clients.
+	s.apiV1Service.Shutdown()
+
 	// Shutdown echo server.
 	if err := s.echoServer.Shutdown(ctx); err != nil {
 		slog.Error("failed to shutdown server", slog.String("error", err.Error()))
 	}
 
-	// Shutdown gRPC server.
-	s.grpcServer.GracefulStop()
+	// Shutdown gRPC server, the remaining streams are closed once the timeout is reached.
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+	}
 
 	// Stop the profiler
 	if s.profiler != nil {
@@ -199,6 +224,32 @@ func (s *Server) StartBackgroundRunners(ctx context.Context) {
 		slog.Info("s3presign runner stopped")
 	}()
 
+	// Start webhook delivery runner
+	webhookDeliveryContext, webhookDeliveryCancel := context.WithCancel(ctx)
+	s.runnerCancelFuncs = append(s.runnerCancelFuncs, webhookDeliveryCancel)
+	webhookDeliveryRunner := webhookdelivery.NewRunner(s.Store)
+	go func() {
+		webhookDeliveryRunner.Run(webhookDeliveryContext)
+		slog.Info("webhook delivery runner stopped")
+	}()
+
+	// Start thumbnail runner
+	thumbnailContext, thumbnailCancel := context.WithCancel(ctx)
+	s.runnerCancelFuncs = append(s.runnerCancelFuncs, thumbnailCancel)
+	go func() {
+		s.thumbnailRunner.Run(thumbnailContext)
+		slog.Info("thumbnail runner stopped")
+	}()
+
+	// Start attachment garbage collection runner
+	attachmentGCContext, attachmentGCCancel := context.WithCancel(ctx)
+	s.runnerCancelFuncs = append(s.runnerCancelFuncs, attachmentGCCancel)
+	attachmentGCRunner := attachmentgc.NewRunner(s.Store, s.Profile)
+	go func() {
+		attachmentGCRunner.Run(attachmentGCContext)
+		slog.Info("attachment gc runner stopped")
+	}()
+
 	// Log the number of goroutines running
 	slog.Info("background runners started", "goroutines", runtime.NumGoroutine())
 }
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+)
+
+// ActivityPubFollower is a remote actor following a user over ActivityPub.
+type ActivityPubFollower struct {
+	ID        int32
+	CreatedTs int64
+	UserID    int32
+	// ActorID is the id of the remote actor.
+	ActorID string
+	// Inbox is the personal inbox of the remote actor.
+	Inbox string
+	// SharedInbox is the shared inbox of the remote server, if any.
+	SharedInbox string
+}
+
+type FindActivityPubFollower struct {
+	UserID  *int32
+	ActorID *string
+}
+
+type DeleteActivityPubFollower struct {
+	UserID  int32
+	ActorID string
+}
+
+func (s *Store) UpsertActivityPubFollower(ctx context.Context, upsert *ActivityPubFollower) (*ActivityPubFollower, error) {
+	return s.driver.UpsertActivityPubFollower(ctx, upsert)
+}
+
+func (s *Store) ListActivityPubFollowers(ctx context.Context, find *FindActivityPubFollower) ([]*ActivityPubFollower, error) {
+	return s.driver.ListActivityPubFollowers(ctx, find)
+}
+
+func (s *Store) DeleteActivityPubFollower(ctx context.Context, delete *DeleteActivityPubFollower) error {
+	return s.driver.DeleteActivityPubFollower(ctx, delete)
+}
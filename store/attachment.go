@@ -2,14 +2,10 @@ package store
 
 import (
 	"context"
-	"log/slog"
-	"os"
-	"path/filepath"
 
 	"github.com/pkg/errors"
 
 	"github.com/usememos/memos/internal/base"
-	"github.com/usememos/memos/plugin/storage/s3"
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
@@ -32,6 +28,9 @@ type Attachment struct {
 	StorageType storepb.AttachmentStorageType
 	Reference   string
 	Payload     *storepb.AttachmentPayload
+	// ContentHash is the hash of the attachment blob holding the content, it is empty for the attachments
+	// holding their own content.
+	ContentHash string
 
 	// The related memo ID.
 	MemoID *int32
@@ -47,6 +46,7 @@ type FindAttachment struct {
 	MemoID         *int32
 	HasRelatedMemo bool
 	StorageType    *storepb.AttachmentStorageType
+	ContentHash    *string
 	Limit          *int
 	Offset         *int
 }
@@ -57,8 +57,14 @@ type UpdateAttachment struct {
 	UpdatedTs *int64
 	Filename  *string
 	MemoID    *int32
-	Reference *string
-	Payload   *storepb.AttachmentPayload
+	// StorageType, Reference and ContentHash move the attachment to another stored content.
+	StorageType *storepb.AttachmentStorageType
+	Reference   *string
+	ContentHash *string
+	Payload     *storepb.AttachmentPayload
+	// Blob replaces the content of the attachments stored in the database, along with the Size.
+	Blob []byte
+	Size *int64
 }
 
 type DeleteAttachment struct {
@@ -70,7 +76,16 @@ func (s *Store) CreateAttachment(ctx context.Context, create *Attachment) (*Atta
 	if !base.UIDMatcher.MatchString(create.UID) {
 		return nil, errors.New("invalid uid")
 	}
-	return s.driver.CreateAttachment(ctx, create)
+	attachment, err := s.driver.CreateAttachment(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.ContentHash != "" {
+		if err := s.driver.UpdateAttachmentBlobReferenceCount(ctx, attachment.ContentHash); err != nil {
+			return nil, errors.Wrap(err, "failed to update attachment blob reference count")
+		}
+	}
+	return attachment, nil
 }
 
 func (s *Store) ListAttachments(ctx context.Context, find *FindAttachment) ([]*Attachment, error) {
@@ -105,7 +120,29 @@ func (s *Store) UpdateAttachment(ctx context.Context, update *UpdateAttachment)
 	if update.UID != nil && !base.UIDMatcher.MatchString(*update.UID) {
 		return errors.New("invalid uid")
 	}
-	return s.driver.UpdateAttachment(ctx, update)
+	if update.ContentHash == nil {
+		return s.driver.UpdateAttachment(ctx, update)
+	}
+
+	attachment, err := s.GetAttachment(ctx, &FindAttachment{ID: &update.ID})
+	if err != nil {
+		return errors.Wrap(err, "failed to get attachment")
+	}
+	if attachment == nil {
+		return errors.New("attachment not found")
+	}
+	if err := s.driver.UpdateAttachment(ctx, update); err != nil {
+		return err
+	}
+	if *update.ContentHash != "" {
+		if err := s.driver.UpdateAttachmentBlobReferenceCount(ctx, *update.ContentHash); err != nil {
+			return errors.Wrap(err, "failed to update attachment blob reference count")
+		}
+	}
+	if attachment.ContentHash != "" && attachment.ContentHash != *update.ContentHash {
+		return s.releaseAttachmentBlob(ctx, attachment.ContentHash)
+	}
+	return nil
 }
 
 func (s *Store) DeleteAttachment(ctx context.Context, delete *DeleteAttachment) error {
@@ -117,50 +154,15 @@ func (s *Store) DeleteAttachment(ctx context.Context, delete *DeleteAttachment)
 		return errors.New("attachment not found")
 	}
 
-	if attachment.StorageType == storepb.AttachmentStorageType_LOCAL {
-		if err := func() error {
-			p := filepath.FromSlash(attachment.Reference)
-			if !filepath.IsAbs(p) {
-				p = filepath.Join(s.profile.Data, p)
-			}
-			err := os.Remove(p)
-			if err != nil {
-				return errors.Wrap(err, "failed to delete local file")
-			}
-			return nil
-		}(); err != nil {
-			return errors.Wrap(err, "failed to delete local file")
-		}
-	} else if attachment.StorageType == storepb.AttachmentStorageType_S3 {
-		if err := func() error {
-			s3ObjectPayload := attachment.Payload.GetS3Object()
-			if s3ObjectPayload == nil {
-				return errors.Errorf("No s3 object found")
-			}
-			workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
-			if err != nil {
-				return errors.Wrap(err, "failed to get workspace storage setting")
-			}
-			s3Config := s3ObjectPayload.S3Config
-			if s3Config == nil {
-				if workspaceStorageSetting.S3Config == nil {
-					return errors.Errorf("S3 config is not found")
-				}
-				s3Config = workspaceStorageSetting.S3Config
-			}
-
-			s3Client, err := s3.NewClient(ctx, s3Config)
-			if err != nil {
-				return errors.Wrap(err, "Failed to create s3 client")
-			}
-			if err := s3Client.DeleteObject(ctx, s3ObjectPayload.Key); err != nil {
-				return errors.Wrap(err, "Failed to delete s3 object")
-			}
-			return nil
-		}(); err != nil {
-			slog.Warn("Failed to delete s3 object", slog.Any("err", err))
+	// The content shared with other attachments is deleted along with its last attachment, unless an upload has just reused it.
+	if attachment.ContentHash != "" {
+		if err := s.driver.DeleteAttachment(ctx, delete); err != nil {
+			return err
 		}
+		return s.releaseAttachmentBlob(ctx, attachment.ContentHash)
+	}
+	if err := s.deleteAttachmentContent(ctx, attachment.StorageType, attachment.Reference, attachment.Payload, ""); err != nil {
+		return err
 	}
-
 	return s.driver.DeleteAttachment(ctx, delete)
 }
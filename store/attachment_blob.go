@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// attachmentBlobReuseGracePeriod is how long a blob reused by an upload is kept once no attachment has its content.
+// It leaves time to create the attachment of the upload, the blobs kept are deleted by the garbage collection otherwise.
+const attachmentBlobReuseGracePeriod = time.Hour
+
+// AttachmentBlob is a content stored once for all the attachments with the same content.
+type AttachmentBlob struct {
+	// Hash is the hex encoded SHA-256 hash of the content.
+	Hash      string
+	CreatedTs int64
+	Size      int64
+
+	// StorageType, Reference and Payload locate the stored content like the ones of the attachments.
+	StorageType storepb.AttachmentStorageType
+	Reference   string
+	Payload     *storepb.AttachmentPayload
+	// Blob is the content of the blobs stored in the database.
+	Blob []byte
+
+	// ReferenceCount is the number of the attachments with the content.
+	ReferenceCount int32
+	// UsedTs is the last time an upload reused the content, it is zero when the content has never been reused.
+	UsedTs int64
+}
+
+type FindAttachmentBlob struct {
+	GetBlob     bool
+	Hash        *string
+	StorageType *storepb.AttachmentStorageType
+	Limit       *int
+	Offset      *int
+}
+
+type UpdateAttachmentBlob struct {
+	Hash string
+	// StorageType, Reference and Payload move the blob to another stored content.
+	StorageType *storepb.AttachmentStorageType
+	Reference   *string
+	Payload     *storepb.AttachmentPayload
+	// Blob replaces the content of the blobs stored in the database.
+	Blob []byte
+	// UsedTs marks the blob as reused by an upload.
+	UsedTs *int64
+}
+
+// DeleteAttachmentBlob deletes the blob unless an attachment has its content.
+type DeleteAttachmentBlob struct {
+	Hash string
+	// CreatedBefore keeps the blob created since the time.
+	CreatedBefore *int64
+	// UsedBefore keeps the blob reused by an upload since the time.
+	UsedBefore *int64
+}
+
+func (s *Store) CreateAttachmentBlob(ctx context.Context, create *AttachmentBlob) (*AttachmentBlob, error) {
+	return s.driver.CreateAttachmentBlob(ctx, create)
+}
+
+func (s *Store) ListAttachmentBlobs(ctx context.Context, find *FindAttachmentBlob) ([]*AttachmentBlob, error) {
+	return s.driver.ListAttachmentBlobs(ctx, find)
+}
+
+func (s *Store) UpdateAttachmentBlob(ctx context.Context, update *UpdateAttachmentBlob) error {
+	return s.driver.UpdateAttachmentBlob(ctx, update)
+}
+
+func (s *Store) GetAttachmentBlob(ctx context.Context, find *FindAttachmentBlob) (*AttachmentBlob, error) {
+	list, err := s.ListAttachmentBlobs(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// DeleteAttachmentBlob deletes the blob along with its stored content unless an attachment has its content,
+// and reports whether it is deleted.
+func (s *Store) DeleteAttachmentBlob(ctx context.Context, delete *DeleteAttachmentBlob) (bool, error) {
+	attachmentBlob, err := s.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &delete.Hash})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get attachment blob")
+	}
+	if attachmentBlob == nil {
+		return false, nil
+	}
+	// The blob is deleted before its content, so that no upload reuses the content being deleted.
+	deleted, err := s.driver.DeleteAttachmentBlob(ctx, delete)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to delete attachment blob")
+	}
+	if !deleted {
+		return false, nil
+	}
+	if err := s.deleteAttachmentContent(ctx, attachmentBlob.StorageType, attachmentBlob.Reference, attachmentBlob.Payload, attachmentBlob.Hash); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseAttachmentBlob recounts the attachments with the content of the blob,
+// and deletes the blob along with its stored content once no attachment has it.
+// The blob reused by an upload within the grace period is kept for the attachment of the upload.
+func (s *Store) releaseAttachmentBlob(ctx context.Context, hash string) error {
+	if err := s.driver.UpdateAttachmentBlobReferenceCount(ctx, hash); err != nil {
+		return errors.Wrap(err, "failed to update attachment blob reference count")
+	}
+	usedBefore := time.Now().Add(-attachmentBlobReuseGracePeriod).Unix()
+	_, err := s.DeleteAttachmentBlob(ctx, &DeleteAttachmentBlob{Hash: hash, UsedBefore: &usedBefore})
+	return err
+}
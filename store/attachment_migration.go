@@ -0,0 +1,384 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/usememos/memos/plugin/storage"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+const defaultAttachmentStorageMigrationBatchSize = 100
+
+type MigrateAttachmentStorage struct {
+	From storepb.WorkspaceStorageSetting_StorageType
+	To   storepb.WorkspaceStorageSetting_StorageType
+	// BatchSize is the number of the stored contents listed at once.
+	BatchSize int
+	// Limit is the maximum number of the stored contents moved, all of them are moved if it is zero.
+	Limit int
+}
+
+// AttachmentStorageMigration is the result of moving the stored contents of the attachments.
+type AttachmentStorageMigration struct {
+	// MigratedCount is the number of the attachments moved to the target storage.
+	MigratedCount int
+	// FailedCount is the number of the attachments failed to be moved, they are kept in the source storage.
+	FailedCount int
+	// RemainingCount is the number of the attachments still in the source storage.
+	RemainingCount int
+}
+
+// MigrateAttachmentStorage moves the stored contents of the attachments from a storage to another in batches.
+// Each content is copied to the target storage and verified against its checksum before the attachments are moved to it,
+// and it is only deleted from the source storage afterwards, so the attachments stay readable while the server is running.
+// The migration is resumed by running it again, as the moved contents are no longer in the source storage.
+func (s *Store) MigrateAttachmentStorage(ctx context.Context, migrate *MigrateAttachmentStorage) (*AttachmentStorageMigration, error) {
+	if migrate.From == storepb.WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED || migrate.To == storepb.WorkspaceStorageSetting_STORAGE_TYPE_UNSPECIFIED {
+		return nil, errors.New("storage types are required")
+	}
+	if migrate.From == migrate.To {
+		return nil, errors.New("source and target storages are the same")
+	}
+	workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	targetSetting := proto.Clone(workspaceStorageSetting).(*storepb.WorkspaceStorageSetting)
+	targetSetting.StorageType = migrate.To
+	if migrate.To == storepb.WorkspaceStorageSetting_S3 && targetSetting.S3Config == nil {
+		return nil, errors.New("S3 config is not found")
+	}
+	sourceStorageType := convertAttachmentStorageType(migrate.From)
+	batchSize := migrate.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultAttachmentStorageMigrationBatchSize
+	}
+
+	migration := &AttachmentStorageMigration{}
+	processedCount := 0
+	hasRemainingLimit := func() bool {
+		return migrate.Limit <= 0 || processedCount < migrate.Limit
+	}
+	// The shared contents are moved first, along with all their attachments.
+	failedBlobCount := 0
+	for hasRemainingLimit() {
+		attachmentBlobs, err := s.ListAttachmentBlobs(ctx, &FindAttachmentBlob{
+			StorageType: &sourceStorageType,
+			Limit:       &batchSize,
+			Offset:      &failedBlobCount,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list attachment blobs")
+		}
+		if len(attachmentBlobs) == 0 {
+			break
+		}
+		for _, attachmentBlob := range attachmentBlobs {
+			if !hasRemainingLimit() {
+				break
+			}
+			processedCount++
+			count, err := s.migrateAttachmentBlob(ctx, attachmentBlob, targetSetting)
+			if err != nil {
+				slog.Warn("Failed to migrate attachment blob", slog.String("hash", attachmentBlob.Hash), slog.Any("err", err))
+				failedBlobCount++
+				migration.FailedCount += max(count, 1)
+				continue
+			}
+			migration.MigratedCount += count
+		}
+	}
+
+	// The remaining attachments hold their own contents, or share the contents which were moved before being interrupted.
+	failedAttachmentCount := 0
+	for hasRemainingLimit() {
+		attachments, err := s.ListAttachments(ctx, &FindAttachment{
+			StorageType: &sourceStorageType,
+			Limit:       &batchSize,
+			Offset:      &failedAttachmentCount,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list attachments")
+		}
+		if len(attachments) == 0 {
+			break
+		}
+		for _, attachment := range attachments {
+			if !hasRemainingLimit() {
+				break
+			}
+			processedCount++
+			if err := s.migrateAttachment(ctx, attachment, targetSetting); err != nil {
+				slog.Warn("Failed to migrate attachment", slog.String("uid", attachment.UID), slog.Any("err", err))
+				failedAttachmentCount++
+				migration.FailedCount++
+				continue
+			}
+			migration.MigratedCount++
+		}
+	}
+
+	remainingAttachments, err := s.ListAttachments(ctx, &FindAttachment{StorageType: &sourceStorageType})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments")
+	}
+	migration.RemainingCount = len(remainingAttachments)
+	return migration, nil
+}
+
+// migrateAttachmentBlob moves the content of the attachment blob to the storage of the storage setting,
+// and returns the number of the attachments moved along with it.
+func (s *Store) migrateAttachmentBlob(ctx context.Context, attachmentBlob *AttachmentBlob, storageSetting *storepb.WorkspaceStorageSetting) (int, error) {
+	attachments, err := s.ListAttachments(ctx, &FindAttachment{ContentHash: &attachmentBlob.Hash})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list attachments")
+	}
+	filename, contentType := attachmentBlob.Hash, ""
+	if len(attachments) > 0 {
+		filename, contentType = attachments[0].Filename, attachments[0].Type
+	}
+
+	source, sourceKey, err := s.getStorageBackend(ctx, attachmentBlob.StorageType, attachmentBlob.Reference, attachmentBlob.Payload, attachmentBlob.Hash)
+	if err != nil {
+		return len(attachments), err
+	}
+	content := storage.NewReader(ctx, source, sourceKey, attachmentBlob.Size)
+	defer content.Close()
+	target, storageType, key, err := s.putContent(ctx, storageSetting, filename, contentType, attachmentBlob.Hash, content)
+	if err != nil {
+		return len(attachments), err
+	}
+	if err := verifyContent(ctx, target, key, attachmentBlob.Hash); err != nil {
+		return len(attachments), err
+	}
+
+	location := &Attachment{StorageType: storageType, Reference: key}
+	if storageType == storepb.AttachmentStorageType_S3 {
+		if err := s.presignS3Object(ctx, location, target, key, storageSetting.S3Config); err != nil {
+			return len(attachments), err
+		}
+	} else if storageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		location.Reference = ""
+	}
+	if err := s.UpdateAttachmentBlob(ctx, &UpdateAttachmentBlob{
+		Hash:        attachmentBlob.Hash,
+		StorageType: &location.StorageType,
+		Reference:   &location.Reference,
+		Payload:     &storepb.AttachmentPayload{Payload: location.Payload.GetPayload()},
+	}); err != nil {
+		return len(attachments), errors.Wrap(err, "failed to update attachment blob")
+	}
+	// The content is verified again once the attachment blob points to it, as another content may have been stored
+	// with the same key meanwhile. The attachment blob is pointed back to the source storage if it has been.
+	if err := verifyContent(ctx, target, key, attachmentBlob.Hash); err != nil {
+		if err := s.UpdateAttachmentBlob(ctx, &UpdateAttachmentBlob{
+			Hash:        attachmentBlob.Hash,
+			StorageType: &attachmentBlob.StorageType,
+			Reference:   &attachmentBlob.Reference,
+			Payload:     &storepb.AttachmentPayload{Payload: attachmentBlob.Payload.GetPayload()},
+		}); err != nil {
+			slog.Warn("Failed to restore attachment blob", slog.String("hash", attachmentBlob.Hash), slog.Any("err", err))
+		}
+		return len(attachments), err
+	}
+
+	// The attachments are listed again, as the attachments created meanwhile may share the content.
+	migratedBlob, err := s.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &attachmentBlob.Hash})
+	if err != nil {
+		return len(attachments), errors.Wrap(err, "failed to get attachment blob")
+	}
+	if attachments, err = s.ListAttachments(ctx, &FindAttachment{ContentHash: &attachmentBlob.Hash}); err != nil {
+		return 0, errors.Wrap(err, "failed to list attachments")
+	}
+	for _, attachment := range attachments {
+		if err := s.moveAttachmentToBlob(ctx, attachment, migratedBlob); err != nil {
+			return len(attachments), err
+		}
+	}
+
+	// The content is only deleted from the source storage once no attachment reads it from there.
+	if attachmentBlob.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		if err := s.UpdateAttachmentBlob(ctx, &UpdateAttachmentBlob{Hash: attachmentBlob.Hash, Blob: []byte{}}); err != nil {
+			slog.Warn("Failed to clear migrated attachment blob", slog.String("hash", attachmentBlob.Hash), slog.Any("err", err))
+		}
+	} else if err := s.deleteAttachmentContent(ctx, attachmentBlob.StorageType, attachmentBlob.Reference, attachmentBlob.Payload, attachmentBlob.Hash); err != nil {
+		slog.Warn("Failed to delete migrated attachment content", slog.String("hash", attachmentBlob.Hash), slog.Any("err", err))
+	}
+	return len(attachments), nil
+}
+
+// migrateAttachment moves the content of the attachment to the storage of the storage setting.
+func (s *Store) migrateAttachment(ctx context.Context, attachment *Attachment, storageSetting *storepb.WorkspaceStorageSetting) error {
+	if attachment.ContentHash != "" {
+		attachmentBlob, err := s.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &attachment.ContentHash})
+		if err != nil {
+			return errors.Wrap(err, "failed to get attachment blob")
+		}
+		if attachmentBlob == nil {
+			return errors.Errorf("attachment blob %s not found", attachment.ContentHash)
+		}
+		if attachmentBlob.StorageType == attachment.StorageType {
+			return errors.New("attachment blob isn't migrated")
+		}
+		return s.moveAttachmentToBlob(ctx, attachment, attachmentBlob)
+	}
+
+	content, err := s.OpenAttachmentContent(ctx, attachment)
+	if err != nil {
+		return errors.Wrap(err, "failed to open attachment content")
+	}
+	defer content.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return errors.Wrap(err, "failed to read attachment content")
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "failed to seek attachment content")
+	}
+	payload := &storepb.AttachmentPayload{}
+	if attachment.Payload != nil {
+		payload = proto.Clone(attachment.Payload).(*storepb.AttachmentPayload)
+		payload.Payload = nil
+	}
+	replacement := &Attachment{
+		Filename: attachment.Filename,
+		Type:     attachment.Type,
+		Size:     attachment.Size,
+		Payload:  payload,
+	}
+	if err := s.saveAttachmentContent(ctx, storageSetting, replacement, content); err != nil {
+		return err
+	}
+	target, key, err := s.getStorageBackend(ctx, replacement.StorageType, replacement.Reference, replacement.Payload, replacement.ContentHash)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return errors.New("attachment content isn't stored")
+	}
+	if err := verifyContent(ctx, target, key, hex.EncodeToString(hash.Sum(nil))); err != nil {
+		return err
+	}
+
+	if err := s.UpdateAttachment(ctx, &UpdateAttachment{
+		ID:          attachment.ID,
+		StorageType: &replacement.StorageType,
+		Reference:   &replacement.Reference,
+		ContentHash: &replacement.ContentHash,
+		Payload:     replacement.Payload,
+	}); err != nil {
+		return errors.Wrap(err, "failed to update attachment")
+	}
+	// The content is verified again once the attachment points to it, as another content may have been stored
+	// with the same key meanwhile. The attachment is pointed back to its source content if it has been.
+	if err := verifyContent(ctx, target, key, hex.EncodeToString(hash.Sum(nil))); err != nil {
+		if err := s.restoreAttachment(ctx, attachment, replacement.ContentHash); err != nil {
+			slog.Warn("Failed to restore attachment", slog.String("uid", attachment.UID), slog.Any("err", err))
+		}
+		return err
+	}
+	if attachment.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		if err := s.UpdateAttachment(ctx, &UpdateAttachment{ID: attachment.ID, Blob: []byte{}}); err != nil {
+			slog.Warn("Failed to clear migrated attachment blob", slog.String("uid", attachment.UID), slog.Any("err", err))
+		}
+	} else if err := s.deleteAttachmentContent(ctx, attachment.StorageType, attachment.Reference, attachment.Payload, ""); err != nil {
+		slog.Warn("Failed to delete migrated attachment content", slog.String("uid", attachment.UID), slog.Any("err", err))
+	}
+	return nil
+}
+
+// restoreAttachment points the attachment back to its source content after its migrated content has been overwritten.
+// The attachment blob of the migrated content is deleted when no other attachment shares it, but its stored content
+// is kept, since it is the content stored with the same key by another attachment.
+func (s *Store) restoreAttachment(ctx context.Context, attachment *Attachment, contentHash string) error {
+	payload := attachment.Payload
+	if payload == nil {
+		payload = &storepb.AttachmentPayload{}
+	}
+	if err := s.driver.UpdateAttachment(ctx, &UpdateAttachment{
+		ID:          attachment.ID,
+		StorageType: &attachment.StorageType,
+		Reference:   &attachment.Reference,
+		ContentHash: &attachment.ContentHash,
+		Payload:     payload,
+	}); err != nil {
+		return errors.Wrap(err, "failed to update attachment")
+	}
+	if contentHash == "" {
+		return nil
+	}
+	if err := s.driver.UpdateAttachmentBlobReferenceCount(ctx, contentHash); err != nil {
+		return errors.Wrap(err, "failed to update attachment blob reference count")
+	}
+	attachmentBlob, err := s.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &contentHash})
+	if err != nil {
+		return errors.Wrap(err, "failed to get attachment blob")
+	}
+	if attachmentBlob == nil || attachmentBlob.ReferenceCount > 0 {
+		return nil
+	}
+	usedBefore := time.Now().Add(-attachmentBlobReuseGracePeriod).Unix()
+	if _, err := s.driver.DeleteAttachmentBlob(ctx, &DeleteAttachmentBlob{Hash: contentHash, UsedBefore: &usedBefore}); err != nil {
+		return errors.Wrap(err, "failed to delete attachment blob")
+	}
+	return nil
+}
+
+// moveAttachmentToBlob points the attachment to the stored content of the attachment blob, keeping the rest of its payload.
+func (s *Store) moveAttachmentToBlob(ctx context.Context, attachment *Attachment, attachmentBlob *AttachmentBlob) error {
+	location := &Attachment{}
+	if err := s.useAttachmentBlob(ctx, location, attachmentBlob); err != nil {
+		return err
+	}
+	payload := &storepb.AttachmentPayload{}
+	if attachment.Payload != nil {
+		payload = attachment.Payload
+	}
+	payload.Payload = location.Payload.GetPayload()
+	if err := s.UpdateAttachment(ctx, &UpdateAttachment{
+		ID:          attachment.ID,
+		StorageType: &location.StorageType,
+		Reference:   &location.Reference,
+		Payload:     payload,
+	}); err != nil {
+		return errors.Wrap(err, "failed to update attachment")
+	}
+	return nil
+}
+
+// verifyContent reads the stored content back, and checks its hash.
+func verifyContent(ctx context.Context, backend storage.Backend, key string, contentHash string) error {
+	body, err := backend.Get(ctx, key, 0, -1)
+	if err != nil {
+		return errors.Wrap(err, "failed to read stored content")
+	}
+	defer body.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, body); err != nil {
+		return errors.Wrap(err, "failed to read stored content")
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != contentHash {
+		return errors.New("checksum mismatch of stored content")
+	}
+	return nil
+}
+
+// convertAttachmentStorageType returns the storage type of the attachments stored in the storage.
+func convertAttachmentStorageType(storageType storepb.WorkspaceStorageSetting_StorageType) storepb.AttachmentStorageType {
+	switch storageType {
+	case storepb.WorkspaceStorageSetting_LOCAL:
+		return storepb.AttachmentStorageType_LOCAL
+	case storepb.WorkspaceStorageSetting_S3:
+		return storepb.AttachmentStorageType_S3
+	default:
+		return storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,417 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/storage"
+	"github.com/usememos/memos/plugin/storage/local"
+	"github.com/usememos/memos/plugin/storage/s3"
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// getStorageBackend returns the backend a content is stored in, along with the key of the content.
+// The backend is nil for the contents of the external attachments and the attachments holding their own content in the database.
+func (s *Store) getStorageBackend(ctx context.Context, storageType storepb.AttachmentStorageType, reference string, payload *storepb.AttachmentPayload, contentHash string) (storage.Backend, string, error) {
+	switch storageType {
+	case storepb.AttachmentStorageType_LOCAL:
+		return local.NewBackend(s.profile.Data), reference, nil
+	case storepb.AttachmentStorageType_S3:
+		s3ObjectPayload := payload.GetS3Object()
+		if s3ObjectPayload == nil {
+			return nil, "", errors.Errorf("No s3 object found")
+		}
+		s3Config := s3ObjectPayload.S3Config
+		if s3Config == nil {
+			workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+			if err != nil {
+				return nil, "", errors.Wrap(err, "failed to get workspace storage setting")
+			}
+			if workspaceStorageSetting.S3Config == nil {
+				return nil, "", errors.Errorf("S3 config is not found")
+			}
+			s3Config = workspaceStorageSetting.S3Config
+		}
+		s3Client, err := s3.NewClient(ctx, s3Config)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "Failed to create s3 client")
+		}
+		return s3Client, s3ObjectPayload.Key, nil
+	case storepb.AttachmentStorageType_EXTERNAL:
+		return nil, "", nil
+	default:
+		if contentHash == "" {
+			return nil, "", nil
+		}
+		return &databaseBackend{store: s}, contentHash, nil
+	}
+}
+
+// SaveAttachmentContent stores the content of the attachment in the storage of the workspace storage setting.
+// The content is only held in memory when it is stored in the database.
+// The contents are stored once, the attachments with a stored content share its attachment blob.
+func (s *Store) SaveAttachmentContent(ctx context.Context, create *Attachment, content io.ReadSeeker) error {
+	workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Failed to find workspace storage setting")
+	}
+	return s.saveAttachmentContent(ctx, workspaceStorageSetting, create, content)
+}
+
+func (s *Store) saveAttachmentContent(ctx context.Context, storageSetting *storepb.WorkspaceStorageSetting, create *Attachment, content io.ReadSeeker) error {
+	hash := sha256.New()
+	size, err := io.Copy(hash, content)
+	if err != nil {
+		return errors.Wrap(err, "Failed to hash content")
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Wrap(err, "Failed to seek content")
+	}
+	contentHash := hex.EncodeToString(hash.Sum(nil))
+	attachmentBlob, err := s.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &contentHash})
+	if err != nil {
+		return errors.Wrap(err, "Failed to find attachment blob")
+	}
+	if attachmentBlob != nil {
+		// The blob is marked as used before it is reused, so that it isn't deleted along with its last attachment
+		// before the attachment of the upload is created. The content is stored anew when it is deleted meanwhile.
+		usedTs := time.Now().Unix()
+		if err := s.UpdateAttachmentBlob(ctx, &UpdateAttachmentBlob{Hash: contentHash, UsedTs: &usedTs}); err != nil {
+			return errors.Wrap(err, "Failed to update attachment blob")
+		}
+		attachmentBlob, err = s.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &contentHash})
+		if err != nil {
+			return errors.Wrap(err, "Failed to find attachment blob")
+		}
+	}
+	if attachmentBlob != nil {
+		return s.useAttachmentBlob(ctx, create, attachmentBlob)
+	}
+
+	backend, storageType, key, err := s.putContent(ctx, storageSetting, create.Filename, create.Type, contentHash, content)
+	if err != nil {
+		return err
+	}
+	create.StorageType = storageType
+	create.Reference = key
+	if create.StorageType == storepb.AttachmentStorageType_S3 {
+		if err := s.presignS3Object(ctx, create, backend, key, storageSetting.S3Config); err != nil {
+			return err
+		}
+	}
+	create.ContentHash = contentHash
+	create.Blob = nil
+	if create.StorageType == storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		create.Reference = ""
+		return nil
+	}
+
+	// The attachment keeps its own content if an attachment with the same content has been stored concurrently.
+	if _, err := s.CreateAttachmentBlob(ctx, &AttachmentBlob{
+		Hash:        contentHash,
+		Size:        size,
+		StorageType: create.StorageType,
+		Reference:   create.Reference,
+		Payload:     &storepb.AttachmentPayload{Payload: create.Payload.GetPayload()},
+	}); err != nil {
+		slog.Warn("Failed to create attachment blob", slog.Any("err", err))
+		create.ContentHash = ""
+	}
+	return nil
+}
+
+// putContent stores the content in the storage of the storage setting, and returns the backend, the storage type
+// and the key of the stored content. The key is built from the filepath template of the storage setting, prefixing
+// the filename with the hash when the key is taken, except for the contents stored in the database which are keyed by their hashes.
+// A new backend is added by creating it here, and resolving its storage type in getStorageBackend.
+func (s *Store) putContent(ctx context.Context, storageSetting *storepb.WorkspaceStorageSetting, filename, contentType, contentHash string, content io.Reader) (storage.Backend, storepb.AttachmentStorageType, string, error) {
+	var backend storage.Backend
+	storageType := convertAttachmentStorageType(storageSetting.StorageType)
+	key := buildStorageKey(storageSetting.FilepathTemplate, filename)
+	switch storageSetting.StorageType {
+	case storepb.WorkspaceStorageSetting_LOCAL:
+		backend = local.NewBackend(s.profile.Data)
+	case storepb.WorkspaceStorageSetting_S3:
+		if storageSetting.S3Config == nil {
+			return nil, storageType, "", errors.Errorf("No actived external storage found")
+		}
+		s3Client, err := s3.NewClient(ctx, storageSetting.S3Config)
+		if err != nil {
+			return nil, storageType, "", errors.Wrap(err, "Failed to create s3 client")
+		}
+		backend = s3Client
+	default:
+		// The database backend creates the attachment blob along with its content.
+		backend, key = &databaseBackend{store: s}, contentHash
+	}
+	if storageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		// The keys built from the template may be taken by other contents, such as the files of the same name
+		// stored in the same second, which would be overwritten. The hash makes the key unique to the content.
+		if _, err := backend.Stat(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+			key = buildStorageKey(storageSetting.FilepathTemplate, contentHash[:16]+"_"+filename)
+		}
+	}
+	key, err := backend.Put(ctx, key, contentType, content)
+	if err != nil {
+		return nil, storageType, "", errors.Wrap(err, "Failed to store content")
+	}
+	return backend, storageType, key, nil
+}
+
+// useAttachmentBlob points the attachment to the stored content of the attachment blob.
+func (s *Store) useAttachmentBlob(ctx context.Context, create *Attachment, attachmentBlob *AttachmentBlob) error {
+	create.ContentHash = attachmentBlob.Hash
+	create.StorageType = attachmentBlob.StorageType
+	create.Reference = attachmentBlob.Reference
+	create.Blob = nil
+	if s3Object := attachmentBlob.Payload.GetS3Object(); s3Object != nil {
+		backend, key, err := s.getStorageBackend(ctx, attachmentBlob.StorageType, attachmentBlob.Reference, attachmentBlob.Payload, attachmentBlob.Hash)
+		if err != nil {
+			return err
+		}
+		return s.presignS3Object(ctx, create, backend, key, s3Object.S3Config)
+	}
+	return nil
+}
+
+// presignS3Object sets the reference of the attachment to the presigned URL of its S3 object.
+func (s *Store) presignS3Object(ctx context.Context, create *Attachment, backend storage.Backend, key string, s3Config *storepb.StorageS3Config) error {
+	presignURL, err := backend.Presign(ctx, key, storage.PresignExpiration)
+	if err != nil {
+		return errors.Wrap(err, "Failed to presign via s3 client")
+	}
+	create.Reference = presignURL
+	if create.Payload == nil {
+		create.Payload = &storepb.AttachmentPayload{}
+	}
+	create.Payload.Payload = &storepb.AttachmentPayload_S3Object_{
+		S3Object: &storepb.AttachmentPayload_S3Object{
+			S3Config:          s3Config,
+			Key:               key,
+			LastPresignedTime: timestamppb.New(time.Now()),
+		},
+	}
+	return nil
+}
+
+// PresignAttachmentContent returns a URL the content of the attachment can be downloaded from directly,
+// it returns storage.ErrNotSupported for the storages served by the server.
+func (s *Store) PresignAttachmentContent(ctx context.Context, attachment *Attachment) (string, error) {
+	backend, key, err := s.getStorageBackend(ctx, attachment.StorageType, attachment.Reference, attachment.Payload, attachment.ContentHash)
+	if err != nil {
+		return "", err
+	}
+	if backend == nil {
+		return "", storage.ErrNotSupported
+	}
+	return backend.Presign(ctx, key, storage.PresignExpiration)
+}
+
+// OpenAttachmentContent opens the content of the attachment from its storage without reading it into memory,
+// except for the attachments stored in the database.
+func (s *Store) OpenAttachmentContent(ctx context.Context, attachment *Attachment) (io.ReadSeekCloser, error) {
+	if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+		return nil, errors.New("external attachments can't be opened")
+	}
+	backend, key, err := s.getStorageBackend(ctx, attachment.StorageType, attachment.Reference, attachment.Payload, attachment.ContentHash)
+	if err != nil {
+		return nil, err
+	}
+	if backend != nil {
+		// Only the read ranges of the content are requested.
+		return storage.NewReader(ctx, backend, key, attachment.Size), nil
+	}
+
+	blob := attachment.Blob
+	if blob == nil && attachment.Size > 0 {
+		withBlob, err := s.GetAttachment(ctx, &FindAttachment{ID: &attachment.ID, GetBlob: true})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get attachment blob")
+		}
+		if withBlob == nil {
+			return nil, errors.New("attachment not found")
+		}
+		blob = withBlob.Blob
+	}
+	return nopReadSeekCloser{bytes.NewReader(blob)}, nil
+}
+
+// ReplaceAttachmentContent replaces the content of the attachment in its storage, and updates its size and payload.
+// The external attachments and the contents of attachment blobs can't be replaced.
+func (s *Store) ReplaceAttachmentContent(ctx context.Context, attachment *Attachment, content []byte) error {
+	if attachment.ContentHash != "" {
+		return errors.New("attachment blobs can't be replaced")
+	}
+	if attachment.StorageType == storepb.AttachmentStorageType_EXTERNAL {
+		return errors.New("external attachments can't be replaced")
+	}
+	size := int64(len(content))
+	updatedTs := time.Now().Unix()
+	update := &UpdateAttachment{
+		ID:        attachment.ID,
+		UpdatedTs: &updatedTs,
+		Payload:   attachment.Payload,
+		Size:      &size,
+	}
+	backend, key, err := s.getStorageBackend(ctx, attachment.StorageType, attachment.Reference, attachment.Payload, attachment.ContentHash)
+	if err != nil {
+		return err
+	}
+	if backend != nil {
+		if _, err := backend.Put(ctx, key, attachment.Type, bytes.NewReader(content)); err != nil {
+			return errors.Wrap(err, "failed to replace content")
+		}
+	} else {
+		update.Blob = content
+	}
+	if err := s.UpdateAttachment(ctx, update); err != nil {
+		return err
+	}
+	attachment.Size, attachment.UpdatedTs = size, updatedTs
+	return nil
+}
+
+// deleteAttachmentContent deletes the stored content, the failures to delete S3 objects are only logged.
+func (s *Store) deleteAttachmentContent(ctx context.Context, storageType storepb.AttachmentStorageType, reference string, payload *storepb.AttachmentPayload, contentHash string) error {
+	backend, key, err := s.getStorageBackend(ctx, storageType, reference, payload, contentHash)
+	if err == nil && backend != nil {
+		err = backend.Delete(ctx, key)
+	}
+	if err != nil {
+		if storageType == storepb.AttachmentStorageType_S3 {
+			slog.Warn("Failed to delete s3 object", slog.Any("err", err))
+			return nil
+		}
+		return errors.Wrap(err, "failed to delete attachment content")
+	}
+	return nil
+}
+
+var fileKeyPattern = regexp.MustCompile(`\{[a-z]{1,9}\}`)
+
+// buildStorageKey returns the key of the content of the file from the filepath template of the storage setting.
+func buildStorageKey(template, filename string) string {
+	if !strings.Contains(template, "{filename}") {
+		template = path.Join(template, "{filename}")
+	}
+	t := time.Now()
+	return fileKeyPattern.ReplaceAllStringFunc(template, func(s string) string {
+		switch s {
+		case "{filename}":
+			return filename
+		case "{timestamp}":
+			return fmt.Sprintf("%d", t.Unix())
+		case "{year}":
+			return fmt.Sprintf("%d", t.Year())
+		case "{month}":
+			return fmt.Sprintf("%02d", t.Month())
+		case "{day}":
+			return fmt.Sprintf("%02d", t.Day())
+		case "{hour}":
+			return fmt.Sprintf("%02d", t.Hour())
+		case "{minute}":
+			return fmt.Sprintf("%02d", t.Minute())
+		case "{second}":
+			return fmt.Sprintf("%02d", t.Second())
+		case "{uuid}":
+			return util.GenUUID()
+		}
+		return s
+	})
+}
+
+// databaseBackend stores the contents in the attachment blobs of the database, the keys are the hashes of the contents.
+type databaseBackend struct {
+	store *Store
+}
+
+var _ storage.Backend = (*databaseBackend)(nil)
+
+// Put creates the attachment blob of the content, or stores the content in the existing attachment blob.
+func (b *databaseBackend) Put(ctx context.Context, key string, _ string, content io.Reader) (string, error) {
+	blob, err := io.ReadAll(content)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read content")
+	}
+	attachmentBlob, err := b.store.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &key})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get attachment blob")
+	}
+	if attachmentBlob != nil {
+		if err := b.store.UpdateAttachmentBlob(ctx, &UpdateAttachmentBlob{Hash: key, Blob: blob}); err != nil {
+			return "", errors.Wrap(err, "failed to update attachment blob")
+		}
+		return key, nil
+	}
+	if _, err := b.store.CreateAttachmentBlob(ctx, &AttachmentBlob{
+		Hash: key,
+		Size: int64(len(blob)),
+		Blob: blob,
+	}); err != nil {
+		// The same content may have been stored concurrently.
+		if attachmentBlob, findErr := b.store.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &key}); findErr != nil || attachmentBlob == nil {
+			return "", errors.Wrap(err, "failed to create attachment blob")
+		}
+	}
+	return key, nil
+}
+
+func (b *databaseBackend) Get(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	attachmentBlob, err := b.store.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &key, GetBlob: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attachment blob")
+	}
+	if attachmentBlob == nil {
+		return nil, storage.ErrNotFound
+	}
+	blob := attachmentBlob.Blob[min(offset, int64(len(attachmentBlob.Blob))):]
+	if length >= 0 && length < int64(len(blob)) {
+		blob = blob[:length]
+	}
+	return io.NopCloser(bytes.NewReader(blob)), nil
+}
+
+// Delete is a no-op, the content is deleted along with its attachment blob.
+func (*databaseBackend) Delete(_ context.Context, _ string) error {
+	return nil
+}
+
+// Presign isn't supported, the contents are served by the server.
+func (*databaseBackend) Presign(_ context.Context, _ string, _ time.Duration) (string, error) {
+	return "", storage.ErrNotSupported
+}
+
+func (b *databaseBackend) Stat(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	attachmentBlob, err := b.store.GetAttachmentBlob(ctx, &FindAttachmentBlob{Hash: &key})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get attachment blob")
+	}
+	if attachmentBlob == nil {
+		return nil, storage.ErrNotFound
+	}
+	return &storage.ObjectInfo{
+		Size:         attachmentBlob.Size,
+		ModifiedTime: time.Unix(attachmentBlob.CreatedTs, 0),
+	}, nil
+}
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,70 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertActivityPubFollower(ctx context.Context, upsert *store.ActivityPubFollower) (*store.ActivityPubFollower, error) {
+	stmt := "INSERT INTO `activitypub_follower` (`user_id`, `actor_id`, `inbox`, `shared_inbox`) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE `inbox` = ?, `shared_inbox` = ?"
+	if _, err := d.db.ExecContext(ctx, stmt, upsert.UserID, upsert.ActorID, upsert.Inbox, upsert.SharedInbox, upsert.Inbox, upsert.SharedInbox); err != nil {
+		return nil, err
+	}
+	list, err := d.ListActivityPubFollowers(ctx, &store.FindActivityPubFollower{UserID: &upsert.UserID, ActorID: &upsert.ActorID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to find activitypub follower %s", upsert.ActorID)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListActivityPubFollowers(ctx context.Context, find *store.FindActivityPubFollower) ([]*store.ActivityPubFollower, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.UserID != nil {
+		where, args = append(where, "`user_id` = ?"), append(args, *find.UserID)
+	}
+	if find.ActorID != nil {
+		where, args = append(where, "`actor_id` = ?"), append(args, *find.ActorID)
+	}
+
+	rows, err := d.db.QueryContext(ctx, "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), `user_id`, `actor_id`, `inbox`, `shared_inbox` FROM `activitypub_follower` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` ASC",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.ActivityPubFollower{}
+	for rows.Next() {
+		follower := &store.ActivityPubFollower{}
+		if err := rows.Scan(
+			&follower.ID,
+			&follower.CreatedTs,
+			&follower.UserID,
+			&follower.ActorID,
+			&follower.Inbox,
+			&follower.SharedInbox,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, follower)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteActivityPubFollower(ctx context.Context, delete *store.DeleteActivityPubFollower) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `activitypub_follower` WHERE `user_id` = ? AND `actor_id` = ?", delete.UserID, delete.ActorID)
+	return err
+}
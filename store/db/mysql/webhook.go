@@ -4,13 +4,26 @@ import (
 	"context"
 	"strings"
 
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
 func (d *DB) CreateWebhook(ctx context.Context, create *store.Webhook) (*store.Webhook, error) {
-	fields := []string{"`name`", "`url`", "`creator_id`"}
-	placeholder := []string{"?", "?", "?"}
-	args := []any{create.Name, create.URL, create.CreatorID}
+	payloadString := "{}"
+	if create.Payload != nil {
+		bytes, err := protojson.Marshal(create.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal webhook payload")
+		}
+		payloadString = string(bytes)
+	}
+
+	fields := []string{"`name`", "`url`", "`creator_id`", "`scope`", "`payload`"}
+	placeholder := []string{"?", "?", "?", "?", "?"}
+	args := []any{create.Name, create.URL, create.CreatorID, create.Scope.String(), payloadString}
 
 	stmt := "INSERT INTO `webhook` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
 	result, err := d.db.ExecContext(ctx, stmt, args...)
@@ -35,8 +48,11 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 	if find.CreatorID != nil {
 		where, args = append(where, "`creator_id` = ?"), append(args, *find.CreatorID)
 	}
+	if find.Scope != nil {
+		where, args = append(where, "`scope` = ?"), append(args, find.Scope.String())
+	}
 
-	rows, err := d.db.QueryContext(ctx, "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), UNIX_TIMESTAMP(`updated_ts`),  `creator_id`, `name`, `url` FROM `webhook` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC",
+	rows, err := d.db.QueryContext(ctx, "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), UNIX_TIMESTAMP(`updated_ts`), `creator_id`, `name`, `url`, `scope`, `payload` FROM `webhook` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC",
 		args...,
 	)
 	if err != nil {
@@ -47,6 +63,7 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 	list := []*store.Webhook{}
 	for rows.Next() {
 		webhook := &store.Webhook{}
+		var payloadBytes []byte
 		if err := rows.Scan(
 			&webhook.ID,
 			&webhook.CreatedTs,
@@ -54,9 +71,16 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 			&webhook.CreatorID,
 			&webhook.Name,
 			&webhook.URL,
+			&webhook.Scope,
+			&payloadBytes,
 		); err != nil {
 			return nil, err
 		}
+		payload := &storepb.WebhookPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, err
+		}
+		webhook.Payload = payload
 		list = append(list, webhook)
 	}
 
@@ -86,6 +110,13 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 	if update.URL != nil {
 		set, args = append(set, "`url` = ?"), append(args, *update.URL)
 	}
+	if update.Payload != nil {
+		bytes, err := protojson.Marshal(update.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal webhook payload")
+		}
+		set, args = append(set, "`payload` = ?"), append(args, string(bytes))
+	}
 	args = append(args, update.ID)
 
 	stmt := "UPDATE `webhook` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
@@ -106,3 +137,119 @@ func (d *DB) DeleteWebhook(ctx context.Context, delete *store.DeleteWebhook) err
 	_, err := d.db.ExecContext(ctx, "DELETE FROM `webhook` WHERE `id` = ?", delete.ID)
 	return err
 }
+
+func (d *DB) CreateIncomingWebhook(ctx context.Context, create *store.IncomingWebhook) (*store.IncomingWebhook, error) {
+	payloadString := "{}"
+	if create.Payload != nil {
+		bytes, err := protojson.Marshal(create.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal incoming webhook payload")
+		}
+		payloadString = string(bytes)
+	}
+
+	fields := []string{"`name`", "`token`", "`creator_id`", "`payload`"}
+	placeholder := []string{"?", "?", "?", "?"}
+	args := []any{create.Name, create.Token, create.CreatorID, payloadString}
+	stmt := "INSERT INTO `incoming_webhook` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
+	result, err := d.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	create.ID = int32(id)
+	return d.getIncomingWebhook(ctx, &store.FindIncomingWebhook{ID: &create.ID})
+}
+
+func (d *DB) ListIncomingWebhooks(ctx context.Context, find *store.FindIncomingWebhook) ([]*store.IncomingWebhook, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.CreatorID != nil {
+		where, args = append(where, "`creator_id` = ?"), append(args, *find.CreatorID)
+	}
+	if find.Token != nil {
+		where, args = append(where, "`token` = ?"), append(args, *find.Token)
+	}
+
+	rows, err := d.db.QueryContext(ctx, "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), UNIX_TIMESTAMP(`updated_ts`), `creator_id`, `name`, `token`, `payload` FROM `incoming_webhook` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.IncomingWebhook{}
+	for rows.Next() {
+		incomingWebhook := &store.IncomingWebhook{}
+		var payloadBytes []byte
+		if err := rows.Scan(
+			&incomingWebhook.ID,
+			&incomingWebhook.CreatedTs,
+			&incomingWebhook.UpdatedTs,
+			&incomingWebhook.CreatorID,
+			&incomingWebhook.Name,
+			&incomingWebhook.Token,
+			&payloadBytes,
+		); err != nil {
+			return nil, err
+		}
+		payload := &storepb.IncomingWebhookPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, err
+		}
+		incomingWebhook.Payload = payload
+		list = append(list, incomingWebhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) getIncomingWebhook(ctx context.Context, find *store.FindIncomingWebhook) (*store.IncomingWebhook, error) {
+	list, err := d.ListIncomingWebhooks(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.New("incoming webhook not found")
+	}
+	return list[0], nil
+}
+
+func (d *DB) UpdateIncomingWebhook(ctx context.Context, update *store.UpdateIncomingWebhook) (*store.IncomingWebhook, error) {
+	set, args := []string{"`updated_ts` = CURRENT_TIMESTAMP"}, []any{}
+	if update.Name != nil {
+		set, args = append(set, "`name` = ?"), append(args, *update.Name)
+	}
+	if update.Token != nil {
+		set, args = append(set, "`token` = ?"), append(args, *update.Token)
+	}
+	if update.Payload != nil {
+		bytes, err := protojson.Marshal(update.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal incoming webhook payload")
+		}
+		set, args = append(set, "`payload` = ?"), append(args, string(bytes))
+	}
+	args = append(args, update.ID)
+
+	stmt := "UPDATE `incoming_webhook` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
+	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
+		return nil, err
+	}
+	return d.getIncomingWebhook(ctx, &store.FindIncomingWebhook{ID: &update.ID})
+}
+
+func (d *DB) DeleteIncomingWebhook(ctx context.Context, delete *store.DeleteIncomingWebhook) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `incoming_webhook` WHERE `id` = ?", delete.ID)
+	return err
+}
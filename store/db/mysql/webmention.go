@@ -0,0 +1,101 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertWebmention(ctx context.Context, upsert *store.Webmention) (*store.Webmention, error) {
+	payloadString := "{}"
+	if upsert.Payload != nil {
+		bytes, err := protojson.Marshal(upsert.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal webmention payload")
+		}
+		payloadString = string(bytes)
+	}
+
+	stmt := "INSERT INTO `webmention` (`memo_id`, `source`, `type`, `payload`) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE `type` = ?, `payload` = ?, `updated_ts` = CURRENT_TIMESTAMP"
+	if _, err := d.db.ExecContext(ctx, stmt, upsert.MemoID, upsert.Source, upsert.Type, payloadString, upsert.Type, payloadString); err != nil {
+		return nil, err
+	}
+	list, err := d.ListWebmentions(ctx, &store.FindWebmention{MemoID: &upsert.MemoID, Source: &upsert.Source})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("failed to find webmention %s", upsert.Source)
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListWebmentions(ctx context.Context, find *store.FindWebmention) ([]*store.Webmention, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.MemoID != nil {
+		where, args = append(where, "`memo_id` = ?"), append(args, *find.MemoID)
+	}
+	if find.Source != nil {
+		where, args = append(where, "`source` = ?"), append(args, *find.Source)
+	}
+
+	rows, err := d.db.QueryContext(ctx, "SELECT `id`, UNIX_TIMESTAMP(`created_ts`), UNIX_TIMESTAMP(`updated_ts`), `memo_id`, `source`, `type`, `payload` FROM `webmention` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` ASC",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.Webmention{}
+	for rows.Next() {
+		webmention := &store.Webmention{}
+		var payloadBytes []byte
+		if err := rows.Scan(
+			&webmention.ID,
+			&webmention.CreatedTs,
+			&webmention.UpdatedTs,
+			&webmention.MemoID,
+			&webmention.Source,
+			&webmention.Type,
+			&payloadBytes,
+		); err != nil {
+			return nil, err
+		}
+		payload := &storepb.WebmentionPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, err
+		}
+		webmention.Payload = payload
+		list = append(list, webmention)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteWebmention(ctx context.Context, delete *store.DeleteWebmention) error {
+	where, args := []string{"1 = 1"}, []any{}
+	if delete.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *delete.ID)
+	}
+	if delete.MemoID != nil {
+		where, args = append(where, "`memo_id` = ?"), append(args, *delete.MemoID)
+	}
+	if delete.Source != nil {
+		where, args = append(where, "`source` = ?"), append(args, *delete.Source)
+	}
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `webmention` WHERE "+strings.Join(where, " AND "), args...)
+	return err
+}
@@ -14,7 +14,7 @@ import (
 )
 
 func (d *DB) CreateAttachment(ctx context.Context, create *store.Attachment) (*store.Attachment, error) {
-	fields := []string{"uid", "filename", "blob", "type", "size", "creator_id", "memo_id", "storage_type", "reference", "payload"}
+	fields := []string{"uid", "filename", "blob", "type", "size", "creator_id", "memo_id", "storage_type", "reference", "payload", "content_hash"}
 	storageType := ""
 	if create.StorageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
 		storageType = create.StorageType.String()
@@ -27,7 +27,7 @@ func (d *DB) CreateAttachment(ctx context.Context, create *store.Attachment) (*s
 		}
 		payloadString = string(bytes)
 	}
-	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString}
+	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString, create.ContentHash}
 
 	stmt := "INSERT INTO resource (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
 	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(&create.ID, &create.CreatedTs, &create.UpdatedTs); err != nil {
@@ -61,12 +61,20 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 		where = append(where, "memo_id IS NOT NULL")
 	}
 	if v := find.StorageType; v != nil {
-		where, args = append(where, "storage_type = "+placeholder(len(args)+1)), append(args, v.String())
+		storageType := ""
+		if *v != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+			storageType = v.String()
+		}
+		where, args = append(where, "storage_type = "+placeholder(len(args)+1)), append(args, storageType)
+	}
+	if v := find.ContentHash; v != nil {
+		where, args = append(where, "content_hash = "+placeholder(len(args)+1)), append(args, *v)
 	}
 
-	fields := []string{"id", "uid", "filename", "type", "size", "creator_id", "created_ts", "updated_ts", "memo_id", "storage_type", "reference", "payload"}
+	fields := []string{"id", "uid", "filename", "type", "size", "creator_id", "created_ts", "updated_ts", "memo_id", "storage_type", "reference", "payload", "content_hash"}
 	if find.GetBlob {
-		fields = append(fields, "blob")
+		// The content of the attachments with an attachment blob is stored in the blob.
+		fields = append(fields, "COALESCE((SELECT attachment_blob.blob FROM attachment_blob WHERE attachment_blob.hash = resource.content_hash), resource.blob)")
 	}
 
 	query := fmt.Sprintf(`
@@ -108,6 +116,7 @@ func (d *DB) ListAttachments(ctx context.Context, find *store.FindAttachment) ([
 			&storageType,
 			&attachment.Reference,
 			&payloadBytes,
+			&attachment.ContentHash,
 		}
 		if find.GetBlob {
 			dests = append(dests, &attachment.Blob)
@@ -150,9 +159,25 @@ func (d *DB) UpdateAttachment(ctx context.Context, update *store.UpdateAttachmen
 	if v := update.MemoID; v != nil {
 		set, args = append(set, "memo_id = "+placeholder(len(args)+1)), append(args, *v)
 	}
+	if v := update.StorageType; v != nil {
+		storageType := ""
+		if *v != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+			storageType = v.String()
+		}
+		set, args = append(set, "storage_type = "+placeholder(len(args)+1)), append(args, storageType)
+	}
+	if v := update.ContentHash; v != nil {
+		set, args = append(set, "content_hash = "+placeholder(len(args)+1)), append(args, *v)
+	}
 	if v := update.Reference; v != nil {
 		set, args = append(set, "reference = "+placeholder(len(args)+1)), append(args, *v)
 	}
+	if v := update.Blob; v != nil {
+		set, args = append(set, "blob = "+placeholder(len(args)+1)), append(args, v)
+	}
+	if v := update.Size; v != nil {
+		set, args = append(set, "size = "+placeholder(len(args)+1)), append(args, *v)
+	}
 	if v := update.Payload; v != nil {
 		bytes, err := protojson.Marshal(v)
 		if err != nil {
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) CreateAttachmentBlob(ctx context.Context, create *store.AttachmentBlob) (*store.AttachmentBlob, error) {
+	storageType := ""
+	if create.StorageType != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+		storageType = create.StorageType.String()
+	}
+	payloadString := "{}"
+	if create.Payload != nil {
+		bytes, err := protojson.Marshal(create.Payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal attachment blob payload")
+		}
+		payloadString = string(bytes)
+	}
+
+	stmt := "INSERT INTO attachment_blob (hash, size, storage_type, reference, payload, blob) VALUES (" + placeholders(6) + ") RETURNING created_ts, reference_count"
+	if err := d.db.QueryRowContext(ctx, stmt, create.Hash, create.Size, storageType, create.Reference, payloadString, create.Blob).Scan(
+		&create.CreatedTs,
+		&create.ReferenceCount,
+	); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+func (d *DB) ListAttachmentBlobs(ctx context.Context, find *store.FindAttachmentBlob) ([]*store.AttachmentBlob, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if v := find.Hash; v != nil {
+		where, args = append(where, "hash = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := find.StorageType; v != nil {
+		storageType := ""
+		if *v != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+			storageType = v.String()
+		}
+		where, args = append(where, "storage_type = "+placeholder(len(args)+1)), append(args, storageType)
+	}
+
+	fields := []string{"hash", "created_ts", "size", "storage_type", "reference", "payload", "reference_count", "used_ts"}
+	if find.GetBlob {
+		fields = append(fields, "blob")
+	}
+	query := "SELECT " + strings.Join(fields, ", ") + " FROM attachment_blob WHERE " + strings.Join(where, " AND ") + " ORDER BY created_ts ASC, hash ASC"
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+		if find.Offset != nil {
+			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
+		}
+	}
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.AttachmentBlob{}
+	for rows.Next() {
+		attachmentBlob := &store.AttachmentBlob{}
+		var storageType string
+		var payloadBytes []byte
+		dests := []any{
+			&attachmentBlob.Hash,
+			&attachmentBlob.CreatedTs,
+			&attachmentBlob.Size,
+			&storageType,
+			&attachmentBlob.Reference,
+			&payloadBytes,
+			&attachmentBlob.ReferenceCount,
+			&attachmentBlob.UsedTs,
+		}
+		if find.GetBlob {
+			dests = append(dests, &attachmentBlob.Blob)
+		}
+		if err := rows.Scan(dests...); err != nil {
+			return nil, err
+		}
+		attachmentBlob.StorageType = storepb.AttachmentStorageType(storepb.AttachmentStorageType_value[storageType])
+		payload := &storepb.AttachmentPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, err
+		}
+		attachmentBlob.Payload = payload
+		list = append(list, attachmentBlob)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) UpdateAttachmentBlob(ctx context.Context, update *store.UpdateAttachmentBlob) error {
+	set, args := []string{}, []any{}
+	if v := update.StorageType; v != nil {
+		storageType := ""
+		if *v != storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED {
+			storageType = v.String()
+		}
+		set, args = append(set, "storage_type = "+placeholder(len(args)+1)), append(args, storageType)
+	}
+	if v := update.Reference; v != nil {
+		set, args = append(set, "reference = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := update.Payload; v != nil {
+		bytes, err := protojson.Marshal(v)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal attachment blob payload")
+		}
+		set, args = append(set, "payload = "+placeholder(len(args)+1)), append(args, string(bytes))
+	}
+	if v := update.Blob; v != nil {
+		set, args = append(set, "blob = "+placeholder(len(args)+1)), append(args, v)
+	}
+	if v := update.UsedTs; v != nil {
+		set, args = append(set, "used_ts = "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	stmt := "UPDATE attachment_blob SET " + strings.Join(set, ", ") + " WHERE hash = " + placeholder(len(args)+1)
+	args = append(args, update.Hash)
+	_, err := d.db.ExecContext(ctx, stmt, args...)
+	return err
+}
+
+func (d *DB) UpdateAttachmentBlobReferenceCount(ctx context.Context, hash string) error {
+	stmt := "UPDATE attachment_blob SET reference_count = (SELECT COUNT(*) FROM resource WHERE content_hash = $1) WHERE hash = $1"
+	_, err := d.db.ExecContext(ctx, stmt, hash)
+	return err
+}
+
+func (d *DB) DeleteAttachmentBlob(ctx context.Context, delete *store.DeleteAttachmentBlob) (bool, error) {
+	where, args := []string{"hash = $1", "NOT EXISTS (SELECT 1 FROM resource WHERE content_hash = $1)"}, []any{delete.Hash}
+	if v := delete.CreatedBefore; v != nil {
+		where, args = append(where, "created_ts < "+placeholder(len(args)+1)), append(args, *v)
+	}
+	if v := delete.UsedBefore; v != nil {
+		where, args = append(where, "used_ts < "+placeholder(len(args)+1)), append(args, *v)
+	}
+	result, err := d.db.ExecContext(ctx, "DELETE FROM attachment_blob WHERE "+strings.Join(where, " AND "), args...)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
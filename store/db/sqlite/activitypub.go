@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertActivityPubFollower(ctx context.Context, upsert *store.ActivityPubFollower) (*store.ActivityPubFollower, error) {
+	stmt := `
+		INSERT INTO activitypub_follower (
+			user_id, actor_id, inbox, shared_inbox
+		)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(user_id, actor_id) DO UPDATE
+		SET inbox = EXCLUDED.inbox, shared_inbox = EXCLUDED.shared_inbox
+		RETURNING id, created_ts
+	`
+	if err := d.db.QueryRowContext(ctx, stmt, upsert.UserID, upsert.ActorID, upsert.Inbox, upsert.SharedInbox).Scan(
+		&upsert.ID,
+		&upsert.CreatedTs,
+	); err != nil {
+		return nil, err
+	}
+	return upsert, nil
+}
+
+func (d *DB) ListActivityPubFollowers(ctx context.Context, find *store.FindActivityPubFollower) ([]*store.ActivityPubFollower, error) {
+	where, args := []string{"1 = 1"}, []any{}
+	if find.UserID != nil {
+		where, args = append(where, "`user_id` = ?"), append(args, *find.UserID)
+	}
+	if find.ActorID != nil {
+		where, args = append(where, "`actor_id` = ?"), append(args, *find.ActorID)
+	}
+
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT
+			id,
+			created_ts,
+			user_id,
+			actor_id,
+			inbox,
+			shared_inbox
+		FROM activitypub_follower
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY id ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.ActivityPubFollower{}
+	for rows.Next() {
+		follower := &store.ActivityPubFollower{}
+		if err := rows.Scan(
+			&follower.ID,
+			&follower.CreatedTs,
+			&follower.UserID,
+			&follower.ActorID,
+			&follower.Inbox,
+			&follower.SharedInbox,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, follower)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteActivityPubFollower(ctx context.Context, delete *store.DeleteActivityPubFollower) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `activitypub_follower` WHERE `user_id` = ? AND `actor_id` = ?", delete.UserID, delete.ActorID)
+	return err
+}
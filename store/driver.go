@@ -30,6 +30,13 @@ type Driver interface {
 	ListAttachments(ctx context.Context, find *FindAttachment) ([]*Attachment, error)
 	UpdateAttachment(ctx context.Context, update *UpdateAttachment) error
 	DeleteAttachment(ctx context.Context, delete *DeleteAttachment) error
+	CreateAttachmentBlob(ctx context.Context, create *AttachmentBlob) (*AttachmentBlob, error)
+	ListAttachmentBlobs(ctx context.Context, find *FindAttachmentBlob) ([]*AttachmentBlob, error)
+	UpdateAttachmentBlob(ctx context.Context, update *UpdateAttachmentBlob) error
+	// UpdateAttachmentBlobReferenceCount recounts the attachments with the content of the blob.
+	UpdateAttachmentBlobReferenceCount(ctx context.Context, hash string) error
+	// DeleteAttachmentBlob reports whether the blob is deleted, it is kept when an attachment has its content.
+	DeleteAttachmentBlob(ctx context.Context, delete *DeleteAttachmentBlob) (bool, error)
 
 	// Memo model related methods.
 	CreateMemo(ctx context.Context, create *Memo) (*Memo, error)
@@ -74,12 +81,30 @@ type Driver interface {
 	ListWebhooks(ctx context.Context, find *FindWebhook) ([]*Webhook, error)
 	UpdateWebhook(ctx context.Context, update *UpdateWebhook) (*Webhook, error)
 	DeleteWebhook(ctx context.Context, delete *DeleteWebhook) error
+	CreateIncomingWebhook(ctx context.Context, create *IncomingWebhook) (*IncomingWebhook, error)
+	ListIncomingWebhooks(ctx context.Context, find *FindIncomingWebhook) ([]*IncomingWebhook, error)
+	UpdateIncomingWebhook(ctx context.Context, update *UpdateIncomingWebhook) (*IncomingWebhook, error)
+	DeleteIncomingWebhook(ctx context.Context, delete *DeleteIncomingWebhook) error
+	CreateWebhookDelivery(ctx context.Context, create *WebhookDelivery) (*WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, find *FindWebhookDelivery) ([]*WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, update *UpdateWebhookDelivery) (*WebhookDelivery, error)
+	DeleteWebhookDelivery(ctx context.Context, delete *DeleteWebhookDelivery) error
 
 	// Reaction model related methods.
 	UpsertReaction(ctx context.Context, create *Reaction) (*Reaction, error)
 	ListReactions(ctx context.Context, find *FindReaction) ([]*Reaction, error)
 	DeleteReaction(ctx context.Context, delete *DeleteReaction) error
 
+	// ActivityPubFollower model related methods.
+	UpsertActivityPubFollower(ctx context.Context, upsert *ActivityPubFollower) (*ActivityPubFollower, error)
+	ListActivityPubFollowers(ctx context.Context, find *FindActivityPubFollower) ([]*ActivityPubFollower, error)
+	DeleteActivityPubFollower(ctx context.Context, delete *DeleteActivityPubFollower) error
+
+	// Webmention model related methods.
+	UpsertWebmention(ctx context.Context, upsert *Webmention) (*Webmention, error)
+	ListWebmentions(ctx context.Context, find *FindWebmention) ([]*Webmention, error)
+	DeleteWebmention(ctx context.Context, delete *DeleteWebmention) error
+
 	// Shortcut related methods.
 	ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) error
 }
@@ -0,0 +1,9 @@
+CREATE TABLE `incoming_webhook` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `creator_id` INT NOT NULL,
+  `name` TEXT NOT NULL,
+  `token` VARCHAR(256) NOT NULL UNIQUE,
+  `payload` JSON NOT NULL
+);
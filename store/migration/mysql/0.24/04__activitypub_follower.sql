@@ -0,0 +1,9 @@
+CREATE TABLE `activitypub_follower` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `user_id` INT NOT NULL,
+  `actor_id` VARCHAR(512) NOT NULL,
+  `inbox` TEXT NOT NULL,
+  `shared_inbox` TEXT NOT NULL,
+  UNIQUE(`user_id`,`actor_id`)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE `webmention` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `memo_id` INT NOT NULL,
+  `source` VARCHAR(512) NOT NULL,
+  `type` VARCHAR(32) NOT NULL,
+  `payload` JSON NOT NULL,
+  UNIQUE(`memo_id`,`source`)
+);
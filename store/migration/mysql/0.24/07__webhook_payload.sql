@@ -0,0 +1,3 @@
+ALTER TABLE `webhook` ADD COLUMN `payload_temp` JSON;
+UPDATE `webhook` SET `payload_temp` = '{}';
+ALTER TABLE `webhook` CHANGE COLUMN `payload_temp` `payload` JSON NOT NULL;
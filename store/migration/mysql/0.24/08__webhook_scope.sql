@@ -0,0 +1 @@
+ALTER TABLE `webhook` ADD COLUMN `scope` VARCHAR(256) NOT NULL DEFAULT 'USER';
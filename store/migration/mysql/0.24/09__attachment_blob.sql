@@ -0,0 +1,14 @@
+ALTER TABLE `resource` ADD COLUMN `content_hash` VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX `idx_resource_content_hash` ON `resource` (`content_hash`);
+
+CREATE TABLE `attachment_blob` (
+  `hash` VARCHAR(64) NOT NULL PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `size` BIGINT NOT NULL DEFAULT 0,
+  `storage_type` VARCHAR(256) NOT NULL DEFAULT '',
+  `reference` TEXT NOT NULL DEFAULT (''),
+  `payload` TEXT NOT NULL,
+  `blob` MEDIUMBLOB,
+  `reference_count` INT NOT NULL DEFAULT 0
+);
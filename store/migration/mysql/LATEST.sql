@@ -78,9 +78,12 @@ CREATE TABLE `resource` (
   `memo_id` INT DEFAULT NULL,
   `storage_type` VARCHAR(256) NOT NULL DEFAULT '',
   `reference` TEXT NOT NULL DEFAULT (''),
-  `payload` TEXT NOT NULL
+  `payload` TEXT NOT NULL,
+  `content_hash` VARCHAR(64) NOT NULL DEFAULT ''
 );
 
+CREATE INDEX `idx_resource_content_hash` ON `resource` (`content_hash`);
+
 -- activity
 CREATE TABLE `activity` (
   `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
@@ -118,7 +121,20 @@ CREATE TABLE `webhook` (
   `row_status` VARCHAR(256) NOT NULL DEFAULT 'NORMAL',
   `creator_id` INT NOT NULL,
   `name` TEXT NOT NULL,
-  `url` TEXT NOT NULL
+  `url` TEXT NOT NULL,
+  `payload` JSON NOT NULL,
+  `scope` VARCHAR(256) NOT NULL DEFAULT 'USER'
+);
+
+-- incoming_webhook
+CREATE TABLE `incoming_webhook` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `creator_id` INT NOT NULL,
+  `name` TEXT NOT NULL,
+  `token` VARCHAR(256) NOT NULL UNIQUE,
+  `payload` JSON NOT NULL
 );
 
 -- reaction
@@ -130,3 +146,55 @@ CREATE TABLE `reaction` (
   `reaction_type` VARCHAR(256) NOT NULL,
   UNIQUE(`creator_id`,`content_id`,`reaction_type`)  
 );
+
+-- activitypub_follower
+CREATE TABLE `activitypub_follower` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `user_id` INT NOT NULL,
+  `actor_id` VARCHAR(512) NOT NULL,
+  `inbox` TEXT NOT NULL,
+  `shared_inbox` TEXT NOT NULL,
+  UNIQUE(`user_id`,`actor_id`)
+);
+
+-- webmention
+CREATE TABLE `webmention` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `memo_id` INT NOT NULL,
+  `source` VARCHAR(512) NOT NULL,
+  `type` VARCHAR(32) NOT NULL,
+  `payload` JSON NOT NULL,
+  UNIQUE(`memo_id`,`source`)
+);
+
+-- webhook_delivery
+CREATE TABLE `webhook_delivery` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `webhook_id` INT NOT NULL,
+  `status` VARCHAR(256) NOT NULL DEFAULT 'PENDING',
+  `attempts` INT NOT NULL DEFAULT 0,
+  `next_attempt_ts` BIGINT NOT NULL DEFAULT 0,
+  `payload` JSON NOT NULL
+);
+
+CREATE INDEX `idx_webhook_delivery_webhook_id` ON `webhook_delivery` (`webhook_id`);
+
+CREATE INDEX `idx_webhook_delivery_status_next_attempt_ts` ON `webhook_delivery` (`status`, `next_attempt_ts`);
+
+-- attachment_blob
+CREATE TABLE `attachment_blob` (
+  `hash` VARCHAR(64) NOT NULL PRIMARY KEY,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `size` BIGINT NOT NULL DEFAULT 0,
+  `storage_type` VARCHAR(256) NOT NULL DEFAULT '',
+  `reference` TEXT NOT NULL DEFAULT (''),
+  `payload` TEXT NOT NULL,
+  `blob` MEDIUMBLOB,
+  `reference_count` INT NOT NULL DEFAULT 0,
+  `used_ts` BIGINT NOT NULL DEFAULT 0
+);
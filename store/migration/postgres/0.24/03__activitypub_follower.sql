@@ -0,0 +1,9 @@
+CREATE TABLE activitypub_follower (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  user_id INTEGER NOT NULL,
+  actor_id TEXT NOT NULL,
+  inbox TEXT NOT NULL,
+  shared_inbox TEXT NOT NULL DEFAULT '',
+  UNIQUE(user_id, actor_id)
+);
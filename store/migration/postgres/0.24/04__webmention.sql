@@ -0,0 +1,10 @@
+CREATE TABLE webmention (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  memo_id INTEGER NOT NULL,
+  source TEXT NOT NULL,
+  type TEXT NOT NULL,
+  payload JSONB NOT NULL DEFAULT '{}',
+  UNIQUE(memo_id, source)
+);
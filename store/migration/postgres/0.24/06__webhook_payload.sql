@@ -0,0 +1 @@
+ALTER TABLE webhook ADD COLUMN payload JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1 @@
+ALTER TABLE webhook ADD COLUMN scope TEXT NOT NULL DEFAULT 'USER';
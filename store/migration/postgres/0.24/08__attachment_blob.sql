@@ -0,0 +1,14 @@
+ALTER TABLE resource ADD COLUMN content_hash TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_resource_content_hash ON resource (content_hash);
+
+CREATE TABLE attachment_blob (
+  hash TEXT NOT NULL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  size BIGINT NOT NULL DEFAULT 0,
+  storage_type TEXT NOT NULL DEFAULT '',
+  reference TEXT NOT NULL DEFAULT '',
+  payload TEXT NOT NULL DEFAULT '{}',
+  blob BYTEA,
+  reference_count INTEGER NOT NULL DEFAULT 0
+);
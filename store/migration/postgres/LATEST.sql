@@ -78,9 +78,12 @@ CREATE TABLE resource (
   memo_id INTEGER DEFAULT NULL,
   storage_type TEXT NOT NULL DEFAULT '',
   reference TEXT NOT NULL DEFAULT '',
-  payload TEXT NOT NULL DEFAULT '{}'
+  payload TEXT NOT NULL DEFAULT '{}',
+  content_hash TEXT NOT NULL DEFAULT ''
 );
 
+CREATE INDEX idx_resource_content_hash ON resource (content_hash);
+
 -- activity
 CREATE TABLE activity (
   id SERIAL PRIMARY KEY,
@@ -118,7 +121,20 @@ CREATE TABLE webhook (
   row_status TEXT NOT NULL DEFAULT 'NORMAL',
   creator_id INTEGER NOT NULL,
   name TEXT NOT NULL,
-  url TEXT NOT NULL
+  url TEXT NOT NULL,
+  payload JSONB NOT NULL DEFAULT '{}',
+  scope TEXT NOT NULL DEFAULT 'USER'
+);
+
+-- incoming_webhook
+CREATE TABLE incoming_webhook (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  creator_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  token TEXT NOT NULL UNIQUE,
+  payload JSONB NOT NULL DEFAULT '{}'
 );
 
 -- reaction
@@ -130,3 +146,55 @@ CREATE TABLE reaction (
   reaction_type TEXT NOT NULL,
   UNIQUE(creator_id, content_id, reaction_type)
 );
+
+-- activitypub_follower
+CREATE TABLE activitypub_follower (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  user_id INTEGER NOT NULL,
+  actor_id TEXT NOT NULL,
+  inbox TEXT NOT NULL,
+  shared_inbox TEXT NOT NULL DEFAULT '',
+  UNIQUE(user_id, actor_id)
+);
+
+-- webmention
+CREATE TABLE webmention (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  memo_id INTEGER NOT NULL,
+  source TEXT NOT NULL,
+  type TEXT NOT NULL,
+  payload JSONB NOT NULL DEFAULT '{}',
+  UNIQUE(memo_id, source)
+);
+
+-- webhook_delivery
+CREATE TABLE webhook_delivery (
+  id SERIAL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  updated_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  webhook_id INTEGER NOT NULL,
+  status TEXT NOT NULL DEFAULT 'PENDING',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_ts BIGINT NOT NULL DEFAULT 0,
+  payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_webhook_delivery_webhook_id ON webhook_delivery (webhook_id);
+
+CREATE INDEX idx_webhook_delivery_status_next_attempt_ts ON webhook_delivery (status, next_attempt_ts);
+
+-- attachment_blob
+CREATE TABLE attachment_blob (
+  hash TEXT NOT NULL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  size BIGINT NOT NULL DEFAULT 0,
+  storage_type TEXT NOT NULL DEFAULT '',
+  reference TEXT NOT NULL DEFAULT '',
+  payload TEXT NOT NULL DEFAULT '{}',
+  blob BYTEA,
+  reference_count INTEGER NOT NULL DEFAULT 0,
+  used_ts BIGINT NOT NULL DEFAULT 0
+);
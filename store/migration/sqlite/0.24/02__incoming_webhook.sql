@@ -0,0 +1,11 @@
+CREATE TABLE incoming_webhook (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  creator_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  token TEXT NOT NULL UNIQUE,
+  payload TEXT NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_incoming_webhook_creator_id ON incoming_webhook (creator_id);
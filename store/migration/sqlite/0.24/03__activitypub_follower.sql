@@ -0,0 +1,9 @@
+CREATE TABLE activitypub_follower (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  user_id INTEGER NOT NULL,
+  actor_id TEXT NOT NULL,
+  inbox TEXT NOT NULL,
+  shared_inbox TEXT NOT NULL DEFAULT '',
+  UNIQUE(user_id, actor_id)
+);
@@ -0,0 +1 @@
+ALTER TABLE webhook ADD COLUMN payload TEXT NOT NULL DEFAULT '{}';
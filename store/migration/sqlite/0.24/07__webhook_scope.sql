@@ -0,0 +1 @@
+ALTER TABLE webhook ADD COLUMN scope TEXT NOT NULL CHECK (scope IN ('USER', 'WORKSPACE')) DEFAULT 'USER';
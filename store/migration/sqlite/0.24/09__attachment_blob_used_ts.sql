@@ -0,0 +1 @@
+ALTER TABLE attachment_blob ADD COLUMN used_ts BIGINT NOT NULL DEFAULT 0;
@@ -83,13 +83,16 @@ CREATE TABLE resource (
   memo_id INTEGER,
   storage_type TEXT NOT NULL DEFAULT '',
   reference TEXT NOT NULL DEFAULT '',
-  payload TEXT NOT NULL DEFAULT '{}'
+  payload TEXT NOT NULL DEFAULT '{}',
+  content_hash TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_resource_creator_id ON resource (creator_id);
 
 CREATE INDEX idx_resource_memo_id ON resource (memo_id);
 
+CREATE INDEX idx_resource_content_hash ON resource (content_hash);
+
 -- activity
 CREATE TABLE activity (
   id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -127,11 +130,26 @@ CREATE TABLE webhook (
   row_status TEXT NOT NULL CHECK (row_status IN ('NORMAL', 'ARCHIVED')) DEFAULT 'NORMAL',
   creator_id INTEGER NOT NULL,
   name TEXT NOT NULL,
-  url TEXT NOT NULL
+  url TEXT NOT NULL,
+  payload TEXT NOT NULL DEFAULT '{}',
+  scope TEXT NOT NULL CHECK (scope IN ('USER', 'WORKSPACE')) DEFAULT 'USER'
 );
 
 CREATE INDEX idx_webhook_creator_id ON webhook (creator_id);
 
+-- incoming_webhook
+CREATE TABLE incoming_webhook (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  creator_id INTEGER NOT NULL,
+  name TEXT NOT NULL,
+  token TEXT NOT NULL UNIQUE,
+  payload TEXT NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_incoming_webhook_creator_id ON incoming_webhook (creator_id);
+
 -- reaction
 CREATE TABLE reaction (
   id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -141,3 +159,55 @@ CREATE TABLE reaction (
   reaction_type TEXT NOT NULL,
   UNIQUE(creator_id, content_id, reaction_type)
 );
+
+-- activitypub_follower
+CREATE TABLE activitypub_follower (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  user_id INTEGER NOT NULL,
+  actor_id TEXT NOT NULL,
+  inbox TEXT NOT NULL,
+  shared_inbox TEXT NOT NULL DEFAULT '',
+  UNIQUE(user_id, actor_id)
+);
+
+-- webmention
+CREATE TABLE webmention (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  memo_id INTEGER NOT NULL,
+  source TEXT NOT NULL,
+  type TEXT NOT NULL,
+  payload TEXT NOT NULL DEFAULT '{}',
+  UNIQUE(memo_id, source)
+);
+
+-- webhook_delivery
+CREATE TABLE webhook_delivery (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  updated_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  webhook_id INTEGER NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('PENDING', 'SUCCEEDED', 'DEAD')) DEFAULT 'PENDING',
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_ts BIGINT NOT NULL DEFAULT 0,
+  payload TEXT NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_webhook_delivery_webhook_id ON webhook_delivery (webhook_id);
+
+CREATE INDEX idx_webhook_delivery_status_next_attempt_ts ON webhook_delivery (status, next_attempt_ts);
+
+-- attachment_blob
+CREATE TABLE attachment_blob (
+  hash TEXT NOT NULL PRIMARY KEY,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  size INTEGER NOT NULL DEFAULT 0,
+  storage_type TEXT NOT NULL DEFAULT '',
+  reference TEXT NOT NULL DEFAULT '',
+  payload TEXT NOT NULL DEFAULT '{}',
+  blob BLOB DEFAULT NULL,
+  reference_count INTEGER NOT NULL DEFAULT 0,
+  used_ts BIGINT NOT NULL DEFAULT 0
+);
@@ -0,0 +1,52 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestActivityPubFollowerStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	follower, err := ts.UpsertActivityPubFollower(ctx, &store.ActivityPubFollower{
+		UserID:  user.ID,
+		ActorID: "https://remote.example/users/alice",
+		Inbox:   "https://remote.example/users/alice/inbox",
+	})
+	require.NoError(t, err)
+	require.NotZero(t, follower.ID)
+
+	// Following again updates the inboxes instead of adding a duplicate.
+	_, err = ts.UpsertActivityPubFollower(ctx, &store.ActivityPubFollower{
+		UserID:      user.ID,
+		ActorID:     "https://remote.example/users/alice",
+		Inbox:       "https://remote.example/users/alice/inbox",
+		SharedInbox: "https://remote.example/inbox",
+	})
+	require.NoError(t, err)
+	followers, err := ts.ListActivityPubFollowers(ctx, &store.FindActivityPubFollower{
+		UserID: &user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(followers))
+	require.Equal(t, follower.ID, followers[0].ID)
+	require.Equal(t, "https://remote.example/inbox", followers[0].SharedInbox)
+
+	err = ts.DeleteActivityPubFollower(ctx, &store.DeleteActivityPubFollower{
+		UserID:  user.ID,
+		ActorID: "https://remote.example/users/alice",
+	})
+	require.NoError(t, err)
+	followers, err = ts.ListActivityPubFollowers(ctx, &store.FindActivityPubFollower{
+		UserID: &user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(followers))
+	ts.Close()
+}
@@ -0,0 +1,155 @@
+package teststore
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestAttachmentBlobStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	hash := "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	attachmentBlob, err := ts.CreateAttachmentBlob(ctx, &store.AttachmentBlob{
+		Hash: hash,
+		Size: 3,
+		Blob: []byte("foo"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), attachmentBlob.ReferenceCount)
+
+	createAttachment := func() *store.Attachment {
+		attachment, err := ts.CreateAttachment(ctx, &store.Attachment{
+			UID:         shortuuid.New(),
+			CreatorID:   101,
+			Filename:    "foo.txt",
+			Type:        "text/plain",
+			Size:        3,
+			ContentHash: hash,
+		})
+		require.NoError(t, err)
+		return attachment
+	}
+	first, second := createAttachment(), createAttachment()
+	attachmentBlob, err = ts.GetAttachmentBlob(ctx, &store.FindAttachmentBlob{Hash: &hash})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), attachmentBlob.ReferenceCount)
+	require.Nil(t, attachmentBlob.Blob)
+
+	// The attachments read their content from the blob.
+	attachment, err := ts.GetAttachment(ctx, &store.FindAttachment{ID: &first.ID, GetBlob: true})
+	require.NoError(t, err)
+	require.Equal(t, hash, attachment.ContentHash)
+	require.Equal(t, []byte("foo"), attachment.Blob)
+
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: first.ID}))
+	attachmentBlob, err = ts.GetAttachmentBlob(ctx, &store.FindAttachmentBlob{Hash: &hash, GetBlob: true})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), attachmentBlob.ReferenceCount)
+	require.Equal(t, []byte("foo"), attachmentBlob.Blob)
+
+	// The blob is deleted along with its last attachment.
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: second.ID}))
+	attachmentBlob, err = ts.GetAttachmentBlob(ctx, &store.FindAttachmentBlob{Hash: &hash})
+	require.NoError(t, err)
+	require.Nil(t, attachmentBlob)
+	ts.Close()
+}
+
+func TestAttachmentContentStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	saveAttachment := func(content string) *store.Attachment {
+		create := &store.Attachment{
+			UID:       shortuuid.New(),
+			CreatorID: 101,
+			Filename:  "foo.txt",
+			Type:      "text/plain",
+			Size:      int64(len(content)),
+		}
+		require.NoError(t, ts.SaveAttachmentContent(ctx, create, strings.NewReader(content)))
+		attachment, err := ts.CreateAttachment(ctx, create)
+		require.NoError(t, err)
+		return attachment
+	}
+
+	// The contents stored in the database are shared by the attachments with the same content.
+	first, second := saveAttachment("hello world"), saveAttachment("hello world")
+	require.NotEmpty(t, first.ContentHash)
+	require.Equal(t, first.ContentHash, second.ContentHash)
+	attachmentBlob, err := ts.GetAttachmentBlob(ctx, &store.FindAttachmentBlob{Hash: &first.ContentHash})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), attachmentBlob.ReferenceCount)
+
+	content, err := ts.OpenAttachmentContent(ctx, second)
+	require.NoError(t, err)
+	_, err = content.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+	read, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.Equal(t, "world", string(read))
+	require.NoError(t, content.Close())
+
+	// The blob reused by an upload is kept once no attachment has its content, and deleted after the grace period.
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: first.ID}))
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: second.ID}))
+	attachmentBlob, err = ts.GetAttachmentBlob(ctx, &store.FindAttachmentBlob{Hash: &first.ContentHash})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), attachmentBlob.ReferenceCount)
+	require.NotZero(t, attachmentBlob.UsedTs)
+
+	usedTs := time.Now().Add(-2 * time.Hour).Unix()
+	require.NoError(t, ts.UpdateAttachmentBlob(ctx, &store.UpdateAttachmentBlob{Hash: first.ContentHash, UsedTs: &usedTs}))
+	third := saveAttachment("goodbye")
+	require.NoError(t, ts.UpdateAttachment(ctx, &store.UpdateAttachment{ID: third.ID, ContentHash: &first.ContentHash}))
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: third.ID}))
+	attachmentBlob, err = ts.GetAttachmentBlob(ctx, &store.FindAttachmentBlob{Hash: &first.ContentHash})
+	require.NoError(t, err)
+	require.Nil(t, attachmentBlob)
+	ts.Close()
+}
+
+func TestAttachmentContentStoreReleasingReusedBlob(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	newAttachment := func() *store.Attachment {
+		return &store.Attachment{
+			UID:       shortuuid.New(),
+			CreatorID: 101,
+			Filename:  "foo.txt",
+			Type:      "text/plain",
+			Size:      int64(len("hello world")),
+		}
+	}
+
+	first := newAttachment()
+	require.NoError(t, ts.SaveAttachmentContent(ctx, first, strings.NewReader("hello world")))
+	first, err := ts.CreateAttachment(ctx, first)
+	require.NoError(t, err)
+
+	// The last attachment of the blob is deleted while an upload reuses its content.
+	second := newAttachment()
+	require.NoError(t, ts.SaveAttachmentContent(ctx, second, strings.NewReader("hello world")))
+	require.Equal(t, first.ContentHash, second.ContentHash)
+	require.NoError(t, ts.DeleteAttachment(ctx, &store.DeleteAttachment{ID: first.ID}))
+	second, err = ts.CreateAttachment(ctx, second)
+	require.NoError(t, err)
+
+	attachmentBlob, err := ts.GetAttachmentBlob(ctx, &store.FindAttachmentBlob{Hash: &second.ContentHash})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), attachmentBlob.ReferenceCount)
+	content, err := ts.OpenAttachmentContent(ctx, second)
+	require.NoError(t, err)
+	read, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(read))
+	require.NoError(t, content.Close())
+	ts.Close()
+}
@@ -0,0 +1,146 @@
+package teststore
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestMigrateAttachmentStorage(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	readContent := func(attachment *store.Attachment) string {
+		content, err := ts.OpenAttachmentContent(ctx, attachment)
+		require.NoError(t, err)
+		defer content.Close()
+		read, err := io.ReadAll(content)
+		require.NoError(t, err)
+		return string(read)
+	}
+	getAttachment := func(id int32) *store.Attachment {
+		attachment, err := ts.GetAttachment(ctx, &store.FindAttachment{ID: &id})
+		require.NoError(t, err)
+		return attachment
+	}
+
+	// Two attachments share a content stored in the database, and another one holds its own content.
+	var shared []*store.Attachment
+	for range 2 {
+		create := &store.Attachment{UID: shortuuid.New(), CreatorID: 101, Filename: "shared.txt", Type: "text/plain", Size: 6}
+		require.NoError(t, ts.SaveAttachmentContent(ctx, create, strings.NewReader("shared")))
+		attachment, err := ts.CreateAttachment(ctx, create)
+		require.NoError(t, err)
+		shared = append(shared, attachment)
+	}
+	legacy, err := ts.CreateAttachment(ctx, &store.Attachment{UID: shortuuid.New(), CreatorID: 101, Filename: "legacy.txt", Type: "text/plain", Size: 6, Blob: []byte("legacy")})
+	require.NoError(t, err)
+
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType:      storepb.WorkspaceStorageSetting_LOCAL,
+			FilepathTemplate: "assets/{uuid}_{filename}",
+		}},
+	})
+	require.NoError(t, err)
+
+	// The migration is resumed from where it stopped.
+	migration, err := ts.MigrateAttachmentStorage(ctx, &store.MigrateAttachmentStorage{
+		From:  storepb.WorkspaceStorageSetting_DATABASE,
+		To:    storepb.WorkspaceStorageSetting_LOCAL,
+		Limit: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, migration.MigratedCount)
+	require.Equal(t, 1, migration.RemainingCount)
+	migration, err = ts.MigrateAttachmentStorage(ctx, &store.MigrateAttachmentStorage{
+		From: storepb.WorkspaceStorageSetting_DATABASE,
+		To:   storepb.WorkspaceStorageSetting_LOCAL,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, migration.MigratedCount)
+	require.Equal(t, 0, migration.FailedCount)
+	require.Equal(t, 0, migration.RemainingCount)
+
+	for _, attachment := range append(shared, legacy) {
+		attachment = getAttachment(attachment.ID)
+		require.Equal(t, storepb.AttachmentStorageType_LOCAL, attachment.StorageType)
+		require.NotEmpty(t, attachment.Reference)
+	}
+	require.Equal(t, getAttachment(shared[0].ID).Reference, getAttachment(shared[1].ID).Reference)
+	require.Equal(t, "shared", readContent(getAttachment(shared[1].ID)))
+	require.Equal(t, "legacy", readContent(getAttachment(legacy.ID)))
+	withBlob, err := ts.GetAttachment(ctx, &store.FindAttachment{ID: &legacy.ID, GetBlob: true})
+	require.NoError(t, err)
+	require.Empty(t, withBlob.Blob)
+
+	// The contents are moved back to the database.
+	migration, err = ts.MigrateAttachmentStorage(ctx, &store.MigrateAttachmentStorage{
+		From: storepb.WorkspaceStorageSetting_LOCAL,
+		To:   storepb.WorkspaceStorageSetting_DATABASE,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, migration.MigratedCount)
+	require.Equal(t, 0, migration.RemainingCount)
+	for _, attachment := range append(shared, legacy) {
+		attachment = getAttachment(attachment.ID)
+		require.Equal(t, storepb.AttachmentStorageType_ATTACHMENT_STORAGE_TYPE_UNSPECIFIED, attachment.StorageType)
+		require.NotEmpty(t, attachment.ContentHash)
+	}
+	require.Equal(t, "shared", readContent(getAttachment(shared[0].ID)))
+	require.Equal(t, "legacy", readContent(getAttachment(legacy.ID)))
+
+	_, err = ts.MigrateAttachmentStorage(ctx, &store.MigrateAttachmentStorage{
+		From: storepb.WorkspaceStorageSetting_DATABASE,
+		To:   storepb.WorkspaceStorageSetting_DATABASE,
+	})
+	require.Error(t, err)
+	ts.Close()
+}
+
+func TestMigrateAttachmentStorageWithSameKeys(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	_, err := ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_STORAGE,
+		Value: &storepb.WorkspaceSetting_StorageSetting{StorageSetting: &storepb.WorkspaceStorageSetting{
+			StorageType:      storepb.WorkspaceStorageSetting_LOCAL,
+			FilepathTemplate: "assets/{filename}",
+		}},
+	})
+	require.NoError(t, err)
+
+	// The contents of the files of the same name don't overwrite each other.
+	var attachments []*store.Attachment
+	for _, content := range []string{"first", "second"} {
+		attachment, err := ts.CreateAttachment(ctx, &store.Attachment{UID: shortuuid.New(), CreatorID: 101, Filename: "same.txt", Type: "text/plain", Size: int64(len(content)), Blob: []byte(content)})
+		require.NoError(t, err)
+		attachments = append(attachments, attachment)
+	}
+	migration, err := ts.MigrateAttachmentStorage(ctx, &store.MigrateAttachmentStorage{
+		From: storepb.WorkspaceStorageSetting_DATABASE,
+		To:   storepb.WorkspaceStorageSetting_LOCAL,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, migration.MigratedCount)
+	require.Equal(t, 0, migration.FailedCount)
+	for i, content := range []string{"first", "second"} {
+		attachment, err := ts.GetAttachment(ctx, &store.FindAttachment{ID: &attachments[i].ID})
+		require.NoError(t, err)
+		require.Equal(t, storepb.AttachmentStorageType_LOCAL, attachment.StorageType)
+		reader, err := ts.OpenAttachmentContent(ctx, attachment)
+		require.NoError(t, err)
+		read, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		reader.Close()
+		require.Equal(t, content, string(read))
+	}
+	ts.Close()
+}
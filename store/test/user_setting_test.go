@@ -26,3 +26,27 @@ func TestUserSettingStore(t *testing.T) {
 	require.Equal(t, 1, len(list))
 	ts.Close()
 }
+
+func TestUserFeedTokens(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	feedTokens, err := ts.GetUserFeedTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Empty(t, feedTokens)
+	err = ts.AddUserFeedToken(ctx, user.ID, &storepb.FeedTokensUserSetting_FeedToken{Id: "reader", TokenHash: "hash-1"})
+	require.NoError(t, err)
+	err = ts.AddUserFeedToken(ctx, user.ID, &storepb.FeedTokensUserSetting_FeedToken{Id: "phone", TokenHash: "hash-2"})
+	require.NoError(t, err)
+	feedTokens, err = ts.GetUserFeedTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, feedTokens, 2)
+	err = ts.RemoveUserFeedToken(ctx, user.ID, "reader")
+	require.NoError(t, err)
+	feedTokens, err = ts.GetUserFeedTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, feedTokens, 1)
+	require.Equal(t, "phone", feedTokens[0].Id)
+	ts.Close()
+}
@@ -0,0 +1,79 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestWebhookDeliveryStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	webhook, err := ts.CreateWebhook(ctx, &store.Webhook{
+		CreatorID: user.ID,
+		Name:      "test_webhook",
+		URL:       "https://example.com/hook",
+	})
+	require.NoError(t, err)
+
+	delivery, err := ts.CreateWebhookDelivery(ctx, &store.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		Status:        store.WebhookDeliveryPending,
+		NextAttemptTs: 100,
+		Payload: &storepb.WebhookDeliveryPayload{
+			Url:          webhook.URL,
+			ActivityType: "memos.memo.created",
+			RequestBody:  `{"activityType":"memos.memo.created"}`,
+		},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, delivery.ID)
+
+	// Only the pending deliveries due before the given time are found.
+	pendingStatus := store.WebhookDeliveryPending
+	dueTs := int64(100)
+	deliveries, err := ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		Status:              &pendingStatus,
+		NextAttemptTsBefore: &dueTs,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, "memos.memo.created", deliveries[0].Payload.ActivityType)
+	dueTs = 99
+	deliveries, err = ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{
+		Status:              &pendingStatus,
+		NextAttemptTsBefore: &dueTs,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 0)
+
+	succeededStatus := store.WebhookDeliverySucceeded
+	attempts := int32(1)
+	payload := delivery.Payload
+	payload.ResponseStatus = 200
+	payload.LatencyMs = 42
+	updated, err := ts.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDelivery{
+		ID:       delivery.ID,
+		Status:   &succeededStatus,
+		Attempts: &attempts,
+		Payload:  payload,
+	})
+	require.NoError(t, err)
+	require.Equal(t, store.WebhookDeliverySucceeded, updated.Status)
+	require.Equal(t, int32(1), updated.Attempts)
+	require.Equal(t, int32(200), updated.Payload.ResponseStatus)
+	require.Equal(t, int64(42), updated.Payload.LatencyMs)
+
+	err = ts.DeleteWebhookDelivery(ctx, &store.DeleteWebhookDelivery{WebhookID: &webhook.ID})
+	require.NoError(t, err)
+	deliveries, err = ts.ListWebhookDeliveries(ctx, &store.FindWebhookDelivery{WebhookID: &webhook.ID})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 0)
+	ts.Close()
+}
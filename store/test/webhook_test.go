@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -36,6 +37,13 @@ func TestWebhookStore(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, newName, updatedWebhook.Name)
 	require.Equal(t, webhook.CreatorID, updatedWebhook.CreatorID)
+	updatedWebhook, err = ts.UpdateWebhook(ctx, &store.UpdateWebhook{
+		ID:      webhook.ID,
+		Payload: &storepb.WebhookPayload{SigningSecret: "test_secret"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, newName, updatedWebhook.Name)
+	require.Equal(t, "test_secret", updatedWebhook.Payload.SigningSecret)
 	err = ts.DeleteWebhook(ctx, &store.DeleteWebhook{
 		ID: webhook.ID,
 	})
@@ -47,3 +55,90 @@ func TestWebhookStore(t *testing.T) {
 	require.Equal(t, 0, len(webhooks))
 	ts.Close()
 }
+
+func TestWebhookScope(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	userWebhook, err := ts.CreateWebhook(ctx, &store.Webhook{
+		CreatorID: user.ID,
+		Name:      "user_webhook",
+		URL:       "https://example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, store.WebhookScopeUser, userWebhook.Scope)
+	workspaceWebhook, err := ts.CreateWebhook(ctx, &store.Webhook{
+		CreatorID: user.ID,
+		Name:      "workspace_webhook",
+		URL:       "https://example.com",
+		Scope:     store.WebhookScopeWorkspace,
+	})
+	require.NoError(t, err)
+	require.Equal(t, store.WebhookScopeWorkspace, workspaceWebhook.Scope)
+	workspaceScope := store.WebhookScopeWorkspace
+	webhooks, err := ts.ListWebhooks(ctx, &store.FindWebhook{
+		Scope: &workspaceScope,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(webhooks))
+	require.Equal(t, workspaceWebhook, webhooks[0])
+	ts.Close()
+}
+
+func TestIncomingWebhookStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	incomingWebhook, err := ts.CreateIncomingWebhook(ctx, &store.IncomingWebhook{
+		CreatorID: user.ID,
+		Name:      "ci",
+		Token:     "test_token",
+		Payload: &storepb.IncomingWebhookPayload{
+			Tags:       []string{"ci"},
+			Visibility: store.Private.String(),
+			Template:   "{{ .Body }}",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ci", incomingWebhook.Name)
+	require.Equal(t, user.ID, incomingWebhook.CreatorID)
+	token := "test_token"
+	found, err := ts.GetIncomingWebhook(ctx, &store.FindIncomingWebhook{
+		Token: &token,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.Equal(t, incomingWebhook.ID, found.ID)
+	require.Equal(t, []string{"ci"}, found.Payload.Tags)
+	require.Equal(t, "{{ .Body }}", found.Payload.Template)
+	newToken := "new_token"
+	updatedIncomingWebhook, err := ts.UpdateIncomingWebhook(ctx, &store.UpdateIncomingWebhook{
+		ID:    incomingWebhook.ID,
+		Token: &newToken,
+		Payload: &storepb.IncomingWebhookPayload{
+			Visibility: store.Public.String(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, newToken, updatedIncomingWebhook.Token)
+	require.Equal(t, "ci", updatedIncomingWebhook.Name)
+	require.Equal(t, store.Public.String(), updatedIncomingWebhook.Payload.Visibility)
+	require.Empty(t, updatedIncomingWebhook.Payload.Tags)
+	found, err = ts.GetIncomingWebhook(ctx, &store.FindIncomingWebhook{
+		Token: &token,
+	})
+	require.NoError(t, err)
+	require.Nil(t, found)
+	err = ts.DeleteIncomingWebhook(ctx, &store.DeleteIncomingWebhook{
+		ID: incomingWebhook.ID,
+	})
+	require.NoError(t, err)
+	incomingWebhooks, err := ts.ListIncomingWebhooks(ctx, &store.FindIncomingWebhook{
+		CreatorID: &user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(incomingWebhooks))
+	ts.Close()
+}
@@ -0,0 +1,70 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestWebmentionStore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "test-memo",
+		CreatorID:  user.ID,
+		Content:    "test_content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	source := "https://example.com/replies/1"
+	webmention, err := ts.UpsertWebmention(ctx, &store.Webmention{
+		MemoID: memo.ID,
+		Source: source,
+		Type:   "mention",
+		Payload: &storepb.WebmentionPayload{
+			Content:    "nice",
+			AuthorName: "Jane",
+		},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, webmention.ID)
+
+	// Receiving the same source again updates the mention.
+	_, err = ts.UpsertWebmention(ctx, &store.Webmention{
+		MemoID: memo.ID,
+		Source: source,
+		Type:   "reply",
+		Payload: &storepb.WebmentionPayload{
+			Content:    "nice, edited",
+			AuthorName: "Jane",
+		},
+	})
+	require.NoError(t, err)
+	webmentions, err := ts.ListWebmentions(ctx, &store.FindWebmention{
+		MemoID: &memo.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(webmentions))
+	require.Equal(t, webmention.ID, webmentions[0].ID)
+	require.Equal(t, "reply", webmentions[0].Type)
+	require.Equal(t, "nice, edited", webmentions[0].Payload.Content)
+
+	err = ts.DeleteWebmention(ctx, &store.DeleteWebmention{
+		MemoID: &memo.ID,
+		Source: &source,
+	})
+	require.NoError(t, err)
+	webmentions, err = ts.ListWebmentions(ctx, &store.FindWebmention{
+		MemoID: &memo.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(webmentions))
+	ts.Close()
+}
@@ -2,6 +2,9 @@ package store
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -133,6 +136,91 @@ func (s *Store) RemoveUserAccessToken(ctx context.Context, userID int32, token s
 	return err
 }
 
+// GetUserFeedTokens returns the feed tokens of the user.
+func (s *Store) GetUserFeedTokens(ctx context.Context, userID int32) ([]*storepb.FeedTokensUserSetting_FeedToken, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_FEED_TOKENS,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil {
+		return []*storepb.FeedTokensUserSetting_FeedToken{}, nil
+	}
+
+	feedTokensUserSetting := userSetting.GetFeedTokens()
+	return feedTokensUserSetting.FeedTokens, nil
+}
+
+// GetUserFeedToken returns the feed token of the user matching the token value, or nil if there is none.
+func (s *Store) GetUserFeedToken(ctx context.Context, userID int32, token string) (*storepb.FeedTokensUserSetting_FeedToken, error) {
+	feedTokens, err := s.GetUserFeedTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tokenHash := HashFeedToken(token)
+	for _, feedToken := range feedTokens {
+		if subtle.ConstantTimeCompare([]byte(feedToken.TokenHash), []byte(tokenHash)) == 1 {
+			return feedToken, nil
+		}
+	}
+	return nil, nil
+}
+
+// HashFeedToken returns the hash of a feed token as it is stored.
+func HashFeedToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// AddUserFeedToken adds a feed token to the user.
+func (s *Store) AddUserFeedToken(ctx context.Context, userID int32, feedToken *storepb.FeedTokensUserSetting_FeedToken) error {
+	feedTokens, err := s.GetUserFeedTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_FEED_TOKENS,
+		Value: &storepb.UserSetting_FeedTokens{
+			FeedTokens: &storepb.FeedTokensUserSetting{
+				FeedTokens: append(feedTokens, feedToken),
+			},
+		},
+	})
+
+	return err
+}
+
+// RemoveUserFeedToken removes the feed token of the user.
+func (s *Store) RemoveUserFeedToken(ctx context.Context, userID int32, feedTokenID string) error {
+	oldFeedTokens, err := s.GetUserFeedTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	newFeedTokens := make([]*storepb.FeedTokensUserSetting_FeedToken, 0, len(oldFeedTokens))
+	for _, feedToken := range oldFeedTokens {
+		if feedToken.Id != feedTokenID {
+			newFeedTokens = append(newFeedTokens, feedToken)
+		}
+	}
+
+	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_FEED_TOKENS,
+		Value: &storepb.UserSetting_FeedTokens{
+			FeedTokens: &storepb.FeedTokensUserSetting{
+				FeedTokens: newFeedTokens,
+			},
+		},
+	})
+
+	return err
+}
+
 // GetUserSessions returns the sessions of the user.
 func (s *Store) GetUserSessions(ctx context.Context, userID int32) ([]*storepb.SessionsUserSetting_Session, error) {
 	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
@@ -266,6 +354,18 @@ func convertUserSettingFromRaw(raw *UserSetting) (*storepb.UserSetting, error) {
 			return nil, err
 		}
 		userSetting.Value = &storepb.UserSetting_Shortcuts{Shortcuts: shortcutsUserSetting}
+	case storepb.UserSettingKey_ACTIVITYPUB:
+		activityPubUserSetting := &storepb.ActivityPubUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), activityPubUserSetting); err != nil {
+			return nil, err
+		}
+		userSetting.Value = &storepb.UserSetting_Activitypub{Activitypub: activityPubUserSetting}
+	case storepb.UserSettingKey_FEED_TOKENS:
+		feedTokensUserSetting := &storepb.FeedTokensUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), feedTokensUserSetting); err != nil {
+			return nil, err
+		}
+		userSetting.Value = &storepb.UserSetting_FeedTokens{FeedTokens: feedTokensUserSetting}
 	case storepb.UserSettingKey_LOCALE:
 		userSetting.Value = &storepb.UserSetting_Locale{Locale: raw.Value}
 	case storepb.UserSettingKey_APPEARANCE:
@@ -306,6 +406,20 @@ func convertUserSettingToRaw(userSetting *storepb.UserSetting) (*UserSetting, er
 			return nil, err
 		}
 		raw.Value = string(value)
+	case storepb.UserSettingKey_ACTIVITYPUB:
+		activityPubUserSetting := userSetting.GetActivitypub()
+		value, err := protojson.Marshal(activityPubUserSetting)
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = string(value)
+	case storepb.UserSettingKey_FEED_TOKENS:
+		feedTokensUserSetting := userSetting.GetFeedTokens()
+		value, err := protojson.Marshal(feedTokensUserSetting)
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = string(value)
 	case storepb.UserSettingKey_LOCALE:
 		raw.Value = userSetting.GetLocale()
 	case storepb.UserSettingKey_APPEARANCE:
@@ -2,8 +2,24 @@ package store
 
 import (
 	"context"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
+// WebhookScope is the scope of the events a webhook receives.
+type WebhookScope string
+
+const (
+	// WebhookScopeUser is the scope of webhooks that receive the events of their creator.
+	WebhookScopeUser WebhookScope = "USER"
+	// WebhookScopeWorkspace is the scope of webhooks that receive the events of all users.
+	WebhookScopeWorkspace WebhookScope = "WORKSPACE"
+)
+
+func (s WebhookScope) String() string {
+	return string(s)
+}
+
 type Webhook struct {
 	ID        int32
 	CreatedTs int64
@@ -11,24 +27,59 @@ type Webhook struct {
 	CreatorID int32
 	Name      string
 	URL       string
+	Scope     WebhookScope
+	Payload   *storepb.WebhookPayload
 }
 
 type FindWebhook struct {
 	ID        *int32
 	CreatorID *int32
+	Scope     *WebhookScope
 }
 
 type UpdateWebhook struct {
-	ID   int32
-	Name *string
-	URL  *string
+	ID      int32
+	Name    *string
+	URL     *string
+	Payload *storepb.WebhookPayload
 }
 
 type DeleteWebhook struct {
 	ID int32
 }
 
+// IncomingWebhook is a hook that creates memos for its creator when a request is posted to its secret token.
+type IncomingWebhook struct {
+	ID        int32
+	CreatedTs int64
+	UpdatedTs int64
+	CreatorID int32
+	Name      string
+	Token     string
+	Payload   *storepb.IncomingWebhookPayload
+}
+
+type FindIncomingWebhook struct {
+	ID        *int32
+	CreatorID *int32
+	Token     *string
+}
+
+type UpdateIncomingWebhook struct {
+	ID      int32
+	Name    *string
+	Token   *string
+	Payload *storepb.IncomingWebhookPayload
+}
+
+type DeleteIncomingWebhook struct {
+	ID int32
+}
+
 func (s *Store) CreateWebhook(ctx context.Context, create *Webhook) (*Webhook, error) {
+	if create.Scope == "" {
+		create.Scope = WebhookScopeUser
+	}
 	return s.driver.CreateWebhook(ctx, create)
 }
 
@@ -54,3 +105,30 @@ func (s *Store) UpdateWebhook(ctx context.Context, update *UpdateWebhook) (*Webh
 func (s *Store) DeleteWebhook(ctx context.Context, delete *DeleteWebhook) error {
 	return s.driver.DeleteWebhook(ctx, delete)
 }
+
+func (s *Store) CreateIncomingWebhook(ctx context.Context, create *IncomingWebhook) (*IncomingWebhook, error) {
+	return s.driver.CreateIncomingWebhook(ctx, create)
+}
+
+func (s *Store) ListIncomingWebhooks(ctx context.Context, find *FindIncomingWebhook) ([]*IncomingWebhook, error) {
+	return s.driver.ListIncomingWebhooks(ctx, find)
+}
+
+func (s *Store) GetIncomingWebhook(ctx context.Context, find *FindIncomingWebhook) (*IncomingWebhook, error) {
+	list, err := s.ListIncomingWebhooks(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateIncomingWebhook(ctx context.Context, update *UpdateIncomingWebhook) (*IncomingWebhook, error) {
+	return s.driver.UpdateIncomingWebhook(ctx, update)
+}
+
+func (s *Store) DeleteIncomingWebhook(ctx context.Context, delete *DeleteIncomingWebhook) error {
+	return s.driver.DeleteIncomingWebhook(ctx, delete)
+}
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"context"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// WebhookDeliveryStatus is the status of a webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is the status of a delivery waiting for its next attempt.
+	WebhookDeliveryPending WebhookDeliveryStatus = "PENDING"
+	// WebhookDeliverySucceeded is the status of a delivery accepted by the webhook.
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	// WebhookDeliveryDead is the status of a delivery that failed all of its attempts.
+	WebhookDeliveryDead WebhookDeliveryStatus = "DEAD"
+)
+
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// WebhookDelivery is a request to a webhook, retried until it succeeds or runs out of attempts.
+type WebhookDelivery struct {
+	ID        int32
+	CreatedTs int64
+	UpdatedTs int64
+	WebhookID int32
+	Status    WebhookDeliveryStatus
+	// Attempts is the number of attempts made so far.
+	Attempts int32
+	// NextAttemptTs is the time of the next attempt of a pending delivery.
+	NextAttemptTs int64
+	Payload       *storepb.WebhookDeliveryPayload
+}
+
+type FindWebhookDelivery struct {
+	ID        *int32
+	WebhookID *int32
+	Status    *WebhookDeliveryStatus
+	// NextAttemptTsBefore finds the deliveries due before the given time.
+	NextAttemptTsBefore *int64
+	Limit               *int
+	Offset              *int
+}
+
+type UpdateWebhookDelivery struct {
+	ID            int32
+	Status        *WebhookDeliveryStatus
+	Attempts      *int32
+	NextAttemptTs *int64
+	Payload       *storepb.WebhookDeliveryPayload
+}
+
+type DeleteWebhookDelivery struct {
+	ID        *int32
+	WebhookID *int32
+	// CreatedTsBefore deletes the deliveries created before the given time.
+	CreatedTsBefore *int64
+}
+
+func (s *Store) CreateWebhookDelivery(ctx context.Context, create *WebhookDelivery) (*WebhookDelivery, error) {
+	return s.driver.CreateWebhookDelivery(ctx, create)
+}
+
+func (s *Store) ListWebhookDeliveries(ctx context.Context, find *FindWebhookDelivery) ([]*WebhookDelivery, error) {
+	return s.driver.ListWebhookDeliveries(ctx, find)
+}
+
+func (s *Store) GetWebhookDelivery(ctx context.Context, find *FindWebhookDelivery) (*WebhookDelivery, error) {
+	list, err := s.ListWebhookDeliveries(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) UpdateWebhookDelivery(ctx context.Context, update *UpdateWebhookDelivery) (*WebhookDelivery, error) {
+	return s.driver.UpdateWebhookDelivery(ctx, update)
+}
+
+func (s *Store) DeleteWebhookDelivery(ctx context.Context, delete *DeleteWebhookDelivery) error {
+	return s.driver.DeleteWebhookDelivery(ctx, delete)
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// Webmention is a verified mention of a memo on another site.
+type Webmention struct {
+	ID        int32
+	CreatedTs int64
+	UpdatedTs int64
+	MemoID    int32
+	// Source is the URL of the page mentioning the memo.
+	Source string
+	// Type is the kind of mention, e.g. "reply" or "like".
+	Type    string
+	Payload *storepb.WebmentionPayload
+}
+
+type FindWebmention struct {
+	ID     *int32
+	MemoID *int32
+	Source *string
+}
+
+type DeleteWebmention struct {
+	ID     *int32
+	MemoID *int32
+	Source *string
+}
+
+func (s *Store) UpsertWebmention(ctx context.Context, upsert *Webmention) (*Webmention, error) {
+	return s.driver.UpsertWebmention(ctx, upsert)
+}
+
+func (s *Store) ListWebmentions(ctx context.Context, find *FindWebmention) ([]*Webmention, error) {
+	return s.driver.ListWebmentions(ctx, find)
+}
+
+func (s *Store) GetWebmention(ctx context.Context, find *FindWebmention) (*Webmention, error) {
+	list, err := s.ListWebmentions(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) DeleteWebmention(ctx context.Context, delete *DeleteWebmention) error {
+	return s.driver.DeleteWebmention(ctx, delete)
+}